@@ -0,0 +1,176 @@
+package apisrv
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+// AdminConfig secures the /admin routes. Token enables bearer-token auth;
+// when it is empty, a verified client certificate is required instead (the
+// server must then be running with TLS.ClientCAFile set).
+type AdminConfig struct {
+	Token string `yaml:"Token"`
+}
+
+// enqueueRequest is the POST /admin/queue body. Payload is hex so requests
+// can be pasted from block explorers and logs during incidents.
+type enqueueRequest struct {
+	XtID        types.XtID      `json:"xt_id"`
+	SourceChain types.ChainID   `json:"source_chain"`
+	Chains      []types.ChainID `json:"chains"`
+	PayloadHex  string          `json:"payload_hex"`
+}
+
+// RegisterAdmin mounts the authenticated queue-manipulation endpoints:
+//
+//	GET    /admin/queue        list queued XT requests
+//	POST   /admin/queue        enqueue an XTRequest with a hex payload
+//	DELETE /admin/queue/{xtid} drop one XT from the queue
+//
+// They exist to unblock incidents where a poisoned request keeps failing
+// slots.
+func (s *Server) RegisterAdmin(cfg AdminConfig, queue store.XTQueue) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/admin/queue", Tag: "admin",
+		Summary:  "List queued XT requests",
+		Params:   listParamDocs(),
+		Response: ListEnvelope{Items: []types.XTRequest{}},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodPost, Path: "/admin/queue", Tag: "admin",
+		Summary:  "Manually enqueue an XT request",
+		Response: map[string]string{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodDelete, Path: "/admin/queue/{xtid}", Tag: "admin",
+		Summary: "Remove one XT request from the queue",
+		Params: []ParamDoc{
+			{Name: "xtid", In: "path", Description: "0x-prefixed XT ID", Required: true},
+		},
+		Response: map[string]string{},
+	})
+
+	s.mux.Handle("/admin/queue", adminAuth(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			s.handleQueueList(w, r, queue)
+		case http.MethodPost:
+			s.handleQueueEnqueue(w, r, queue)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "GET or POST only")
+		}
+	})))
+	s.mux.Handle("/admin/queue/", adminAuth(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			writeError(w, http.StatusMethodNotAllowed, "DELETE only")
+			return
+		}
+		s.handleQueueDelete(w, r, queue)
+	})))
+}
+
+// adminAuth enforces bearer-token auth, falling back to mTLS when no token
+// is configured.
+func adminAuth(cfg AdminConfig, next http.Handler) http.Handler {
+	if cfg.Token == "" {
+		return RequireClientCert(next)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(cfg.Token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid admin token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleQueueList(w http.ResponseWriter, r *http.Request, queue store.XTQueue) {
+	params, err := parseListParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	reqs, err := queue.List()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	filtered := reqs[:0]
+	for _, req := range reqs {
+		if !params.InRange(time.Unix(int64(req.ReceivedAt), 0)) {
+			continue
+		}
+		if params.ChainID != nil && req.SourceChain != *params.ChainID {
+			continue
+		}
+		filtered = append(filtered, req)
+	}
+	page, next := paginate(filtered, params, func(req *types.XTRequest) string {
+		return req.XtID.String()
+	})
+	writeList(w, page, next)
+}
+
+func (s *Server) handleQueueEnqueue(w http.ResponseWriter, r *http.Request, queue store.XTQueue) {
+	var body enqueueRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+		return
+	}
+	if body.XtID.IsZero() {
+		writeError(w, http.StatusBadRequest, "xt_id is required")
+		return
+	}
+	if len(body.Chains) == 0 {
+		writeError(w, http.StatusBadRequest, "chains is required")
+		return
+	}
+	payload, err := hex.DecodeString(strings.TrimPrefix(body.PayloadHex, "0x"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid payload_hex: "+err.Error())
+		return
+	}
+	req := &types.XTRequest{
+		XtID:        body.XtID,
+		SourceChain: body.SourceChain,
+		Chains:      body.Chains,
+		Payload:     payload,
+		ReceivedAt:  uint64(time.Now().Unix()),
+	}
+	if err := queue.Enqueue(req); err != nil {
+		if errors.Is(err, store.ErrDuplicate) {
+			writeError(w, http.StatusConflict, "XT already queued")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.logger.Warn("XT request enqueued via admin API", zap.String("xt_id", body.XtID.String()))
+	writeJSON(w, http.StatusCreated, map[string]string{"status": "enqueued"})
+}
+
+func (s *Server) handleQueueDelete(w http.ResponseWriter, r *http.Request, queue store.XTQueue) {
+	raw := strings.TrimPrefix(r.URL.Path, "/admin/queue/")
+	xtID, err := types.ParseHash(raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid XT ID: "+err.Error())
+		return
+	}
+	if err := queue.Remove(xtID); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	s.logger.Warn("XT request removed via admin API", zap.String("xt_id", xtID.String()))
+	writeJSON(w, http.StatusOK, map[string]string{"status": "removed"})
+}
@@ -0,0 +1,91 @@
+package apisrv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+func startAdminServer(t *testing.T, token string) (*Server, store.XTQueue) {
+	t.Helper()
+	queue := store.NewMemoryXTQueue()
+	srv := New(zap.NewNop(), Config{Addr: "127.0.0.1:0"})
+	srv.RegisterAdmin(AdminConfig{Token: token}, queue)
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+	return srv, queue
+}
+
+func adminDo(t *testing.T, method, url, token, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestAdminQueueRequiresToken(t *testing.T) {
+	srv, _ := startAdminServer(t, "secret")
+	url := fmt.Sprintf("http://%s/admin/queue", srv.Addr())
+
+	resp := adminDo(t, http.MethodGet, url, "", "")
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = adminDo(t, http.MethodGet, url, "wrong", "")
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp = adminDo(t, http.MethodGet, url, "secret", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestAdminEnqueueAndDelete(t *testing.T) {
+	srv, queue := startAdminServer(t, "secret")
+	base := fmt.Sprintf("http://%s/admin/queue", srv.Addr())
+
+	xt := types.Hash{7}
+	body := fmt.Sprintf(`{"xt_id":%q,"source_chain":3,"chains":[3,4],"payload_hex":"0xdeadbeef"}`, xt)
+	resp := adminDo(t, http.MethodPost, base, "secret", body)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	// Duplicate enqueue conflicts.
+	resp = adminDo(t, http.MethodPost, base, "secret", body)
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	queued, err := queue.List()
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	require.Equal(t, []byte{0xde, 0xad, 0xbe, 0xef}, queued[0].Payload)
+
+	resp = adminDo(t, http.MethodDelete, base+"/"+xt.String(), "secret", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	queued, err = queue.List()
+	require.NoError(t, err)
+	require.Empty(t, queued)
+}
+
+func TestAdminEnqueueValidation(t *testing.T) {
+	srv, _ := startAdminServer(t, "secret")
+	base := fmt.Sprintf("http://%s/admin/queue", srv.Addr())
+
+	for _, body := range []string{
+		`{"chains":[1]}`, // missing xt_id
+		fmt.Sprintf(`{"xt_id":%q}`, types.Hash{1}),                                 // missing chains
+		fmt.Sprintf(`{"xt_id":%q,"chains":[1],"payload_hex":"zz"}`, types.Hash{1}), // bad hex
+	} {
+		resp := adminDo(t, http.MethodPost, base, "secret", body)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode, "body %s", body)
+	}
+}
@@ -0,0 +1,62 @@
+package apisrv
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/registry"
+)
+
+// RegisterChains mounts the rollup registry endpoints:
+//
+//	GET /chains       every registered rollup's metadata
+//	GET /chains/{id}  one rollup's entry
+func (s *Server) RegisterChains(svc registry.Service) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/chains", Tag: "chains",
+		Summary:  "Registered rollups and their metadata",
+		Response: []registry.ChainInfo{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/chains/{id}", Tag: "chains",
+		Summary: "One rollup's registry entry",
+		Params: []ParamDoc{
+			{Name: "id", In: "path", Description: "chain ID", Required: true},
+		},
+		Response: registry.ChainInfo{},
+	})
+
+	s.mux.HandleFunc("/chains", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		writeJSON(w, http.StatusOK, svc.Chains())
+	})
+
+	s.mux.HandleFunc("/chains/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		rest := strings.TrimPrefix(r.URL.Path, "/chains/")
+		chainID, err := strconv.ParseUint(rest, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid chain ID")
+			return
+		}
+		info, err := svc.ChainInfo(types.ChainID(chainID))
+		if err != nil {
+			if errors.Is(err, registry.ErrUnknownChain) {
+				writeError(w, http.StatusNotFound, "no registered chain with that ID")
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, info)
+	})
+}
@@ -0,0 +1,139 @@
+package apisrv
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/compose-network/publisher/x/consensus"
+)
+
+// ConsensusInspector exposes SCP instance snapshots for debugging.
+type ConsensusInspector interface {
+	Transactions() []consensus.InstanceView
+}
+
+// RegisterConsensus mounts GET /consensus/transactions. The endpoint
+// returns active and recent 2PC transactions as JSON, or as a graph via
+// ?format=dot / ?format=mermaid for stuck-XT debugging.
+func (s *Server) RegisterConsensus(inspector ConsensusInspector) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/consensus/transactions", Tag: "consensus",
+		Summary: "Active and recent 2PC transactions",
+		Params: append([]ParamDoc{
+			{Name: "format", In: "query", Description: "json (default), dot or mermaid"},
+		}, listParamDocs()...),
+		Response: ListEnvelope{Items: []consensus.InstanceView{}},
+	})
+	s.mux.HandleFunc("/consensus/transactions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		params, err := parseListParams(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		views := filterViews(inspector.Transactions(), params)
+		switch r.URL.Query().Get("format") {
+		case "", "json":
+			sort.Slice(views, func(i, j int) bool {
+				return views[i].XtID.String() < views[j].XtID.String()
+			})
+			page, next := paginate(views, params, func(v consensus.InstanceView) string {
+				return v.XtID.String()
+			})
+			writeList(w, page, next)
+		case "dot":
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			_, _ = w.Write([]byte(renderDOT(views)))
+		case "mermaid":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(renderMermaid(views)))
+		default:
+			writeError(w, http.StatusBadRequest, "format must be json, dot or mermaid")
+		}
+	})
+}
+
+// filterViews applies the shared chain and time-range filters. The chain
+// filter matches any participant; the time range applies to StartedAt.
+func filterViews(views []consensus.InstanceView, params ListParams) []consensus.InstanceView {
+	out := views[:0]
+	for _, view := range views {
+		if !params.InRange(view.StartedAt) {
+			continue
+		}
+		if params.ChainID != nil {
+			found := false
+			for _, p := range view.Participants {
+				if p == *params.ChainID {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		out = append(out, view)
+	}
+	return out
+}
+
+func shortID(view consensus.InstanceView) string {
+	return view.XtID.String()[:10]
+}
+
+func renderDOT(views []consensus.InstanceView) string {
+	var b strings.Builder
+	b.WriteString("digraph consensus {\n  rankdir=LR;\n")
+	for _, view := range views {
+		xt := shortID(view)
+		fmt.Fprintf(&b, "  %q [shape=box,label=\"%s\\nslot %d\\n%s\"];\n", xt, xt, view.Slot, view.State)
+		voted := make(map[string]bool)
+		for _, vote := range view.Votes {
+			label := "commit"
+			if !vote.Commit {
+				label = "abort"
+			}
+			chain := fmt.Sprintf("chain %d", vote.ChainID)
+			voted[chain] = true
+			fmt.Fprintf(&b, "  %q -> %q [label=%q];\n", chain, xt, label)
+		}
+		for _, p := range view.Participants {
+			chain := fmt.Sprintf("chain %d", p)
+			if !voted[chain] {
+				fmt.Fprintf(&b, "  %q -> %q [label=\"pending\",style=dashed];\n", chain, xt)
+			}
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func renderMermaid(views []consensus.InstanceView) string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, view := range views {
+		xt := shortID(view)
+		fmt.Fprintf(&b, "  %s[\"%s<br/>slot %d<br/>%s\"]\n", xt, xt, view.Slot, view.State)
+		voted := make(map[uint64]bool)
+		for _, vote := range view.Votes {
+			label := "commit"
+			if !vote.Commit {
+				label = "abort"
+			}
+			voted[uint64(vote.ChainID)] = true
+			fmt.Fprintf(&b, "  chain%d -- %s --> %s\n", vote.ChainID, label, xt)
+		}
+		for _, p := range view.Participants {
+			if !voted[uint64(p)] {
+				fmt.Fprintf(&b, "  chain%d -. pending .-> %s\n", p, xt)
+			}
+		}
+	}
+	return b.String()
+}
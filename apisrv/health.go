@@ -0,0 +1,71 @@
+package apisrv
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/health"
+)
+
+// RegisterChainHealth mounts the chain reliability endpoints:
+//
+//	GET  /chains/health                per-chain scores and quarantine state
+//	POST /chains/health/{id}/override  pin or release a chain's quarantine
+func (s *Server) RegisterChainHealth(tracker *health.Tracker) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/chains/health", Tag: "chains",
+		Summary:  "Per-chain health scores and quarantine state",
+		Response: []health.ChainHealth{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodPost, Path: "/chains/health/{id}/override", Tag: "chains",
+		Summary: "Override a chain's quarantine decision",
+		Params: []ParamDoc{
+			{Name: "id", In: "path", Description: "chain ID", Required: true},
+		},
+		Response: map[string]string{},
+	})
+
+	s.mux.HandleFunc("/chains/health", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		writeJSON(w, http.StatusOK, tracker.Snapshot())
+	})
+
+	s.mux.HandleFunc("/chains/health/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "POST only")
+			return
+		}
+		rest := strings.TrimPrefix(r.URL.Path, "/chains/health/")
+		idPart, ok := strings.CutSuffix(rest, "/override")
+		if !ok {
+			writeError(w, http.StatusNotFound, "unknown chain health route")
+			return
+		}
+		chainID, err := strconv.ParseUint(idPart, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid chain ID")
+			return
+		}
+		var body struct {
+			// Override is "allow", "quarantine", or "" to return the chain
+			// to score-based handling.
+			Override health.Override `json:"override"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		if err := tracker.SetOverride(types.ChainID(chainID), body.Override); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+}
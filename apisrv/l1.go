@@ -0,0 +1,82 @@
+package apisrv
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+// RegisterL1Submissions mounts the L1 publication tracking endpoints:
+//
+//	GET /l1/submissions      all tracked publication transactions
+//	GET /l1/submissions/{n}  the transaction for one superblock
+func (s *Server) RegisterL1Submissions(tracked store.TrackedTxStore) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/l1/submissions", Tag: "l1",
+		Summary: "List tracked L1 publication transactions",
+		Params: []ParamDoc{
+			{Name: "state", In: "query", Description: "filter by transaction state"},
+		},
+		Response: []types.TrackedTx{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/l1/submissions/{n}", Tag: "l1",
+		Summary: "The L1 transaction publishing one superblock",
+		Params: []ParamDoc{
+			{Name: "n", In: "path", Description: "superblock number", Required: true},
+		},
+		Response: types.TrackedTx{},
+	})
+
+	s.mux.HandleFunc("/l1/submissions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		txs, err := tracked.ListTrackedTxs()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if state := r.URL.Query().Get("state"); state != "" {
+			filtered := make([]*types.TrackedTx, 0, len(txs))
+			for _, tx := range txs {
+				if tx.State == types.TransactionState(state) {
+					filtered = append(filtered, tx)
+				}
+			}
+			txs = filtered
+		}
+		if txs == nil {
+			txs = []*types.TrackedTx{}
+		}
+		writeJSON(w, http.StatusOK, txs)
+	})
+
+	s.mux.HandleFunc("/l1/submissions/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		rest := strings.TrimPrefix(r.URL.Path, "/l1/submissions/")
+		number, err := strconv.ParseUint(rest, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid superblock number")
+			return
+		}
+		tx, err := tracked.GetTrackedTx(number)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				writeError(w, http.StatusNotFound, "no tracked transaction for that superblock")
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, tx)
+	})
+}
@@ -0,0 +1,48 @@
+package apisrv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+func TestL1SubmissionsEndpoints(t *testing.T) {
+	tracked := store.NewMemoryTrackedTxStore()
+	require.NoError(t, tracked.PutTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 1, TxHash: types.Hash{1}, State: types.TxStateConfirmed, GasUsed: 21000,
+	}))
+	require.NoError(t, tracked.PutTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 2, TxHash: types.Hash{2}, State: types.TxStateSubmitted,
+	}))
+
+	srv := New(zap.NewNop(), Config{Addr: "127.0.0.1:0"})
+	srv.RegisterL1Submissions(tracked)
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	var txs []types.TrackedTx
+	code := getJSON(t, fmt.Sprintf("http://%s/l1/submissions", srv.Addr()), &txs)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, txs, 2)
+
+	txs = nil
+	code = getJSON(t, fmt.Sprintf("http://%s/l1/submissions?state=confirmed", srv.Addr()), &txs)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, txs, 1)
+	require.Equal(t, uint64(21000), txs[0].GasUsed)
+
+	var tx types.TrackedTx
+	code = getJSON(t, fmt.Sprintf("http://%s/l1/submissions/2", srv.Addr()), &tx)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, types.TxStateSubmitted, tx.State)
+
+	code = getJSON(t, fmt.Sprintf("http://%s/l1/submissions/99", srv.Addr()), nil)
+	require.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,101 @@
+package apisrv
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/logging"
+)
+
+// logLevelRequest is the PUT /admin/log-levels body. An empty module sets
+// the global level; an empty level removes the module's override.
+type logLevelRequest struct {
+	Module string `json:"module"`
+	Level  string `json:"level"`
+}
+
+// RegisterAdminLogLevels mounts the runtime log-level controls:
+//
+//	GET /admin/log-levels  effective global and per-module levels
+//	PUT /admin/log-levels  adjust one level at runtime
+func (s *Server) RegisterAdminLogLevels(cfg AdminConfig, levels *logging.Levels) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/admin/log-levels", Tag: "admin",
+		Summary:  "Effective log levels",
+		Response: map[string]string{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodPut, Path: "/admin/log-levels", Tag: "admin",
+		Summary:  "Adjust a log level at runtime",
+		Response: map[string]string{},
+	})
+	s.mux.Handle("/admin/log-levels", adminAuth(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, levels.Snapshot())
+		case http.MethodPut:
+			var body logLevelRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+				return
+			}
+			var err error
+			if body.Module == "" {
+				err = levels.SetGlobal(body.Level)
+			} else {
+				err = levels.SetModule(body.Module, body.Level)
+			}
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			s.logger.Warn("log level changed via admin API",
+				zap.String("module", body.Module),
+				zap.String("level", body.Level))
+			writeJSON(w, http.StatusOK, levels.Snapshot())
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "GET or PUT only")
+		}
+	})))
+}
+
+// RegisterAdminLogFormat mounts the runtime encoder toggle:
+//
+//	GET /admin/log-format  the active format
+//	PUT /admin/log-format  switch between console and json
+func (s *Server) RegisterAdminLogFormat(cfg AdminConfig, format *logging.Format) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/admin/log-format", Tag: "admin",
+		Summary:  "Active log format",
+		Response: map[string]string{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodPut, Path: "/admin/log-format", Tag: "admin",
+		Summary:  "Switch the log format at runtime",
+		Response: map[string]string{},
+	})
+	s.mux.Handle("/admin/log-format", adminAuth(cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]string{"format": format.Get()})
+		case http.MethodPut:
+			var body struct {
+				Format string `json:"format"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid body: "+err.Error())
+				return
+			}
+			if err := format.Set(body.Format); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			s.logger.Warn("log format changed via admin API", zap.String("format", body.Format))
+			writeJSON(w, http.StatusOK, map[string]string{"format": format.Get()})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "GET or PUT only")
+		}
+	})))
+}
@@ -0,0 +1,202 @@
+package apisrv
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// RouteDoc describes one API route for the OpenAPI document. Subsystems
+// register their routes with Describe so the served specification stays in
+// step with the mux.
+type RouteDoc struct {
+	Method  string
+	Path    string
+	Summary string
+	Tag     string
+	Params  []ParamDoc
+	// Response is a zero value of the success response body; its type is
+	// reflected into the schema. Nil means no JSON body.
+	Response any
+}
+
+// ParamDoc describes a query or path parameter.
+type ParamDoc struct {
+	Name        string
+	In          string // "query" or "path"
+	Description string
+	Required    bool
+}
+
+// Describe adds a route to the OpenAPI document served at /openapi.json.
+func (s *Server) Describe(doc RouteDoc) {
+	s.docs = append(s.docs, doc)
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "GET only")
+		return
+	}
+	writeJSON(w, http.StatusOK, buildSpec(s.docs))
+}
+
+// buildSpec renders the registered routes as an OpenAPI 3.0 document.
+func buildSpec(docs []RouteDoc) map[string]any {
+	paths := map[string]any{}
+	byPath := map[string][]RouteDoc{}
+	for _, doc := range docs {
+		byPath[doc.Path] = append(byPath[doc.Path], doc)
+	}
+	for path, routes := range byPath {
+		ops := map[string]any{}
+		for _, doc := range routes {
+			op := map[string]any{
+				"summary": doc.Summary,
+				"responses": map[string]any{
+					"200": responseObject(doc.Response),
+				},
+			}
+			if doc.Tag != "" {
+				op["tags"] = []string{doc.Tag}
+			}
+			if len(doc.Params) > 0 {
+				params := make([]any, 0, len(doc.Params))
+				for _, p := range doc.Params {
+					params = append(params, map[string]any{
+						"name":        p.Name,
+						"in":          p.In,
+						"description": p.Description,
+						"required":    p.Required,
+						"schema":      map[string]any{"type": "string"},
+					})
+				}
+				op["parameters"] = params
+			}
+			ops[lowerMethod(doc.Method)] = op
+		}
+		paths[path] = ops
+	}
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "compose publisher API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+	}
+}
+
+func lowerMethod(method string) string {
+	switch method {
+	case http.MethodGet:
+		return "get"
+	case http.MethodPost:
+		return "post"
+	case http.MethodPut:
+		return "put"
+	case http.MethodDelete:
+		return "delete"
+	}
+	return "get"
+}
+
+func responseObject(body any) map[string]any {
+	resp := map[string]any{"description": "success"}
+	if body == nil {
+		return resp
+	}
+	resp["content"] = map[string]any{
+		"application/json": map[string]any{
+			"schema": schemaOf(reflect.TypeOf(body)),
+		},
+	}
+	return resp
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaOf reflects a Go type into a JSON schema fragment, following the
+// same encoding rules encoding/json applies to our types.
+func schemaOf(t reflect.Type) map[string]any {
+	switch {
+	case t == nil:
+		return map[string]any{}
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaOf(t.Elem())
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// []byte and hash types marshal to strings.
+			return map[string]any{"type": "string"}
+		}
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaOf(t.Elem())}
+	case reflect.Struct:
+		props := map[string]any{}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, omit := jsonFieldName(field)
+			if omit {
+				continue
+			}
+			props[name] = schemaOf(field.Type)
+		}
+		return map[string]any{"type": "object", "properties": props}
+	}
+	return map[string]any{}
+}
+
+func jsonFieldName(field reflect.StructField) (name string, omit bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name = field.Name
+	for i, part := range splitComma(tag) {
+		if i == 0 && part != "" {
+			name = part
+		}
+	}
+	return name, false
+}
+
+func splitComma(s string) []string {
+	var parts []string
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		if i == len(s) || s[i] == ',' {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return parts
+}
+
+// sortedPaths is used by tests to assert the spec is complete.
+func sortedPaths(spec map[string]any) []string {
+	paths, _ := spec["paths"].(map[string]any)
+	out := make([]string, 0, len(paths))
+	for p := range paths {
+		out = append(out, p)
+	}
+	sort.Strings(out)
+	return out
+}
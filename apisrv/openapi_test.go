@@ -0,0 +1,48 @@
+package apisrv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/x/consensus"
+)
+
+func TestOpenAPISpecCoversRegisteredRoutes(t *testing.T) {
+	srv := New(zap.NewNop(), Config{Addr: "127.0.0.1:0"})
+	coordinator := consensus.NewCoordinator(zap.NewNop(), nil, nil)
+	srv.RegisterConsensus(coordinator)
+
+	spec := buildSpec(srv.docs)
+	require.Equal(t, "3.0.3", spec["openapi"])
+	require.Equal(t, []string{
+		"/consensus/transactions",
+		"/health",
+		"/openapi.json",
+	}, sortedPaths(spec))
+}
+
+func TestSchemaOfReflectsJSONShape(t *testing.T) {
+	type inner struct {
+		Count int `json:"count"`
+	}
+	type sample struct {
+		Name    string  `json:"name"`
+		Hidden  string  `json:"-"`
+		Blob    []byte  `json:"blob"`
+		Inners  []inner `json:"inners"`
+		NoTag   bool
+		Pointer *inner `json:"pointer"`
+	}
+	schema := schemaOf(reflect.TypeOf(sample{}))
+	props := schema["properties"].(map[string]any)
+	require.Contains(t, props, "name")
+	require.NotContains(t, props, "Hidden")
+	require.Contains(t, props, "NoTag")
+	require.Equal(t, map[string]any{"type": "string"}, props["blob"])
+	items := props["inners"].(map[string]any)["items"].(map[string]any)
+	require.Contains(t, items["properties"], "count")
+	require.Equal(t, "object", props["pointer"].(map[string]any)["type"])
+}
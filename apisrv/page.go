@@ -0,0 +1,132 @@
+package apisrv
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// Pagination limits shared by every list endpoint.
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 1000
+)
+
+// ListParams are the common query parameters of list endpoints:
+// limit/cursor pagination plus time-range and chain filters.
+type ListParams struct {
+	Limit  int
+	Cursor string
+	// Since and Until bound the time range; zero means unbounded.
+	Since time.Time
+	Until time.Time
+	// ChainID filters to one chain when non-nil.
+	ChainID *types.ChainID
+}
+
+// InRange reports whether ts falls inside the [Since, Until) filter.
+func (p ListParams) InRange(ts time.Time) bool {
+	if !p.Since.IsZero() && ts.Before(p.Since) {
+		return false
+	}
+	if !p.Until.IsZero() && !ts.Before(p.Until) {
+		return false
+	}
+	return true
+}
+
+// parseListParams extracts the shared list parameters from the request.
+// Timestamps accept RFC 3339 or unix seconds.
+func parseListParams(r *http.Request) (ListParams, error) {
+	q := r.URL.Query()
+	params := ListParams{Limit: DefaultPageLimit, Cursor: q.Get("cursor")}
+	if raw := q.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return params, fmt.Errorf("invalid limit %q", raw)
+		}
+		if limit > MaxPageLimit {
+			limit = MaxPageLimit
+		}
+		params.Limit = limit
+	}
+	for name, dst := range map[string]*time.Time{"since": &params.Since, "until": &params.Until} {
+		raw := q.Get(name)
+		if raw == "" {
+			continue
+		}
+		ts, err := parseTimestamp(raw)
+		if err != nil {
+			return params, fmt.Errorf("invalid %s %q", name, raw)
+		}
+		*dst = ts
+	}
+	if raw := q.Get("chain"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return params, fmt.Errorf("invalid chain %q", raw)
+		}
+		chain := types.ChainID(id)
+		params.ChainID = &chain
+	}
+	return params, nil
+}
+
+func parseTimestamp(raw string) (time.Time, error) {
+	if ts, err := time.Parse(time.RFC3339, raw); err == nil {
+		return ts, nil
+	}
+	unix, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(unix, 0), nil
+}
+
+// ListEnvelope is the response shape of every list endpoint. NextCursor is
+// empty on the final page.
+type ListEnvelope struct {
+	Items      any    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// paginate applies cursor/limit to an already filtered and stably ordered
+// slice. cursorOf must return a unique, order-stable key per item; the
+// cursor is exclusive, i.e. the page starts after the item it names.
+func paginate[T any](items []T, params ListParams, cursorOf func(T) string) (page []T, nextCursor string) {
+	start := 0
+	if params.Cursor != "" {
+		for i, item := range items {
+			if cursorOf(item) == params.Cursor {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := start + params.Limit
+	if end >= len(items) {
+		return items[start:], ""
+	}
+	page = items[start:end]
+	return page, cursorOf(page[len(page)-1])
+}
+
+// writeList writes a page in the shared envelope format.
+func writeList(w http.ResponseWriter, items any, nextCursor string) {
+	writeJSON(w, http.StatusOK, ListEnvelope{Items: items, NextCursor: nextCursor})
+}
+
+// listParamDocs are the OpenAPI parameter entries for the shared list
+// conventions; list endpoints append them to their own params.
+func listParamDocs() []ParamDoc {
+	return []ParamDoc{
+		{Name: "limit", In: "query", Description: fmt.Sprintf("page size, default %d, max %d", DefaultPageLimit, MaxPageLimit)},
+		{Name: "cursor", In: "query", Description: "opaque cursor from the previous page's next_cursor"},
+		{Name: "since", In: "query", Description: "lower time bound, RFC 3339 or unix seconds"},
+		{Name: "until", In: "query", Description: "upper time bound, RFC 3339 or unix seconds"},
+		{Name: "chain", In: "query", Description: "filter to one chain ID"},
+	}
+}
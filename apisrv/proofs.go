@@ -0,0 +1,185 @@
+package apisrv
+
+import (
+	"crypto/sha256"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/prover"
+)
+
+// ProofSource streams accepted proof payloads. *prover.Collector satisfies
+// it.
+type ProofSource interface {
+	OpenProof(chainID types.ChainID, superblockNumber uint64) (io.ReadCloser, error)
+}
+
+// ProofPipelineStats snapshots the proving backlog. *prover.Pipeline
+// satisfies it.
+type ProofPipelineStats interface {
+	Stats() prover.PipelineStats
+}
+
+// ProofCollectorStats reports the proofs collected per superblock.
+// *prover.Collector satisfies it.
+type ProofCollectorStats interface {
+	Stats() []prover.SuperblockProofs
+}
+
+// ProofStatusSource reports each superblock's proving lifecycle status
+// and transition history. *prover.StatusTracker satisfies it.
+type ProofStatusSource interface {
+	Statuses() []prover.SuperblockStatus
+}
+
+// proofStats is the /proofs/stats response body.
+type proofStats struct {
+	Pipeline  prover.PipelineStats      `json:"pipeline"`
+	Collector []prover.SuperblockProofs `json:"collector"`
+	// Statuses is each tracked superblock's lifecycle status with its
+	// transition history; omitted when no tracker is wired.
+	Statuses []prover.SuperblockStatus `json:"statuses,omitempty"`
+}
+
+// RegisterProofStats mounts GET /proofs/stats: the pipeline's per-stage
+// backlog, last completion, which chains have delivered proofs for each
+// outstanding superblock, and the per-superblock lifecycle history. Any
+// source may be nil when that part of the proving path is not running.
+func (s *Server) RegisterProofStats(pipeline ProofPipelineStats, collector ProofCollectorStats, statuses ProofStatusSource) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/proofs/stats", Tag: "proofs",
+		Summary:  "Proving backlog and per-superblock collector state",
+		Response: proofStats{},
+	})
+	s.mux.HandleFunc("/proofs/stats", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		stats := proofStats{Collector: []prover.SuperblockProofs{}}
+		if pipeline != nil {
+			stats.Pipeline = pipeline.Stats()
+		}
+		if collector != nil {
+			stats.Collector = collector.Stats()
+		}
+		if statuses != nil {
+			stats.Statuses = statuses.Statuses()
+		}
+		writeJSON(w, http.StatusOK, stats)
+	})
+}
+
+// ProofSink accepts proof submissions. *prover.Collector satisfies it.
+type ProofSink interface {
+	Submit(sub prover.ProofSubmission) error
+}
+
+// maxProofBytes bounds a posted proof body; real proofs are far smaller.
+const maxProofBytes = 32 << 20
+
+// RegisterProofArtifacts mounts proof retrieval and submission:
+//
+//	GET  /proofs/{chain}/{n}  raw proof bytes for one chain and superblock
+//	POST /proofs/{chain}/{n}  submit raw proof bytes for collection
+//
+// sink may be nil for read-only deployments; POST then answers 405.
+func (s *Server) RegisterProofArtifacts(proofs ProofSource, sink ProofSink) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/proofs/{chain}/{n}", Tag: "proofs",
+		Summary: "Stream the accepted proof for a chain and superblock",
+		Params: []ParamDoc{
+			{Name: "chain", In: "path", Description: "chain ID", Required: true},
+			{Name: "n", In: "path", Description: "superblock number", Required: true},
+		},
+	})
+	if sink != nil {
+		s.Describe(RouteDoc{
+			Method: http.MethodPost, Path: "/proofs/{chain}/{n}", Tag: "proofs",
+			Summary: "Submit the raw proof bytes for a chain and superblock",
+			Params: []ParamDoc{
+				{Name: "chain", In: "path", Description: "chain ID", Required: true},
+				{Name: "n", In: "path", Description: "superblock number", Required: true},
+			},
+		})
+	}
+
+	s.mux.HandleFunc("/proofs/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && !(r.Method == http.MethodPost && sink != nil) {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/proofs/"), "/")
+		if len(parts) != 2 {
+			writeError(w, http.StatusBadRequest, "expected /proofs/{chain}/{n}")
+			return
+		}
+		chainID, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid chain ID")
+			return
+		}
+		number, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid superblock number")
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			s.submitProof(w, r, sink, types.ChainID(chainID), number)
+			return
+		}
+
+		rc, err := proofs.OpenProof(types.ChainID(chainID), number)
+		if err != nil {
+			if errors.Is(err, prover.ErrNoArtifact) {
+				writeError(w, http.StatusNotFound, "no accepted proof for that chain and superblock")
+				return
+			}
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		defer rc.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		if _, err := io.Copy(w, rc); err != nil {
+			s.logger.Warn("streaming proof artifact failed", zap.Error(err))
+		}
+	})
+}
+
+// submitProof hands a posted proof body to the collector. The proof hash
+// is derived from the bytes, so HTTP submitters dedupe exactly like
+// transport-side ones.
+func (s *Server) submitProof(w http.ResponseWriter, r *http.Request, sink ProofSink, chainID types.ChainID, number uint64) {
+	proof, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxProofBytes))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "read proof body: "+err.Error())
+		return
+	}
+	if len(proof) == 0 {
+		writeError(w, http.StatusBadRequest, "empty proof body")
+		return
+	}
+	proofHash := types.Hash(sha256.Sum256(proof))
+	err = sink.Submit(prover.ProofSubmission{
+		ChainID: chainID, SuperblockNumber: number,
+		ProofHash: proofHash, Proof: proof,
+	})
+	switch {
+	case errors.Is(err, prover.ErrInvalidProof):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, prover.ErrDuplicateProof), errors.Is(err, prover.ErrProofSuperseded):
+		writeError(w, http.StatusConflict, err.Error())
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err.Error())
+	default:
+		writeJSON(w, http.StatusAccepted, map[string]string{"proof_hash": proofHash.String()})
+	}
+}
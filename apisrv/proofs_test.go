@@ -0,0 +1,65 @@
+package apisrv
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/prover"
+)
+
+func TestProofArtifactStreaming(t *testing.T) {
+	collector := prover.NewCollector(zap.NewNop(), nil)
+	collector.SetArtifactStore(prover.NewMemoryArtifactStore())
+	payload := []byte("proof bytes")
+	require.NoError(t, collector.Submit(prover.ProofSubmission{
+		ChainID: 1, SuperblockNumber: 7, ProofHash: types.Hash{7}, Proof: payload,
+	}))
+
+	srv := New(zap.NewNop(), Config{Addr: "127.0.0.1:0"})
+	srv.RegisterProofArtifacts(collector, nil)
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/proofs/1/7", srv.Addr()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "application/octet-stream", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, payload, body)
+
+	code := getJSON(t, fmt.Sprintf("http://%s/proofs/1/99", srv.Addr()), nil)
+	require.Equal(t, http.StatusNotFound, code)
+
+	code = getJSON(t, fmt.Sprintf("http://%s/proofs/not-a-chain/7", srv.Addr()), nil)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestProofStatsIncludesStatuses(t *testing.T) {
+	tracker := prover.NewStatusTracker()
+	tracker.Begin(3)
+	require.NoError(t, tracker.Transition(3, prover.StatusQueued, ""))
+
+	srv := New(zap.NewNop(), Config{Addr: "127.0.0.1:0"})
+	srv.RegisterProofStats(nil, nil, tracker)
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	var stats struct {
+		Statuses []prover.SuperblockStatus `json:"statuses"`
+	}
+	code := getJSON(t, fmt.Sprintf("http://%s/proofs/stats", srv.Addr()), &stats)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, stats.Statuses, 1)
+	require.Equal(t, uint64(3), stats.Statuses[0].SuperblockNumber)
+	require.Equal(t, prover.StatusQueued, stats.Statuses[0].Status)
+	require.Len(t, stats.Statuses[0].History, 2)
+}
@@ -0,0 +1,88 @@
+package apisrv
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// CheckFunc probes one subsystem; nil means ready. Checks must be fast and
+// non-blocking — readiness is polled by orchestrators.
+type CheckFunc func() error
+
+// componentStatus is one subsystem's entry in the readiness response.
+type componentStatus struct {
+	Status string `json:"status"` // "ok" or "error"
+	Error  string `json:"error,omitempty"`
+}
+
+// readyResponse is the aggregate readiness report.
+type readyResponse struct {
+	Status     string                     `json:"status"` // "ready" or "degraded"
+	Components map[string]componentStatus `json:"components"`
+}
+
+// readiness aggregates named subsystem checks.
+type readiness struct {
+	mu     sync.RWMutex
+	checks map[string]CheckFunc
+}
+
+// RegisterReady mounts GET /ready. Subsystems contribute via
+// AddReadyCheck; with no checks registered the endpoint reports ready, so
+// it is safe to mount before wiring. The endpoint returns 503 with
+// per-component detail as soon as any check fails, letting orchestrators
+// pull a degraded publisher out of rotation.
+func (s *Server) RegisterReady() {
+	s.ready = &readiness{checks: make(map[string]CheckFunc)}
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/ready", Tag: "ops",
+		Summary:  "Aggregated subsystem readiness",
+		Response: readyResponse{},
+	})
+	s.mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		response := s.ready.report()
+		status := http.StatusOK
+		if response.Status != "ready" {
+			status = http.StatusServiceUnavailable
+		}
+		writeJSON(w, status, response)
+	})
+}
+
+// AddReadyCheck registers a named subsystem check. Registering the same
+// name replaces the previous check. RegisterReady must have been called.
+func (s *Server) AddReadyCheck(name string, check CheckFunc) {
+	s.ready.mu.Lock()
+	defer s.ready.mu.Unlock()
+	s.ready.checks[name] = check
+}
+
+func (r *readiness) report() readyResponse {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checks := make([]CheckFunc, len(names))
+	for i, name := range names {
+		checks[i] = r.checks[name]
+	}
+	r.mu.RUnlock()
+
+	response := readyResponse{Status: "ready", Components: make(map[string]componentStatus, len(names))}
+	for i, name := range names {
+		if err := checks[i](); err != nil {
+			response.Status = "degraded"
+			response.Components[name] = componentStatus{Status: "error", Error: err.Error()}
+			continue
+		}
+		response.Components[name] = componentStatus{Status: "ok"}
+	}
+	return response
+}
@@ -0,0 +1,52 @@
+package apisrv
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReadyAggregatesComponentChecks(t *testing.T) {
+	srv := New(zap.NewNop(), Config{Addr: "127.0.0.1:0"})
+	srv.RegisterReady()
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+	url := fmt.Sprintf("http://%s/ready", srv.Addr())
+
+	fetch := func() (int, readyResponse) {
+		t.Helper()
+		resp, err := http.Get(url)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var response readyResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		return resp.StatusCode, response
+	}
+
+	// No checks registered: ready.
+	code, response := fetch()
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ready", response.Status)
+
+	proverErr := error(nil)
+	srv.AddReadyCheck("store", func() error { return nil })
+	srv.AddReadyCheck("prover", func() error { return proverErr })
+
+	code, response = fetch()
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "ready", response.Status)
+	require.Equal(t, "ok", response.Components["prover"].Status)
+
+	proverErr = errors.New("circuit open")
+	code, response = fetch()
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "degraded", response.Status)
+	require.Equal(t, "circuit open", response.Components["prover"].Error)
+	require.Equal(t, "ok", response.Components["store"].Status)
+}
@@ -0,0 +1,119 @@
+// Package apisrv hosts the publisher's HTTP API: health and stats,
+// proof submission, and operational inspection endpoints.
+package apisrv
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+// Config configures the HTTP API server.
+type Config struct {
+	// Addr is the listen address, e.g. ":8080".
+	Addr string `yaml:"Addr"`
+	// TLS, when set, serves the API over HTTPS with automatic certificate
+	// reload and optional client-certificate auth.
+	TLS TLSConfig `yaml:"TLS"`
+}
+
+// Server is the publisher's HTTP API server. Subsystems register their
+// endpoints via Handle before Start.
+type Server struct {
+	logger *zap.Logger
+	cfg    Config
+	mux    *http.ServeMux
+	srv    *http.Server
+	ln     net.Listener
+	docs   []RouteDoc
+	ready  *readiness
+}
+
+// New creates an API server with the built-in health and metrics routes.
+func New(logger *zap.Logger, cfg Config) *Server {
+	s := &Server{
+		logger: logger.Named("apisrv"),
+		cfg:    cfg,
+		mux:    http.NewServeMux(),
+	}
+	s.mux.HandleFunc("/health", s.handleHealth)
+	s.mux.Handle("/metrics", promhttp.Handler())
+	s.mux.HandleFunc("/openapi.json", s.handleOpenAPI)
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/health", Tag: "ops",
+		Summary:  "Liveness check",
+		Response: map[string]string{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/openapi.json", Tag: "ops",
+		Summary: "This OpenAPI document",
+	})
+	return s
+}
+
+// Handle registers a route. Must be called before Start.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Start begins serving; it returns once the listener is bound.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	if s.cfg.TLS.Enabled() {
+		tlsCfg, err := buildTLSConfig(s.cfg.TLS)
+		if err != nil {
+			_ = ln.Close()
+			return err
+		}
+		ln = tls.NewListener(ln, tlsCfg)
+	}
+	s.ln = ln
+	s.srv = &http.Server{
+		Handler:           s.mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("api server terminated", zap.Error(err))
+		}
+	}()
+	s.logger.Info("api server listening", zap.String("addr", ln.Addr().String()))
+	return nil
+}
+
+// Addr returns the bound listen address; valid after Start.
+func (s *Server) Addr() net.Addr {
+	return s.ln.Addr()
+}
+
+// Stop shuts the server down gracefully.
+func (s *Server) Stop(ctx context.Context) error {
+	if s.srv == nil {
+		return nil
+	}
+	return s.srv.Shutdown(ctx)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
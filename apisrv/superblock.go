@@ -0,0 +1,184 @@
+package apisrv
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/prover"
+)
+
+// blockManifestEntry is one row of a superblock's block manifest: enough
+// to identify every included L2 block without shipping the block bodies.
+type blockManifestEntry struct {
+	ChainID types.ChainID `json:"chain_id"`
+	Number  uint64        `json:"number"`
+	Hash    types.Hash    `json:"hash"`
+}
+
+// RegisterSuperblocks mounts the superblock inspection endpoints:
+//
+//	GET /superblocks                       list, filterable by status and number range
+//	GET /superblocks/{n}                   one superblock
+//	GET /superblocks/{n}/journal           construction journal
+//	GET /superblocks/{n}/blocks            block manifest
+//	GET /superblocks/{n}/prover-input      the exact ProofJobInput for the superblock
+func (s *Server) RegisterSuperblocks(superblocks store.SuperblockStore, journal store.ConstructionJournal) {
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/superblocks", Tag: "superblocks",
+		Summary: "List superblocks",
+		Params: append([]ParamDoc{
+			{Name: "status", In: "query", Description: "filter by publication status"},
+			{Name: "from", In: "query", Description: "lowest superblock number to include"},
+			{Name: "to", In: "query", Description: "highest superblock number to include"},
+		}, listParamDocs()...),
+		Response: ListEnvelope{Items: []types.Superblock{}},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/superblocks/{n}", Tag: "superblocks",
+		Summary: "One superblock by number",
+		Params: []ParamDoc{
+			{Name: "n", In: "path", Description: "superblock number", Required: true},
+		},
+		Response: types.Superblock{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/superblocks/{n}/journal", Tag: "superblocks",
+		Summary: "Construction journal of one superblock",
+		Params: []ParamDoc{
+			{Name: "n", In: "path", Description: "superblock number", Required: true},
+		},
+		Response: []store.JournalEvent{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/superblocks/{n}/blocks", Tag: "superblocks",
+		Summary: "Block manifest of one superblock",
+		Params: []ParamDoc{
+			{Name: "n", In: "path", Description: "superblock number", Required: true},
+		},
+		Response: []blockManifestEntry{},
+	})
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/superblocks/{n}/prover-input", Tag: "superblocks",
+		Summary: "Prover job input for one superblock",
+		Params: []ParamDoc{
+			{Name: "n", In: "path", Description: "superblock number", Required: true},
+		},
+		Response: prover.ProofJobInput{},
+	})
+
+	s.mux.HandleFunc("/superblocks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		s.handleListSuperblocks(w, r, superblocks)
+	})
+	s.mux.HandleFunc("/superblocks/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		rest := strings.TrimPrefix(r.URL.Path, "/superblocks/")
+		number, sub, _ := strings.Cut(rest, "/")
+		n, err := strconv.ParseUint(number, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid superblock number")
+			return
+		}
+		if sub == "journal" {
+			events, err := journal.Events(n)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+			if len(events) == 0 {
+				writeError(w, http.StatusNotFound, "no journal for superblock")
+				return
+			}
+			writeJSON(w, http.StatusOK, events)
+			return
+		}
+
+		sb, err := superblocks.GetSuperblock(n)
+		if errors.Is(err, store.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "superblock not found")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		switch sub {
+		case "":
+			writeJSON(w, http.StatusOK, sb)
+		case "blocks":
+			manifest := make([]blockManifestEntry, len(sb.L2Blocks))
+			for i, block := range sb.L2Blocks {
+				manifest[i] = blockManifestEntry{ChainID: block.ChainID, Number: block.Number, Hash: block.Hash}
+			}
+			w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=superblock-%d-blocks.json", n))
+			writeJSON(w, http.StatusOK, manifest)
+		case "prover-input":
+			writeJSON(w, http.StatusOK, prover.JobInput(sb))
+		default:
+			writeError(w, http.StatusNotFound, "not found")
+		}
+	})
+}
+
+func (s *Server) handleListSuperblocks(w http.ResponseWriter, r *http.Request, superblocks store.SuperblockStore) {
+	params, err := parseListParams(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	q := r.URL.Query()
+	status := types.SuperblockStatus(q.Get("status"))
+	from := uint64(0)
+	if v := q.Get("from"); v != "" {
+		if from, err = strconv.ParseUint(v, 10, 64); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from")
+			return
+		}
+	}
+	var to uint64
+	if v := q.Get("to"); v != "" {
+		if to, err = strconv.ParseUint(v, 10, 64); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid to")
+			return
+		}
+	} else {
+		latest, err := superblocks.LatestSuperblock()
+		if errors.Is(err, store.ErrNotFound) {
+			writeList(w, []*types.Superblock{}, "")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		to = latest.Number
+	}
+
+	all, err := superblocks.ListSuperblocks(from, to)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	filtered := all[:0]
+	for _, sb := range all {
+		if status != "" && sb.Status != status {
+			continue
+		}
+		filtered = append(filtered, sb)
+	}
+	page, next := paginate(filtered, params, func(sb *types.Superblock) string {
+		return strconv.FormatUint(sb.Number, 10)
+	})
+	writeList(w, page, next)
+}
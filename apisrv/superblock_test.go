@@ -0,0 +1,116 @@
+package apisrv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/prover"
+)
+
+func startSuperblockServer(t *testing.T) (*Server, store.SuperblockStore) {
+	t.Helper()
+	superblocks := store.NewMemorySuperblockStore()
+	srv := New(zap.NewNop(), Config{Addr: "127.0.0.1:0"})
+	srv.RegisterSuperblocks(superblocks, store.NewMemoryConstructionJournal())
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+	return srv, superblocks
+}
+
+func getJSON(t *testing.T, url string, out any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil && resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestListSuperblocksByStatus(t *testing.T) {
+	srv, superblocks := startSuperblockServer(t)
+	for n := uint64(1); n <= 4; n++ {
+		status := types.SuperblockStatusSealed
+		if n%2 == 0 {
+			status = types.SuperblockStatusConfirmed
+		}
+		require.NoError(t, superblocks.PutSuperblock(&types.Superblock{Number: n, Status: status}))
+	}
+
+	var envelope struct {
+		Items []types.Superblock `json:"items"`
+	}
+	code := getJSON(t, fmt.Sprintf("http://%s/superblocks?status=confirmed", srv.Addr()), &envelope)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, envelope.Items, 2)
+	for _, sb := range envelope.Items {
+		require.Equal(t, types.SuperblockStatusConfirmed, sb.Status)
+	}
+
+	// Number range filter.
+	code = getJSON(t, fmt.Sprintf("http://%s/superblocks?from=2&to=3", srv.Addr()), &envelope)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, envelope.Items, 2)
+	require.Equal(t, uint64(2), envelope.Items[0].Number)
+}
+
+func TestSuperblockProverInputAndManifest(t *testing.T) {
+	srv, superblocks := startSuperblockServer(t)
+	sb := &types.Superblock{
+		Number:       7,
+		MerkleRoot:   types.Hash{1},
+		MerkleScheme: "binary",
+		L2Blocks: []*types.L2Block{
+			{ChainID: 1, Number: 10, Hash: types.Hash{0xa}},
+			{ChainID: 2, Number: 20, Hash: types.Hash{0xb}},
+		},
+		Status: types.SuperblockStatusSealed,
+	}
+	require.NoError(t, superblocks.PutSuperblock(sb))
+
+	var input prover.ProofJobInput
+	code := getJSON(t, fmt.Sprintf("http://%s/superblocks/7/prover-input", srv.Addr()), &input)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, uint64(7), input.SuperblockNumber)
+	require.Equal(t, "binary", input.MerkleScheme)
+	require.Len(t, input.L2Blocks, 2)
+
+	var manifest []blockManifestEntry
+	code = getJSON(t, fmt.Sprintf("http://%s/superblocks/7/blocks", srv.Addr()), &manifest)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, manifest, 2)
+	require.Equal(t, types.ChainID(2), manifest[1].ChainID)
+
+	code = getJSON(t, fmt.Sprintf("http://%s/superblocks/9/prover-input", srv.Addr()), nil)
+	require.Equal(t, http.StatusNotFound, code)
+	code = getJSON(t, fmt.Sprintf("http://%s/superblocks/x", srv.Addr()), nil)
+	require.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestVersionEndpoint(t *testing.T) {
+	srv := New(zap.NewNop(), Config{Addr: "127.0.0.1:0"})
+	srv.RegisterVersion(BuildInfo{
+		Version:  "v1.2.3",
+		Features: map[string]bool{"proofs": true, "l1": false},
+		Keys:     map[string]string{"publisher": "02abcd"},
+	})
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	var info BuildInfo
+	code := getJSON(t, fmt.Sprintf("http://%s/version", srv.Addr()), &info)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "v1.2.3", info.Version)
+	require.Equal(t, "unknown", info.GitCommit) // link-time default
+	require.True(t, info.Features["proofs"])
+	require.Equal(t, "02abcd", info.Keys["publisher"])
+}
@@ -0,0 +1,166 @@
+package apisrv
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// writeSelfSigned writes a self-signed cert/key pair with the given CN and
+// returns the cert file, key file and parsed certificate.
+func writeSelfSigned(t *testing.T, dir, cn string) (certFile, keyFile string, cert tls.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	certFile = filepath.Join(dir, cn+".crt")
+	keyFile = filepath.Join(dir, cn+".key")
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+
+	cert, err = tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	return certFile, keyFile, cert
+}
+
+func TestServerServesTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, _ := writeSelfSigned(t, dir, "server")
+
+	srv := New(zap.NewNop(), Config{
+		Addr: "127.0.0.1:0",
+		TLS:  TLSConfig{CertFile: certFile, KeyFile: keyFile},
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop(context.Background())
+
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+	resp, err := client.Get(fmt.Sprintf("https://%s/health", srv.Addr()))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServerReloadsRotatedCert(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, _ := writeSelfSigned(t, dir, "server")
+
+	srv := New(zap.NewNop(), Config{
+		Addr: "127.0.0.1:0",
+		TLS: TLSConfig{
+			CertFile:       certFile,
+			KeyFile:        keyFile,
+			ReloadInterval: time.Millisecond,
+		},
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop(context.Background())
+
+	// Rotate the keypair on disk; the server should start serving the new
+	// cert without a restart.
+	rotatedDir := t.TempDir()
+	newCertFile, newKeyFile, rotated := writeSelfSigned(t, rotatedDir, "server")
+	newCertPEM, err := os.ReadFile(newCertFile)
+	require.NoError(t, err)
+	newKeyPEM, err := os.ReadFile(newKeyFile)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, newCertPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, newKeyPEM, 0o600))
+
+	want := rotated.Leaf
+	if want == nil {
+		want, err = x509.ParseCertificate(rotated.Certificate[0])
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		conn, err := tls.Dial("tcp", srv.Addr().String(), &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return false
+		}
+		defer conn.Close()
+		served := conn.ConnectionState().PeerCertificates[0]
+		return served.SerialNumber.Cmp(want.SerialNumber) == 0
+	}, 2*time.Second, 20*time.Millisecond, "rotated cert never served")
+}
+
+func TestRequireClientCert(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, _ := writeSelfSigned(t, dir, "server")
+	clientCertFile, _, clientCert := writeSelfSigned(t, dir, "client")
+
+	srv := New(zap.NewNop(), Config{
+		Addr: "127.0.0.1:0",
+		TLS: TLSConfig{
+			CertFile:     certFile,
+			KeyFile:      keyFile,
+			ClientCAFile: clientCertFile,
+		},
+	})
+	srv.Handle("/protected", RequireClientCert(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})))
+	require.NoError(t, srv.Start())
+	defer srv.Stop(context.Background())
+
+	url := fmt.Sprintf("https://%s/protected", srv.Addr())
+
+	// Without a client cert the protected route refuses, but /health works.
+	anon := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+	resp, err := anon.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	resp, err = anon.Get(fmt.Sprintf("https://%s/health", srv.Addr()))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// With the trusted client cert the protected route serves.
+	authed := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
+			Certificates:       []tls.Certificate{clientCert},
+		},
+	}}
+	resp, err = authed.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,48 @@
+package apisrv
+
+import (
+	"net/http"
+
+	"github.com/compose-network/publisher/version"
+)
+
+// BuildInfo is the /version response: build metadata, the feature flags in
+// effect, and the configured key identities (public material only), so
+// fleet tooling can audit what each instance runs.
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"git_commit"`
+	BuildTime string `json:"build_time"`
+	// Features maps feature names ("proofs", "l1", "auth", ...) to whether
+	// they are enabled in this instance's configuration.
+	Features map[string]bool `json:"features,omitempty"`
+	// Keys maps key IDs to their compressed public keys. Never include
+	// private material here.
+	Keys map[string]string `json:"keys,omitempty"`
+}
+
+// RegisterVersion mounts GET /version. Empty build fields fall back to the
+// values stamped into the version package at link time.
+func (s *Server) RegisterVersion(info BuildInfo) {
+	if info.Version == "" {
+		info.Version = version.Version
+	}
+	if info.GitCommit == "" {
+		info.GitCommit = version.GitCommit
+	}
+	if info.BuildTime == "" {
+		info.BuildTime = version.BuildTime
+	}
+	s.Describe(RouteDoc{
+		Method: http.MethodGet, Path: "/version", Tag: "ops",
+		Summary:  "Build info, feature flags and key configuration",
+		Response: BuildInfo{},
+	})
+	s.mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "GET only")
+			return
+		}
+		writeJSON(w, http.StatusOK, info)
+	})
+}
@@ -0,0 +1,193 @@
+// Package client is a typed REST client for the publisher's HTTP API, so
+// external tooling does not hand-roll requests. Its methods mirror the
+// routes described by the server's /openapi.json document.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/consensus"
+)
+
+// APIError is returned for non-2xx responses carrying the server's JSON
+// error envelope.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("publisher api: %d: %s", e.StatusCode, e.Message)
+}
+
+// Option customizes a Client.
+type Option func(*Client)
+
+// WithHTTPClient substitutes the underlying *http.Client, e.g. to supply
+// TLS client certificates for mTLS-protected endpoints.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.http = hc }
+}
+
+// Client talks to one publisher API server.
+type Client struct {
+	base string
+	http *http.Client
+}
+
+// New creates a client for the API server at baseURL (e.g.
+// "http://127.0.0.1:8080").
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		base: strings.TrimRight(baseURL, "/"),
+		http: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Health reports whether the publisher answers its liveness check.
+func (c *Client) Health(ctx context.Context) error {
+	var out map[string]string
+	return c.get(ctx, "/health", nil, &out)
+}
+
+// OpenAPI fetches the server's OpenAPI document.
+func (c *Client) OpenAPI(ctx context.Context) (map[string]any, error) {
+	var out map[string]any
+	if err := c.get(ctx, "/openapi.json", nil, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOptions are the shared pagination and filter parameters of list
+// endpoints. The zero value requests the first page with server defaults.
+type ListOptions struct {
+	Limit  int
+	Cursor string
+	Since  time.Time
+	Until  time.Time
+	// Chain filters to one chain when non-nil.
+	Chain *types.ChainID
+}
+
+func (o ListOptions) values() url.Values {
+	q := url.Values{}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Cursor != "" {
+		q.Set("cursor", o.Cursor)
+	}
+	if !o.Since.IsZero() {
+		q.Set("since", o.Since.Format(time.RFC3339))
+	}
+	if !o.Until.IsZero() {
+		q.Set("until", o.Until.Format(time.RFC3339))
+	}
+	if o.Chain != nil {
+		q.Set("chain", strconv.FormatUint(uint64(*o.Chain), 10))
+	}
+	return q
+}
+
+// listEnvelope mirrors the server's shared list response shape.
+type listEnvelope[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor"`
+}
+
+// ConsensusTransactions lists active and recent 2PC transactions. The
+// returned cursor is empty on the final page.
+func (c *Client) ConsensusTransactions(ctx context.Context, opts ListOptions) ([]consensus.InstanceView, string, error) {
+	var out listEnvelope[consensus.InstanceView]
+	if err := c.get(ctx, "/consensus/transactions", opts.values(), &out); err != nil {
+		return nil, "", err
+	}
+	return out.Items, out.NextCursor, nil
+}
+
+// SubmitProof posts raw proof bytes for a chain and superblock to the
+// publisher's proof collector and returns the proof hash the server
+// derived from them.
+func (c *Client) SubmitProof(ctx context.Context, chainID types.ChainID, superblockNumber uint64, proof []byte) (types.Hash, error) {
+	path := fmt.Sprintf("/proofs/%d/%d", chainID, superblockNumber)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+path, bytes.NewReader(proof))
+	if err != nil {
+		return types.Hash{}, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	var out struct {
+		ProofHash types.Hash `json:"proof_hash"`
+	}
+	if err := c.do(req, &out); err != nil {
+		return types.Hash{}, err
+	}
+	return out.ProofHash, nil
+}
+
+// get performs a GET request and decodes the JSON response into out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	u := c.base + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, out)
+}
+
+// post performs a POST request with a JSON body and decodes the response.
+func (c *Client) post(ctx context.Context, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = strings.NewReader(string(encoded))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req, out)
+}
+
+func (c *Client) do(req *http.Request, out any) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var envelope struct {
+			Error string `json:"error"`
+		}
+		msg := resp.Status
+		if err := json.NewDecoder(resp.Body).Decode(&envelope); err == nil && envelope.Error != "" {
+			msg = envelope.Error
+		}
+		return &APIError{StatusCode: resp.StatusCode, Message: msg}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/apisrv"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/consensus"
+)
+
+func startAPI(t *testing.T) (*apisrv.Server, *consensus.Coordinator) {
+	t.Helper()
+	coordinator := consensus.NewCoordinator(zap.NewNop(), nil, nil)
+	srv := apisrv.New(zap.NewNop(), apisrv.Config{Addr: "127.0.0.1:0"})
+	srv.RegisterConsensus(coordinator)
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+	return srv, coordinator
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	srv, coordinator := startAPI(t)
+	c := New(fmt.Sprintf("http://%s", srv.Addr()))
+
+	require.NoError(t, c.Health(context.Background()))
+
+	spec, err := c.OpenAPI(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, spec["paths"], "/consensus/transactions")
+
+	xt := types.Hash{1}
+	coordinator.StartSC(context.Background(), &types.XTRequest{XtID: xt, Chains: []types.ChainID{1, 2}}, 5)
+	views, next, err := c.ConsensusTransactions(context.Background(), ListOptions{})
+	require.NoError(t, err)
+	require.Empty(t, next)
+	require.Len(t, views, 1)
+	require.Equal(t, xt, views[0].XtID)
+}
+
+func TestClientPagination(t *testing.T) {
+	srv, coordinator := startAPI(t)
+	c := New(fmt.Sprintf("http://%s", srv.Addr()))
+
+	for i := byte(1); i <= 5; i++ {
+		coordinator.StartSC(context.Background(), &types.XTRequest{XtID: types.Hash{i}, Chains: []types.ChainID{types.ChainID(i)}}, 1)
+	}
+
+	var (
+		got    []types.XtID
+		cursor string
+	)
+	for {
+		views, next, err := c.ConsensusTransactions(context.Background(), ListOptions{Limit: 2, Cursor: cursor})
+		require.NoError(t, err)
+		for _, v := range views {
+			got = append(got, v.XtID)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	require.Len(t, got, 5)
+
+	// Chain filter narrows to the single matching instance.
+	chain := types.ChainID(3)
+	views, _, err := c.ConsensusTransactions(context.Background(), ListOptions{Chain: &chain})
+	require.NoError(t, err)
+	require.Len(t, views, 1)
+	require.Equal(t, types.Hash{3}, views[0].XtID)
+}
+
+func TestClientSurfacesAPIErrors(t *testing.T) {
+	srv, _ := startAPI(t)
+	c := New(fmt.Sprintf("http://%s", srv.Addr()))
+
+	err := c.get(context.Background(), "/missing", nil, nil)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, 404, apiErr.StatusCode)
+}
@@ -0,0 +1,194 @@
+// Package workflow runs YAML-scripted scenarios against a publisher's
+// HTTP API, so end-to-end flows — proof collection alongside XT
+// submission and the rest — are exercised from one declarative file
+// instead of ad-hoc curl scripts.
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/compose-network/publisher/client"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+// Step is one scripted action. Action selects the behaviour; the other
+// fields parameterize it and unused ones are ignored.
+type Step struct {
+	// Action is the step kind: "submit-proof", "wait-for-slot",
+	// "wait-in-slot", "send-l2block".
+	Action string `yaml:"Action"`
+	// Chain scopes chain-addressed actions.
+	Chain types.ChainID `yaml:"Chain"`
+	// Superblock scopes superblock-addressed actions.
+	Superblock uint64 `yaml:"Superblock"`
+	// File is a path, resolved relative to the scenario file.
+	File string `yaml:"File"`
+	// Slot is the target of wait-for-slot.
+	Slot types.Slot `yaml:"Slot"`
+	// Offset is wait-in-slot's position within the slot. "45ms" with a
+	// 60ms slot and 50ms cutover scripts "5ms before seal".
+	Offset time.Duration `yaml:"Offset"`
+}
+
+// Scenario is a parsed workflow file.
+type Scenario struct {
+	Name string `yaml:"Name"`
+	// Slots is the network's slot timing, required by the wait actions.
+	// It must match the publisher's configuration — the shared slot math
+	// turns it into the same boundaries the coordinator enforces.
+	Slots slot.Config `yaml:"Slots"`
+	Steps []Step      `yaml:"Steps"`
+
+	// dir is the scenario file's directory, for resolving relative paths.
+	dir string
+}
+
+// Load parses the scenario file at path.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("workflow: read scenario: %w", err)
+	}
+	var scenario Scenario
+	if err := yaml.Unmarshal(data, &scenario); err != nil {
+		return nil, fmt.Errorf("workflow: parse %s: %w", filepath.Base(path), err)
+	}
+	if len(scenario.Steps) == 0 {
+		return nil, fmt.Errorf("workflow: %s has no steps", filepath.Base(path))
+	}
+	scenario.dir = filepath.Dir(path)
+	return &scenario, nil
+}
+
+// Runner executes scenarios against one publisher.
+type Runner struct {
+	client *client.Client
+	out    io.Writer
+
+	// blocks is the optional sequencer connection for send-l2block steps;
+	// parents and numbers continue the per-chain block lineage across
+	// steps.
+	blocks  L2BlockSender
+	parents map[types.ChainID]types.Hash
+	numbers map[types.ChainID]uint64
+}
+
+// NewRunner creates a runner reporting step progress to out.
+func NewRunner(c *client.Client, out io.Writer) *Runner {
+	return &Runner{
+		client:  c,
+		out:     out,
+		parents: make(map[types.ChainID]types.Hash),
+		numbers: make(map[types.ChainID]uint64),
+	}
+}
+
+// Run executes the scenario's steps in order, stopping at the first
+// failure with the step that caused it.
+func (r *Runner) Run(ctx context.Context, scenario *Scenario) error {
+	for i, step := range scenario.Steps {
+		if err := r.step(ctx, scenario, step); err != nil {
+			return fmt.Errorf("workflow: step %d (%s): %w", i+1, step.Action, err)
+		}
+	}
+	return nil
+}
+
+func (r *Runner) step(ctx context.Context, scenario *Scenario, step Step) error {
+	switch step.Action {
+	case "submit-proof":
+		return r.submitProof(ctx, scenario, step)
+	case "wait-for-slot":
+		return r.waitForSlot(ctx, scenario, step)
+	case "wait-in-slot":
+		return r.waitInSlot(ctx, scenario, step)
+	case "send-l2block":
+		return r.sendL2Block(scenario, step)
+	default:
+		return fmt.Errorf("unknown action %q", step.Action)
+	}
+}
+
+// submitProof posts the step's proof file to the publisher's proofs API.
+func (r *Runner) submitProof(ctx context.Context, scenario *Scenario, step Step) error {
+	if step.File == "" {
+		return fmt.Errorf("submit-proof needs File")
+	}
+	proof, err := os.ReadFile(scenario.resolve(step.File))
+	if err != nil {
+		return err
+	}
+	proofHash, err := r.client.SubmitProof(ctx, step.Chain, step.Superblock, proof)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(r.out, "submit-proof: chain %d superblock %d accepted as %s\n",
+		step.Chain, step.Superblock, proofHash)
+	return nil
+}
+
+// waitForSlot blocks until the target slot opens. A slot already reached
+// passes through immediately, so re-running a scenario mid-network does
+// not hang on history.
+func (r *Runner) waitForSlot(ctx context.Context, scenario *Scenario, step Step) error {
+	if err := scenario.Slots.Validate(); err != nil {
+		return fmt.Errorf("wait-for-slot needs the scenario's Slots config: %w", err)
+	}
+	target := scenario.Slots.StartOf(step.Slot)
+	fmt.Fprintf(r.out, "wait-for-slot: slot %d opens at %s\n", step.Slot, target.Format(time.RFC3339Nano))
+	return sleepUntil(ctx, target)
+}
+
+// waitInSlot blocks until Offset into a slot: the current one if that
+// point is still ahead, otherwise the next, so the step always lands at a
+// deterministic position relative to the seal cutover.
+func (r *Runner) waitInSlot(ctx context.Context, scenario *Scenario, step Step) error {
+	if err := scenario.Slots.Validate(); err != nil {
+		return fmt.Errorf("wait-in-slot needs the scenario's Slots config: %w", err)
+	}
+	if step.Offset < 0 || step.Offset >= scenario.Slots.Duration {
+		return fmt.Errorf("wait-in-slot Offset %s must be within the %s slot",
+			step.Offset, scenario.Slots.Duration)
+	}
+	now := time.Now()
+	current := scenario.Slots.At(now)
+	target := scenario.Slots.StartOf(current).Add(step.Offset)
+	if !target.After(now) {
+		current++
+		target = scenario.Slots.StartOf(current).Add(step.Offset)
+	}
+	fmt.Fprintf(r.out, "wait-in-slot: %s into slot %d\n", step.Offset, current)
+	return sleepUntil(ctx, target)
+}
+
+// sleepUntil blocks until at, or until ctx ends.
+func sleepUntil(ctx context.Context, at time.Time) error {
+	wait := time.Until(at)
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// resolve makes a scenario-relative path absolute.
+func (s *Scenario) resolve(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(s.dir, path)
+}
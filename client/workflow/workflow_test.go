@@ -0,0 +1,149 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/apisrv"
+	"github.com/compose-network/publisher/client"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/prover"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+func TestSubmitProofScenario(t *testing.T) {
+	collector := prover.NewCollector(zap.NewNop(), nil)
+	srv := apisrv.New(zap.NewNop(), apisrv.Config{Addr: "127.0.0.1:0"})
+	srv.RegisterProofArtifacts(collector, collector)
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "proof.bin"), []byte("proof bytes"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scenario.yaml"), []byte(`
+Name: proof collection
+Steps:
+  - Action: submit-proof
+    Chain: 1
+    Superblock: 7
+    File: proof.bin
+`), 0o600))
+
+	scenario, err := Load(filepath.Join(dir, "scenario.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "proof collection", scenario.Name)
+
+	var out bytes.Buffer
+	runner := NewRunner(client.New(fmt.Sprintf("http://%s", srv.Addr())), &out)
+	require.NoError(t, runner.Run(context.Background(), scenario))
+	require.Contains(t, out.String(), "chain 1 superblock 7 accepted")
+
+	_, ok := collector.Accepted(1, 7)
+	require.True(t, ok, "the posted proof reaches the collector")
+
+	// Replaying the scenario is a duplicate submission, surfaced with the
+	// failing step.
+	err = runner.Run(context.Background(), scenario)
+	require.ErrorContains(t, err, "step 1 (submit-proof)")
+}
+
+func TestWaitActionsFollowSlotMath(t *testing.T) {
+	genesis := time.Now()
+	scenario := &Scenario{
+		Slots: slot.Config{GenesisTime: genesis, Duration: 60 * time.Millisecond, SealCutover: 50 * time.Millisecond},
+		Steps: []Step{
+			{Action: "wait-for-slot", Slot: 2},
+			// 45ms into the slot: 5ms before the seal cutover.
+			{Action: "wait-in-slot", Offset: 45 * time.Millisecond},
+		},
+	}
+
+	var out bytes.Buffer
+	runner := NewRunner(nil, &out)
+	require.NoError(t, runner.Run(context.Background(), scenario))
+
+	elapsed := time.Since(genesis)
+	require.GreaterOrEqual(t, elapsed, 120*time.Millisecond, "slot 2 had not opened")
+	require.GreaterOrEqual(t, elapsed, 165*time.Millisecond, "45ms into slot 2 had not passed")
+}
+
+func TestWaitActionsValidateInputs(t *testing.T) {
+	runner := NewRunner(nil, io.Discard)
+	err := runner.Run(context.Background(), &Scenario{Steps: []Step{{Action: "wait-for-slot", Slot: 1}}})
+	require.ErrorContains(t, err, "Slots config")
+
+	slots := slot.Config{GenesisTime: time.Now(), Duration: 50 * time.Millisecond, SealCutover: 40 * time.Millisecond}
+	err = runner.Run(context.Background(), &Scenario{
+		Slots: slots,
+		Steps: []Step{{Action: "wait-in-slot", Offset: 50 * time.Millisecond}},
+	})
+	require.ErrorContains(t, err, "within the")
+
+	// A far-future slot respects cancellation.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = runner.Run(ctx, &Scenario{Slots: slots, Steps: []Step{{Action: "wait-for-slot", Slot: 1 << 20}}})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type capturedBlocks struct {
+	blocks []*types.L2Block
+}
+
+func (c *capturedBlocks) SendL2Block(block *types.L2Block) error {
+	c.blocks = append(c.blocks, block)
+	return nil
+}
+
+func TestSendL2BlockTracksLineage(t *testing.T) {
+	scenario := &Scenario{
+		Slots: slot.Config{GenesisTime: time.Now().Add(-time.Second), Duration: 100 * time.Millisecond, SealCutover: 75 * time.Millisecond},
+		Steps: []Step{
+			{Action: "send-l2block", Chain: 3},
+			{Action: "send-l2block", Chain: 3},
+			{Action: "send-l2block", Chain: 4},
+		},
+	}
+	sent := &capturedBlocks{}
+	runner := NewRunner(nil, io.Discard)
+	runner.SetL2BlockSender(sent)
+	require.NoError(t, runner.Run(context.Background(), scenario))
+	require.Len(t, sent.blocks, 3)
+
+	first, second, other := sent.blocks[0], sent.blocks[1], sent.blocks[2]
+	require.Equal(t, uint64(1), first.Number)
+	require.True(t, first.ParentHash.IsZero())
+	require.False(t, first.Hash.IsZero())
+	require.NotEmpty(t, first.Payload)
+
+	require.Equal(t, uint64(2), second.Number)
+	require.Equal(t, first.Hash, second.ParentHash, "parent hash continues the chain's lineage")
+	require.InDelta(t, float64(scenario.Slots.At(time.Now())), float64(second.Slot), 1,
+		"slot auto-derived from the shared slot math")
+
+	require.Equal(t, uint64(1), other.Number, "chains number independently")
+
+	runner = NewRunner(nil, io.Discard)
+	err := runner.Run(context.Background(), scenario)
+	require.ErrorContains(t, err, "sequencer connection")
+}
+
+func TestLoadRejectsEmptyScenario(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scenario.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("Name: empty\n"), 0o600))
+	_, err := Load(path)
+	require.ErrorContains(t, err, "no steps")
+
+	_, err = Load(filepath.Join(dir, "missing.yaml"))
+	require.Error(t, err)
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+// backfillCmd imports historical L2 blocks into the persistent store, for
+// onboarding a rollup whose chain already has history. Blocks come either
+// from exported JSON-lines files (one types.L2Block per line) or from a
+// sequencer HTTP endpoint serving block ranges.
+func backfillCmd() *cobra.Command {
+	var (
+		dbPath  string
+		rpcURL  string
+		chainID uint64
+		from    uint64
+		to      uint64
+		verify  bool
+	)
+	cmd := &cobra.Command{
+		Use:   "backfill [files...]",
+		Short: "Import historical L2 blocks from exported files or a sequencer RPC",
+		Long: `Backfill populates the L2 block store (including head tracking, which
+follows the highest imported number per chain) from pre-existing chain
+history. Two sources are supported:
+
+  files  each argument is a JSON-lines file with one L2 block per line,
+         in the same encoding the API and db subcommands emit
+  --rpc  a sequencer endpoint serving GET <url>/l2blocks?chain=&from=&to=
+         as a JSON array; requires --chain and --from/--to`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if rpcURL == "" && len(args) == 0 {
+				return fmt.Errorf("either --rpc or at least one exported file is required")
+			}
+			if rpcURL != "" && chainID == 0 {
+				return fmt.Errorf("--rpc requires --chain")
+			}
+			return withDB(dbPath, func(db *store.DB) error {
+				blocks, err := store.NewBoltL2BlockStore(db)
+				if err != nil {
+					return err
+				}
+				imp := &importer{store: blocks, verify: verify, out: cmd.OutOrStdout()}
+				for _, path := range args {
+					if err := imp.fromFile(path); err != nil {
+						return fmt.Errorf("backfill %s: %w", path, err)
+					}
+				}
+				if rpcURL != "" {
+					if err := imp.fromRPC(rpcURL, types.ChainID(chainID), from, to); err != nil {
+						return fmt.Errorf("backfill %s: %w", rpcURL, err)
+					}
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "imported %d blocks (%d skipped)\n", imp.imported, imp.skipped)
+				return nil
+			})
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "publisher.db", "path to the database file")
+	cmd.Flags().StringVar(&rpcURL, "rpc", "", "sequencer base URL to fetch blocks from")
+	cmd.Flags().Uint64Var(&chainID, "chain", 0, "chain ID (required with --rpc)")
+	cmd.Flags().Uint64Var(&from, "from", 0, "first block number to fetch (with --rpc)")
+	cmd.Flags().Uint64Var(&to, "to", 0, "last block number to fetch (with --rpc)")
+	cmd.Flags().BoolVar(&verify, "verify", true, "check parent-hash continuity against stored blocks")
+	return cmd
+}
+
+// importer writes blocks through the store, tracking counts and optionally
+// verifying each block links to its stored parent.
+type importer struct {
+	store    store.L2BlockStore
+	verify   bool
+	out      io.Writer
+	imported int
+	skipped  int
+}
+
+func (imp *importer) put(block *types.L2Block) error {
+	if block.ChainID == 0 {
+		return fmt.Errorf("block %d has no chain ID", block.Number)
+	}
+	if existing, err := imp.store.GetL2Block(block.ChainID, block.Number); err == nil {
+		if existing.Hash == block.Hash {
+			imp.skipped++
+			return nil
+		}
+		return fmt.Errorf("chain %d block %d already stored with hash %s (import has %s)",
+			block.ChainID, block.Number, existing.Hash, block.Hash)
+	}
+	if imp.verify && block.Number > 0 {
+		parent, err := imp.store.GetL2Block(block.ChainID, block.Number-1)
+		if err == nil && parent.Hash != block.ParentHash {
+			return fmt.Errorf("chain %d block %d parent hash %s does not match stored block %d hash %s",
+				block.ChainID, block.Number, block.ParentHash, block.Number-1, parent.Hash)
+		}
+	}
+	if err := imp.store.PutL2Block(block); err != nil {
+		return err
+	}
+	imp.imported++
+	if imp.imported%10000 == 0 {
+		fmt.Fprintf(imp.out, "... %d blocks imported\n", imp.imported)
+	}
+	return nil
+}
+
+// fromFile imports a JSON-lines export: one types.L2Block per line, blank
+// lines ignored.
+func (imp *importer) fromFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 1<<20), 64<<20) // payloads can be large
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var block types.L2Block
+		if err := json.Unmarshal(scanner.Bytes(), &block); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := imp.put(&block); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// rpcPageSize bounds each range request so a long history does not come
+// back as one enormous response.
+const rpcPageSize = 512
+
+// fromRPC pages through GET <base>/l2blocks?chain=&from=&to=, expecting a
+// JSON array of blocks per page.
+func (imp *importer) fromRPC(base string, chainID types.ChainID, from, to uint64) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+	for start := from; start <= to; {
+		end := start + rpcPageSize - 1
+		if end > to {
+			end = to
+		}
+		blocks, err := fetchBlockPage(client, base, chainID, start, end)
+		if err != nil {
+			return err
+		}
+		for _, block := range blocks {
+			if block.ChainID != chainID {
+				return fmt.Errorf("block %d reports chain %d, expected %d", block.Number, block.ChainID, chainID)
+			}
+			if err := imp.put(block); err != nil {
+				return err
+			}
+		}
+		if end == ^uint64(0) {
+			break
+		}
+		start = end + 1
+	}
+	return nil
+}
+
+func fetchBlockPage(client *http.Client, base string, chainID types.ChainID, from, to uint64) ([]*types.L2Block, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return nil, err
+	}
+	u.Path, err = url.JoinPath(u.Path, "l2blocks")
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("chain", strconv.FormatUint(uint64(chainID), 10))
+	q.Set("from", strconv.FormatUint(from, 10))
+	q.Set("to", strconv.FormatUint(to, 10))
+	u.RawQuery = q.Encode()
+
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("blocks %d-%d: %s: %s", from, to, resp.Status, body)
+	}
+	var blocks []*types.L2Block
+	if err := json.NewDecoder(resp.Body).Decode(&blocks); err != nil {
+		return nil, fmt.Errorf("blocks %d-%d: %w", from, to, err)
+	}
+	return blocks, nil
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/consensus"
+	"github.com/compose-network/publisher/x/superblock"
+)
+
+// benchResult is the machine-readable output of `publisher bench`, kept
+// flat and stable so successive runs can be diffed over time.
+type benchResult struct {
+	Timestamp    string `json:"timestamp"`
+	GoVersion    string `json:"go_version"`
+	SlotDuration string `json:"slot_duration"`
+
+	L2BlockIngestPerSec float64 `json:"l2_block_ingest_per_sec"`
+	XTDecisionsPerSec   float64 `json:"xt_decisions_per_sec"`
+	MaxXTsPerSlot       float64 `json:"max_xts_per_slot"`
+	SuperblockBuildMs   float64 `json:"superblock_build_ms"`
+}
+
+// benchCmd measures slot-pipeline throughput against memory stores, so the
+// numbers isolate the publisher's own code from disk and network.
+func benchCmd() *cobra.Command {
+	var (
+		measure time.Duration
+		slotDur time.Duration
+		chains  int
+	)
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Measure slot throughput against memory stores, emitting JSON",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if chains < 1 {
+				return fmt.Errorf("--chains must be at least 1")
+			}
+			result := benchResult{
+				Timestamp:    time.Now().UTC().Format(time.RFC3339),
+				GoVersion:    runtime.Version(),
+				SlotDuration: slotDur.String(),
+			}
+			result.L2BlockIngestPerSec = benchIngest(measure, chains)
+			result.XTDecisionsPerSec = benchDecisions(measure, chains)
+			result.MaxXTsPerSlot = result.XTDecisionsPerSec * slotDur.Seconds()
+			result.SuperblockBuildMs = benchBuild(measure, chains)
+
+			enc := json.NewEncoder(cmd.OutOrStdout())
+			enc.SetIndent("", "  ")
+			return enc.Encode(result)
+		},
+	}
+	cmd.Flags().DurationVar(&measure, "measure", 2*time.Second, "wall time spent per measurement")
+	cmd.Flags().DurationVar(&slotDur, "slot", 2*time.Second, "slot duration used to derive max XTs per slot")
+	cmd.Flags().IntVar(&chains, "chains", 3, "chains per XT and per superblock")
+	return cmd
+}
+
+// benchIngest returns L2 blocks ingested per second.
+func benchIngest(measure time.Duration, chains int) float64 {
+	blocks := store.NewMemoryL2BlockStore()
+	payload := make([]byte, 512)
+	deadline := time.Now().Add(measure)
+	start := time.Now()
+	n := 0
+	for time.Now().Before(deadline) {
+		block := &types.L2Block{
+			ChainID: types.ChainID(n%chains + 1),
+			Number:  uint64(n),
+			Slot:    types.Slot(n / 8),
+			Payload: payload,
+		}
+		block.Hash[0], block.Hash[1], block.Hash[2], block.Hash[3] = byte(n), byte(n>>8), byte(n>>16), byte(n>>24)
+		if err := blocks.PutL2Block(block); err != nil {
+			break
+		}
+		n++
+	}
+	return float64(n) / time.Since(start).Seconds()
+}
+
+// benchDecisions returns full 2PC rounds (StartSC plus all commit votes)
+// decided per second.
+func benchDecisions(measure time.Duration, chains int) float64 {
+	participants := make([]types.ChainID, chains)
+	for i := range participants {
+		participants[i] = types.ChainID(i + 1)
+	}
+	coordinator := consensus.NewCoordinator(zap.NewNop(), nil, func(types.Decision) {})
+	deadline := time.Now().Add(measure)
+	start := time.Now()
+	n := 0
+	for time.Now().Before(deadline) {
+		var xtID types.XtID
+		xtID[0], xtID[1], xtID[2], xtID[3] = byte(n), byte(n>>8), byte(n>>16), byte(n>>24)
+		req := &types.XTRequest{XtID: xtID, SourceChain: 1, Chains: participants}
+		if _, err := coordinator.StartSC(context.Background(), req, 1); err != nil {
+			break
+		}
+		for _, chain := range participants {
+			if err := coordinator.OnVote(context.Background(), chain, xtID, true); err != nil {
+				return float64(n) / time.Since(start).Seconds()
+			}
+		}
+		n++
+	}
+	return float64(n) / time.Since(start).Seconds()
+}
+
+// benchBuild returns the mean superblock build latency in milliseconds for
+// a 64-block slot.
+func benchBuild(measure time.Duration, chains int) float64 {
+	builder := superblock.NewBuilder(zap.NewNop(), store.NewMemoryConstructionJournal(), nil)
+	blocks := make([]*types.L2Block, 0, 64)
+	for i := 0; i < 64; i++ {
+		block := &types.L2Block{
+			ChainID: types.ChainID(i%chains + 1), Number: uint64(i / chains), Slot: 1,
+			Payload: make([]byte, 512),
+		}
+		block.Hash[0], block.Hash[1] = byte(i), byte(i>>8)
+		blocks = append(blocks, block)
+	}
+	decisions := make([]types.Decision, 8)
+	for i := range decisions {
+		decisions[i] = types.Decision{Committed: i%2 == 0, Slot: 1}
+		decisions[i].XtID[0] = byte(i)
+	}
+
+	deadline := time.Now().Add(measure)
+	start := time.Now()
+	n := 0
+	for time.Now().Before(deadline) {
+		if _, err := builder.Build(uint64(n+1), 1, types.Hash{}, blocks, decisions); err != nil {
+			break
+		}
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return time.Since(start).Seconds() * 1000 / float64(n)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/compose-network/publisher/x/prover"
+)
+
+// confighashCmd computes the rollup config hash operators put in their
+// proof policy (ProofPolicy.RollupConfigHash). It covers the full
+// rollup.json + genesis.json pair, not just the genesis block hash, so a
+// prover can bind proofs to the exact network configuration.
+func confighashCmd() *cobra.Command {
+	var (
+		rollupPath  string
+		genesisPath string
+	)
+	cmd := &cobra.Command{
+		Use:   "confighash",
+		Short: "Compute the rollup config hash from rollup.json and genesis.json",
+		Long: `Confighash canonicalizes the rollup and genesis configuration files and
+prints the hash committing to the pair. Formatting and key order do not
+affect the result; any configuration change does. Put the value in the
+network's ProofPolicy so proof job inputs carry it.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			rollupJSON, err := os.ReadFile(rollupPath)
+			if err != nil {
+				return err
+			}
+			genesisJSON, err := os.ReadFile(genesisPath)
+			if err != nil {
+				return err
+			}
+			hash, err := prover.RollupConfigHash(rollupJSON, genesisJSON)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), hash.String())
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&rollupPath, "rollup", "rollup.json", "rollup configuration file")
+	cmd.Flags().StringVar(&genesisPath, "genesis", "genesis.json", "genesis configuration file")
+	return cmd
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/conformance"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+// conformanceCmd runs the sequencer conformance suite: it listens as a
+// publisher, waits for the candidate implementation to connect, drives it
+// through the scripted protocol sequences and prints the report.
+func conformanceCmd() *cobra.Command {
+	var (
+		addr      string
+		chain     uint64
+		keyID     string
+		pubKeyHex string
+		slotDur   time.Duration
+		cutover   time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "conformance",
+		Short: "Verify a candidate sequencer implementation against the protocol",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if pubKeyHex == "" {
+				return errors.New("--pubkey is required: the candidate must authenticate like a real sequencer")
+			}
+			authManager := auth.NewManager()
+			if err := authManager.AddTrustedKey(keyID, pubKeyHex); err != nil {
+				return err
+			}
+			logger, err := devLogger()
+			if err != nil {
+				return err
+			}
+			harness := conformance.NewHarness(logger, conformance.Config{
+				Addr:    addr,
+				ChainID: types.ChainID(chain),
+				Slots: slot.Config{
+					GenesisTime: time.Now(),
+					Duration:    slotDur,
+					SealCutover: cutover,
+				},
+			}, authManager)
+			if err := harness.Start(); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "listening on %s, waiting for chain %d to connect\n",
+				harness.Addr(), chain)
+			report, err := harness.Run(cmd.Context())
+			if err != nil {
+				return err
+			}
+			report.Write(cmd.OutOrStdout())
+			if !report.Passed() {
+				return errors.New("conformance failed")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&addr, "addr", "127.0.0.1:9555", "address to listen on for the candidate")
+	cmd.Flags().Uint64Var(&chain, "chain", 1, "chain ID the candidate must connect as")
+	cmd.Flags().StringVar(&keyID, "key-id", "candidate", "candidate's key ID")
+	cmd.Flags().StringVar(&pubKeyHex, "pubkey", "", "candidate's public key (hex)")
+	cmd.Flags().DurationVar(&slotDur, "slot", 2*time.Second, "slot duration to advertise")
+	cmd.Flags().DurationVar(&cutover, "cutover", 1500*time.Millisecond, "seal cutover to advertise")
+	return cmd
+}
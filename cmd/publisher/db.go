@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+func dbCmd() *cobra.Command {
+	var dbPath string
+	cmd := &cobra.Command{
+		Use:   "db",
+		Short: "Inspect the persistent stores",
+	}
+	cmd.PersistentFlags().StringVar(&dbPath, "db", "publisher.db", "path to the database file")
+	cmd.AddCommand(
+		dbSuperblocksCmd(&dbPath),
+		dbSuperblockCmd(&dbPath),
+		dbL2BlockCmd(&dbPath),
+		dbQueueCmd(&dbPath),
+		dbDeadLettersCmd(&dbPath),
+	)
+	return cmd
+}
+
+func withDB(path string, fn func(db *store.DB) error) error {
+	db, err := store.Open(path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return fn(db)
+}
+
+func printJSON(cmd *cobra.Command, v any) error {
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func dbSuperblocksCmd(dbPath *string) *cobra.Command {
+	var from, to uint64
+	cmd := &cobra.Command{
+		Use:   "superblocks",
+		Short: "List stored superblocks (summaries)",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withDB(*dbPath, func(db *store.DB) error {
+				sbs, err := store.NewBoltSuperblockStore(db).ListSuperblocks(from, to)
+				if err != nil {
+					return err
+				}
+				type summary struct {
+					Number   uint64                 `json:"number"`
+					Slot     types.Slot             `json:"slot"`
+					Hash     types.Hash             `json:"hash"`
+					Status   types.SuperblockStatus `json:"status"`
+					Chains   int                    `json:"chains"`
+					XTs      int                    `json:"xts"`
+					L1TxHash types.Hash             `json:"l1_tx_hash,omitempty"`
+				}
+				out := make([]summary, 0, len(sbs))
+				for _, sb := range sbs {
+					out = append(out, summary{
+						Number: sb.Number, Slot: sb.Slot, Hash: sb.Hash, Status: sb.Status,
+						Chains: len(sb.L2Blocks), XTs: len(sb.Decisions), L1TxHash: sb.L1TxHash,
+					})
+				}
+				return printJSON(cmd, out)
+			})
+		},
+	}
+	cmd.Flags().Uint64Var(&from, "from", 0, "first superblock number")
+	cmd.Flags().Uint64Var(&to, "to", ^uint64(0), "last superblock number")
+	return cmd
+}
+
+func dbSuperblockCmd(dbPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "superblock <number>",
+		Short: "Show a full superblock",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			number, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid superblock number %q", args[0])
+			}
+			return withDB(*dbPath, func(db *store.DB) error {
+				sb, err := store.NewBoltSuperblockStore(db).GetSuperblock(number)
+				if err != nil {
+					return err
+				}
+				return printJSON(cmd, sb)
+			})
+		},
+	}
+}
+
+func dbL2BlockCmd(dbPath *string) *cobra.Command {
+	var (
+		chainID uint64
+		number  uint64
+		hashStr string
+	)
+	cmd := &cobra.Command{
+		Use:   "l2block",
+		Short: "Dump a specific L2 block by (chain, number) or hash",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withDB(*dbPath, func(db *store.DB) error {
+				blocks, err := store.NewBoltL2BlockStore(db)
+				if err != nil {
+					return err
+				}
+				var block *types.L2Block
+				switch {
+				case hashStr != "":
+					hash, err := types.ParseHash(hashStr)
+					if err != nil {
+						return err
+					}
+					block, err = blocks.GetL2BlockByHash(hash)
+					if err != nil {
+						return err
+					}
+				case chainID != 0:
+					block, err = blocks.GetL2Block(types.ChainID(chainID), number)
+					if err != nil {
+						return err
+					}
+				default:
+					return errors.New("either --hash or --chain/--number is required")
+				}
+				return printJSON(cmd, block)
+			})
+		},
+	}
+	cmd.Flags().Uint64Var(&chainID, "chain", 0, "chain ID")
+	cmd.Flags().Uint64Var(&number, "number", 0, "block number")
+	cmd.Flags().StringVar(&hashStr, "hash", "", "block hash")
+	return cmd
+}
+
+func dbQueueCmd(dbPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "queue",
+		Short: "Show pending XT requests",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withDB(*dbPath, func(db *store.DB) error {
+				reqs, err := store.NewBoltXTQueue(db).List()
+				if err != nil {
+					return err
+				}
+				return printJSON(cmd, reqs)
+			})
+		},
+	}
+}
+
+func dbDeadLettersCmd(dbPath *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deadletters",
+		Short: "Show dead-lettered XT requests",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withDB(*dbPath, func(db *store.DB) error {
+				letters, err := store.NewBoltXTQueue(db).DeadLetters()
+				if err != nil {
+					return err
+				}
+				return printJSON(cmd, letters)
+			})
+		},
+	}
+}
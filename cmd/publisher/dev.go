@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/metrics"
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/consensus"
+	"github.com/compose-network/publisher/x/l1"
+	"github.com/compose-network/publisher/x/prover"
+	"github.com/compose-network/publisher/x/superblock"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+// devCmd runs a self-contained publisher in one process: in-memory stores,
+// a mock L1, a mock prover and a few simulated sequencers, printing slot
+// and XT activity as it happens. No docker, no config file, no keys.
+func devCmd() *cobra.Command {
+	var (
+		chains   int
+		slots    int
+		slotDur  time.Duration
+		cutover  time.Duration
+		seed     int64
+		abortPct int
+	)
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Run an in-memory publisher with simulated sequencers",
+		Long: `Dev starts the full slot pipeline against in-memory stores. Each slot the
+simulated sequencers produce one L2 block apiece and occasionally submit a
+cross-chain XT, which runs through real 2PC consensus; at the seal cutover
+undecided XTs are aborted, the superblock is built, mock-proven and
+published to a mock L1. Intended for protocol exploration and demos.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if chains < 1 {
+				return fmt.Errorf("--chains must be at least 1")
+			}
+			if cutover == 0 {
+				cutover = slotDur * 3 / 4
+			}
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+			ctx, cancel := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+			return runDev(ctx, cmd.OutOrStdout(), devParams{
+				chains: chains, slots: slots,
+				slotDur: slotDur, cutover: cutover,
+				seed: seed, abortPct: abortPct,
+			})
+		},
+	}
+	cmd.Flags().IntVar(&chains, "chains", 2, "number of simulated sequencers")
+	cmd.Flags().IntVar(&slots, "slots", 0, "stop after this many sealed slots (0 runs until interrupted)")
+	cmd.Flags().DurationVar(&slotDur, "slot", 2*time.Second, "slot duration")
+	cmd.Flags().DurationVar(&cutover, "cutover", 0, "seal cutover within the slot (default 3/4 of --slot)")
+	cmd.Flags().Int64Var(&seed, "seed", 0, "RNG seed for block hashes and vote timing (0 picks one)")
+	cmd.Flags().IntVar(&abortPct, "abort-pct", 10, "percent of XT votes that vote abort")
+	return cmd
+}
+
+type devParams struct {
+	chains   int
+	slots    int
+	slotDur  time.Duration
+	cutover  time.Duration
+	seed     int64
+	abortPct int
+}
+
+// devRunner owns the single-process wiring behind `publisher dev`.
+type devRunner struct {
+	out    io.Writer
+	rng    *rand.Rand
+	params devParams
+
+	slotCfg     slot.Config
+	l2blocks    store.L2BlockStore
+	superblocks store.SuperblockStore
+	decisions   store.DecisionLedger
+	coordinator *consensus.Coordinator
+	builder     *superblock.Builder
+	collector   *prover.Collector
+	publisher   *l1.Worker
+
+	nextNumber atomic.Uint64
+	parentHash types.Hash
+	blockNums  []uint64
+	sealed     int
+	done       chan struct{}
+}
+
+func runDev(ctx context.Context, out io.Writer, params devParams) error {
+	logger, err := devLogger()
+	if err != nil {
+		return err
+	}
+	defer logger.Sync() //nolint:errcheck
+
+	r := &devRunner{
+		out:    out,
+		rng:    rand.New(rand.NewSource(params.seed)),
+		params: params,
+		slotCfg: slot.Config{
+			GenesisTime: time.Now(),
+			Duration:    params.slotDur,
+			SealCutover: params.cutover,
+		},
+		l2blocks:    store.NewMemoryL2BlockStore(),
+		superblocks: store.NewMemorySuperblockStore(),
+		decisions:   store.NewMemoryDecisionLedger(),
+		blockNums:   make([]uint64, params.chains),
+		done:        make(chan struct{}),
+	}
+	r.nextNumber.Store(1)
+	r.coordinator = consensus.NewCoordinator(logger, r.slotCfg, r.onDecided)
+	r.builder = superblock.NewBuilder(logger, store.NewMemoryConstructionJournal(), nil)
+	r.collector = prover.NewCollector(logger, nil)
+	// The mock L1 gets its own RNG: publishes run on the worker goroutine,
+	// and the shared RNG is not safe for concurrent use.
+	submitter := l1.NewSubmitter(logger, &devL1Client{rng: rand.New(rand.NewSource(params.seed + 1))}, store.NewMemoryTrackedTxStore())
+	r.publisher = l1.NewWorker(logger, submitter, l1.WorkerConfig{})
+	r.publisher.SetPublishedFunc(r.onPublished)
+	r.publisher.Start()
+	defer r.publisher.Stop()
+
+	sched, err := slot.NewScheduler(logger, r.slotCfg)
+	if err != nil {
+		return err
+	}
+	for state, hook := range map[slot.State]slot.HookFunc{
+		slot.StateFree:    r.onSlotFree,
+		slot.StateSealing: r.onSlotSealing,
+		slot.StateSealed:  r.onSlotSealed,
+	} {
+		if err := sched.RegisterSlotHook(state, hook); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "dev publisher: %d chains, %s slots (cutover %s), seed %d\n",
+		params.chains, params.slotDur, params.cutover, params.seed)
+	sched.Start()
+	defer sched.Stop()
+
+	select {
+	case <-ctx.Done():
+		fmt.Fprintln(out, "interrupted; aborting undecided XTs")
+		r.coordinator.AbortAllUndecided()
+	case <-r.done:
+	}
+	return nil
+}
+
+// onSlotFree opens a slot: every sequencer produces one L2 block, and some
+// submit a cross-chain XT that runs through real 2PC.
+func (r *devRunner) onSlotFree(s types.Slot, at time.Time) {
+	fmt.Fprintf(r.out, "slot %d open\n", s)
+	for chain := 0; chain < r.params.chains; chain++ {
+		block := r.produceBlock(types.ChainID(chain+1), s)
+		if err := r.l2blocks.PutL2Block(block); err != nil {
+			fmt.Fprintf(r.out, "  chain %d: store block: %v\n", chain+1, err)
+			continue
+		}
+		fmt.Fprintf(r.out, "  chain %d block %d %s\n", block.ChainID, block.Number, short(block.Hash))
+	}
+	// Roughly two of three slots see an XT from a random source chain.
+	if r.rng.Intn(3) == 0 {
+		return
+	}
+	req := &types.XTRequest{
+		XtID:        r.randHash(),
+		SourceChain: types.ChainID(r.rng.Intn(r.params.chains) + 1),
+		Chains:      allChains(r.params.chains),
+		ReceivedAt:  uint64(at.Unix()),
+	}
+	if _, err := r.coordinator.StartSC(context.Background(), req, s); err != nil {
+		fmt.Fprintf(r.out, "  xt %s: %v\n", short(req.XtID), err)
+		return
+	}
+	fmt.Fprintf(r.out, "  xt %s started (source chain %d, %d participants)\n",
+		short(req.XtID), req.SourceChain, len(req.Chains))
+	// Vote timing and outcomes are drawn here, on the scheduler goroutine,
+	// because the shared RNG is not safe for concurrent use.
+	for _, chain := range req.Chains {
+		delay := time.Duration(r.rng.Int63n(int64(r.params.cutover)))
+		if delay > r.params.cutover*9/10 {
+			continue // lost vote; the deadline timer aborts the XT
+		}
+		commit := r.rng.Intn(100) >= r.params.abortPct
+		go r.castVote(chain, req.XtID, delay, commit)
+	}
+}
+
+// castVote delivers one simulated participant vote after a delay inside
+// the voting window; votes past the cutover are dropped by the caller so
+// timeouts happen too.
+func (r *devRunner) castVote(chain types.ChainID, xtID types.XtID, delay time.Duration, commit bool) {
+	time.Sleep(delay)
+	_ = r.coordinator.OnVote(context.Background(), chain, xtID, commit)
+}
+
+func (r *devRunner) onSlotSealing(s types.Slot, at time.Time) {
+	for range r.coordinator.ForceAbortUndecided(s) {
+	}
+}
+
+// onSlotSealed builds the slot's superblock, mock-proves it and publishes
+// it to the mock L1.
+func (r *devRunner) onSlotSealed(s types.Slot, at time.Time) {
+	blocks, err := r.l2blocks.L2BlocksBySlot(s)
+	if err != nil {
+		fmt.Fprintf(r.out, "slot %d: list blocks: %v\n", s, err)
+		return
+	}
+	records, err := r.decisions.DecisionsBySlot(s)
+	if err != nil {
+		fmt.Fprintf(r.out, "slot %d: list decisions: %v\n", s, err)
+		return
+	}
+	decisions := make([]types.Decision, 0, len(records))
+	for _, rec := range records {
+		decisions = append(decisions, rec.Decision)
+	}
+
+	number := r.nextNumber.Load()
+	buildStart := time.Now()
+	sb, err := r.builder.Build(number, s, r.parentHash, blocks, decisions)
+	if err != nil {
+		fmt.Fprintf(r.out, "slot %d: build superblock: %v\n", s, err)
+		return
+	}
+	metrics.ObserveSuperblockBuild(time.Since(buildStart))
+	sb.Status = types.SuperblockStatusSealed
+	if err := r.superblocks.PutSuperblock(sb); err != nil {
+		fmt.Fprintf(r.out, "slot %d: store superblock: %v\n", s, err)
+		return
+	}
+	r.parentHash = sb.Hash
+	r.nextNumber.Add(1)
+
+	for _, chain := range allChains(r.params.chains) {
+		_ = r.collector.Submit(prover.ProofSubmission{
+			ChainID: chain, SuperblockNumber: number,
+			ProofHash: r.randHash(), Proof: []byte("mock proof"),
+		})
+	}
+	r.sealed++
+	if r.params.slots > 0 && r.sealed >= r.params.slots {
+		defer close(r.done)
+	}
+
+	// The build budget runs until the next slot's seal cutover; either way
+	// the L1 publish happens on the worker's goroutine, so the scheduler is
+	// never held up and the next slot starts on time.
+	if deadline := r.slotCfg.BuildDeadlineOf(s); time.Now().After(deadline) {
+		metrics.RecordBuildDeadlineExceeded()
+		fmt.Fprintf(r.out, "slot %d: build overran its budget by %s\n",
+			s, time.Since(deadline).Round(time.Millisecond))
+	}
+	if err := r.publisher.Enqueue(sb); err != nil {
+		fmt.Fprintf(r.out, "slot %d: publish: %v\n", s, err)
+	}
+}
+
+// onPublished runs on the publish worker's goroutine once a superblock's
+// L1 transaction is accepted.
+func (r *devRunner) onPublished(sb *types.Superblock, txHash types.Hash) {
+	r.collector.Release(sb.Number)
+	fmt.Fprintf(r.out, "superblock %d sealed: %d blocks, %d decisions, proven, L1 tx %s\n",
+		sb.Number, len(sb.L2Blocks), len(sb.Decisions), short(txHash))
+}
+
+// onDecided records every XT outcome in the ledger and prints it.
+func (r *devRunner) onDecided(decision types.Decision) {
+	if _, err := r.decisions.AppendDecision(decision, r.nextNumber.Load()); err != nil {
+		fmt.Fprintf(r.out, "  xt %s: record decision: %v\n", short(decision.XtID), err)
+		return
+	}
+	if decision.Committed {
+		fmt.Fprintf(r.out, "  xt %s committed\n", short(decision.XtID))
+		return
+	}
+	fmt.Fprintf(r.out, "  xt %s aborted (%s)\n", short(decision.XtID), decision.AbortReason)
+}
+
+func (r *devRunner) produceBlock(chain types.ChainID, s types.Slot) *types.L2Block {
+	r.blockNums[chain-1]++
+	return &types.L2Block{
+		ChainID:   chain,
+		Number:    r.blockNums[chain-1],
+		Hash:      r.randHash(),
+		Slot:      s,
+		Timestamp: uint64(time.Now().Unix()),
+	}
+}
+
+func (r *devRunner) randHash() types.Hash {
+	var h types.Hash
+	r.rng.Read(h[:]) //nolint:errcheck // math/rand Read never fails
+	return h
+}
+
+// devL1Client is the mock settlement chain: every submission succeeds with
+// a random transaction hash and nothing is ever pre-published.
+type devL1Client struct {
+	rng *rand.Rand
+}
+
+func (c *devL1Client) SubmitSuperblock(ctx context.Context, sb *types.Superblock) (types.Hash, error) {
+	var h types.Hash
+	c.rng.Read(h[:]) //nolint:errcheck
+	return h, nil
+}
+
+func (c *devL1Client) PublishedTx(ctx context.Context, superblockNumber uint64) (types.Hash, bool, error) {
+	return types.Hash{}, false, nil
+}
+
+// devLogger builds a console logger that stays quiet unless a component
+// warns; the dev runner's own printfs carry the interesting activity.
+func devLogger() (*zap.Logger, error) {
+	cfg := zap.NewDevelopmentConfig()
+	cfg.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+	return cfg.Build()
+}
+
+func allChains(n int) []types.ChainID {
+	chains := make([]types.ChainID, n)
+	for i := range chains {
+		chains[i] = types.ChainID(i + 1)
+	}
+	return chains
+}
+
+func short(h types.Hash) string {
+	return h.String()[:10]
+}
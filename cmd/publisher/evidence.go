@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/store/wal"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+)
+
+// evidenceBundle packages everything an operator can produce about one
+// slot when a chain disputes its contents: the raw WAL records with their
+// timestamps, the decoded coordinator view, and the superblock actually
+// built. The bundle is signed so recipients can hold the operator to it.
+type evidenceBundle struct {
+	Slot        types.Slot        `json:"slot"`
+	GeneratedAt int64             `json:"generated_at"` // unix nanoseconds
+	Entries     []wal.Entry       `json:"entries"`
+	View        *wal.SlotView     `json:"view"`
+	Superblock  *types.Superblock `json:"superblock,omitempty"`
+}
+
+// signedEvidence is the archive written to disk. Signature covers the raw
+// bundle bytes and verifies with auth.VerifyWithKey against PublicKey.
+type signedEvidence struct {
+	Bundle    json.RawMessage `json:"bundle"`
+	KeyID     string          `json:"key_id"`
+	PublicKey string          `json:"public_key"`
+	Signature string          `json:"signature"`
+}
+
+func evidenceCmd() *cobra.Command {
+	var (
+		walPath    string
+		dbPath     string
+		slotArg    uint64
+		keyID      string
+		privKeyHex string
+		outPath    string
+	)
+	cmd := &cobra.Command{
+		Use:   "evidence",
+		Short: "Export a signed evidence bundle for a disputed slot",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if privKeyHex == "" {
+				return errors.New("--key is required: an unsigned bundle is not evidence")
+			}
+			signer, err := auth.NewSigner(keyID, privKeyHex)
+			if err != nil {
+				return err
+			}
+
+			bundle := evidenceBundle{
+				Slot:        types.Slot(slotArg),
+				GeneratedAt: time.Now().UnixNano(),
+			}
+			replayErr := wal.Replay(walPath, func(e wal.Entry) error {
+				if e.Slot == types.Slot(slotArg) {
+					bundle.Entries = append(bundle.Entries, e)
+				}
+				return nil
+			})
+			if errors.Is(replayErr, wal.ErrCorrupt) {
+				fmt.Fprintln(cmd.ErrOrStderr(), "warning: corrupt WAL tail, bundle may be incomplete")
+			} else if replayErr != nil {
+				return replayErr
+			}
+			view, err := wal.ReconstructSlot(walPath, types.Slot(slotArg))
+			if err != nil && !errors.Is(err, wal.ErrCorrupt) {
+				return err
+			}
+			bundle.View = view
+
+			if dbPath != "" {
+				if err := withDB(dbPath, func(db *store.DB) error {
+					sbs, err := store.NewBoltSuperblockStore(db).ListSuperblocks(0, ^uint64(0))
+					if err != nil {
+						return err
+					}
+					for _, sb := range sbs {
+						if sb.Slot == types.Slot(slotArg) {
+							bundle.Superblock = sb
+							break
+						}
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+			}
+
+			raw, err := json.Marshal(bundle)
+			if err != nil {
+				return err
+			}
+			archive := signedEvidence{
+				Bundle:    raw,
+				KeyID:     signer.KeyID(),
+				PublicKey: signer.PublicKeyHex(),
+				Signature: hex.EncodeToString(signer.Sign(raw)),
+			}
+			out, err := json.MarshalIndent(archive, "", "  ")
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(outPath, out, 0o600); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "wrote %s: %d WAL entries, superblock %s\n",
+				outPath, len(bundle.Entries), evidenceSuperblockNote(bundle.Superblock, view))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&walPath, "wal", "publisher.wal", "path to the WAL file")
+	cmd.Flags().StringVar(&dbPath, "db", "", "database file holding the built superblock")
+	cmd.Flags().Uint64Var(&slotArg, "slot", 0, "disputed slot")
+	cmd.Flags().StringVar(&keyID, "key-id", "publisher", "key ID recorded in the archive")
+	cmd.Flags().StringVar(&privKeyHex, "key", "", "hex-encoded ed25519 private key signing the bundle")
+	cmd.Flags().StringVar(&outPath, "out", "evidence.json", "output archive path")
+	return cmd
+}
+
+func evidenceSuperblockNote(sb *types.Superblock, view *wal.SlotView) string {
+	switch {
+	case sb != nil:
+		return "from store"
+	case view != nil && view.Superblock != nil:
+		return "from WAL"
+	default:
+		return "absent"
+	}
+}
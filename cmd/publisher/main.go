@@ -0,0 +1,25 @@
+// Command publisher is the operator CLI and entry point for the superblock
+// publisher.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func main() {
+	root := &cobra.Command{
+		Use:           "publisher",
+		Short:         "Compose network superblock publisher",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.AddCommand(walCmd(), dbCmd(), backfillCmd(), proverCmd(), devCmd(), benchCmd(), evidenceCmd(), confighashCmd(), workflowCmd(), conformanceCmd(), versionCmd())
+
+	if err := root.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
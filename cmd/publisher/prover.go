@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/compose-network/publisher/x/prover"
+)
+
+func proverCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prover",
+		Short: "Interact with the external prover service",
+	}
+	cmd.AddCommand(proverCheckCmd())
+	return cmd
+}
+
+func proverCheckCmd() *cobra.Command {
+	var (
+		url  string
+		vkey string
+	)
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Verify the prover service's input schema and vkey compatibility",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			info, err := prover.VerifyService(cmd.Context(), url, vkey)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "compatible: schema %s, vkey %s\n",
+				info.SchemaVersion, info.VKeyDigest)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&url, "url", "http://127.0.0.1:9090", "prover service base URL")
+	cmd.Flags().StringVar(&vkey, "vkey", "", "expected verifying key digest (optional)")
+	return cmd
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/store/wal"
+	"github.com/compose-network/publisher/types"
+)
+
+func walCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "wal",
+		Short: "Inspect and maintain the write-ahead log",
+	}
+	cmd.AddCommand(walInspectCmd(), walTruncateCmd(), walReplayCmd())
+	return cmd
+}
+
+func walReplayCmd() *cobra.Command {
+	var (
+		path    string
+		dbPath  string
+		slotArg uint64
+	)
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Reconstruct the coordinator's view of a slot and diff it against the built superblock",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			view, err := wal.ReconstructSlot(path, types.Slot(slotArg))
+			if errors.Is(err, wal.ErrCorrupt) {
+				fmt.Fprintln(cmd.ErrOrStderr(), "warning: corrupt tail, view may be incomplete")
+				err = nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := printJSON(cmd, view); err != nil {
+				return err
+			}
+
+			actual := view.Superblock
+			if dbPath != "" {
+				// The store's copy is what was actually published; prefer it
+				// over the WAL's own superblock record when available.
+				if err := withDB(dbPath, func(db *store.DB) error {
+					sbs, err := store.NewBoltSuperblockStore(db).ListSuperblocks(0, ^uint64(0))
+					if err != nil {
+						return err
+					}
+					for _, sb := range sbs {
+						if sb.Slot == types.Slot(slotArg) {
+							actual = sb
+							break
+						}
+					}
+					return nil
+				}); err != nil {
+					return err
+				}
+			}
+			if actual == nil {
+				fmt.Fprintln(cmd.ErrOrStderr(), "no built superblock found for the slot; nothing to diff")
+				return nil
+			}
+			findings := view.DiffSuperblock(actual)
+			if len(findings) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "superblock is consistent with the WAL")
+				return nil
+			}
+			for _, finding := range findings {
+				fmt.Fprintf(cmd.OutOrStdout(), "diff: %s\n", finding)
+			}
+			return fmt.Errorf("%d discrepancies between the WAL and the built superblock", len(findings))
+		},
+	}
+	cmd.Flags().StringVar(&path, "path", "publisher.wal", "path to the WAL file")
+	cmd.Flags().StringVar(&dbPath, "db", "", "database file holding the built superblock (defaults to the WAL's own record)")
+	cmd.Flags().Uint64Var(&slotArg, "slot", 0, "slot to reconstruct")
+	return cmd
+}
+
+func walInspectCmd() *cobra.Command {
+	var (
+		path     string
+		jsonOut  bool
+		fromSlot uint64
+	)
+	cmd := &cobra.Command{
+		Use:   "inspect",
+		Short: "Decode WAL entries (type, slot, timestamp, payload summary)",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			n := 0
+			err := wal.Replay(path, func(e wal.Entry) error {
+				if e.Slot < types.Slot(fromSlot) {
+					return nil
+				}
+				n++
+				if jsonOut {
+					raw, err := json.Marshal(e)
+					if err != nil {
+						return err
+					}
+					fmt.Fprintln(cmd.OutOrStdout(), string(raw))
+					return nil
+				}
+				ts := time.Unix(0, e.Timestamp).UTC().Format(time.RFC3339Nano)
+				fmt.Fprintf(cmd.OutOrStdout(), "%-12s slot=%-8d %s payload=%dB\n", e.Type, e.Slot, ts, len(e.Payload))
+				return nil
+			})
+			if errors.Is(err, wal.ErrCorrupt) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "warning: corrupt tail after %d entries\n", n)
+				err = nil
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "%d entries\n", n)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&path, "path", "publisher.wal", "path to the WAL file")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "emit entries as JSON lines")
+	cmd.Flags().Uint64Var(&fromSlot, "from-slot", 0, "skip entries below this slot")
+	return cmd
+}
+
+func walTruncateCmd() *cobra.Command {
+	var (
+		path       string
+		beforeSlot uint64
+	)
+	cmd := &cobra.Command{
+		Use:   "truncate",
+		Short: "Drop WAL entries below a slot to reclaim disk",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if beforeSlot == 0 {
+				return errors.New("--before-slot is required")
+			}
+			if _, err := os.Stat(path); err != nil {
+				return err
+			}
+			dropped, err := wal.TruncateBeforeSlot(path, types.Slot(beforeSlot))
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "dropped %d entries before slot %d\n", dropped, beforeSlot)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&path, "path", "publisher.wal", "path to the WAL file")
+	cmd.Flags().Uint64Var(&beforeSlot, "before-slot", 0, "drop entries with slot below this value")
+	return cmd
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/client"
+	"github.com/compose-network/publisher/client/workflow"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/tcp"
+)
+
+// workflowCmd runs a YAML workflow scenario against a publisher's HTTP
+// API, step by step. With --seq-addr it additionally connects as a
+// sequencer over the transport, enabling send-l2block steps.
+func workflowCmd() *cobra.Command {
+	var (
+		apiURL       string
+		scenarioPath string
+		seqAddr      string
+		seqChain     uint64
+		seqKeyID     string
+		seqKeyHex    string
+	)
+	cmd := &cobra.Command{
+		Use:   "workflow",
+		Short: "Run a YAML workflow scenario against a publisher",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			scenario, err := workflow.Load(scenarioPath)
+			if err != nil {
+				return err
+			}
+			runner := workflow.NewRunner(client.New(apiURL), cmd.OutOrStdout())
+			if seqAddr != "" {
+				signer, err := auth.NewSigner(seqKeyID, seqKeyHex)
+				if err != nil {
+					return err
+				}
+				seq := tcp.NewClient(zap.NewNop(), tcp.ClientConfig{
+					Addr:    seqAddr,
+					ChainID: types.ChainID(seqChain),
+					Slots:   scenario.Slots,
+				}, signer)
+				if err := seq.Connect(); err != nil {
+					return err
+				}
+				defer seq.Close() //nolint:errcheck
+				runner.SetL2BlockSender(seq)
+			}
+			return runner.Run(cmd.Context(), scenario)
+		},
+	}
+	cmd.Flags().StringVar(&apiURL, "api", "http://127.0.0.1:8080", "publisher API base URL")
+	cmd.Flags().StringVar(&scenarioPath, "scenario", "scenario.yaml", "workflow scenario file")
+	cmd.Flags().StringVar(&seqAddr, "seq-addr", "", "publisher transport address for sequencer actions")
+	cmd.Flags().Uint64Var(&seqChain, "seq-chain", 0, "chain ID to connect as")
+	cmd.Flags().StringVar(&seqKeyID, "seq-key-id", "", "sequencer signing key ID")
+	cmd.Flags().StringVar(&seqKeyHex, "seq-key", "", "sequencer signing key (hex)")
+	return cmd
+}
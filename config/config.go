@@ -0,0 +1,81 @@
+// Package config loads YAML configuration files with optional environment
+// overlays. A deployment keeps one base file (config.yaml) plus small
+// per-environment overlays (config.prod.yaml, config.testnet.yaml) holding
+// only the keys that differ, instead of full copies that drift apart.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Load reads the YAML file at path into out. If profile is non-empty, the
+// overlay file next to it — config.yaml plus profile "prod" resolves to
+// config.prod.yaml — is deep-merged on top of the base before decoding.
+// The overlay file must exist when a profile is named; a typo silently
+// running with base-only config is worse than an error.
+func Load(path, profile string, out any) error {
+	base, err := loadMap(path)
+	if err != nil {
+		return err
+	}
+	if profile != "" {
+		overlay, err := loadMap(OverlayPath(path, profile))
+		if err != nil {
+			return err
+		}
+		base = Merge(base, overlay)
+	}
+	data, err := yaml.Marshal(base)
+	if err != nil {
+		return fmt.Errorf("re-encode merged config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode config: %w", err)
+	}
+	return nil
+}
+
+// OverlayPath resolves the overlay file for a profile: the base name gains
+// the profile before its extension, so dir/config.yaml with profile "prod"
+// becomes dir/config.prod.yaml.
+func OverlayPath(path, profile string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + profile + ext
+}
+
+// Merge deep-merges overlay onto base and returns the result. Nested maps
+// merge recursively; scalars, sequences and explicit nulls in the overlay
+// replace the base value wholesale. Neither input is modified.
+func Merge(base, overlay map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overlay {
+		overlayMap, overlayIsMap := value.(map[string]any)
+		baseMap, baseIsMap := merged[key].(map[string]any)
+		if overlayIsMap && baseIsMap {
+			merged[key] = Merge(baseMap, overlayMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+func loadMap(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	var m map[string]any
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	return m, nil
+}
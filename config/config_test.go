@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestLoadMergesProfileOverlay(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+	writeFile(t, base, `
+Addr: 127.0.0.1:8080
+Slots:
+  Duration: 2s
+  SealCutover: 1500ms
+Chains: [1, 2]
+`)
+	writeFile(t, filepath.Join(dir, "config.prod.yaml"), `
+Addr: 0.0.0.0:8080
+Slots:
+  Duration: 4s
+Chains: [1, 2, 3]
+`)
+
+	type slots struct {
+		Duration    string `yaml:"Duration"`
+		SealCutover string `yaml:"SealCutover"`
+	}
+	var cfg struct {
+		Addr   string `yaml:"Addr"`
+		Slots  slots  `yaml:"Slots"`
+		Chains []int  `yaml:"Chains"`
+	}
+
+	require.NoError(t, Load(base, "prod", &cfg))
+	require.Equal(t, "0.0.0.0:8080", cfg.Addr)
+	// Nested maps merge key-by-key: Duration overridden, SealCutover kept.
+	require.Equal(t, "4s", cfg.Slots.Duration)
+	require.Equal(t, "1500ms", cfg.Slots.SealCutover)
+	// Sequences replace wholesale.
+	require.Equal(t, []int{1, 2, 3}, cfg.Chains)
+
+	// No profile loads the base alone.
+	require.NoError(t, Load(base, "", &cfg))
+	require.Equal(t, "127.0.0.1:8080", cfg.Addr)
+	require.Equal(t, "2s", cfg.Slots.Duration)
+
+	// A named profile whose overlay is missing is an error, not a silent
+	// fallback to base config.
+	require.Error(t, Load(base, "staging", &cfg))
+}
+
+func TestOverlayPath(t *testing.T) {
+	require.Equal(t, "deploy/config.prod.yaml", OverlayPath("deploy/config.yaml", "prod"))
+	require.Equal(t, "config.testnet.yml", OverlayPath("config.yml", "testnet"))
+}
+
+func TestMergeDoesNotModifyInputs(t *testing.T) {
+	base := map[string]any{"a": map[string]any{"x": 1, "y": 2}}
+	overlay := map[string]any{"a": map[string]any{"x": 9}}
+	merged := Merge(base, overlay)
+
+	require.Equal(t, 9, merged["a"].(map[string]any)["x"])
+	require.Equal(t, 2, merged["a"].(map[string]any)["y"])
+	require.Equal(t, 1, base["a"].(map[string]any)["x"])
+	require.NotContains(t, overlay["a"].(map[string]any), "y")
+}
@@ -0,0 +1,359 @@
+// Package logging builds the publisher's zap logger. On top of plain zap
+// it adds per-module level overrides — keyed by the named-logger path, so
+// "tcp" or "coordinator" can be silenced independently — adjustable at
+// runtime through the admin API, and sampling so high-volume repetitive
+// messages (every L2 block, every vote) cannot drown real issues.
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingConfig bounds repeated identical messages per second: the first
+// Initial occurrences pass, then one in every Thereafter.
+type SamplingConfig struct {
+	// Disabled turns sampling off entirely.
+	Disabled bool `yaml:"Disabled"`
+	// Initial is how many identical messages pass per second before
+	// sampling kicks in. Zero means 100.
+	Initial int `yaml:"Initial"`
+	// Thereafter keeps every Nth message once sampling. Zero means 10.
+	Thereafter int `yaml:"Thereafter"`
+}
+
+// Output sinks for Config.Output.
+const (
+	OutputStdout = "stdout"
+	OutputFile   = "file"
+)
+
+// Encoder formats for Config.Format, switchable at runtime.
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// Config configures the logger.
+type Config struct {
+	// Level is the global minimum level. Empty means info.
+	Level string `yaml:"Level"`
+	// ModuleLevels overrides the level per named-logger path, e.g.
+	// {"tcp": "warn", "coordinator": "debug"}. The longest matching prefix
+	// of the dot-separated logger name wins.
+	ModuleLevels map[string]string `yaml:"ModuleLevels"`
+	Sampling     SamplingConfig    `yaml:"Sampling"`
+	// Output selects the sink: stdout (default) or file.
+	Output string `yaml:"Output"`
+	// Format selects the initial encoder: console (default) or json. The
+	// format can be switched at runtime via Controls.Format.
+	Format string `yaml:"Format"`
+	// File parameterizes rotation when Output is file.
+	File FileConfig `yaml:"File"`
+}
+
+// Levels is the runtime-adjustable level state shared by the logger and
+// the admin API.
+type Levels struct {
+	mu      sync.RWMutex
+	global  zapcore.Level
+	modules map[string]zapcore.Level
+}
+
+// NewLevels parses the configured levels.
+func NewLevels(cfg Config) (*Levels, error) {
+	global, err := parseLevel(cfg.Level, zapcore.InfoLevel)
+	if err != nil {
+		return nil, err
+	}
+	l := &Levels{global: global, modules: make(map[string]zapcore.Level)}
+	for module, raw := range cfg.ModuleLevels {
+		level, err := parseLevel(raw, zapcore.InfoLevel)
+		if err != nil {
+			return nil, fmt.Errorf("logging: module %q: %w", module, err)
+		}
+		l.modules[module] = level
+	}
+	return l, nil
+}
+
+func parseLevel(raw string, fallback zapcore.Level) (zapcore.Level, error) {
+	if raw == "" {
+		return fallback, nil
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(raw)); err != nil {
+		return 0, fmt.Errorf("logging: invalid level %q", raw)
+	}
+	return level, nil
+}
+
+// SetGlobal changes the global minimum level.
+func (l *Levels) SetGlobal(raw string) error {
+	level, err := parseLevel(raw, zapcore.InfoLevel)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.global = level
+	l.mu.Unlock()
+	return nil
+}
+
+// SetModule overrides (or, with an empty level, removes the override for)
+// one module.
+func (l *Levels) SetModule(module, raw string) error {
+	if raw == "" {
+		l.mu.Lock()
+		delete(l.modules, module)
+		l.mu.Unlock()
+		return nil
+	}
+	level, err := parseLevel(raw, zapcore.InfoLevel)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	l.modules[module] = level
+	l.mu.Unlock()
+	return nil
+}
+
+// Snapshot returns the effective levels for display: the global level
+// under "", plus every module override.
+func (l *Levels) Snapshot() map[string]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	out := make(map[string]string, len(l.modules)+1)
+	out[""] = l.global.String()
+	for module, level := range l.modules {
+		out[module] = level.String()
+	}
+	return out
+}
+
+// enabled reports whether a message from the named logger at level passes.
+// The longest module prefix ("tcp" matches "tcp.client") wins over the
+// global level.
+func (l *Levels) enabled(loggerName string, level zapcore.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	best := l.global
+	bestLen := -1
+	for module, moduleLevel := range l.modules {
+		if len(module) > bestLen && matchesModule(loggerName, module) {
+			best = moduleLevel
+			bestLen = len(module)
+		}
+	}
+	return level >= best
+}
+
+// floor is the lowest level any module admits; the inner core must not
+// reject entries below the global level that an override still wants.
+func (l *Levels) floor() zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	min := l.global
+	for _, level := range l.modules {
+		if level < min {
+			min = level
+		}
+	}
+	return min
+}
+
+func matchesModule(loggerName, module string) bool {
+	return loggerName == module || strings.HasPrefix(loggerName, module+".")
+}
+
+// Modules returns the override names, sorted, for diagnostics.
+func (l *Levels) Modules() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	names := make([]string, 0, len(l.modules))
+	for module := range l.modules {
+		names = append(names, module)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// levelFilterCore applies Levels per entry, using the logger name.
+type levelFilterCore struct {
+	zapcore.Core
+	levels *Levels
+}
+
+func (c *levelFilterCore) Enabled(level zapcore.Level) bool {
+	return level >= c.levels.floor()
+}
+
+func (c *levelFilterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelFilterCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *levelFilterCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.enabled(entry.LoggerName, entry.Level) {
+		return ce
+	}
+	return c.Core.Check(entry, ce)
+}
+
+// Format is the runtime-switchable encoder selection shared by the logger
+// and the admin API.
+type Format struct {
+	mu    sync.RWMutex
+	value string
+}
+
+// NewFormat validates and wraps an initial format; empty means console.
+func NewFormat(raw string) (*Format, error) {
+	f := &Format{}
+	if raw == "" {
+		raw = FormatConsole
+	}
+	if err := f.Set(raw); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Set switches the encoder; subsequent log entries use the new format.
+func (f *Format) Set(raw string) error {
+	if raw != FormatConsole && raw != FormatJSON {
+		return fmt.Errorf("logging: invalid format %q", raw)
+	}
+	f.mu.Lock()
+	f.value = raw
+	f.mu.Unlock()
+	return nil
+}
+
+// Get returns the active format.
+func (f *Format) Get() string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.value
+}
+
+// formatSwitchCore encodes each entry with whichever encoder Format
+// currently selects. Both cores share the same sink.
+type formatSwitchCore struct {
+	console zapcore.Core
+	json    zapcore.Core
+	format  *Format
+}
+
+func (c *formatSwitchCore) active() zapcore.Core {
+	if c.format.Get() == FormatJSON {
+		return c.json
+	}
+	return c.console
+}
+
+func (c *formatSwitchCore) Enabled(level zapcore.Level) bool {
+	return c.console.Enabled(level)
+}
+
+func (c *formatSwitchCore) With(fields []zapcore.Field) zapcore.Core {
+	return &formatSwitchCore{
+		console: c.console.With(fields),
+		json:    c.json.With(fields),
+		format:  c.format,
+	}
+}
+
+func (c *formatSwitchCore) Check(entry zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return ce.AddCore(entry, c)
+	}
+	return ce
+}
+
+func (c *formatSwitchCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.active().Write(entry, fields)
+}
+
+func (c *formatSwitchCore) Sync() error {
+	if err := c.console.Sync(); err != nil {
+		return err
+	}
+	return c.json.Sync()
+}
+
+// Controls are the runtime-adjustable knobs New hands back alongside the
+// logger, for wiring into the admin API.
+type Controls struct {
+	Levels *Levels
+	Format *Format
+}
+
+// New builds the logger and its runtime controls.
+func New(cfg Config) (*zap.Logger, *Controls, error) {
+	levels, err := NewLevels(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	format, err := NewFormat(cfg.Format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sink zapcore.WriteSyncer
+	switch cfg.Output {
+	case "", OutputStdout:
+		sink = zapcore.Lock(os.Stdout)
+	case OutputFile:
+		if cfg.File.Path == "" {
+			return nil, nil, fmt.Errorf("logging: file output requires File.Path")
+		}
+		writer, err := newRotatingWriter(cfg.File)
+		if err != nil {
+			return nil, nil, err
+		}
+		sink = writer
+	default:
+		return nil, nil, fmt.Errorf("logging: invalid output %q", cfg.Output)
+	}
+
+	core := newFormatSwitchCore(sink, format)
+	return zap.New(wrapCore(core, levels, cfg.Sampling)), &Controls{Levels: levels, Format: format}, nil
+}
+
+// newFormatSwitchCore builds the dual-encoder core over one sink.
+func newFormatSwitchCore(sink zapcore.WriteSyncer, format *Format) zapcore.Core {
+	consoleCfg := zap.NewProductionEncoderConfig()
+	consoleCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	jsonCfg := zap.NewProductionEncoderConfig()
+	return &formatSwitchCore{
+		// Levels does the real filtering, hence DebugLevel here.
+		console: zapcore.NewCore(zapcore.NewConsoleEncoder(consoleCfg), sink, zapcore.DebugLevel),
+		json:    zapcore.NewCore(zapcore.NewJSONEncoder(jsonCfg), sink, zapcore.DebugLevel),
+		format:  format,
+	}
+}
+
+// wrapCore layers the module filter and sampler over a base core.
+func wrapCore(core zapcore.Core, levels *Levels, sampling SamplingConfig) zapcore.Core {
+	wrapped := zapcore.Core(&levelFilterCore{Core: core, levels: levels})
+	if sampling.Disabled {
+		return wrapped
+	}
+	initial := sampling.Initial
+	if initial <= 0 {
+		initial = 100
+	}
+	thereafter := sampling.Thereafter
+	if thereafter <= 0 {
+		thereafter = 10
+	}
+	return zapcore.NewSamplerWithOptions(wrapped, time.Second, initial, thereafter)
+}
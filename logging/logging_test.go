@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newObservedLogger builds a logger over an observer core with the module
+// filter (sampling disabled so counts are exact).
+func newObservedLogger(t *testing.T, cfg Config) (*zap.Logger, *Levels, *observer.ObservedLogs) {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	levels, err := NewLevels(cfg)
+	require.NoError(t, err)
+	return zap.New(wrapCore(core, levels, SamplingConfig{Disabled: true})), levels, logs
+}
+
+func TestModuleLevelOverrides(t *testing.T) {
+	logger, _, logs := newObservedLogger(t, Config{
+		Level:        "info",
+		ModuleLevels: map[string]string{"tcp": "warn", "coordinator": "debug"},
+	})
+
+	logger.Named("tcp").Info("noisy")                 // filtered by override
+	logger.Named("tcp").Named("client").Info("noisy") // prefix match filters too
+	logger.Named("tcp").Warn("kept")                  // at override level
+	logger.Named("coordinator").Debug("kept")         // override lowers the bar
+	logger.Named("builder").Debug("filtered")         // global info level
+	logger.Named("builder").Info("kept")
+
+	messages := make([]string, 0, logs.Len())
+	for _, entry := range logs.All() {
+		messages = append(messages, entry.Message)
+	}
+	require.Equal(t, []string{"kept", "kept", "kept"}, messages)
+}
+
+func TestRuntimeLevelChanges(t *testing.T) {
+	logger, levels, logs := newObservedLogger(t, Config{Level: "info"})
+
+	logger.Named("tcp").Debug("before")
+	require.Equal(t, 0, logs.Len())
+
+	require.NoError(t, levels.SetModule("tcp", "debug"))
+	logger.Named("tcp").Debug("after")
+	require.Equal(t, 1, logs.Len())
+
+	require.NoError(t, levels.SetModule("tcp", "")) // remove override
+	logger.Named("tcp").Debug("gone")
+	require.Equal(t, 1, logs.Len())
+
+	require.NoError(t, levels.SetGlobal("error"))
+	logger.Named("builder").Warn("filtered")
+	require.Equal(t, 1, logs.Len())
+
+	require.Error(t, levels.SetGlobal("nope"))
+}
+
+func TestSnapshotListsEffectiveLevels(t *testing.T) {
+	_, levels, _ := newObservedLogger(t, Config{Level: "warn", ModuleLevels: map[string]string{"tcp": "debug"}})
+	snap := levels.Snapshot()
+	require.Equal(t, "warn", snap[""])
+	require.Equal(t, "debug", snap["tcp"])
+	require.Equal(t, []string{"tcp"}, levels.Modules())
+}
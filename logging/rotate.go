@@ -0,0 +1,184 @@
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileConfig parameterizes file output with rotation.
+type FileConfig struct {
+	// Path is the active log file; rotated files live next to it with a
+	// timestamp suffix.
+	Path string `yaml:"Path"`
+	// MaxSizeMB rotates the file once it exceeds this size. Zero means 100.
+	MaxSizeMB int `yaml:"MaxSizeMB"`
+	// MaxAge rotates the file once it has been open this long, so quiet
+	// instances still produce bounded, dated files. Zero disables
+	// time-based rotation.
+	MaxAge time.Duration `yaml:"MaxAge"`
+	// MaxBackups is how many rotated files to keep. Zero means 7.
+	MaxBackups int `yaml:"MaxBackups"`
+	// Compress gzips rotated files.
+	Compress bool `yaml:"Compress"`
+}
+
+func (c FileConfig) withDefaults() FileConfig {
+	if c.MaxSizeMB <= 0 {
+		c.MaxSizeMB = 100
+	}
+	if c.MaxBackups <= 0 {
+		c.MaxBackups = 7
+	}
+	return c
+}
+
+// rotatingWriter is an io.Writer that rotates, optionally gzips, and
+// prunes its backing file. It implements zapcore.WriteSyncer.
+type rotatingWriter struct {
+	cfg FileConfig
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// newRotatingWriter opens (or appends to) the log file.
+func newRotatingWriter(cfg FileConfig) (*rotatingWriter, error) {
+	w := &rotatingWriter{cfg: cfg.withDefaults()}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *rotatingWriter) open() error {
+	file, err := os.OpenFile(w.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("logging: open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("logging: stat log file: %w", err)
+	}
+	w.file = file
+	w.size = info.Size()
+	w.openedAt = time.Now()
+	return nil
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			// Keep logging to the current file rather than losing output.
+			fmt.Fprintf(os.Stderr, "logging: rotation failed: %v\n", err)
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Sync satisfies zapcore.WriteSyncer.
+func (w *rotatingWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Sync()
+}
+
+// Close flushes and closes the active file.
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+func (w *rotatingWriter) shouldRotate(incoming int) bool {
+	if w.size+int64(incoming) > int64(w.cfg.MaxSizeMB)*1024*1024 {
+		return true
+	}
+	return w.cfg.MaxAge > 0 && w.size > 0 && time.Since(w.openedAt) >= w.cfg.MaxAge
+}
+
+// rotate moves the active file aside, gzips it when configured, reopens a
+// fresh file and prunes old backups. Callers hold w.mu.
+func (w *rotatingWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	backup := fmt.Sprintf("%s.%s", w.cfg.Path, time.Now().UTC().Format("20060102-150405.000000000"))
+	if err := os.Rename(w.cfg.Path, backup); err != nil {
+		return err
+	}
+	if w.cfg.Compress {
+		if err := gzipFile(backup); err != nil {
+			return err
+		}
+	}
+	if err := w.prune(); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+// gzipFile compresses path into path.gz and removes the original.
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(dst)
+	if _, err := io.Copy(zw, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// prune removes the oldest backups beyond MaxBackups. Backup names sort
+// chronologically, so lexical order is age order.
+func (w *rotatingWriter) prune() error {
+	dir := filepath.Dir(w.cfg.Path)
+	base := filepath.Base(w.cfg.Path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if name != base && strings.HasPrefix(name, base+".") {
+			backups = append(backups, name)
+		}
+	}
+	sort.Strings(backups)
+	for len(backups) > w.cfg.MaxBackups {
+		if err := os.Remove(filepath.Join(dir, backups[0])); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func backups(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), "pub.log.") {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+func TestRotationBySizeGzipsAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pub.log")
+	writer, err := newRotatingWriter(FileConfig{Path: path, MaxSizeMB: 1, MaxBackups: 2, Compress: true})
+	require.NoError(t, err)
+	defer writer.Close()
+
+	line := bytes.Repeat([]byte("x"), 512*1024)
+	for i := 0; i < 8; i++ {
+		_, err := writer.Write(append(line, '\n'))
+		require.NoError(t, err)
+	}
+
+	names := backups(t, dir)
+	require.Len(t, names, 2) // pruned down to MaxBackups
+	for _, name := range names {
+		require.True(t, strings.HasSuffix(name, ".gz"), name)
+		f, err := os.Open(filepath.Join(dir, name))
+		require.NoError(t, err)
+		zr, err := gzip.NewReader(f)
+		require.NoError(t, err)
+		content, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		require.NotEmpty(t, content)
+		require.NoError(t, f.Close())
+	}
+	// The active file keeps accepting writes.
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Greater(t, info.Size(), int64(0))
+}
+
+func TestRuntimeFormatSwitch(t *testing.T) {
+	var buf bytes.Buffer
+	format, err := NewFormat("")
+	require.NoError(t, err)
+	require.Equal(t, FormatConsole, format.Get())
+
+	core := newFormatSwitchCore(zapcore.AddSync(&buf), format)
+	entry := zapcore.Entry{Level: zapcore.InfoLevel, Message: "hello"}
+	require.NoError(t, core.Write(entry, nil))
+	consoleLine := buf.String()
+	require.NotEmpty(t, consoleLine)
+	require.False(t, strings.HasPrefix(consoleLine, "{"))
+
+	buf.Reset()
+	require.NoError(t, format.Set(FormatJSON))
+	require.NoError(t, core.Write(entry, nil))
+	require.True(t, strings.HasPrefix(buf.String(), "{"))
+	require.Contains(t, buf.String(), `"msg":"hello"`)
+
+	require.Error(t, format.Set("yaml"))
+}
+
+func TestNewFileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pub.log")
+	logger, controls, err := New(Config{Output: OutputFile, Format: FormatJSON, File: FileConfig{Path: path}})
+	require.NoError(t, err)
+	logger.Info("written to file")
+	require.NoError(t, logger.Sync())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(content), `"msg":"written to file"`)
+	require.Equal(t, FormatJSON, controls.Format.Get())
+
+	_, _, err = New(Config{Output: OutputFile})
+	require.Error(t, err) // file output requires a path
+	_, _, err = New(Config{Output: "syslog"})
+	require.Error(t, err)
+}
@@ -0,0 +1,17 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var busEventsDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "publisher",
+	Name:      "bus_events_dropped_total",
+	Help:      "Event bus deliveries dropped because a subscriber's buffer was full, by topic.",
+}, []string{"topic"})
+
+// RecordBusEventDropped counts an event a subscriber missed.
+func RecordBusEventDropped(topic string) {
+	busEventsDropped.WithLabelValues(topic).Inc()
+}
@@ -0,0 +1,19 @@
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var intakeRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "publisher",
+	Name:      "intake_rejected_total",
+	Help:      "XT submissions rejected by the intake adapter, by source chain and reason.",
+}, []string{"chain", "reason"})
+
+// RecordIntakeRejected counts a rejected XT submission.
+func RecordIntakeRejected(chainID uint64, reason string) {
+	intakeRejected.WithLabelValues(strconv.FormatUint(chainID, 10), reason).Inc()
+}
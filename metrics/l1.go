@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	l1GasSpent = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "l1_gas_spent_total",
+		Help:      "Gas consumed by L1 superblock publication transactions.",
+	})
+	l1PublishPaused = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "publisher",
+		Name:      "l1_publish_paused",
+		Help:      "1 while publishing is paused because the fee budget is exhausted.",
+	})
+	l1BudgetExceeded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "l1_budget_exceeded_total",
+		Help:      "Budget violations, by kind (daily, superblock).",
+	}, []string{"kind"})
+)
+
+// RecordL1GasSpent accumulates publication gas.
+func RecordL1GasSpent(gas uint64) {
+	l1GasSpent.Add(float64(gas))
+}
+
+// SetL1PublishPaused flags whether budget exhaustion is blocking publishes.
+func SetL1PublishPaused(paused bool) {
+	if paused {
+		l1PublishPaused.Set(1)
+	} else {
+		l1PublishPaused.Set(0)
+	}
+}
+
+// RecordL1BudgetExceeded counts a budget violation.
+func RecordL1BudgetExceeded(kind string) {
+	l1BudgetExceeded.WithLabelValues(kind).Inc()
+}
@@ -0,0 +1,304 @@
+// Package metrics exposes the publisher's Prometheus instrumentation.
+//
+// All per-chain metrics are labeled with chain_id. To keep label
+// cardinality bounded when unknown or misbehaving chains send traffic,
+// chain labels pass through a ChainGuard which maps disallowed or
+// excess chain IDs to a single overflow bucket.
+package metrics
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// OverflowChainLabel is the chain_id label value used for chains that are
+// not in the allowlist or that would exceed the configured chain limit.
+const OverflowChainLabel = "other"
+
+// DefaultMaxChains bounds the number of distinct chain_id label values
+// when no explicit allowlist is configured.
+const DefaultMaxChains = 64
+
+var (
+	messagesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "messages_received_total",
+		Help:      "Inbound protocol messages by chain and payload type.",
+	}, []string{"chain_id", "type"})
+
+	messagesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "messages_sent_total",
+		Help:      "Outbound protocol messages by chain and payload type.",
+	}, []string{"chain_id", "type"})
+
+	l2BlockLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "publisher",
+		Name:      "l2_block_latency_seconds",
+		Help:      "Delay between slot start and L2 block arrival, by chain.",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"chain_id"})
+
+	voteLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "publisher",
+		Name:      "vote_latency_seconds",
+		Help:      "Delay between StartSC broadcast and vote arrival, by chain.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"chain_id"})
+
+	proofSubmissions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "proof_submissions_total",
+		Help:      "Proof submissions by chain and outcome.",
+	}, []string{"chain_id", "status"})
+
+	proofSubmissionLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "publisher",
+		Name:      "proof_submission_latency_seconds",
+		Help:      "Time from superblock seal to proof submission, by chain.",
+		Buckets:   prometheus.ExponentialBuckets(0.5, 2, 14),
+	}, []string{"chain_id"})
+
+	bytesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "bytes_received_total",
+		Help:      "Inbound payload bytes by chain and payload type.",
+	}, []string{"chain_id", "type"})
+
+	bytesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "bytes_sent_total",
+		Help:      "Outbound payload bytes by chain and payload type.",
+	}, []string{"chain_id", "type"})
+
+	bandwidthQuotaExceeded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "bandwidth_quota_exceeded_total",
+		Help:      "Messages rejected because a chain exhausted its byte quota.",
+	}, []string{"chain_id"})
+
+	bufferedBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "publisher",
+		Name:      "transport_buffered_bytes",
+		Help:      "Bytes currently held in chunk reassembly buffers across all connections.",
+	})
+
+	bufferRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "transport_buffer_rejections_total",
+		Help:      "Chunks rejected by a reassembly buffer cap, by chain and scope.",
+	}, []string{"chain_id", "scope"})
+
+	handshakeFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "handshake_failures_total",
+		Help:      "Handshake rejections by reason (untrusted_key, bad_signature, clock_drift, replay, ...).",
+	}, []string{"reason"})
+
+	acceptsRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "transport_accepts_rejected_total",
+		Help:      "Connections dropped before the handshake, by reason.",
+	}, []string{"reason"})
+
+	circDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "circ_messages_dropped_total",
+		Help:      "CIRC messages dropped or expired, by chain and reason.",
+	}, []string{"chain_id", "reason"})
+
+	superblockBuildSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "publisher",
+		Name:      "superblock_build_seconds",
+		Help:      "Wall time spent assembling one superblock.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+	})
+
+	buildDeadlineExceeded = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "superblock_build_deadline_exceeded_total",
+		Help:      "Superblock builds that overran their slot-time budget.",
+	})
+
+	invalidMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "invalid_messages_total",
+		Help:      "Inbound messages failing schema validation, by chain and payload type.",
+	}, []string{"chain_id", "type"})
+
+	chainLabelOverflows = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "chain_label_overflow_total",
+		Help:      "Observations attributed to the overflow chain label.",
+	})
+)
+
+// ChainGuardConfig controls which chain IDs become label values.
+type ChainGuardConfig struct {
+	// AllowedChainIDs, when non-empty, is the exact set of chain IDs that
+	// get their own label value; everything else maps to the overflow bucket.
+	AllowedChainIDs []uint64 `yaml:"AllowedChainIDs"`
+	// MaxChains caps the number of distinct chain labels admitted when no
+	// allowlist is set. Zero means DefaultMaxChains.
+	MaxChains int `yaml:"MaxChains"`
+}
+
+type chainGuard struct {
+	mu      sync.RWMutex
+	allowed map[uint64]struct{}
+	seen    map[uint64]struct{}
+	max     int
+}
+
+var guard = &chainGuard{
+	seen: make(map[uint64]struct{}),
+	max:  DefaultMaxChains,
+}
+
+// ConfigureChainGuard installs the chain label policy. It is expected to be
+// called once during startup, before traffic is served.
+func ConfigureChainGuard(cfg ChainGuardConfig) {
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+
+	if len(cfg.AllowedChainIDs) > 0 {
+		guard.allowed = make(map[uint64]struct{}, len(cfg.AllowedChainIDs))
+		for _, id := range cfg.AllowedChainIDs {
+			guard.allowed[id] = struct{}{}
+		}
+	} else {
+		guard.allowed = nil
+	}
+	guard.seen = make(map[uint64]struct{})
+	guard.max = cfg.MaxChains
+	if guard.max <= 0 {
+		guard.max = DefaultMaxChains
+	}
+}
+
+// ChainLabel maps a chain ID to its metric label value, applying the
+// allowlist / cardinality limit.
+func ChainLabel(chainID uint64) string {
+	guard.mu.RLock()
+	if guard.allowed != nil {
+		_, ok := guard.allowed[chainID]
+		guard.mu.RUnlock()
+		if !ok {
+			chainLabelOverflows.Inc()
+			return OverflowChainLabel
+		}
+		return strconv.FormatUint(chainID, 10)
+	}
+	if _, ok := guard.seen[chainID]; ok {
+		guard.mu.RUnlock()
+		return strconv.FormatUint(chainID, 10)
+	}
+	guard.mu.RUnlock()
+
+	guard.mu.Lock()
+	defer guard.mu.Unlock()
+	if _, ok := guard.seen[chainID]; ok {
+		return strconv.FormatUint(chainID, 10)
+	}
+	if len(guard.seen) >= guard.max {
+		chainLabelOverflows.Inc()
+		return OverflowChainLabel
+	}
+	guard.seen[chainID] = struct{}{}
+	return strconv.FormatUint(chainID, 10)
+}
+
+// RecordMessageReceived counts an inbound message of the given payload type.
+func RecordMessageReceived(chainID uint64, payloadType string) {
+	messagesReceived.WithLabelValues(ChainLabel(chainID), payloadType).Inc()
+}
+
+// RecordMessageSent counts an outbound message of the given payload type.
+func RecordMessageSent(chainID uint64, payloadType string) {
+	messagesSent.WithLabelValues(ChainLabel(chainID), payloadType).Inc()
+}
+
+// ObserveL2BlockLatency records how late into the slot an L2 block arrived.
+func ObserveL2BlockLatency(chainID uint64, d time.Duration) {
+	l2BlockLatency.WithLabelValues(ChainLabel(chainID)).Observe(d.Seconds())
+}
+
+// ObserveVoteLatency records the time between StartSC and the chain's vote.
+func ObserveVoteLatency(chainID uint64, d time.Duration) {
+	voteLatency.WithLabelValues(ChainLabel(chainID)).Observe(d.Seconds())
+}
+
+// RecordProofSubmission counts a proof submission outcome ("accepted",
+// "rejected", "duplicate", ...).
+func RecordProofSubmission(chainID uint64, status string) {
+	proofSubmissions.WithLabelValues(ChainLabel(chainID), status).Inc()
+}
+
+// RecordBytesReceived accounts inbound payload bytes.
+func RecordBytesReceived(chainID uint64, payloadType string, n int) {
+	bytesReceived.WithLabelValues(ChainLabel(chainID), payloadType).Add(float64(n))
+}
+
+// RecordBytesSent accounts outbound payload bytes.
+func RecordBytesSent(chainID uint64, payloadType string, n int) {
+	bytesSent.WithLabelValues(ChainLabel(chainID), payloadType).Add(float64(n))
+}
+
+// ObserveSuperblockBuild records how long one superblock took to assemble.
+func ObserveSuperblockBuild(d time.Duration) {
+	superblockBuildSeconds.Observe(d.Seconds())
+}
+
+// RecordBuildDeadlineExceeded counts a superblock build that overran its
+// slot-time budget.
+func RecordBuildDeadlineExceeded() {
+	buildDeadlineExceeded.Inc()
+}
+
+// RecordInvalidMessage counts an inbound message that failed schema
+// validation.
+func RecordInvalidMessage(chainID uint64, payloadType string) {
+	invalidMessages.WithLabelValues(ChainLabel(chainID), payloadType).Inc()
+}
+
+// RecordQuotaExceeded counts a message rejected by the byte quota.
+func RecordQuotaExceeded(chainID uint64) {
+	bandwidthQuotaExceeded.WithLabelValues(ChainLabel(chainID)).Inc()
+}
+
+// RecordBufferedBytes adjusts the reassembly buffer gauge by delta bytes.
+func RecordBufferedBytes(delta int) {
+	bufferedBytes.Add(float64(delta))
+}
+
+// RecordBufferRejected counts a chunk rejected by a reassembly buffer cap;
+// scope is "connection" or "server".
+func RecordBufferRejected(chainID uint64, scope string) {
+	bufferRejections.WithLabelValues(ChainLabel(chainID), scope).Inc()
+}
+
+// RecordHandshakeFailure counts a rejected handshake by reason.
+func RecordHandshakeFailure(reason string) {
+	handshakeFailures.WithLabelValues(reason).Inc()
+}
+
+// RecordAcceptRejected counts a connection dropped before its handshake;
+// reason is "rate", "handshake_queue" or "banned".
+func RecordAcceptRejected(reason string) {
+	acceptsRejected.WithLabelValues(reason).Inc()
+}
+
+// RecordCIRCDropped counts a CIRC message dropped or expired for the given
+// reason ("payload_too_large", "xt_limit", "ttl", "decided", ...).
+func RecordCIRCDropped(chainID uint64, reason string) {
+	circDropped.WithLabelValues(ChainLabel(chainID), reason).Inc()
+}
+
+// ObserveProofSubmissionLatency records seal-to-proof-submission time.
+func ObserveProofSubmissionLatency(chainID uint64, d time.Duration) {
+	proofSubmissionLatency.WithLabelValues(ChainLabel(chainID)).Observe(d.Seconds())
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainLabelAllowlist(t *testing.T) {
+	ConfigureChainGuard(ChainGuardConfig{AllowedChainIDs: []uint64{10, 42}})
+	defer ConfigureChainGuard(ChainGuardConfig{})
+
+	require.Equal(t, "10", ChainLabel(10))
+	require.Equal(t, "42", ChainLabel(42))
+	require.Equal(t, OverflowChainLabel, ChainLabel(7))
+}
+
+func TestChainLabelLimit(t *testing.T) {
+	ConfigureChainGuard(ChainGuardConfig{MaxChains: 2})
+	defer ConfigureChainGuard(ChainGuardConfig{})
+
+	require.Equal(t, "1", ChainLabel(1))
+	require.Equal(t, "2", ChainLabel(2))
+	require.Equal(t, OverflowChainLabel, ChainLabel(3))
+	// Already-admitted chains keep their label.
+	require.Equal(t, "1", ChainLabel(1))
+}
@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pipelineJobs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "publisher",
+		Name:      "proof_pipeline_jobs",
+		Help:      "Proof jobs currently queued or running, by stage.",
+	}, []string{"stage"})
+
+	pipelineStageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "publisher",
+		Name:      "proof_pipeline_stage_duration_seconds",
+		Help:      "Wall time a job spent in one stage, including queueing.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 14),
+	}, []string{"stage"})
+
+	pipelineRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "proof_pipeline_retries_total",
+		Help:      "Proof job retries, by stage.",
+	}, []string{"stage"})
+
+	proverRequestLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "publisher",
+		Name:      "prover_request_latency_seconds",
+		Help:      "Latency of individual prover requests, by stage.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 14),
+	}, []string{"stage"})
+
+	proofsCompleted = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "proofs_completed_total",
+		Help:      "Proof jobs that finished every stage.",
+	})
+
+	proofsFailed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "publisher",
+		Name:      "proofs_failed_total",
+		Help:      "Proof jobs abandoned after exhausting retries, by stage.",
+	}, []string{"stage"})
+
+	pipelineQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "publisher",
+		Name:      "proof_pipeline_queue_depth",
+		Help:      "Proof jobs waiting for a stage slot (excluding running), by stage.",
+	}, []string{"stage"})
+
+	lastProofCompleted = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "publisher",
+		Name:      "proof_last_completed_timestamp_seconds",
+		Help:      "Unix time the pipeline last completed a full proof job.",
+	})
+)
+
+// RecordPipelineJobStart accounts a job entering a stage.
+func RecordPipelineJobStart(stage string) {
+	pipelineJobs.WithLabelValues(stage).Inc()
+}
+
+// RecordPipelineJobEnd accounts a job leaving a stage, with the time it
+// spent there.
+func RecordPipelineJobEnd(stage string, d time.Duration) {
+	pipelineJobs.WithLabelValues(stage).Dec()
+	pipelineStageDuration.WithLabelValues(stage).Observe(d.Seconds())
+}
+
+// RecordPipelineRetry counts a stage retry.
+func RecordPipelineRetry(stage string) {
+	pipelineRetries.WithLabelValues(stage).Inc()
+}
+
+// ObserveProverRequestLatency records one prover round trip.
+func ObserveProverRequestLatency(stage string, d time.Duration) {
+	proverRequestLatency.WithLabelValues(stage).Observe(d.Seconds())
+}
+
+// RecordProofCompleted counts a job that cleared every stage.
+func RecordProofCompleted() {
+	proofsCompleted.Inc()
+}
+
+// RecordProofFailed counts a job abandoned in the given stage.
+func RecordProofFailed(stage string) {
+	proofsFailed.WithLabelValues(stage).Inc()
+}
+
+// SetPipelineQueueDepth publishes the number of jobs waiting in a stage's
+// queue.
+func SetPipelineQueueDepth(stage string, depth int) {
+	pipelineQueueDepth.WithLabelValues(stage).Set(float64(depth))
+}
+
+// SetLastProofCompleted publishes when the pipeline last finished a job.
+func SetLastProofCompleted(t time.Time) {
+	lastProofCompleted.Set(float64(t.Unix()))
+}
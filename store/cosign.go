@@ -0,0 +1,104 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+const bucketCosignatures = "cosignatures" // superblock number -> json(CosignatureSet)
+
+// Cosignature is one sequencer's signature over a superblock header hash.
+type Cosignature struct {
+	ChainID   types.ChainID `json:"chain_id"`
+	KeyID     string        `json:"key_id"`
+	Signature []byte        `json:"signature"`
+}
+
+// CosignatureSet is the aggregated follower co-signatures of one
+// superblock.
+type CosignatureSet struct {
+	SuperblockNumber uint64        `json:"superblock_number"`
+	Hash             types.Hash    `json:"hash"`
+	Cosignatures     []Cosignature `json:"cosignatures"`
+}
+
+// CosignatureStore persists aggregated co-signature sets.
+type CosignatureStore interface {
+	// PutCosignatures stores a set, replacing any previous one for the
+	// superblock.
+	PutCosignatures(set *CosignatureSet) error
+	// GetCosignatures returns a superblock's set, or ErrNotFound.
+	GetCosignatures(superblockNumber uint64) (*CosignatureSet, error)
+}
+
+type boltCosignatureStore struct {
+	db *DB
+}
+
+// NewBoltCosignatureStore returns a disk-backed CosignatureStore on the
+// shared DB.
+func NewBoltCosignatureStore(db *DB) (CosignatureStore, error) {
+	if err := db.ensureBuckets(bucketCosignatures); err != nil {
+		return nil, err
+	}
+	return &boltCosignatureStore{db: db}, nil
+}
+
+func (s *boltCosignatureStore) PutCosignatures(set *CosignatureSet) error {
+	raw, err := json.Marshal(set)
+	if err != nil {
+		return fmt.Errorf("encode cosignature set: %w", err)
+	}
+	return s.db.bolt.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketCosignatures)).Put(u64Key(set.SuperblockNumber), raw)
+	})
+}
+
+func (s *boltCosignatureStore) GetCosignatures(superblockNumber uint64) (*CosignatureSet, error) {
+	var set *CosignatureSet
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket([]byte(bucketCosignatures)).Get(u64Key(superblockNumber))
+		if raw == nil {
+			return ErrNotFound
+		}
+		set = new(CosignatureSet)
+		return json.Unmarshal(raw, set)
+	})
+	return set, err
+}
+
+type memoryCosignatureStore struct {
+	mu   sync.RWMutex
+	sets map[uint64]*CosignatureSet
+}
+
+// NewMemoryCosignatureStore returns a non-persistent CosignatureStore.
+func NewMemoryCosignatureStore() CosignatureStore {
+	return &memoryCosignatureStore{sets: make(map[uint64]*CosignatureSet)}
+}
+
+func (s *memoryCosignatureStore) PutCosignatures(set *CosignatureSet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *set
+	cp.Cosignatures = append([]Cosignature(nil), set.Cosignatures...)
+	s.sets[set.SuperblockNumber] = &cp
+	return nil
+}
+
+func (s *memoryCosignatureStore) GetCosignatures(superblockNumber uint64) (*CosignatureSet, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	set, ok := s.sets[superblockNumber]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *set
+	cp.Cosignatures = append([]Cosignature(nil), set.Cosignatures...)
+	return &cp, nil
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// DB wraps the bbolt database file shared by the publisher's persistent
+// stores. A single DB is opened per process and handed to the individual
+// store constructors.
+type DB struct {
+	bolt *bolt.DB
+}
+
+// Open opens (creating if necessary) the database file at path.
+func Open(path string) (*DB, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open database %q: %w", path, err)
+	}
+	wrapped := &DB{bolt: db}
+	if err := wrapped.ensureBuckets(txnBuckets...); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return wrapped, nil
+}
+
+// Close closes the underlying database file.
+func (db *DB) Close() error {
+	return db.bolt.Close()
+}
+
+func (db *DB) ensureBuckets(names ...string) error {
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		for _, name := range names {
+			if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+				return fmt.Errorf("create bucket %q: %w", name, err)
+			}
+		}
+		return nil
+	})
+}
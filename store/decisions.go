@@ -0,0 +1,150 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+const (
+	bucketDecisions       = "decisions"         // seq -> json(DecisionRecord)
+	bucketDecisionsByXt   = "decisions_by_xt"   // xtid -> seq
+	bucketDecisionsBySlot = "decisions_by_slot" // slot|seq -> seq
+)
+
+// DecisionRecord is one appended entry of the decisions ledger, tying an XT
+// outcome to the superblock that anchored it.
+type DecisionRecord struct {
+	Seq              uint64         `json:"seq"`
+	Decision         types.Decision `json:"decision"`
+	SuperblockNumber uint64         `json:"superblock_number"`
+}
+
+// DecisionLedger is the append-only record of every XT outcome. Entries are
+// never mutated or deleted, so sequencers and users can later prove an XT
+// was committed or aborted at a given slot.
+type DecisionLedger interface {
+	// AppendDecision appends an outcome, assigning its sequence number.
+	AppendDecision(d types.Decision, superblockNumber uint64) (*DecisionRecord, error)
+	// DecisionsBySlot returns the ledger entries for a slot in append order.
+	DecisionsBySlot(slot types.Slot) ([]*DecisionRecord, error)
+	// LatestDecision returns the most recent entry for an XT, or ErrNotFound.
+	LatestDecision(xtID types.XtID) (*DecisionRecord, error)
+}
+
+type boltDecisionLedger struct {
+	db *DB
+}
+
+// NewBoltDecisionLedger returns a disk-backed DecisionLedger on the shared DB.
+func NewBoltDecisionLedger(db *DB) (DecisionLedger, error) {
+	if err := db.ensureBuckets(bucketDecisions, bucketDecisionsByXt, bucketDecisionsBySlot); err != nil {
+		return nil, err
+	}
+	return &boltDecisionLedger{db: db}, nil
+}
+
+func (l *boltDecisionLedger) AppendDecision(d types.Decision, superblockNumber uint64) (*DecisionRecord, error) {
+	var rec *DecisionRecord
+	err := l.db.bolt.Update(func(tx *bolt.Tx) error {
+		decisions := tx.Bucket([]byte(bucketDecisions))
+		seq, err := decisions.NextSequence()
+		if err != nil {
+			return err
+		}
+		rec = &DecisionRecord{Seq: seq, Decision: d, SuperblockNumber: superblockNumber}
+		raw, err := json.Marshal(rec)
+		if err != nil {
+			return fmt.Errorf("encode decision record: %w", err)
+		}
+		if err := decisions.Put(u64Key(seq), raw); err != nil {
+			return err
+		}
+		if err := tx.Bucket([]byte(bucketDecisionsByXt)).Put(d.XtID[:], u64Key(seq)); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte(bucketDecisionsBySlot)).Put(
+			compositeKey(uint64(d.Slot), seq), u64Key(seq))
+	})
+	return rec, err
+}
+
+func (l *boltDecisionLedger) DecisionsBySlot(slot types.Slot) ([]*DecisionRecord, error) {
+	var recs []*DecisionRecord
+	err := l.db.bolt.View(func(tx *bolt.Tx) error {
+		decisions := tx.Bucket([]byte(bucketDecisions))
+		prefix := u64Key(uint64(slot))
+		c := tx.Bucket([]byte(bucketDecisionsBySlot)).Cursor()
+		for k, seq := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, seq = c.Next() {
+			var rec DecisionRecord
+			if err := json.Unmarshal(decisions.Get(seq), &rec); err != nil {
+				return fmt.Errorf("decode decision record: %w", err)
+			}
+			recs = append(recs, &rec)
+		}
+		return nil
+	})
+	return recs, err
+}
+
+func (l *boltDecisionLedger) LatestDecision(xtID types.XtID) (*DecisionRecord, error) {
+	var rec *DecisionRecord
+	err := l.db.bolt.View(func(tx *bolt.Tx) error {
+		seq := tx.Bucket([]byte(bucketDecisionsByXt)).Get(xtID[:])
+		if seq == nil {
+			return ErrNotFound
+		}
+		rec = new(DecisionRecord)
+		return json.Unmarshal(tx.Bucket([]byte(bucketDecisions)).Get(seq), rec)
+	})
+	return rec, err
+}
+
+type memoryDecisionLedger struct {
+	mu   sync.RWMutex
+	recs []*DecisionRecord
+	byXt map[types.XtID]int
+}
+
+// NewMemoryDecisionLedger returns a non-persistent DecisionLedger.
+func NewMemoryDecisionLedger() DecisionLedger {
+	return &memoryDecisionLedger{byXt: make(map[types.XtID]int)}
+}
+
+func (l *memoryDecisionLedger) AppendDecision(d types.Decision, superblockNumber uint64) (*DecisionRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rec := &DecisionRecord{Seq: uint64(len(l.recs) + 1), Decision: d, SuperblockNumber: superblockNumber}
+	l.byXt[d.XtID] = len(l.recs)
+	l.recs = append(l.recs, rec)
+	return rec, nil
+}
+
+func (l *memoryDecisionLedger) DecisionsBySlot(slot types.Slot) ([]*DecisionRecord, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	var recs []*DecisionRecord
+	for _, rec := range l.recs {
+		if rec.Decision.Slot == slot {
+			cp := *rec
+			recs = append(recs, &cp)
+		}
+	}
+	return recs, nil
+}
+
+func (l *memoryDecisionLedger) LatestDecision(xtID types.XtID) (*DecisionRecord, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	i, ok := l.byXt[xtID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *l.recs[i]
+	return &cp, nil
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestDecisionLedger(t *testing.T) {
+	boltLedger, err := NewBoltDecisionLedger(openTestDB(t))
+	require.NoError(t, err)
+
+	for name, ledger := range map[string]DecisionLedger{
+		"memory": NewMemoryDecisionLedger(),
+		"bolt":   boltLedger,
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := types.Decision{XtID: types.Hash{1}, Committed: true, Slot: 5}
+			b := types.Decision{XtID: types.Hash{2}, Committed: false, Slot: 5}
+			c := types.Decision{XtID: types.Hash{3}, Committed: true, Slot: 6}
+
+			rec, err := ledger.AppendDecision(a, 100)
+			require.NoError(t, err)
+			require.Equal(t, uint64(1), rec.Seq)
+			_, err = ledger.AppendDecision(b, 100)
+			require.NoError(t, err)
+			_, err = ledger.AppendDecision(c, 101)
+			require.NoError(t, err)
+
+			bySlot, err := ledger.DecisionsBySlot(5)
+			require.NoError(t, err)
+			require.Len(t, bySlot, 2)
+			require.Equal(t, a.XtID, bySlot[0].Decision.XtID)
+
+			latest, err := ledger.LatestDecision(b.XtID)
+			require.NoError(t, err)
+			require.False(t, latest.Decision.Committed)
+			require.Equal(t, uint64(100), latest.SuperblockNumber)
+
+			_, err = ledger.LatestDecision(types.Hash{9})
+			require.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestDecisionsCommitmentOrderIndependent(t *testing.T) {
+	a := types.Decision{XtID: types.Hash{1}, Committed: true, Slot: 5}
+	b := types.Decision{XtID: types.Hash{2}, Committed: false, Slot: 5}
+
+	require.Equal(t,
+		types.DecisionsCommitment([]types.Decision{a, b}),
+		types.DecisionsCommitment([]types.Decision{b, a}))
+	require.NotEqual(t,
+		types.DecisionsCommitment([]types.Decision{a}),
+		types.DecisionsCommitment([]types.Decision{b}))
+	require.True(t, types.DecisionsCommitment(nil).IsZero())
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+const bucketJournal = "sb_journal" // number|seq -> json(JournalEvent)
+
+// JournalEvent is one step of superblock assembly, recorded by the builder
+// so operators and prover debugging can replay how a superblock was put
+// together.
+type JournalEvent struct {
+	Seq              uint64            `json:"seq"`
+	SuperblockNumber uint64            `json:"superblock_number"`
+	Slot             types.Slot        `json:"slot"`
+	Type             string            `json:"type"`
+	At               time.Time         `json:"at"`
+	Detail           map[string]string `json:"detail,omitempty"`
+}
+
+// ConstructionJournal persists the event-sourced superblock assembly log.
+type ConstructionJournal interface {
+	// AppendEvent appends an event, assigning its sequence number.
+	AppendEvent(event *JournalEvent) error
+	// Events returns a superblock's events in append order.
+	Events(superblockNumber uint64) ([]*JournalEvent, error)
+}
+
+type boltJournal struct {
+	db *DB
+}
+
+// NewBoltConstructionJournal returns a disk-backed journal on the shared DB.
+func NewBoltConstructionJournal(db *DB) (ConstructionJournal, error) {
+	if err := db.ensureBuckets(bucketJournal); err != nil {
+		return nil, err
+	}
+	return &boltJournal{db: db}, nil
+}
+
+func (j *boltJournal) AppendEvent(event *JournalEvent) error {
+	return j.db.bolt.Update(func(tx *bolt.Tx) error {
+		journal := tx.Bucket([]byte(bucketJournal))
+		seq, err := journal.NextSequence()
+		if err != nil {
+			return err
+		}
+		event.Seq = seq
+		raw, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encode journal event: %w", err)
+		}
+		return journal.Put(compositeKey(event.SuperblockNumber, seq), raw)
+	})
+}
+
+func (j *boltJournal) Events(superblockNumber uint64) ([]*JournalEvent, error) {
+	var events []*JournalEvent
+	err := j.db.bolt.View(func(tx *bolt.Tx) error {
+		prefix := u64Key(superblockNumber)
+		c := tx.Bucket([]byte(bucketJournal)).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			var event JournalEvent
+			if err := json.Unmarshal(v, &event); err != nil {
+				return fmt.Errorf("decode journal event: %w", err)
+			}
+			events = append(events, &event)
+		}
+		return nil
+	})
+	return events, err
+}
+
+type memoryJournal struct {
+	mu     sync.RWMutex
+	seq    uint64
+	events map[uint64][]*JournalEvent
+}
+
+// NewMemoryConstructionJournal returns a non-persistent journal.
+func NewMemoryConstructionJournal() ConstructionJournal {
+	return &memoryJournal{events: make(map[uint64][]*JournalEvent)}
+}
+
+func (j *memoryJournal) AppendEvent(event *JournalEvent) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.seq++
+	event.Seq = j.seq
+	cp := *event
+	j.events[event.SuperblockNumber] = append(j.events[event.SuperblockNumber], &cp)
+	return nil
+}
+
+func (j *memoryJournal) Events(superblockNumber uint64) ([]*JournalEvent, error) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	events := make([]*JournalEvent, 0, len(j.events[superblockNumber]))
+	for _, event := range j.events[superblockNumber] {
+		cp := *event
+		events = append(events, &cp)
+	}
+	return events, nil
+}
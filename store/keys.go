@@ -0,0 +1,150 @@
+package store
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+const bucketTrustedKeys = "trusted_keys" // key ID -> json(TrustedKeyRecord)
+
+// TrustedKeyRecord is the persisted form of one trusted sequencer key and
+// its certificate-style metadata. Zero values mean unrestricted: no expiry,
+// any chain, any payload type.
+type TrustedKeyRecord struct {
+	ID        string `json:"id"`
+	PubKeyHex string `json:"pub_key_hex"`
+	// NotAfter is the key's expiry as unix seconds; 0 never expires.
+	NotAfter int64 `json:"not_after,omitempty"`
+	// AllowedChains restricts which chain IDs may handshake with the key.
+	AllowedChains []types.ChainID `json:"allowed_chains,omitempty"`
+	// AllowedPayloadTypes restricts which message types the key's
+	// connections may send.
+	AllowedPayloadTypes []string `json:"allowed_payload_types,omitempty"`
+	// Revoked keys are refused at handshake; the record is kept so
+	// revocation survives restarts and re-registration.
+	Revoked bool `json:"revoked,omitempty"`
+}
+
+// TrustedKeyStore persists trusted keys and their revocation state.
+type TrustedKeyStore interface {
+	PutTrustedKey(record *TrustedKeyRecord) error
+	// GetTrustedKey returns the record for a key ID, or ErrNotFound.
+	GetTrustedKey(id string) (*TrustedKeyRecord, error)
+	// ListTrustedKeys returns all records ordered by key ID.
+	ListTrustedKeys() ([]*TrustedKeyRecord, error)
+	// RevokeTrustedKey marks a key revoked, or ErrNotFound.
+	RevokeTrustedKey(id string) error
+}
+
+type boltTrustedKeyStore struct {
+	db *DB
+}
+
+// NewBoltTrustedKeyStore returns a disk-backed TrustedKeyStore on the
+// shared DB.
+func NewBoltTrustedKeyStore(db *DB) (TrustedKeyStore, error) {
+	if err := db.ensureBuckets(bucketTrustedKeys); err != nil {
+		return nil, err
+	}
+	return &boltTrustedKeyStore{db: db}, nil
+}
+
+func (s *boltTrustedKeyStore) PutTrustedKey(record *TrustedKeyRecord) error {
+	return s.db.bolt.Update(func(tx *bolt.Tx) error {
+		return putJSON(tx, bucketTrustedKeys, []byte(record.ID), record)
+	})
+}
+
+func (s *boltTrustedKeyStore) GetTrustedKey(id string) (*TrustedKeyRecord, error) {
+	record := &TrustedKeyRecord{}
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		return getJSON(tx, bucketTrustedKeys, []byte(id), record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (s *boltTrustedKeyStore) ListTrustedKeys() ([]*TrustedKeyRecord, error) {
+	var records []*TrustedKeyRecord
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketTrustedKeys)).ForEach(func(_, v []byte) error {
+			var record TrustedKeyRecord
+			if err := json.Unmarshal(v, &record); err != nil {
+				return err
+			}
+			records = append(records, &record)
+			return nil
+		})
+	})
+	return records, err
+}
+
+func (s *boltTrustedKeyStore) RevokeTrustedKey(id string) error {
+	return s.db.bolt.Update(func(tx *bolt.Tx) error {
+		record := &TrustedKeyRecord{}
+		if err := getJSON(tx, bucketTrustedKeys, []byte(id), record); err != nil {
+			return err
+		}
+		record.Revoked = true
+		return putJSON(tx, bucketTrustedKeys, []byte(id), record)
+	})
+}
+
+type memoryTrustedKeyStore struct {
+	mu      sync.RWMutex
+	records map[string]*TrustedKeyRecord
+}
+
+// NewMemoryTrustedKeyStore returns a non-persistent TrustedKeyStore.
+func NewMemoryTrustedKeyStore() TrustedKeyStore {
+	return &memoryTrustedKeyStore{records: make(map[string]*TrustedKeyRecord)}
+}
+
+func (s *memoryTrustedKeyStore) PutTrustedKey(record *TrustedKeyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *record
+	s.records[record.ID] = &cp
+	return nil
+}
+
+func (s *memoryTrustedKeyStore) GetTrustedKey(id string) (*TrustedKeyRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	record, ok := s.records[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *record
+	return &cp, nil
+}
+
+func (s *memoryTrustedKeyStore) ListTrustedKeys() ([]*TrustedKeyRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records := make([]*TrustedKeyRecord, 0, len(s.records))
+	for _, record := range s.records {
+		cp := *record
+		records = append(records, &cp)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].ID < records[j].ID })
+	return records, nil
+}
+
+func (s *memoryTrustedKeyStore) RevokeTrustedKey(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[id]
+	if !ok {
+		return ErrNotFound
+	}
+	record.Revoked = true
+	return nil
+}
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// L2BlockStore persists L2 blocks with lookup by (chain, number), by block
+// hash and by slot, plus range queries used by rollback recovery and the
+// proofs pipeline.
+type L2BlockStore interface {
+	// PutL2Block stores a block and updates all secondary indexes. Storing
+	// the same (chain, number) twice overwrites the previous entry.
+	PutL2Block(block *types.L2Block) error
+	// GetL2Block returns the block for (chain, number), or ErrNotFound.
+	GetL2Block(chainID types.ChainID, number uint64) (*types.L2Block, error)
+	// GetL2BlockByHash returns the block with the given hash, or ErrNotFound.
+	GetL2BlockByHash(hash types.Hash) (*types.L2Block, error)
+	// L2BlocksBySlot returns all blocks recorded for a slot, ordered by
+	// chain ID then number.
+	L2BlocksBySlot(slot types.Slot) ([]*types.L2Block, error)
+	// L2BlockRange returns the chain's blocks with from <= number <= to,
+	// in ascending number order. Missing numbers are skipped.
+	L2BlockRange(chainID types.ChainID, from, to uint64) ([]*types.L2Block, error)
+	// HeadL2Block returns the highest-numbered block stored for the chain,
+	// or ErrNotFound if the chain has no blocks.
+	HeadL2Block(chainID types.ChainID) (*types.L2Block, error)
+}
+
+type memoryL2BlockStore struct {
+	mu      sync.RWMutex
+	byKey   map[[2]uint64]*types.L2Block
+	byHash  map[types.Hash][2]uint64
+	bySlot  map[types.Slot][][2]uint64
+	heads   map[types.ChainID]uint64
+	hasHead map[types.ChainID]bool
+}
+
+// NewMemoryL2BlockStore returns a non-persistent L2BlockStore for tests and
+// local development.
+func NewMemoryL2BlockStore() L2BlockStore {
+	return &memoryL2BlockStore{
+		byKey:   make(map[[2]uint64]*types.L2Block),
+		byHash:  make(map[types.Hash][2]uint64),
+		bySlot:  make(map[types.Slot][][2]uint64),
+		heads:   make(map[types.ChainID]uint64),
+		hasHead: make(map[types.ChainID]bool),
+	}
+}
+
+func (s *memoryL2BlockStore) PutL2Block(block *types.L2Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := [2]uint64{uint64(block.ChainID), block.Number}
+	if prev, ok := s.byKey[key]; ok {
+		delete(s.byHash, prev.Hash)
+	} else {
+		s.bySlot[block.Slot] = append(s.bySlot[block.Slot], key)
+	}
+	cp := *block
+	s.byKey[key] = &cp
+	s.byHash[block.Hash] = key
+	if !s.hasHead[block.ChainID] || block.Number > s.heads[block.ChainID] {
+		s.heads[block.ChainID] = block.Number
+		s.hasHead[block.ChainID] = true
+	}
+	return nil
+}
+
+func (s *memoryL2BlockStore) GetL2Block(chainID types.ChainID, number uint64) (*types.L2Block, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	block, ok := s.byKey[[2]uint64{uint64(chainID), number}]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *block
+	return &cp, nil
+}
+
+func (s *memoryL2BlockStore) GetL2BlockByHash(hash types.Hash) (*types.L2Block, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.byHash[hash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *s.byKey[key]
+	return &cp, nil
+}
+
+func (s *memoryL2BlockStore) L2BlocksBySlot(slot types.Slot) ([]*types.L2Block, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := s.bySlot[slot]
+	blocks := make([]*types.L2Block, 0, len(keys))
+	for _, key := range keys {
+		cp := *s.byKey[key]
+		blocks = append(blocks, &cp)
+	}
+	types.SortL2Blocks(blocks)
+	return blocks, nil
+}
+
+func (s *memoryL2BlockStore) L2BlockRange(chainID types.ChainID, from, to uint64) ([]*types.L2Block, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var blocks []*types.L2Block
+	for n := from; n <= to; n++ {
+		if block, ok := s.byKey[[2]uint64{uint64(chainID), n}]; ok {
+			cp := *block
+			blocks = append(blocks, &cp)
+		}
+	}
+	return blocks, nil
+}
+
+func (s *memoryL2BlockStore) HeadL2Block(chainID types.ChainID) (*types.L2Block, error) {
+	s.mu.RLock()
+	if !s.hasHead[chainID] {
+		s.mu.RUnlock()
+		return nil, ErrNotFound
+	}
+	head := s.heads[chainID]
+	s.mu.RUnlock()
+	return s.GetL2Block(chainID, head)
+}
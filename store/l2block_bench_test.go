@@ -0,0 +1,29 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// BenchmarkMemoryL2BlockIngest measures raw block ingest into the memory
+// store, including all secondary index updates.
+func BenchmarkMemoryL2BlockIngest(b *testing.B) {
+	blocks := NewMemoryL2BlockStore()
+	payload := make([]byte, 512)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		block := &types.L2Block{
+			ChainID: types.ChainID(i%4 + 1),
+			Number:  uint64(i),
+			Slot:    types.Slot(i / 8),
+			Payload: payload,
+		}
+		block.Hash[0], block.Hash[1], block.Hash[2], block.Hash[3] = byte(i), byte(i>>8), byte(i>>16), byte(i>>24)
+		if err := blocks.PutL2Block(block); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+const (
+	bucketL2Blocks       = "l2blocks"         // chain|number -> json(L2Block)
+	bucketL2BlocksByHash = "l2blocks_by_hash" // hash -> chain|number
+	bucketL2BlocksBySlot = "l2blocks_by_slot" // slot|chain|number -> nil
+	bucketL2Heads        = "l2block_heads"    // chain -> number
+)
+
+type boltL2BlockStore struct {
+	db *DB
+}
+
+// NewBoltL2BlockStore returns a disk-backed L2BlockStore on the shared DB.
+func NewBoltL2BlockStore(db *DB) (L2BlockStore, error) {
+	if err := db.ensureBuckets(bucketL2Blocks, bucketL2BlocksByHash, bucketL2BlocksBySlot, bucketL2Heads); err != nil {
+		return nil, err
+	}
+	return &boltL2BlockStore{db: db}, nil
+}
+
+func (s *boltL2BlockStore) PutL2Block(block *types.L2Block) error {
+	return s.db.bolt.Update(func(tx *bolt.Tx) error {
+		return putL2BlockTx(tx, block)
+	})
+}
+
+// putL2BlockTx writes a block and its indexes inside an existing transaction
+// so callers composing multi-store writes can reuse it.
+func putL2BlockTx(tx *bolt.Tx, block *types.L2Block) error {
+	key := compositeKey(uint64(block.ChainID), block.Number)
+	blocks := tx.Bucket([]byte(bucketL2Blocks))
+
+	if prev := blocks.Get(key); prev != nil {
+		var old types.L2Block
+		if err := json.Unmarshal(prev, &old); err == nil {
+			if err := tx.Bucket([]byte(bucketL2BlocksByHash)).Delete(old.Hash[:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	raw, err := json.Marshal(block)
+	if err != nil {
+		return fmt.Errorf("encode l2 block: %w", err)
+	}
+	if err := blocks.Put(key, raw); err != nil {
+		return err
+	}
+	if err := tx.Bucket([]byte(bucketL2BlocksByHash)).Put(block.Hash[:], key); err != nil {
+		return err
+	}
+	slotKey := compositeKey(uint64(block.Slot), uint64(block.ChainID), block.Number)
+	if err := tx.Bucket([]byte(bucketL2BlocksBySlot)).Put(slotKey, nil); err != nil {
+		return err
+	}
+
+	heads := tx.Bucket([]byte(bucketL2Heads))
+	chainKey := u64Key(uint64(block.ChainID))
+	if head := heads.Get(chainKey); head == nil || binary.BigEndian.Uint64(head) < block.Number {
+		if err := heads.Put(chainKey, u64Key(block.Number)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *boltL2BlockStore) GetL2Block(chainID types.ChainID, number uint64) (*types.L2Block, error) {
+	var block *types.L2Block
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		var err error
+		block, err = getL2BlockTx(tx, compositeKey(uint64(chainID), number))
+		return err
+	})
+	return block, err
+}
+
+func getL2BlockTx(tx *bolt.Tx, key []byte) (*types.L2Block, error) {
+	raw := tx.Bucket([]byte(bucketL2Blocks)).Get(key)
+	if raw == nil {
+		return nil, ErrNotFound
+	}
+	var block types.L2Block
+	if err := json.Unmarshal(raw, &block); err != nil {
+		return nil, fmt.Errorf("decode l2 block: %w", err)
+	}
+	return &block, nil
+}
+
+func (s *boltL2BlockStore) GetL2BlockByHash(hash types.Hash) (*types.L2Block, error) {
+	var block *types.L2Block
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		key := tx.Bucket([]byte(bucketL2BlocksByHash)).Get(hash[:])
+		if key == nil {
+			return ErrNotFound
+		}
+		var err error
+		block, err = getL2BlockTx(tx, key)
+		return err
+	})
+	return block, err
+}
+
+func (s *boltL2BlockStore) L2BlocksBySlot(slot types.Slot) ([]*types.L2Block, error) {
+	var blocks []*types.L2Block
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		prefix := u64Key(uint64(slot))
+		c := tx.Bucket([]byte(bucketL2BlocksBySlot)).Cursor()
+		for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Next() {
+			block, err := getL2BlockTx(tx, k[8:])
+			if err != nil {
+				return err
+			}
+			blocks = append(blocks, block)
+		}
+		return nil
+	})
+	return blocks, err
+}
+
+func (s *boltL2BlockStore) L2BlockRange(chainID types.ChainID, from, to uint64) ([]*types.L2Block, error) {
+	var blocks []*types.L2Block
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		prefix := u64Key(uint64(chainID))
+		c := tx.Bucket([]byte(bucketL2Blocks)).Cursor()
+		for k, v := c.Seek(compositeKey(uint64(chainID), from)); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if binary.BigEndian.Uint64(k[8:]) > to {
+				break
+			}
+			var block types.L2Block
+			if err := json.Unmarshal(v, &block); err != nil {
+				return fmt.Errorf("decode l2 block: %w", err)
+			}
+			blocks = append(blocks, &block)
+		}
+		return nil
+	})
+	return blocks, err
+}
+
+func (s *boltL2BlockStore) HeadL2Block(chainID types.ChainID) (*types.L2Block, error) {
+	var block *types.L2Block
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		head := tx.Bucket([]byte(bucketL2Heads)).Get(u64Key(uint64(chainID)))
+		if head == nil {
+			return ErrNotFound
+		}
+		var err error
+		block, err = getL2BlockTx(tx, compositeKey(uint64(chainID), binary.BigEndian.Uint64(head)))
+		return err
+	})
+	return block, err
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func testBlock(chain types.ChainID, number uint64, slot types.Slot) *types.L2Block {
+	var hash types.Hash
+	hash[0] = byte(chain)
+	hash[1] = byte(number)
+	return &types.L2Block{
+		ChainID:   chain,
+		Number:    number,
+		Hash:      hash,
+		Slot:      slot,
+		Timestamp: 1700000000 + number,
+		Payload:   []byte{0xde, 0xad},
+	}
+}
+
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open(filepath.Join(t.TempDir(), "publisher.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	return db
+}
+
+func TestL2BlockStore(t *testing.T) {
+	boltStore, err := NewBoltL2BlockStore(openTestDB(t))
+	require.NoError(t, err)
+
+	for name, s := range map[string]L2BlockStore{
+		"memory": NewMemoryL2BlockStore(),
+		"bolt":   boltStore,
+	} {
+		t.Run(name, func(t *testing.T) {
+			for n := uint64(1); n <= 5; n++ {
+				require.NoError(t, s.PutL2Block(testBlock(10, n, types.Slot(n/2))))
+			}
+			require.NoError(t, s.PutL2Block(testBlock(20, 3, 1)))
+
+			block, err := s.GetL2Block(10, 3)
+			require.NoError(t, err)
+			require.Equal(t, uint64(3), block.Number)
+
+			_, err = s.GetL2Block(10, 99)
+			require.ErrorIs(t, err, ErrNotFound)
+
+			byHash, err := s.GetL2BlockByHash(block.Hash)
+			require.NoError(t, err)
+			require.Equal(t, block.ChainID, byHash.ChainID)
+			require.Equal(t, block.Number, byHash.Number)
+
+			bySlot, err := s.L2BlocksBySlot(1)
+			require.NoError(t, err)
+			require.Len(t, bySlot, 3) // chain 10 blocks 2,3 and chain 20 block 3
+			require.Equal(t, types.ChainID(10), bySlot[0].ChainID)
+
+			ranged, err := s.L2BlockRange(10, 2, 4)
+			require.NoError(t, err)
+			require.Len(t, ranged, 3)
+			require.Equal(t, uint64(2), ranged[0].Number)
+			require.Equal(t, uint64(4), ranged[2].Number)
+
+			head, err := s.HeadL2Block(10)
+			require.NoError(t, err)
+			require.Equal(t, uint64(5), head.Number)
+
+			_, err = s.HeadL2Block(99)
+			require.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
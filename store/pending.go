@@ -0,0 +1,114 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+const bucketPendingSCP = "pending_scp" // xtid -> json(PendingSCP)
+
+// PendingSCP is an undecided SCP instance persisted across a restart: the
+// request that opened it, the slot it belongs to, and the votes already
+// cast, so the restarted coordinator can resume the round instead of
+// leaving its sequencers waiting.
+type PendingSCP struct {
+	Request *types.XTRequest       `json:"request"`
+	Slot    types.Slot             `json:"slot"`
+	Votes   map[types.ChainID]bool `json:"votes,omitempty"`
+	SavedAt uint64                 `json:"saved_at"` // unix seconds
+}
+
+// PendingSCPStore persists undecided SCP instances over a shutdown. The
+// set is expected to be empty except during the drain/recover window.
+type PendingSCPStore interface {
+	PutPendingSCP(rec *PendingSCP) error
+	// ListPendingSCPs returns every persisted instance, in no particular
+	// order.
+	ListPendingSCPs() ([]*PendingSCP, error)
+	DeletePendingSCP(xtID types.XtID) error
+}
+
+type boltPendingSCPStore struct {
+	db *DB
+}
+
+// NewBoltPendingSCPStore returns a disk-backed PendingSCPStore on the
+// shared DB.
+func NewBoltPendingSCPStore(db *DB) (PendingSCPStore, error) {
+	if err := db.ensureBuckets(bucketPendingSCP); err != nil {
+		return nil, err
+	}
+	return &boltPendingSCPStore{db: db}, nil
+}
+
+func (s *boltPendingSCPStore) PutPendingSCP(rec *PendingSCP) error {
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode pending scp: %w", err)
+	}
+	return s.db.bolt.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketPendingSCP)).Put(rec.Request.XtID[:], raw)
+	})
+}
+
+func (s *boltPendingSCPStore) ListPendingSCPs() ([]*PendingSCP, error) {
+	var recs []*PendingSCP
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketPendingSCP)).ForEach(func(_, v []byte) error {
+			var rec PendingSCP
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return fmt.Errorf("decode pending scp: %w", err)
+			}
+			recs = append(recs, &rec)
+			return nil
+		})
+	})
+	return recs, err
+}
+
+func (s *boltPendingSCPStore) DeletePendingSCP(xtID types.XtID) error {
+	return s.db.bolt.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketPendingSCP)).Delete(xtID[:])
+	})
+}
+
+type memoryPendingSCPStore struct {
+	mu   sync.RWMutex
+	recs map[types.XtID]*PendingSCP
+}
+
+// NewMemoryPendingSCPStore returns a non-persistent PendingSCPStore.
+func NewMemoryPendingSCPStore() PendingSCPStore {
+	return &memoryPendingSCPStore{recs: make(map[types.XtID]*PendingSCP)}
+}
+
+func (s *memoryPendingSCPStore) PutPendingSCP(rec *PendingSCP) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *rec
+	s.recs[rec.Request.XtID] = &cp
+	return nil
+}
+
+func (s *memoryPendingSCPStore) ListPendingSCPs() ([]*PendingSCP, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	recs := make([]*PendingSCP, 0, len(s.recs))
+	for _, rec := range s.recs {
+		cp := *rec
+		recs = append(recs, &cp)
+	}
+	return recs, nil
+}
+
+func (s *memoryPendingSCPStore) DeletePendingSCP(xtID types.XtID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.recs, xtID)
+	return nil
+}
@@ -0,0 +1,135 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// XTQueue is the pending cross-chain transaction queue feeding the
+// consensus coordinator, with a dead-letter side channel for abandoned
+// requests.
+type XTQueue interface {
+	// Enqueue appends a request; ErrDuplicate if its XtID is already queued.
+	Enqueue(req *types.XTRequest) error
+	// Remove deletes a request from the queue; missing entries are ignored.
+	Remove(xtID types.XtID) error
+	// List returns queued requests in FIFO order.
+	List() ([]*types.XTRequest, error)
+	// DeadLetter removes a request from the queue and archives it with a
+	// reason.
+	DeadLetter(req *types.XTRequest, reason string) error
+	// DeadLetters returns archived requests.
+	DeadLetters() ([]*DeadLetter, error)
+}
+
+type boltXTQueue struct {
+	db *DB
+}
+
+// NewBoltXTQueue returns a disk-backed XTQueue on the shared DB.
+func NewBoltXTQueue(db *DB) XTQueue {
+	return &boltXTQueue{db: db}
+}
+
+func (q *boltXTQueue) Enqueue(req *types.XTRequest) error {
+	return q.db.Update(func(txn *Txn) error { return txn.Enqueue(req) })
+}
+
+func (q *boltXTQueue) Remove(xtID types.XtID) error {
+	return q.db.Update(func(txn *Txn) error { return txn.RemoveQueued(xtID) })
+}
+
+func (q *boltXTQueue) List() ([]*types.XTRequest, error) {
+	var reqs []*types.XTRequest
+	err := q.db.View(func(txn *Txn) error {
+		var err error
+		reqs, err = txn.QueuedRequests()
+		return err
+	})
+	return reqs, err
+}
+
+func (q *boltXTQueue) DeadLetter(req *types.XTRequest, reason string) error {
+	return q.db.Update(func(txn *Txn) error { return txn.DeadLetter(req, reason) })
+}
+
+func (q *boltXTQueue) DeadLetters() ([]*DeadLetter, error) {
+	var letters []*DeadLetter
+	err := q.db.View(func(txn *Txn) error {
+		var err error
+		letters, err = txn.DeadLetters()
+		return err
+	})
+	return letters, err
+}
+
+type memoryXTQueue struct {
+	mu     sync.Mutex
+	reqs   []*types.XTRequest
+	queued map[types.XtID]struct{}
+	dead   []*DeadLetter
+}
+
+// NewMemoryXTQueue returns a non-persistent XTQueue.
+func NewMemoryXTQueue() XTQueue {
+	return &memoryXTQueue{queued: make(map[types.XtID]struct{})}
+}
+
+func (q *memoryXTQueue) Enqueue(req *types.XTRequest) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.queued[req.XtID]; ok {
+		return ErrDuplicate
+	}
+	cp := *req
+	q.reqs = append(q.reqs, &cp)
+	q.queued[req.XtID] = struct{}{}
+	return nil
+}
+
+func (q *memoryXTQueue) Remove(xtID types.XtID) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.removeLocked(xtID)
+	return nil
+}
+
+func (q *memoryXTQueue) removeLocked(xtID types.XtID) {
+	if _, ok := q.queued[xtID]; !ok {
+		return
+	}
+	delete(q.queued, xtID)
+	for i, req := range q.reqs {
+		if req.XtID == xtID {
+			q.reqs = append(q.reqs[:i], q.reqs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (q *memoryXTQueue) List() ([]*types.XTRequest, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	reqs := make([]*types.XTRequest, len(q.reqs))
+	for i, req := range q.reqs {
+		cp := *req
+		reqs[i] = &cp
+	}
+	return reqs, nil
+}
+
+func (q *memoryXTQueue) DeadLetter(req *types.XTRequest, reason string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.removeLocked(req.XtID)
+	cp := *req
+	q.dead = append(q.dead, &DeadLetter{Request: &cp, Reason: reason})
+	return nil
+}
+
+func (q *memoryXTQueue) DeadLetters() ([]*DeadLetter, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]*DeadLetter(nil), q.dead...), nil
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"errors"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const bucketGasSpend = "gas_spend" // day (YYYY-MM-DD) -> json(GasSpend)
+
+// GasSpend is one day's accumulated L1 publication gas.
+type GasSpend struct {
+	Day         string `json:"day"` // YYYY-MM-DD, UTC
+	Gas         uint64 `json:"gas"`
+	Superblocks uint64 `json:"superblocks"`
+}
+
+// GasSpendStore persists daily L1 gas accounting so fee budgets survive a
+// restart.
+type GasSpendStore interface {
+	// AddSpend accumulates gas for one published superblock into the day's
+	// record and returns the updated total.
+	AddSpend(day string, gas uint64) (*GasSpend, error)
+	// Spend returns the day's record, or ErrNotFound if nothing was spent.
+	Spend(day string) (*GasSpend, error)
+}
+
+type boltGasSpendStore struct {
+	db *DB
+}
+
+// NewBoltGasSpendStore returns a disk-backed GasSpendStore on the shared DB.
+func NewBoltGasSpendStore(db *DB) (GasSpendStore, error) {
+	if err := db.ensureBuckets(bucketGasSpend); err != nil {
+		return nil, err
+	}
+	return &boltGasSpendStore{db: db}, nil
+}
+
+func (s *boltGasSpendStore) AddSpend(day string, gas uint64) (*GasSpend, error) {
+	spend := &GasSpend{Day: day}
+	err := s.db.bolt.Update(func(tx *bolt.Tx) error {
+		if err := getJSON(tx, bucketGasSpend, []byte(day), spend); err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		spend.Day = day
+		spend.Gas += gas
+		spend.Superblocks++
+		return putJSON(tx, bucketGasSpend, []byte(day), spend)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return spend, nil
+}
+
+func (s *boltGasSpendStore) Spend(day string) (*GasSpend, error) {
+	spend := &GasSpend{}
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		return getJSON(tx, bucketGasSpend, []byte(day), spend)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return spend, nil
+}
+
+type memoryGasSpendStore struct {
+	mu    sync.Mutex
+	spend map[string]*GasSpend
+}
+
+// NewMemoryGasSpendStore returns a non-persistent GasSpendStore.
+func NewMemoryGasSpendStore() GasSpendStore {
+	return &memoryGasSpendStore{spend: make(map[string]*GasSpend)}
+}
+
+func (s *memoryGasSpendStore) AddSpend(day string, gas uint64) (*GasSpend, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.spend[day]
+	if !ok {
+		record = &GasSpend{Day: day}
+		s.spend[day] = record
+	}
+	record.Gas += gas
+	record.Superblocks++
+	out := *record
+	return &out, nil
+}
+
+func (s *memoryGasSpendStore) Spend(day string) (*GasSpend, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.spend[day]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	out := *record
+	return &out, nil
+}
@@ -0,0 +1,32 @@
+// Package store provides the publisher's persistence layer. Stores come in
+// two flavors: in-memory implementations for tests and local development,
+// and bbolt-backed implementations for production, sharing the same
+// interfaces.
+package store
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrNotFound is returned when a requested record does not exist.
+var ErrNotFound = errors.New("store: not found")
+
+// ErrDuplicate is returned when inserting a record whose key already exists.
+var ErrDuplicate = errors.New("store: duplicate")
+
+func u64Key(v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return b[:]
+}
+
+func compositeKey(parts ...uint64) []byte {
+	b := make([]byte, 0, 8*len(parts))
+	for _, p := range parts {
+		var k [8]byte
+		binary.BigEndian.PutUint64(k[:], p)
+		b = append(b, k[:]...)
+	}
+	return b
+}
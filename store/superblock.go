@@ -0,0 +1,158 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// SuperblockStore persists sealed superblocks and their publication status.
+type SuperblockStore interface {
+	PutSuperblock(sb *types.Superblock) error
+	GetSuperblock(number uint64) (*types.Superblock, error)
+	// LatestSuperblock returns the highest-numbered superblock, or
+	// ErrNotFound if none has been stored.
+	LatestSuperblock() (*types.Superblock, error)
+	// ListSuperblocks returns superblocks with from <= number <= to in
+	// ascending order, skipping gaps.
+	ListSuperblocks(from, to uint64) ([]*types.Superblock, error)
+	SetSuperblockStatus(number uint64, status types.SuperblockStatus, l1TxHash types.Hash) error
+}
+
+type boltSuperblockStore struct {
+	db *DB
+}
+
+// NewBoltSuperblockStore returns a disk-backed SuperblockStore on the
+// shared DB.
+func NewBoltSuperblockStore(db *DB) SuperblockStore {
+	return &boltSuperblockStore{db: db}
+}
+
+func (s *boltSuperblockStore) PutSuperblock(sb *types.Superblock) error {
+	return s.db.Update(func(txn *Txn) error { return txn.PutSuperblock(sb) })
+}
+
+func (s *boltSuperblockStore) GetSuperblock(number uint64) (*types.Superblock, error) {
+	var sb *types.Superblock
+	err := s.db.View(func(txn *Txn) error {
+		var err error
+		sb, err = txn.GetSuperblock(number)
+		return err
+	})
+	return sb, err
+}
+
+func (s *boltSuperblockStore) LatestSuperblock() (*types.Superblock, error) {
+	var sb *types.Superblock
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		k, v := tx.Bucket([]byte(bucketSuperblocks)).Cursor().Last()
+		if k == nil {
+			return ErrNotFound
+		}
+		sb = new(types.Superblock)
+		return json.Unmarshal(v, sb)
+	})
+	return sb, err
+}
+
+func (s *boltSuperblockStore) ListSuperblocks(from, to uint64) ([]*types.Superblock, error) {
+	var sbs []*types.Superblock
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucketSuperblocks)).Cursor()
+		for k, v := c.Seek(u64Key(from)); k != nil && binary.BigEndian.Uint64(k) <= to; k, v = c.Next() {
+			var sb types.Superblock
+			if err := json.Unmarshal(v, &sb); err != nil {
+				return fmt.Errorf("decode superblock: %w", err)
+			}
+			sbs = append(sbs, &sb)
+		}
+		return nil
+	})
+	return sbs, err
+}
+
+func (s *boltSuperblockStore) SetSuperblockStatus(number uint64, status types.SuperblockStatus, l1TxHash types.Hash) error {
+	return s.db.Update(func(txn *Txn) error {
+		return txn.SetSuperblockStatus(number, status, l1TxHash)
+	})
+}
+
+type memorySuperblockStore struct {
+	mu  sync.RWMutex
+	sbs map[uint64]*types.Superblock
+}
+
+// NewMemorySuperblockStore returns a non-persistent SuperblockStore.
+func NewMemorySuperblockStore() SuperblockStore {
+	return &memorySuperblockStore{sbs: make(map[uint64]*types.Superblock)}
+}
+
+func (s *memorySuperblockStore) PutSuperblock(sb *types.Superblock) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *sb
+	s.sbs[sb.Number] = &cp
+	return nil
+}
+
+func (s *memorySuperblockStore) GetSuperblock(number uint64) (*types.Superblock, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sb, ok := s.sbs[number]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *sb
+	return &cp, nil
+}
+
+func (s *memorySuperblockStore) LatestSuperblock() (*types.Superblock, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var latest *types.Superblock
+	for _, sb := range s.sbs {
+		if latest == nil || sb.Number > latest.Number {
+			latest = sb
+		}
+	}
+	if latest == nil {
+		return nil, ErrNotFound
+	}
+	cp := *latest
+	return &cp, nil
+}
+
+func (s *memorySuperblockStore) ListSuperblocks(from, to uint64) ([]*types.Superblock, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var sbs []*types.Superblock
+	for n, sb := range s.sbs {
+		if n >= from && n <= to {
+			cp := *sb
+			sbs = append(sbs, &cp)
+		}
+	}
+	sort.Slice(sbs, func(i, j int) bool { return sbs[i].Number < sbs[j].Number })
+	return sbs, nil
+}
+
+func (s *memorySuperblockStore) SetSuperblockStatus(number uint64, status types.SuperblockStatus, l1TxHash types.Hash) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sb, ok := s.sbs[number]
+	if !ok {
+		return ErrNotFound
+	}
+	sb.Status = status
+	if !l1TxHash.IsZero() {
+		sb.L1TxHash = l1TxHash
+	}
+	return nil
+}
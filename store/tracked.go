@@ -0,0 +1,99 @@
+package store
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// TrackedTxStore persists the L1 transactions being watched for inclusion
+// and finality, keyed by superblock number.
+type TrackedTxStore interface {
+	PutTrackedTx(tracked *types.TrackedTx) error
+	GetTrackedTx(superblockNumber uint64) (*types.TrackedTx, error)
+	// ListTrackedTxs returns all tracked transactions in superblock order.
+	ListTrackedTxs() ([]*types.TrackedTx, error)
+}
+
+type boltTrackedTxStore struct {
+	db *DB
+}
+
+// NewBoltTrackedTxStore returns a disk-backed TrackedTxStore on the shared DB.
+func NewBoltTrackedTxStore(db *DB) TrackedTxStore {
+	return &boltTrackedTxStore{db: db}
+}
+
+func (s *boltTrackedTxStore) PutTrackedTx(tracked *types.TrackedTx) error {
+	return s.db.Update(func(txn *Txn) error { return txn.PutTrackedTx(tracked) })
+}
+
+func (s *boltTrackedTxStore) GetTrackedTx(superblockNumber uint64) (*types.TrackedTx, error) {
+	var tracked *types.TrackedTx
+	err := s.db.View(func(txn *Txn) error {
+		var err error
+		tracked, err = txn.GetTrackedTx(superblockNumber)
+		return err
+	})
+	return tracked, err
+}
+
+func (s *boltTrackedTxStore) ListTrackedTxs() ([]*types.TrackedTx, error) {
+	var txs []*types.TrackedTx
+	err := s.db.bolt.View(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucketTrackedTxs)).ForEach(func(_, v []byte) error {
+			var tracked types.TrackedTx
+			if err := json.Unmarshal(v, &tracked); err != nil {
+				return err
+			}
+			txs = append(txs, &tracked)
+			return nil
+		})
+	})
+	return txs, err
+}
+
+type memoryTrackedTxStore struct {
+	mu  sync.RWMutex
+	txs map[uint64]*types.TrackedTx
+}
+
+// NewMemoryTrackedTxStore returns a non-persistent TrackedTxStore.
+func NewMemoryTrackedTxStore() TrackedTxStore {
+	return &memoryTrackedTxStore{txs: make(map[uint64]*types.TrackedTx)}
+}
+
+func (s *memoryTrackedTxStore) PutTrackedTx(tracked *types.TrackedTx) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp := *tracked
+	s.txs[tracked.SuperblockNumber] = &cp
+	return nil
+}
+
+func (s *memoryTrackedTxStore) GetTrackedTx(superblockNumber uint64) (*types.TrackedTx, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tracked, ok := s.txs[superblockNumber]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cp := *tracked
+	return &cp, nil
+}
+
+func (s *memoryTrackedTxStore) ListTrackedTxs() ([]*types.TrackedTx, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	txs := make([]*types.TrackedTx, 0, len(s.txs))
+	for _, tracked := range s.txs {
+		cp := *tracked
+		txs = append(txs, &cp)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].SuperblockNumber < txs[j].SuperblockNumber })
+	return txs, nil
+}
@@ -0,0 +1,180 @@
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+const (
+	bucketSuperblocks = "superblocks" // number -> json(Superblock)
+	bucketTrackedTxs  = "tracked_txs" // superblock number -> json(TrackedTx)
+	bucketQueue       = "xt_queue"    // seq -> json(XTRequest)
+	bucketQueueIndex  = "xt_queue_ix" // xtid -> seq
+	bucketDeadLetters = "xt_dead"     // xtid -> json(DeadLetter)
+)
+
+var txnBuckets = []string{
+	bucketSuperblocks, bucketTrackedTxs, bucketQueue, bucketQueueIndex, bucketDeadLetters,
+}
+
+// Txn exposes typed operations over a single atomic bbolt transaction.
+// Coordinator paths that must keep several records consistent (superblock
+// write, tracked L1 tx, queue mutation) compose them inside one
+// DB.Update call so a crash can never observe a partial write.
+type Txn struct {
+	tx *bolt.Tx
+}
+
+// Update runs fn inside one read-write transaction; all writes commit
+// atomically or not at all.
+func (db *DB) Update(fn func(txn *Txn) error) error {
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		return fn(&Txn{tx: tx})
+	})
+}
+
+// View runs fn inside a read-only transaction.
+func (db *DB) View(fn func(txn *Txn) error) error {
+	return db.bolt.View(func(tx *bolt.Tx) error {
+		return fn(&Txn{tx: tx})
+	})
+}
+
+func putJSON(tx *bolt.Tx, bucket string, key []byte, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s record: %w", bucket, err)
+	}
+	return tx.Bucket([]byte(bucket)).Put(key, raw)
+}
+
+func getJSON(tx *bolt.Tx, bucket string, key []byte, v any) error {
+	raw := tx.Bucket([]byte(bucket)).Get(key)
+	if raw == nil {
+		return ErrNotFound
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("decode %s record: %w", bucket, err)
+	}
+	return nil
+}
+
+// PutSuperblock stores (or overwrites) a superblock.
+func (t *Txn) PutSuperblock(sb *types.Superblock) error {
+	return putJSON(t.tx, bucketSuperblocks, u64Key(sb.Number), sb)
+}
+
+// GetSuperblock loads a superblock by number.
+func (t *Txn) GetSuperblock(number uint64) (*types.Superblock, error) {
+	var sb types.Superblock
+	if err := getJSON(t.tx, bucketSuperblocks, u64Key(number), &sb); err != nil {
+		return nil, err
+	}
+	return &sb, nil
+}
+
+// SetSuperblockStatus updates a stored superblock's status and, when
+// non-zero, its L1 transaction hash.
+func (t *Txn) SetSuperblockStatus(number uint64, status types.SuperblockStatus, l1TxHash types.Hash) error {
+	sb, err := t.GetSuperblock(number)
+	if err != nil {
+		return err
+	}
+	sb.Status = status
+	if !l1TxHash.IsZero() {
+		sb.L1TxHash = l1TxHash
+	}
+	return t.PutSuperblock(sb)
+}
+
+// PutTrackedTx records the L1 transaction being watched for a superblock.
+func (t *Txn) PutTrackedTx(tracked *types.TrackedTx) error {
+	return putJSON(t.tx, bucketTrackedTxs, u64Key(tracked.SuperblockNumber), tracked)
+}
+
+// GetTrackedTx loads the tracked L1 transaction for a superblock.
+func (t *Txn) GetTrackedTx(superblockNumber uint64) (*types.TrackedTx, error) {
+	var tracked types.TrackedTx
+	if err := getJSON(t.tx, bucketTrackedTxs, u64Key(superblockNumber), &tracked); err != nil {
+		return nil, err
+	}
+	return &tracked, nil
+}
+
+// Enqueue appends an XT request to the queue. Returns ErrDuplicate if the
+// XtID is already queued.
+func (t *Txn) Enqueue(req *types.XTRequest) error {
+	index := t.tx.Bucket([]byte(bucketQueueIndex))
+	if index.Get(req.XtID[:]) != nil {
+		return ErrDuplicate
+	}
+	seq, err := t.tx.Bucket([]byte(bucketQueue)).NextSequence()
+	if err != nil {
+		return err
+	}
+	if err := putJSON(t.tx, bucketQueue, u64Key(seq), req); err != nil {
+		return err
+	}
+	return index.Put(req.XtID[:], u64Key(seq))
+}
+
+// RemoveQueued deletes an XT request from the queue, typically once a 2PC
+// round has started for it. Missing entries are not an error.
+func (t *Txn) RemoveQueued(xtID types.XtID) error {
+	index := t.tx.Bucket([]byte(bucketQueueIndex))
+	seq := index.Get(xtID[:])
+	if seq == nil {
+		return nil
+	}
+	if err := t.tx.Bucket([]byte(bucketQueue)).Delete(seq); err != nil {
+		return err
+	}
+	return index.Delete(xtID[:])
+}
+
+// QueuedRequests returns the queue contents in FIFO order.
+func (t *Txn) QueuedRequests() ([]*types.XTRequest, error) {
+	var reqs []*types.XTRequest
+	err := t.tx.Bucket([]byte(bucketQueue)).ForEach(func(_, v []byte) error {
+		var req types.XTRequest
+		if err := json.Unmarshal(v, &req); err != nil {
+			return fmt.Errorf("decode queued request: %w", err)
+		}
+		reqs = append(reqs, &req)
+		return nil
+	})
+	return reqs, err
+}
+
+// DeadLetter moves a queued XT request into the dead-letter bucket with a
+// reason, removing it from the live queue.
+func (t *Txn) DeadLetter(req *types.XTRequest, reason string) error {
+	if err := t.RemoveQueued(req.XtID); err != nil {
+		return err
+	}
+	return putJSON(t.tx, bucketDeadLetters, req.XtID[:], &DeadLetter{Request: req, Reason: reason})
+}
+
+// DeadLetters returns all dead-lettered requests.
+func (t *Txn) DeadLetters() ([]*DeadLetter, error) {
+	var letters []*DeadLetter
+	err := t.tx.Bucket([]byte(bucketDeadLetters)).ForEach(func(_, v []byte) error {
+		var dl DeadLetter
+		if err := json.Unmarshal(v, &dl); err != nil {
+			return fmt.Errorf("decode dead letter: %w", err)
+		}
+		letters = append(letters, &dl)
+		return nil
+	})
+	return letters, err
+}
+
+// DeadLetter is a queue entry that was abandoned, kept for inspection.
+type DeadLetter struct {
+	Request *types.XTRequest `json:"request"`
+	Reason  string           `json:"reason"`
+}
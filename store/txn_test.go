@@ -0,0 +1,74 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestAtomicMultiWrite(t *testing.T) {
+	db := openTestDB(t)
+
+	req := &types.XTRequest{XtID: types.Hash{1}, SourceChain: 10, Chains: []types.ChainID{10, 20}}
+	require.NoError(t, NewBoltXTQueue(db).Enqueue(req))
+
+	sb := &types.Superblock{Number: 7, Slot: 3, Status: types.SuperblockStatusSealed}
+	tracked := &types.TrackedTx{SuperblockNumber: 7, TxHash: types.Hash{2}, State: types.TxStateSubmitted}
+
+	// Superblock, tracked tx and queue mutation commit atomically.
+	require.NoError(t, db.Update(func(txn *Txn) error {
+		if err := txn.PutSuperblock(sb); err != nil {
+			return err
+		}
+		if err := txn.PutTrackedTx(tracked); err != nil {
+			return err
+		}
+		return txn.RemoveQueued(req.XtID)
+	}))
+
+	got, err := NewBoltSuperblockStore(db).GetSuperblock(7)
+	require.NoError(t, err)
+	require.Equal(t, types.SuperblockStatusSealed, got.Status)
+
+	queued, err := NewBoltXTQueue(db).List()
+	require.NoError(t, err)
+	require.Empty(t, queued)
+
+	// A failing transaction leaves no partial writes behind.
+	boom := errors.New("boom")
+	err = db.Update(func(txn *Txn) error {
+		if err := txn.SetSuperblockStatus(7, types.SuperblockStatusSubmitted, types.Hash{3}); err != nil {
+			return err
+		}
+		return boom
+	})
+	require.ErrorIs(t, err, boom)
+
+	got, err = NewBoltSuperblockStore(db).GetSuperblock(7)
+	require.NoError(t, err)
+	require.Equal(t, types.SuperblockStatusSealed, got.Status)
+	require.True(t, got.L1TxHash.IsZero())
+}
+
+func TestQueueDeadLetters(t *testing.T) {
+	db := openTestDB(t)
+	q := NewBoltXTQueue(db)
+
+	req := &types.XTRequest{XtID: types.Hash{9}, SourceChain: 10}
+	require.NoError(t, q.Enqueue(req))
+	require.ErrorIs(t, q.Enqueue(req), ErrDuplicate)
+
+	require.NoError(t, q.DeadLetter(req, "unknown chain"))
+
+	queued, err := q.List()
+	require.NoError(t, err)
+	require.Empty(t, queued)
+
+	dead, err := q.DeadLetters()
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.Equal(t, "unknown chain", dead[0].Reason)
+}
@@ -0,0 +1,140 @@
+package wal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// VoteRecord is the WAL's encoding of one SCP vote, kept free of transport
+// types so the log stays replayable without the wire protocol.
+type VoteRecord struct {
+	XtID    types.XtID    `json:"xt_id"`
+	ChainID types.ChainID `json:"chain_id"`
+	Commit  bool          `json:"commit"`
+}
+
+// SlotView is the coordinator's reconstructed view of one past slot: what
+// it had received and decided by the time the slot sealed. It is rebuilt
+// from the WAL alone, which makes it the ground truth when a built
+// superblock is disputed.
+type SlotView struct {
+	Slot      types.Slot        `json:"slot"`
+	Blocks    []types.L2Block   `json:"blocks"`
+	Requests  []types.XTRequest `json:"requests"`
+	Votes     []VoteRecord      `json:"votes"`
+	Decisions []types.Decision  `json:"decisions"`
+	// Superblock is the sealed superblock the WAL recorded for the slot,
+	// nil when the slot never sealed (or the record fell to truncation).
+	Superblock *types.Superblock `json:"superblock,omitempty"`
+}
+
+// ReconstructSlot replays the log at path and rebuilds the coordinator's
+// view of one slot. A corrupt tail returns the view assembled so far
+// together with ErrCorrupt, since a partial view is still useful when
+// investigating a disputed slot.
+func ReconstructSlot(path string, slot types.Slot) (*SlotView, error) {
+	view := &SlotView{Slot: slot}
+	err := Replay(path, func(e Entry) error {
+		if e.Slot != slot {
+			return nil
+		}
+		switch e.Type {
+		case TypeL2Block:
+			var block types.L2Block
+			if err := json.Unmarshal(e.Payload, &block); err != nil {
+				return fmt.Errorf("wal: bad %s payload at slot %d: %w", e.Type, e.Slot, err)
+			}
+			view.Blocks = append(view.Blocks, block)
+		case TypeXTRequest:
+			var req types.XTRequest
+			if err := json.Unmarshal(e.Payload, &req); err != nil {
+				return fmt.Errorf("wal: bad %s payload at slot %d: %w", e.Type, e.Slot, err)
+			}
+			view.Requests = append(view.Requests, req)
+		case TypeVote:
+			var vote VoteRecord
+			if err := json.Unmarshal(e.Payload, &vote); err != nil {
+				return fmt.Errorf("wal: bad %s payload at slot %d: %w", e.Type, e.Slot, err)
+			}
+			view.Votes = append(view.Votes, vote)
+		case TypeDecision:
+			var decision types.Decision
+			if err := json.Unmarshal(e.Payload, &decision); err != nil {
+				return fmt.Errorf("wal: bad %s payload at slot %d: %w", e.Type, e.Slot, err)
+			}
+			view.Decisions = append(view.Decisions, decision)
+		case TypeSuperblock:
+			var sb types.Superblock
+			if err := json.Unmarshal(e.Payload, &sb); err != nil {
+				return fmt.Errorf("wal: bad %s payload at slot %d: %w", e.Type, e.Slot, err)
+			}
+			view.Superblock = &sb
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, ErrCorrupt) {
+		return nil, err
+	}
+	return view, err
+}
+
+// DiffSuperblock compares the reconstructed view against a built superblock
+// and describes every discrepancy, one finding per line. An empty result
+// means the superblock is consistent with what the WAL says the coordinator
+// saw.
+func (v *SlotView) DiffSuperblock(sb *types.Superblock) []string {
+	var findings []string
+
+	received := make(map[types.Hash]types.L2Block, len(v.Blocks))
+	for _, block := range v.Blocks {
+		received[block.Hash] = block
+	}
+	included := make(map[types.Hash]struct{}, len(sb.L2Blocks))
+	for _, block := range sb.L2Blocks {
+		included[block.Hash] = struct{}{}
+		if _, ok := received[block.Hash]; !ok {
+			findings = append(findings, fmt.Sprintf(
+				"block %s (chain %d, number %d) included but never received", block.Hash, block.ChainID, block.Number))
+		}
+	}
+	for _, block := range v.Blocks {
+		if _, ok := included[block.Hash]; !ok {
+			findings = append(findings, fmt.Sprintf(
+				"block %s (chain %d, number %d) received but not included", block.Hash, block.ChainID, block.Number))
+		}
+	}
+
+	decided := make(map[types.XtID]types.Decision, len(v.Decisions))
+	for _, decision := range v.Decisions {
+		decided[decision.XtID] = decision
+	}
+	published := make(map[types.XtID]struct{}, len(sb.Decisions))
+	for _, decision := range sb.Decisions {
+		published[decision.XtID] = struct{}{}
+		logged, ok := decided[decision.XtID]
+		switch {
+		case !ok:
+			findings = append(findings, fmt.Sprintf(
+				"decision for xt %s published but never logged", decision.XtID))
+		case logged.Committed != decision.Committed:
+			findings = append(findings, fmt.Sprintf(
+				"decision for xt %s published as committed=%t but logged as committed=%t",
+				decision.XtID, decision.Committed, logged.Committed))
+		}
+	}
+	for _, decision := range v.Decisions {
+		if _, ok := published[decision.XtID]; !ok {
+			findings = append(findings, fmt.Sprintf(
+				"decision for xt %s logged but missing from the superblock", decision.XtID))
+		}
+	}
+
+	if sb.Slot != v.Slot {
+		findings = append(findings, fmt.Sprintf(
+			"superblock carries slot %d, expected %d", sb.Slot, v.Slot))
+	}
+	return findings
+}
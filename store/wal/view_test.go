@@ -0,0 +1,88 @@
+package wal
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func appendJSON(t *testing.T, w *WAL, entryType string, slot types.Slot, payload any) {
+	t.Helper()
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.NoError(t, w.Append(Entry{Type: entryType, Slot: slot, Payload: raw}))
+}
+
+func TestReconstructSlot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "publisher.wal")
+	w, err := Open(path)
+	require.NoError(t, err)
+
+	blockA := types.L2Block{ChainID: 10, Number: 5, Hash: types.Hash{0xa}, Slot: 7}
+	blockB := types.L2Block{ChainID: 11, Number: 9, Hash: types.Hash{0xb}, Slot: 7}
+	appendJSON(t, w, TypeL2Block, 7, blockA)
+	appendJSON(t, w, TypeL2Block, 7, blockB)
+	appendJSON(t, w, TypeXTRequest, 7, types.XTRequest{XtID: types.Hash{1}, Chains: []types.ChainID{10, 11}})
+	appendJSON(t, w, TypeVote, 7, VoteRecord{XtID: types.Hash{1}, ChainID: 10, Commit: true})
+	appendJSON(t, w, TypeVote, 7, VoteRecord{XtID: types.Hash{1}, ChainID: 11, Commit: true})
+	appendJSON(t, w, TypeDecision, 7, types.Decision{XtID: types.Hash{1}, Committed: true, Slot: 7})
+	// Noise from a neighbouring slot must not leak into the view.
+	appendJSON(t, w, TypeL2Block, 8, types.L2Block{ChainID: 10, Number: 6, Hash: types.Hash{0xc}, Slot: 8})
+	require.NoError(t, w.Close())
+
+	view, err := ReconstructSlot(path, 7)
+	require.NoError(t, err)
+	require.Len(t, view.Blocks, 2)
+	require.Len(t, view.Requests, 1)
+	require.Len(t, view.Votes, 2)
+	require.Len(t, view.Decisions, 1)
+	require.Nil(t, view.Superblock)
+}
+
+func TestDiffSuperblock(t *testing.T) {
+	view := &SlotView{
+		Slot: 7,
+		Blocks: []types.L2Block{
+			{ChainID: 10, Number: 5, Hash: types.Hash{0xa}, Slot: 7},
+			{ChainID: 11, Number: 9, Hash: types.Hash{0xb}, Slot: 7},
+		},
+		Decisions: []types.Decision{
+			{XtID: types.Hash{1}, Committed: true, Slot: 7},
+			{XtID: types.Hash{2}, Committed: false, Slot: 7},
+		},
+	}
+
+	consistent := &types.Superblock{
+		Slot: 7,
+		L2Blocks: []*types.L2Block{
+			{ChainID: 10, Number: 5, Hash: types.Hash{0xa}, Slot: 7},
+			{ChainID: 11, Number: 9, Hash: types.Hash{0xb}, Slot: 7},
+		},
+		Decisions: []types.Decision{
+			{XtID: types.Hash{1}, Committed: true, Slot: 7},
+			{XtID: types.Hash{2}, Committed: false, Slot: 7},
+		},
+	}
+	require.Empty(t, view.DiffSuperblock(consistent))
+
+	disputed := &types.Superblock{
+		Slot: 7,
+		L2Blocks: []*types.L2Block{
+			{ChainID: 10, Number: 5, Hash: types.Hash{0xa}, Slot: 7},
+			{ChainID: 12, Number: 1, Hash: types.Hash{0xd}, Slot: 7}, // never received
+		},
+		Decisions: []types.Decision{
+			{XtID: types.Hash{1}, Committed: false, Slot: 7}, // flipped outcome
+		},
+	}
+	findings := view.DiffSuperblock(disputed)
+	require.Len(t, findings, 4)
+	require.Contains(t, findings[0], "included but never received")
+	require.Contains(t, findings[1], "received but not included")
+	require.Contains(t, findings[2], "published as committed=false but logged as committed=true")
+	require.Contains(t, findings[3], "logged but missing from the superblock")
+}
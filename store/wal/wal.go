@@ -0,0 +1,176 @@
+// Package wal implements the publisher's write-ahead log. Every state
+// transition that must survive a crash (received L2 blocks, XT requests,
+// votes, decisions, sealed superblocks) is appended here before being acted
+// on, and replayed during recovery.
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// Entry types recorded in the log.
+const (
+	TypeL2Block    = "l2_block"
+	TypeXTRequest  = "xt_request"
+	TypeVote       = "vote"
+	TypeDecision   = "decision"
+	TypeSuperblock = "superblock"
+)
+
+// ErrCorrupt is returned when a record fails its checksum; replay stops at
+// the last good record.
+var ErrCorrupt = errors.New("wal: corrupt record")
+
+// Entry is a single write-ahead log record.
+type Entry struct {
+	Type      string     `json:"type"`
+	Slot      types.Slot `json:"slot"`
+	Timestamp int64      `json:"timestamp"` // unix nanoseconds
+	Payload   []byte     `json:"payload,omitempty"`
+}
+
+// WAL is an append-only, checksummed log file.
+//
+// On-disk format per record: 4-byte big-endian length, 4-byte CRC32C of the
+// body, JSON-encoded Entry body.
+type WAL struct {
+	mu   sync.Mutex
+	f    *os.File
+	w    *bufio.Writer
+	path string
+}
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Open opens (creating if necessary) the log at path for appending.
+func Open(path string) (*WAL, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open wal %q: %w", path, err)
+	}
+	return &WAL{f: f, w: bufio.NewWriter(f), path: path}, nil
+}
+
+// Append writes an entry and syncs it to disk. The entry's timestamp is
+// filled in when zero.
+func (w *WAL) Append(e Entry) error {
+	if e.Timestamp == 0 {
+		e.Timestamp = time.Now().UnixNano()
+	}
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode wal entry: %w", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[0:4], uint32(len(body)))
+	binary.BigEndian.PutUint32(header[4:8], crc32.Checksum(body, crcTable))
+	if _, err := w.w.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(body); err != nil {
+		return err
+	}
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+	return w.f.Sync()
+}
+
+// Close flushes and closes the log file.
+func (w *WAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+	return w.f.Close()
+}
+
+// Replay reads the log at path from the beginning, invoking fn per entry.
+// A truncated or corrupt tail terminates replay with ErrCorrupt; entries
+// before it have already been delivered.
+func Replay(path string, fn func(Entry) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open wal %q: %w", path, err)
+	}
+	defer f.Close()
+	return replay(f, fn)
+}
+
+func replay(r io.Reader, fn func(Entry) error) error {
+	br := bufio.NewReader(r)
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return ErrCorrupt
+		}
+		length := binary.BigEndian.Uint32(header[0:4])
+		body := make([]byte, length)
+		if _, err := io.ReadFull(br, body); err != nil {
+			return ErrCorrupt
+		}
+		if crc32.Checksum(body, crcTable) != binary.BigEndian.Uint32(header[4:8]) {
+			return ErrCorrupt
+		}
+		var e Entry
+		if err := json.Unmarshal(body, &e); err != nil {
+			return ErrCorrupt
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+}
+
+// TruncateBeforeSlot rewrites the log at path keeping only entries with
+// slot >= beforeSlot, reclaiming disk after slots are safely finalized.
+// It returns the number of entries dropped.
+func TruncateBeforeSlot(path string, beforeSlot types.Slot) (int, error) {
+	tmpPath := path + ".tmp"
+	tmp, err := Open(tmpPath)
+	if err != nil {
+		return 0, err
+	}
+
+	dropped := 0
+	replayErr := Replay(path, func(e Entry) error {
+		if e.Slot < beforeSlot {
+			dropped++
+			return nil
+		}
+		return tmp.Append(e)
+	})
+	if replayErr != nil && !errors.Is(replayErr, ErrCorrupt) {
+		_ = tmp.Close()
+		_ = os.Remove(tmpPath)
+		return 0, replayErr
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return 0, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return 0, err
+	}
+	return dropped, nil
+}
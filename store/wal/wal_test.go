@@ -0,0 +1,75 @@
+package wal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestAppendReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "publisher.wal")
+	w, err := Open(path)
+	require.NoError(t, err)
+
+	for slot := types.Slot(1); slot <= 3; slot++ {
+		require.NoError(t, w.Append(Entry{Type: TypeL2Block, Slot: slot, Payload: []byte{byte(slot)}}))
+	}
+	require.NoError(t, w.Close())
+
+	var entries []Entry
+	require.NoError(t, Replay(path, func(e Entry) error {
+		entries = append(entries, e)
+		return nil
+	}))
+	require.Len(t, entries, 3)
+	require.Equal(t, types.Slot(2), entries[1].Slot)
+	require.NotZero(t, entries[0].Timestamp)
+}
+
+func TestReplayCorruptTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "publisher.wal")
+	w, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, w.Append(Entry{Type: TypeVote, Slot: 1}))
+	require.NoError(t, w.Close())
+
+	// Append garbage simulating a torn write.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 9, 1, 2})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	var n int
+	err = Replay(path, func(Entry) error {
+		n++
+		return nil
+	})
+	require.ErrorIs(t, err, ErrCorrupt)
+	require.Equal(t, 1, n)
+}
+
+func TestTruncateBeforeSlot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "publisher.wal")
+	w, err := Open(path)
+	require.NoError(t, err)
+	for slot := types.Slot(1); slot <= 5; slot++ {
+		require.NoError(t, w.Append(Entry{Type: TypeDecision, Slot: slot}))
+	}
+	require.NoError(t, w.Close())
+
+	dropped, err := TruncateBeforeSlot(path, 4)
+	require.NoError(t, err)
+	require.Equal(t, 3, dropped)
+
+	var kept []types.Slot
+	require.NoError(t, Replay(path, func(e Entry) error {
+		kept = append(kept, e.Slot)
+		return nil
+	}))
+	require.Equal(t, []types.Slot{4, 5}, kept)
+}
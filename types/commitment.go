@@ -0,0 +1,43 @@
+package types
+
+import (
+	"encoding/binary"
+	"sort"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// DecisionsCommitment computes the commitment over a slot's decisions that
+// is embedded in the superblock as DecisionsRoot. Decisions are hashed in
+// canonical XtID order so the commitment is independent of arrival order.
+func DecisionsCommitment(decisions []Decision) Hash {
+	if len(decisions) == 0 {
+		return ZeroHash
+	}
+	sorted := append([]Decision(nil), decisions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i].XtID, sorted[j].XtID
+		for k := range a {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return false
+	})
+
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range sorted {
+		h.Write(d.XtID[:])
+		if d.Committed {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+		var slot [8]byte
+		binary.BigEndian.PutUint64(slot[:], uint64(d.Slot))
+		h.Write(slot[:])
+	}
+	var root Hash
+	h.Sum(root[:0])
+	return root
+}
@@ -0,0 +1,131 @@
+package types
+
+// XtID identifies a cross-chain transaction (XT) across its lifecycle.
+type XtID = Hash
+
+// SuperblockStatus tracks a superblock through sealing and L1 publication.
+type SuperblockStatus string
+
+const (
+	SuperblockStatusBuilding  SuperblockStatus = "building"
+	SuperblockStatusSealed    SuperblockStatus = "sealed"
+	SuperblockStatusSubmitted SuperblockStatus = "submitted"
+	SuperblockStatusConfirmed SuperblockStatus = "confirmed"
+	SuperblockStatusFinalized SuperblockStatus = "finalized"
+	SuperblockStatusFailed    SuperblockStatus = "failed"
+	// SuperblockStatusRolledBack marks a superblock whose publication
+	// transaction was dropped or reverted; derivation pipelines must
+	// unwind any heads built on it.
+	SuperblockStatusRolledBack SuperblockStatus = "rolled_back"
+)
+
+// Superblock bundles one slot's L2 blocks and XT decisions under a single
+// merkle commitment published to L1.
+type Superblock struct {
+	Number     uint64 `json:"number"`
+	Slot       Slot   `json:"slot"`
+	Hash       Hash   `json:"hash"`
+	ParentHash Hash   `json:"parent_hash"`
+	MerkleRoot Hash   `json:"merkle_root"`
+	// MerkleScheme names the construction behind MerkleRoot so verifiers
+	// replay proofs with the right hashing rules.
+	MerkleScheme string     `json:"merkle_scheme,omitempty"`
+	Timestamp    uint64     `json:"timestamp"`
+	L2Blocks     []*L2Block `json:"l2_blocks"`
+	Decisions    []Decision `json:"decisions"`
+	// DecisionsRoot commits to the slot's entries in the decisions ledger,
+	// anchoring XT outcomes on L1 alongside the block data.
+	DecisionsRoot Hash             `json:"decisions_root,omitempty"`
+	Status        SuperblockStatus `json:"status"`
+	L1TxHash      Hash             `json:"l1_tx_hash,omitempty"`
+	// DA records where the superblock's L2 block data was made available
+	// and the commitment binding it.
+	DA *DACommitment `json:"da,omitempty"`
+}
+
+// DACommitment binds a superblock's L2 block data to a data-availability
+// provider.
+type DACommitment struct {
+	// Provider names the DA scheme: calldata, blobs or http.
+	Provider string `json:"provider"`
+	// Commitment is the content commitment over the encoded block data.
+	Commitment Hash `json:"commitment"`
+	// Pointer locates the data at the provider (blob ID, DA height), empty
+	// when the data travels with the L1 transaction itself.
+	Pointer string `json:"pointer,omitempty"`
+	// Size is the encoded data size in bytes.
+	Size int `json:"size"`
+}
+
+// Decision is the recorded 2PC outcome for an XT.
+type Decision struct {
+	XtID      XtID `json:"xt_id"`
+	Committed bool `json:"committed"`
+	Slot      Slot `json:"slot"`
+	// AbortReason says why an aborted XT aborted, so sequencers can tell
+	// "rejected" from "ran out of time". Empty on committed decisions.
+	AbortReason AbortReason `json:"abort_reason,omitempty"`
+}
+
+// AbortReason classifies why an XT was aborted.
+type AbortReason string
+
+const (
+	// AbortReasonVote: a participant explicitly voted abort.
+	AbortReasonVote AbortReason = "vote"
+	// AbortReasonTimeout: the vote deadline passed with votes missing.
+	AbortReasonTimeout AbortReason = "timeout"
+	// AbortReasonSealCutover: the slot sealed with the round undecided.
+	AbortReasonSealCutover AbortReason = "seal_cutover"
+	// AbortReasonRestart: the publisher shut down with the round undecided.
+	AbortReasonRestart AbortReason = "restart"
+)
+
+// XTRequest is a cross-chain transaction submitted by a sequencer, queued
+// until the consensus coordinator starts a 2PC round for it.
+type XTRequest struct {
+	XtID        XtID      `json:"xt_id"`
+	SourceChain ChainID   `json:"source_chain"`
+	Chains      []ChainID `json:"chains"`
+	Payload     []byte    `json:"payload"`
+	ReceivedAt  uint64    `json:"received_at"`
+}
+
+// CIRCMessage is a cross-chain inter-rollup communication message emitted
+// while an XT executes, relayed by the publisher to the destination chain.
+type CIRCMessage struct {
+	XtID        XtID    `json:"xt_id"`
+	SourceChain ChainID `json:"source_chain"`
+	DestChain   ChainID `json:"dest_chain"`
+	// Seq is assigned by the publisher's relay and orders messages per
+	// destination chain.
+	Seq     uint64 `json:"seq,omitempty"`
+	Payload []byte `json:"payload"`
+}
+
+// TransactionState tracks an L1 transaction from submission to finality.
+type TransactionState string
+
+const (
+	TxStateSubmitted TransactionState = "submitted"
+	TxStateIncluded  TransactionState = "included"
+	TxStateConfirmed TransactionState = "confirmed"
+	TxStateFinalized TransactionState = "finalized"
+	TxStateFailed    TransactionState = "failed"
+)
+
+// TrackedTx is an L1 superblock-publication transaction being watched by
+// the receipt poller.
+type TrackedTx struct {
+	SuperblockNumber uint64           `json:"superblock_number"`
+	TxHash           Hash             `json:"tx_hash"`
+	State            TransactionState `json:"state"`
+	GasUsed          uint64           `json:"gas_used,omitempty"`
+	BlockNumber      uint64           `json:"block_number,omitempty"`
+	Confirmations    uint64           `json:"confirmations,omitempty"`
+	// GasAccounted marks GasUsed as recorded against the fee budget, so a
+	// transaction's gas is counted once no matter which transition first
+	// carried its receipt.
+	GasAccounted bool   `json:"gas_accounted,omitempty"`
+	UpdatedAt    uint64 `json:"updated_at"`
+}
@@ -0,0 +1,116 @@
+// Package types holds the core domain types shared across the publisher:
+// chains, slots, L2 blocks, superblocks and cross-chain transactions.
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ChainID identifies a rollup participating in the network.
+type ChainID uint64
+
+// NetworkID names one isolated rollup set hosted by a publisher. A single
+// process can serve several networks, each with its own registry, slots and
+// superblock chain.
+type NetworkID string
+
+// DefaultNetworkID is assumed when a peer does not name a network.
+const DefaultNetworkID NetworkID = "default"
+
+// Slot is the publisher's global slot number, derived from genesis time.
+type Slot uint64
+
+// Hash is a 32-byte digest, JSON-encoded as 0x-prefixed hex.
+type Hash [32]byte
+
+// ZeroHash is the all-zero hash.
+var ZeroHash Hash
+
+// String returns the 0x-prefixed hex representation of h.
+func (h Hash) String() string {
+	return "0x" + hex.EncodeToString(h[:])
+}
+
+// IsZero reports whether h is the zero hash.
+func (h Hash) IsZero() bool {
+	return h == ZeroHash
+}
+
+// MarshalJSON implements json.Marshaler.
+func (h Hash) MarshalJSON() ([]byte, error) {
+	return json.Marshal(h.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *Hash) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseHash(s)
+	if err != nil {
+		return err
+	}
+	*h = parsed
+	return nil
+}
+
+// ParseHash parses a 0x-prefixed (or bare) 64-character hex string.
+func ParseHash(s string) (Hash, error) {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+	}
+	var h Hash
+	if len(s) != 2*len(h) {
+		return Hash{}, fmt.Errorf("invalid hash length %d", len(s))
+	}
+	if _, err := hex.Decode(h[:], []byte(s)); err != nil {
+		return Hash{}, fmt.Errorf("invalid hash: %w", err)
+	}
+	return h, nil
+}
+
+// L2Block is a rollup block submitted to the publisher for inclusion in a
+// superblock.
+type L2Block struct {
+	ChainID    ChainID `json:"chain_id"`
+	Number     uint64  `json:"number"`
+	Hash       Hash    `json:"hash"`
+	ParentHash Hash    `json:"parent_hash"`
+	Slot       Slot    `json:"slot"`
+	Timestamp  uint64  `json:"timestamp"`
+	// Payload is the opaque encoded block body; the publisher treats it as
+	// data availability bytes and never executes it.
+	Payload []byte `json:"payload"`
+}
+
+// L2BlockLess is the canonical superblock ordering of L2 blocks: chain ID
+// ascending, then block number. Stored superblocks, API responses, prover
+// inputs and merkle hashing all use this one ordering; anything else would
+// let the same block set produce different roots.
+func L2BlockLess(a, b *L2Block) bool {
+	if a.ChainID != b.ChainID {
+		return a.ChainID < b.ChainID
+	}
+	return a.Number < b.Number
+}
+
+// SortL2Blocks sorts blocks in place into the canonical ordering.
+func SortL2Blocks(blocks []*L2Block) {
+	sort.Slice(blocks, func(i, j int) bool { return L2BlockLess(blocks[i], blocks[j]) })
+}
+
+// L2BlocksCanonical reports whether blocks are already in the canonical
+// ordering, for consumers that want to reject mis-ordered input instead of
+// silently re-sorting it.
+func L2BlocksCanonical(blocks []*L2Block) bool {
+	for i := 1; i < len(blocks); i++ {
+		if L2BlockLess(blocks[i], blocks[i-1]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,13 @@
+// Package version carries build metadata stamped at link time:
+//
+//	go build -ldflags "-X github.com/compose-network/publisher/version.Version=v1.2.3 ..."
+package version
+
+var (
+	// Version is the release tag, or "dev" for untagged builds.
+	Version = "dev"
+	// GitCommit is the short commit hash the binary was built from.
+	GitCommit = "unknown"
+	// BuildTime is the UTC build timestamp, RFC 3339.
+	BuildTime = "unknown"
+)
@@ -0,0 +1,193 @@
+// Package auth manages the keys that authenticate publisher and sequencer
+// connections. The publisher keeps a set of trusted sequencer public keys;
+// clients hold a Signer around their private key and sign handshake
+// payloads with it.
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// Errors returned during verification and authorization.
+var (
+	ErrUntrustedKey = errors.New("auth: untrusted key")
+	ErrBadSignature = errors.New("auth: invalid signature")
+	ErrKeyRevoked   = errors.New("auth: key revoked")
+	ErrKeyExpired   = errors.New("auth: key expired")
+	ErrChainDenied  = errors.New("auth: chain not allowed for key")
+)
+
+// trustedKey pairs a public key with its scheme and certificate-style
+// metadata.
+type trustedKey struct {
+	pub    PublicKey
+	scheme string
+	meta   KeyMetadata
+}
+
+// Manager holds the trusted public keys, keyed by operator-assigned key ID.
+type Manager struct {
+	mu      sync.RWMutex
+	trusted map[string]*trustedKey
+	// revoked is kept separately from trusted so revocation outlives
+	// removal and re-registration of the key.
+	revoked map[string]struct{}
+}
+
+// NewManager returns an empty Manager.
+func NewManager() *Manager {
+	return &Manager{
+		trusted: make(map[string]*trustedKey),
+		revoked: make(map[string]struct{}),
+	}
+}
+
+// AddTrustedKey registers an unrestricted trusted key by its compressed
+// secp256k1 public key in hex.
+func (m *Manager) AddTrustedKey(id string, pubKeyHex string) error {
+	return m.AddTrustedKeyWithMetadata(id, pubKeyHex, KeyMetadata{})
+}
+
+// AddTrustedKeyWithMetadata registers a secp256k1 trusted key with expiry
+// and usage restrictions; zero-value metadata fields mean unrestricted.
+func (m *Manager) AddTrustedKeyWithMetadata(id string, pubKeyHex string, meta KeyMetadata) error {
+	return m.AddTrustedKeyForScheme(id, SchemeSecp256k1, pubKeyHex, meta)
+}
+
+// AddTrustedKeyForScheme registers a trusted key under a named signature
+// scheme; handshakes with the key must negotiate the same scheme.
+func (m *Manager) AddTrustedKeyForScheme(id, schemeName, pubKeyHex string, meta KeyMetadata) error {
+	scheme, err := SchemeByName(schemeName)
+	if err != nil {
+		return err
+	}
+	raw, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return fmt.Errorf("auth: decode public key: %w", err)
+	}
+	pub, err := scheme.ParsePublicKey(raw)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.trusted[id] = &trustedKey{pub: pub, scheme: scheme.Name(), meta: meta}
+	return nil
+}
+
+// SchemeOf returns the signature scheme a trusted key was registered
+// under, or ErrUntrustedKey.
+func (m *Manager) SchemeOf(id string) (string, error) {
+	m.mu.RLock()
+	key, ok := m.trusted[id]
+	m.mu.RUnlock()
+	if !ok {
+		return "", ErrUntrustedKey
+	}
+	return key.scheme, nil
+}
+
+// RemoveTrustedKey drops a key; subsequent handshakes with it fail.
+func (m *Manager) RemoveTrustedKey(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.trusted, id)
+}
+
+// Verify checks sig (DER) over msg against the trusted key id. Revoked
+// keys fail regardless of the signature.
+func (m *Manager) Verify(id string, msg, sig []byte) error {
+	m.mu.RLock()
+	key, ok := m.trusted[id]
+	_, revoked := m.revoked[id]
+	m.mu.RUnlock()
+	if revoked {
+		return ErrKeyRevoked
+	}
+	if !ok {
+		return ErrUntrustedKey
+	}
+	if !key.pub.Verify(msg, sig) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// VerifyWithKey checks sig (DER) over msg against a bare compressed public
+// key in hex, for callers that pin a single key instead of using a Manager.
+func VerifyWithKey(pubKeyHex string, msg, sig []byte) error {
+	raw, err := hex.DecodeString(pubKeyHex)
+	if err != nil {
+		return fmt.Errorf("auth: decode public key: %w", err)
+	}
+	pub, err := secp256k1.ParsePubKey(raw)
+	if err != nil {
+		return fmt.Errorf("auth: parse public key: %w", err)
+	}
+	parsed, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBadSignature, err)
+	}
+	digest := sha256.Sum256(msg)
+	if !parsed.Verify(digest[:], pub) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+// Signer signs handshake payloads with a secp256k1 private key.
+type Signer struct {
+	keyID string
+	priv  *secp256k1.PrivateKey
+}
+
+// NewSigner wraps an existing private key given in hex.
+func NewSigner(keyID, privKeyHex string) (*Signer, error) {
+	raw, err := hex.DecodeString(privKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("auth: decode private key: %w", err)
+	}
+	if len(raw) != 32 {
+		return nil, errors.New("auth: private key must be 32 bytes")
+	}
+	return &Signer{keyID: keyID, priv: secp256k1.PrivKeyFromBytes(raw)}, nil
+}
+
+// GenerateSigner creates a Signer with a fresh key, for tests and tooling.
+func GenerateSigner(keyID string) (*Signer, error) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{keyID: keyID, priv: priv}, nil
+}
+
+// KeyID returns the signer's key ID.
+func (s *Signer) KeyID() string {
+	return s.keyID
+}
+
+// SchemeName returns the signer's signature scheme, sent in the handshake
+// so the server can match it against the key's registration.
+func (s *Signer) SchemeName() string {
+	return SchemeSecp256k1
+}
+
+// Sign returns a DER signature over sha256(msg).
+func (s *Signer) Sign(msg []byte) []byte {
+	digest := sha256.Sum256(msg)
+	return ecdsa.Sign(s.priv, digest[:]).Serialize()
+}
+
+// PublicKeyHex returns the compressed public key in hex, the form accepted
+// by Manager.AddTrustedKey.
+func (s *Signer) PublicKeyHex() string {
+	return hex.EncodeToString(s.priv.PubKey().SerializeCompressed())
+}
@@ -0,0 +1,49 @@
+package auth
+
+import "errors"
+
+// SchemeBLS12381 names the BLS12-381 scheme. It has no built-in
+// implementation: the module does not carry a pairing library, so stacks
+// that operate BLS keys link one and register it via RegisterBLSBackend at
+// init — blank-importing x/auth/bls12381 does exactly that. Until a
+// backend is registered the scheme name resolves to nothing and
+// handshakes claiming it are refused.
+const SchemeBLS12381 = "bls12-381"
+
+// ErrNoBLSBackend is returned by the placeholder when BLS operations are
+// attempted without a linked backend.
+var ErrNoBLSBackend = errors.New("auth: no BLS12-381 backend linked")
+
+// BLSBackend is the surface a BLS12-381 library must provide. Signatures
+// and keys use the conventional encoding: 48-byte G1 public keys, 96-byte
+// G2 signatures.
+type BLSBackend interface {
+	ParsePublicKey(raw []byte) (PublicKey, error)
+	AggregateSignatures(sigs [][]byte) ([]byte, error)
+	VerifyAggregate(pubs []PublicKey, msg, aggSig []byte) bool
+}
+
+// RegisterBLSBackend installs a BLS12-381 implementation, making
+// SchemeBLS12381 available for trusted keys, handshakes and aggregation.
+func RegisterBLSBackend(backend BLSBackend) {
+	RegisterScheme(blsScheme{backend: backend})
+}
+
+// blsScheme adapts a BLSBackend to the Scheme and Aggregator interfaces.
+type blsScheme struct {
+	backend BLSBackend
+}
+
+func (blsScheme) Name() string { return SchemeBLS12381 }
+
+func (s blsScheme) ParsePublicKey(raw []byte) (PublicKey, error) {
+	return s.backend.ParsePublicKey(raw)
+}
+
+func (s blsScheme) AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	return s.backend.AggregateSignatures(sigs)
+}
+
+func (s blsScheme) VerifyAggregate(pubs []PublicKey, msg, aggSig []byte) bool {
+	return s.backend.VerifyAggregate(pubs, msg, aggSig)
+}
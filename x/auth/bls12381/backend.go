@@ -0,0 +1,176 @@
+// Package bls12381 is the concrete BLS12-381 backend for x/auth. It lives
+// in its own module so the core publisher stays free of the pairing
+// dependency; blank-importing it makes auth.SchemeBLS12381 usable:
+//
+//	import _ "github.com/compose-network/publisher/x/auth/bls12381"
+//
+// Encodings follow the conventional minimal-pubkey variant: public keys
+// are 48-byte compressed G1 points, signatures 96-byte compressed G2
+// points, and messages hash to G2 under the proof-of-possession
+// ciphersuite DST. Same-message aggregation is only safe against
+// rogue-key attacks when registered keys carry a proof of possession,
+// which the trusted-key onboarding flow is expected to check.
+package bls12381
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	bls "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
+	"github.com/compose-network/publisher/x/auth"
+)
+
+// DST is the hash-to-curve domain separation tag (the BLS signature
+// proof-of-possession ciphersuite over G2).
+const DST = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+
+func init() {
+	_, _, g1Gen, _ = bls.Generators()
+	g1GenNeg.Neg(&g1Gen)
+	auth.RegisterBLSBackend(Backend{})
+}
+
+var g1Gen, g1GenNeg bls.G1Affine
+
+// Backend implements auth.BLSBackend over gnark-crypto's pairing.
+type Backend struct{}
+
+// ParsePublicKey decodes a 48-byte compressed G1 public key, rejecting
+// off-curve, out-of-subgroup and identity points.
+func (Backend) ParsePublicKey(raw []byte) (auth.PublicKey, error) {
+	if len(raw) != bls.SizeOfG1AffineCompressed {
+		return nil, fmt.Errorf("bls12381: public key must be %d bytes, got %d", bls.SizeOfG1AffineCompressed, len(raw))
+	}
+	var p bls.G1Affine
+	if _, err := p.SetBytes(raw); err != nil {
+		return nil, fmt.Errorf("bls12381: parse public key: %w", err)
+	}
+	if p.IsInfinity() {
+		return nil, errors.New("bls12381: public key is the identity point")
+	}
+	return PublicKey{point: p}, nil
+}
+
+// AggregateSignatures sums the signatures' G2 points into one 96-byte
+// aggregate.
+func (Backend) AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("bls12381: no signatures to aggregate")
+	}
+	var agg bls.G2Jac
+	for i, raw := range sigs {
+		p, err := parseSignature(raw)
+		if err != nil {
+			return nil, fmt.Errorf("bls12381: signature %d: %w", i, err)
+		}
+		agg.AddMixed(&p)
+	}
+	var out bls.G2Affine
+	out.FromJacobian(&agg)
+	b := out.Bytes()
+	return b[:], nil
+}
+
+// VerifyAggregate checks aggSig against the sum of the public keys, all
+// over the same message. Keys from another backend fail verification
+// rather than panicking.
+func (Backend) VerifyAggregate(pubs []auth.PublicKey, msg, aggSig []byte) bool {
+	if len(pubs) == 0 {
+		return false
+	}
+	var agg bls.G1Jac
+	for _, pub := range pubs {
+		k, ok := pub.(PublicKey)
+		if !ok {
+			return false
+		}
+		agg.AddMixed(&k.point)
+	}
+	var sum bls.G1Affine
+	sum.FromJacobian(&agg)
+	return PublicKey{point: sum}.Verify(msg, aggSig)
+}
+
+// PublicKey is a BLS12-381 public key on G1.
+type PublicKey struct {
+	point bls.G1Affine
+}
+
+// Verify reports whether sig is a valid signature over msg, i.e. whether
+// e(pk, H(msg)) == e(g1, sig).
+func (k PublicKey) Verify(msg, sig []byte) bool {
+	sigPoint, err := parseSignature(sig)
+	if err != nil {
+		return false
+	}
+	hm, err := bls.HashToG2(msg, []byte(DST))
+	if err != nil {
+		return false
+	}
+	ok, err := bls.PairingCheck(
+		[]bls.G1Affine{k.point, g1GenNeg},
+		[]bls.G2Affine{hm, sigPoint},
+	)
+	return err == nil && ok
+}
+
+// Bytes returns the 48-byte compressed encoding, the form trusted-key
+// registration expects.
+func (k PublicKey) Bytes() []byte {
+	b := k.point.Bytes()
+	return b[:]
+}
+
+func parseSignature(sig []byte) (bls.G2Affine, error) {
+	var p bls.G2Affine
+	if len(sig) != bls.SizeOfG2AffineCompressed {
+		return p, fmt.Errorf("signature must be %d bytes, got %d", bls.SizeOfG2AffineCompressed, len(sig))
+	}
+	if _, err := p.SetBytes(sig); err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// SecretKey signs under BLS12-381. The publisher itself only verifies;
+// signing lives here for sequencer-side tooling and tests.
+type SecretKey struct {
+	scalar big.Int
+}
+
+// GenerateKey creates a fresh key pair.
+func GenerateKey() (*SecretKey, PublicKey, error) {
+	s, err := rand.Int(rand.Reader, fr.Modulus())
+	if err != nil {
+		return nil, PublicKey{}, fmt.Errorf("bls12381: generate key: %w", err)
+	}
+	if s.Sign() == 0 {
+		s.SetInt64(1)
+	}
+	sk := &SecretKey{}
+	sk.scalar.Set(s)
+	return sk, sk.PublicKey(), nil
+}
+
+// PublicKey derives the matching public key g1*sk.
+func (sk *SecretKey) PublicKey() PublicKey {
+	var p bls.G1Affine
+	p.ScalarMultiplication(&g1Gen, &sk.scalar)
+	return PublicKey{point: p}
+}
+
+// Sign produces the 96-byte signature H(msg)*sk.
+func (sk *SecretKey) Sign(msg []byte) ([]byte, error) {
+	hm, err := bls.HashToG2(msg, []byte(DST))
+	if err != nil {
+		return nil, fmt.Errorf("bls12381: hash message: %w", err)
+	}
+	var sig bls.G2Affine
+	sig.ScalarMultiplication(&hm, &sk.scalar)
+	b := sig.Bytes()
+	return b[:], nil
+}
@@ -0,0 +1,11 @@
+module github.com/compose-network/publisher/x/auth/bls12381
+
+go 1.21
+
+require (
+	github.com/compose-network/publisher v0.0.0
+	github.com/consensys/gnark-crypto v0.12.1
+	github.com/stretchr/testify v1.9.0
+)
+
+replace github.com/compose-network/publisher => ../../..
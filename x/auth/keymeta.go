@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+// KeyMetadata restricts what a trusted key may do. Zero values mean
+// unrestricted: no expiry, any chain, any payload type.
+type KeyMetadata struct {
+	// NotAfter is the key's expiry; handshakes after it are refused.
+	NotAfter time.Time
+	// AllowedChains restricts which chain IDs may handshake with the key.
+	AllowedChains []types.ChainID
+	// AllowedPayloadTypes restricts which message types the key's
+	// connections may send.
+	AllowedPayloadTypes []string
+}
+
+// Authorize checks a handshake against the key's metadata: the key must be
+// trusted, not revoked, not expired, and allowed to act for the chain. It
+// complements Verify, which only checks the signature.
+func (m *Manager) Authorize(id string, chainID types.ChainID) error {
+	m.mu.RLock()
+	key, ok := m.trusted[id]
+	_, revoked := m.revoked[id]
+	m.mu.RUnlock()
+	if revoked {
+		return ErrKeyRevoked
+	}
+	if !ok {
+		return ErrUntrustedKey
+	}
+	if !key.meta.NotAfter.IsZero() && time.Now().After(key.meta.NotAfter) {
+		return ErrKeyExpired
+	}
+	if len(key.meta.AllowedChains) > 0 {
+		for _, allowed := range key.meta.AllowedChains {
+			if allowed == chainID {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: chain %d", ErrChainDenied, chainID)
+	}
+	return nil
+}
+
+// PayloadAllowed reports whether the key may send the given message type.
+// Keys without a payload restriction may send anything.
+func (m *Manager) PayloadAllowed(id string, msgType string) bool {
+	m.mu.RLock()
+	key, ok := m.trusted[id]
+	m.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	if len(key.meta.AllowedPayloadTypes) == 0 {
+		return true
+	}
+	for _, allowed := range key.meta.AllowedPayloadTypes {
+		if allowed == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// RevokeKey puts a key on the revocation list. Revocation is independent
+// of the trusted set: re-adding the key does not clear it.
+func (m *Manager) RevokeKey(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.revoked[id] = struct{}{}
+}
+
+// LoadTrustedKeys populates the manager from the persisted key store, so
+// key metadata and the revocation list survive restarts.
+func LoadTrustedKeys(m *Manager, keys store.TrustedKeyStore) error {
+	records, err := keys.ListTrustedKeys()
+	if err != nil {
+		return fmt.Errorf("auth: list trusted keys: %w", err)
+	}
+	for _, record := range records {
+		meta := KeyMetadata{
+			AllowedChains:       record.AllowedChains,
+			AllowedPayloadTypes: record.AllowedPayloadTypes,
+		}
+		if record.NotAfter != 0 {
+			meta.NotAfter = time.Unix(record.NotAfter, 0)
+		}
+		if err := m.AddTrustedKeyWithMetadata(record.ID, record.PubKeyHex, meta); err != nil {
+			return fmt.Errorf("auth: load key %s: %w", record.ID, err)
+		}
+		if record.Revoked {
+			m.RevokeKey(record.ID)
+		}
+	}
+	return nil
+}
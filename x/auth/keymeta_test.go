@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+func TestAuthorizeEnforcesMetadata(t *testing.T) {
+	m := NewManager()
+	signer, err := GenerateSigner("seq-1")
+	require.NoError(t, err)
+
+	// Unrestricted key: any chain authorizes.
+	require.NoError(t, m.AddTrustedKey("seq-1", signer.PublicKeyHex()))
+	require.NoError(t, m.Authorize("seq-1", 7))
+
+	// Chain allowlist.
+	require.NoError(t, m.AddTrustedKeyWithMetadata("seq-1", signer.PublicKeyHex(), KeyMetadata{
+		AllowedChains: []types.ChainID{1, 2},
+	}))
+	require.NoError(t, m.Authorize("seq-1", 2))
+	require.ErrorIs(t, m.Authorize("seq-1", 7), ErrChainDenied)
+
+	// Expiry.
+	require.NoError(t, m.AddTrustedKeyWithMetadata("seq-1", signer.PublicKeyHex(), KeyMetadata{
+		NotAfter: time.Now().Add(-time.Minute),
+	}))
+	require.ErrorIs(t, m.Authorize("seq-1", 1), ErrKeyExpired)
+
+	require.ErrorIs(t, m.Authorize("unknown", 1), ErrUntrustedKey)
+}
+
+func TestRevocationOutlivesReRegistration(t *testing.T) {
+	m := NewManager()
+	signer, err := GenerateSigner("seq-2")
+	require.NoError(t, err)
+	require.NoError(t, m.AddTrustedKey("seq-2", signer.PublicKeyHex()))
+
+	msg := []byte("payload")
+	sig := signer.Sign(msg)
+	require.NoError(t, m.Verify("seq-2", msg, sig))
+
+	m.RevokeKey("seq-2")
+	require.ErrorIs(t, m.Verify("seq-2", msg, sig), ErrKeyRevoked)
+	require.ErrorIs(t, m.Authorize("seq-2", 1), ErrKeyRevoked)
+
+	// Re-adding the key does not clear the revocation.
+	require.NoError(t, m.AddTrustedKey("seq-2", signer.PublicKeyHex()))
+	require.ErrorIs(t, m.Verify("seq-2", msg, sig), ErrKeyRevoked)
+}
+
+func TestPayloadAllowed(t *testing.T) {
+	m := NewManager()
+	signer, err := GenerateSigner("seq-3")
+	require.NoError(t, err)
+	require.NoError(t, m.AddTrustedKeyWithMetadata("seq-3", signer.PublicKeyHex(), KeyMetadata{
+		AllowedPayloadTypes: []string{"l2_block", "vote"},
+	}))
+
+	require.True(t, m.PayloadAllowed("seq-3", "vote"))
+	require.False(t, m.PayloadAllowed("seq-3", "xt_request"))
+	require.False(t, m.PayloadAllowed("unknown", "vote"))
+
+	// No restriction admits everything.
+	require.NoError(t, m.AddTrustedKey("seq-3", signer.PublicKeyHex()))
+	require.True(t, m.PayloadAllowed("seq-3", "xt_request"))
+}
+
+func TestLoadTrustedKeys(t *testing.T) {
+	keys := store.NewMemoryTrustedKeyStore()
+	signer, err := GenerateSigner("seq-4")
+	require.NoError(t, err)
+	require.NoError(t, keys.PutTrustedKey(&store.TrustedKeyRecord{
+		ID: "seq-4", PubKeyHex: signer.PublicKeyHex(),
+		NotAfter:      time.Now().Add(time.Hour).Unix(),
+		AllowedChains: []types.ChainID{4},
+	}))
+	revokedSigner, err := GenerateSigner("seq-5")
+	require.NoError(t, err)
+	require.NoError(t, keys.PutTrustedKey(&store.TrustedKeyRecord{
+		ID: "seq-5", PubKeyHex: revokedSigner.PublicKeyHex(), Revoked: true,
+	}))
+
+	m := NewManager()
+	require.NoError(t, LoadTrustedKeys(m, keys))
+	require.NoError(t, m.Authorize("seq-4", 4))
+	require.ErrorIs(t, m.Authorize("seq-4", 5), ErrChainDenied)
+	require.ErrorIs(t, m.Authorize("seq-5", 4), ErrKeyRevoked)
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// SchemeSecp256k1 is the default connection-auth scheme.
+const SchemeSecp256k1 = "secp256k1"
+
+// PublicKey verifies signatures under one scheme.
+type PublicKey interface {
+	// Verify reports whether sig is a valid signature over msg.
+	Verify(msg, sig []byte) bool
+}
+
+// Scheme is a signature scheme usable for connection auth. Schemes are
+// negotiated in the handshake: a trusted key is registered under one
+// scheme, and a client must present the same one.
+type Scheme interface {
+	Name() string
+	// ParsePublicKey decodes a public key from its scheme-native raw
+	// encoding (compressed point for secp256k1, 48 bytes for BLS12-381).
+	ParsePublicKey(raw []byte) (PublicKey, error)
+}
+
+// Aggregator is implemented by schemes that support signature aggregation
+// (BLS), for co-signing flows that combine many sequencer signatures into
+// one.
+type Aggregator interface {
+	// AggregateSignatures combines signatures over the same message.
+	AggregateSignatures(sigs [][]byte) ([]byte, error)
+	// VerifyAggregate checks an aggregated signature against every
+	// contributing public key.
+	VerifyAggregate(pubs []PublicKey, msg, aggSig []byte) bool
+}
+
+var (
+	schemeMu sync.RWMutex
+	schemes  = map[string]Scheme{
+		SchemeSecp256k1: secpScheme{},
+	}
+)
+
+// RegisterScheme makes a scheme available by name, replacing any previous
+// registration.
+func RegisterScheme(s Scheme) {
+	schemeMu.Lock()
+	defer schemeMu.Unlock()
+	schemes[s.Name()] = s
+}
+
+// SchemeByName returns a registered scheme. The empty name selects
+// secp256k1.
+func SchemeByName(name string) (Scheme, error) {
+	if name == "" {
+		name = SchemeSecp256k1
+	}
+	schemeMu.RLock()
+	s, ok := schemes[name]
+	schemeMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("auth: unknown signature scheme %q", name)
+	}
+	return s, nil
+}
+
+// AggregateSignatures combines signatures under a named scheme, which must
+// support aggregation.
+func AggregateSignatures(schemeName string, sigs [][]byte) ([]byte, error) {
+	s, err := SchemeByName(schemeName)
+	if err != nil {
+		return nil, err
+	}
+	agg, ok := s.(Aggregator)
+	if !ok {
+		return nil, fmt.Errorf("auth: scheme %q does not support aggregation", s.Name())
+	}
+	return agg.AggregateSignatures(sigs)
+}
+
+// VerifyAggregate checks an aggregated signature under a named scheme.
+func VerifyAggregate(schemeName string, pubs []PublicKey, msg, aggSig []byte) (bool, error) {
+	s, err := SchemeByName(schemeName)
+	if err != nil {
+		return false, err
+	}
+	agg, ok := s.(Aggregator)
+	if !ok {
+		return false, fmt.Errorf("auth: scheme %q does not support aggregation", s.Name())
+	}
+	return agg.VerifyAggregate(pubs, msg, aggSig), nil
+}
+
+// secpScheme is the built-in secp256k1 ECDSA scheme: DER signatures over
+// sha256(msg), compressed public keys.
+type secpScheme struct{}
+
+func (secpScheme) Name() string { return SchemeSecp256k1 }
+
+func (secpScheme) ParsePublicKey(raw []byte) (PublicKey, error) {
+	pub, err := secp256k1.ParsePubKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("auth: parse public key: %w", err)
+	}
+	return secpPublicKey{pub: pub}, nil
+}
+
+type secpPublicKey struct {
+	pub *secp256k1.PublicKey
+}
+
+func (k secpPublicKey) Verify(msg, sig []byte) bool {
+	parsed, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256(msg)
+	return parsed.Verify(digest[:], k.pub)
+}
@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBLSBackend is a toy aggregation backend: keys are their raw bytes, a
+// signature is valid when it equals the key, and aggregation concatenates.
+// It exists to exercise the scheme plumbing, not cryptography.
+type fakeBLSBackend struct{}
+
+type fakeBLSKey []byte
+
+func (k fakeBLSKey) Verify(msg, sig []byte) bool { return bytes.Equal(sig, k) }
+
+func (fakeBLSBackend) ParsePublicKey(raw []byte) (PublicKey, error) {
+	return fakeBLSKey(append([]byte(nil), raw...)), nil
+}
+
+func (fakeBLSBackend) AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	return bytes.Join(sigs, nil), nil
+}
+
+func (fakeBLSBackend) VerifyAggregate(pubs []PublicKey, msg, aggSig []byte) bool {
+	var want []byte
+	for _, pub := range pubs {
+		want = append(want, pub.(fakeBLSKey)...)
+	}
+	return bytes.Equal(aggSig, want)
+}
+
+func TestSchemeByName(t *testing.T) {
+	s, err := SchemeByName("")
+	require.NoError(t, err)
+	require.Equal(t, SchemeSecp256k1, s.Name())
+
+	_, err = SchemeByName("no-such-scheme")
+	require.Error(t, err)
+}
+
+func TestManagerTracksKeyScheme(t *testing.T) {
+	RegisterBLSBackend(fakeBLSBackend{})
+
+	m := NewManager()
+	signer, err := GenerateSigner("secp-key")
+	require.NoError(t, err)
+	require.NoError(t, m.AddTrustedKey("secp-key", signer.PublicKeyHex()))
+	require.NoError(t, m.AddTrustedKeyForScheme("bls-key", SchemeBLS12381, hex.EncodeToString([]byte("blspub")), KeyMetadata{}))
+
+	scheme, err := m.SchemeOf("secp-key")
+	require.NoError(t, err)
+	require.Equal(t, SchemeSecp256k1, scheme)
+
+	scheme, err = m.SchemeOf("bls-key")
+	require.NoError(t, err)
+	require.Equal(t, SchemeBLS12381, scheme)
+
+	_, err = m.SchemeOf("unknown")
+	require.ErrorIs(t, err, ErrUntrustedKey)
+
+	// Verification dispatches to the key's own scheme.
+	msg := []byte("handshake bytes")
+	require.NoError(t, m.Verify("secp-key", msg, signer.Sign(msg)))
+	require.NoError(t, m.Verify("bls-key", msg, []byte("blspub")))
+	require.ErrorIs(t, m.Verify("bls-key", msg, []byte("wrong")), ErrBadSignature)
+}
+
+func TestAggregationHelpers(t *testing.T) {
+	RegisterBLSBackend(fakeBLSBackend{})
+
+	agg, err := AggregateSignatures(SchemeBLS12381, [][]byte{[]byte("ab"), []byte("cd")})
+	require.NoError(t, err)
+	require.Equal(t, []byte("abcd"), agg)
+
+	scheme, err := SchemeByName(SchemeBLS12381)
+	require.NoError(t, err)
+	pub1, err := scheme.ParsePublicKey([]byte("ab"))
+	require.NoError(t, err)
+	pub2, err := scheme.ParsePublicKey([]byte("cd"))
+	require.NoError(t, err)
+
+	ok, err := VerifyAggregate(SchemeBLS12381, []PublicKey{pub1, pub2}, nil, agg)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// secp256k1 does not aggregate.
+	_, err = AggregateSignatures(SchemeSecp256k1, [][]byte{[]byte("x")})
+	require.Error(t, err)
+}
@@ -0,0 +1,125 @@
+// Package bus is the publisher's in-process event bus. Subsystems publish
+// typed topic events; subscribers receive them over buffered channels.
+// Unlike the ad-hoc channels it replaces, a full subscriber is counted and
+// logged instead of silently dropping, and events can optionally be
+// persisted for post-mortem replay.
+package bus
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/metrics"
+)
+
+// Topics published by the superblock subsystems.
+const (
+	TopicSuperblockBuilt    = "superblock.built"
+	TopicSuperblockSealed   = "superblock.sealed"
+	TopicSuperblockCosigned = "superblock.cosigned"
+	TopicProofCompleted     = "proof.completed"
+)
+
+// DefaultSubscriberBuffer is the channel buffer used when a subscriber
+// does not choose one.
+const DefaultSubscriberBuffer = 64
+
+// Event is one published occurrence.
+type Event struct {
+	Topic   string
+	At      time.Time
+	Payload any
+}
+
+// PersistFunc, when installed, receives every published event before
+// fan-out. It must not block; slow sinks should buffer internally.
+type PersistFunc func(Event)
+
+// Subscription is one subscriber's feed. Events arrive on C until Close.
+type Subscription struct {
+	// C delivers the subscriber's events.
+	C <-chan Event
+
+	bus   *Bus
+	topic string
+	ch    chan Event
+}
+
+// Close detaches the subscription and closes C.
+func (s *Subscription) Close() {
+	s.bus.unsubscribe(s)
+}
+
+// Bus fans events out to topic subscribers.
+type Bus struct {
+	logger  *zap.Logger
+	persist PersistFunc
+
+	mu   sync.RWMutex
+	subs map[string][]*Subscription
+}
+
+// New creates an empty bus.
+func New(logger *zap.Logger) *Bus {
+	return &Bus{
+		logger: logger.Named("bus"),
+		subs:   make(map[string][]*Subscription),
+	}
+}
+
+// SetPersist installs the optional event sink. Must be called before
+// publishing starts.
+func (b *Bus) SetPersist(fn PersistFunc) {
+	b.persist = fn
+}
+
+// Subscribe returns a feed of the topic's events. buffer <= 0 selects
+// DefaultSubscriberBuffer.
+func (b *Bus) Subscribe(topic string, buffer int) *Subscription {
+	if buffer <= 0 {
+		buffer = DefaultSubscriberBuffer
+	}
+	ch := make(chan Event, buffer)
+	sub := &Subscription{C: ch, bus: b, topic: topic, ch: ch}
+	b.mu.Lock()
+	b.subs[topic] = append(b.subs[topic], sub)
+	b.mu.Unlock()
+	return sub
+}
+
+func (b *Bus) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	subs := b.subs[sub.topic]
+	for i, s := range subs {
+		if s == sub {
+			b.subs[sub.topic] = append(subs[:i], subs[i+1:]...)
+			close(sub.ch)
+			break
+		}
+	}
+	b.mu.Unlock()
+}
+
+// Publish delivers an event to every subscriber of the topic. Delivery
+// never blocks: a subscriber whose buffer is full misses the event, which
+// is counted per topic and logged.
+func (b *Bus) Publish(topic string, payload any) {
+	event := Event{Topic: topic, At: time.Now(), Payload: payload}
+	if b.persist != nil {
+		b.persist(event)
+	}
+	b.mu.RLock()
+	subs := b.subs[topic]
+	b.mu.RUnlock()
+	for _, sub := range subs {
+		select {
+		case sub.ch <- event:
+		default:
+			metrics.RecordBusEventDropped(topic)
+			b.logger.Warn("event dropped: subscriber buffer full",
+				zap.String("topic", topic))
+		}
+	}
+}
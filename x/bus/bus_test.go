@@ -0,0 +1,71 @@
+package bus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPublishFansOutToSubscribers(t *testing.T) {
+	b := New(zap.NewNop())
+	a := b.Subscribe(TopicSuperblockBuilt, 4)
+	c := b.Subscribe(TopicSuperblockBuilt, 4)
+	other := b.Subscribe(TopicProofCompleted, 4)
+
+	b.Publish(TopicSuperblockBuilt, 7)
+
+	for _, sub := range []*Subscription{a, c} {
+		select {
+		case event := <-sub.C:
+			require.Equal(t, TopicSuperblockBuilt, event.Topic)
+			require.Equal(t, 7, event.Payload)
+		case <-time.After(time.Second):
+			t.Fatal("subscriber did not receive event")
+		}
+	}
+	select {
+	case <-other.C:
+		t.Fatal("received event for a different topic")
+	default:
+	}
+}
+
+func TestFullSubscriberDropsWithoutBlocking(t *testing.T) {
+	b := New(zap.NewNop())
+	sub := b.Subscribe(TopicSuperblockSealed, 1)
+
+	done := make(chan struct{})
+	go func() {
+		b.Publish(TopicSuperblockSealed, 1)
+		b.Publish(TopicSuperblockSealed, 2) // buffer full: dropped, not blocked
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on a full subscriber")
+	}
+	event := <-sub.C
+	require.Equal(t, 1, event.Payload)
+}
+
+func TestCloseStopsDelivery(t *testing.T) {
+	b := New(zap.NewNop())
+	sub := b.Subscribe(TopicSuperblockBuilt, 1)
+	sub.Close()
+	_, open := <-sub.C
+	require.False(t, open)
+	b.Publish(TopicSuperblockBuilt, 1) // no subscriber; must not panic
+}
+
+func TestPersistSeesEveryEvent(t *testing.T) {
+	b := New(zap.NewNop())
+	var got []Event
+	b.SetPersist(func(event Event) { got = append(got, event) })
+	b.Publish(TopicSuperblockBuilt, 1)
+	b.Publish(TopicProofCompleted, 2)
+	require.Len(t, got, 2)
+	require.Equal(t, TopicProofCompleted, got[1].Topic)
+}
@@ -0,0 +1,325 @@
+// Package conformance verifies a candidate sequencer implementation
+// against the publisher's wire protocol. The harness plays the publisher
+// side: it accepts the candidate's connection, drives it through scripted
+// StartSlot, StartSC and Decided sequences, and checks the responses —
+// L2 blocks, votes, CIRC messages — emitting a pass/fail report. New
+// sequencer implementations run it before joining a network.
+package conformance
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/superblock/slot"
+	"github.com/compose-network/publisher/x/transport/protocol"
+	"github.com/compose-network/publisher/x/transport/tcp"
+)
+
+// Check is one verified protocol behaviour.
+type Check struct {
+	Name   string `json:"name"`
+	Pass   bool   `json:"pass"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report is the outcome of a conformance run.
+type Report struct {
+	ChainID types.ChainID `json:"chain_id"`
+	Checks  []Check       `json:"checks"`
+}
+
+// Passed reports whether every check passed.
+func (r *Report) Passed() bool {
+	for _, check := range r.Checks {
+		if !check.Pass {
+			return false
+		}
+	}
+	return true
+}
+
+// Write renders the report as text.
+func (r *Report) Write(w io.Writer) {
+	passed := 0
+	for _, check := range r.Checks {
+		if check.Pass {
+			passed++
+		}
+	}
+	fmt.Fprintf(w, "conformance report for chain %d: %d/%d checks passed\n",
+		r.ChainID, passed, len(r.Checks))
+	for _, check := range r.Checks {
+		verdict := "PASS"
+		if !check.Pass {
+			verdict = "FAIL"
+		}
+		fmt.Fprintf(w, "  %s  %s", verdict, check.Name)
+		if check.Detail != "" {
+			fmt.Fprintf(w, ": %s", check.Detail)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// Config parameterizes a conformance run.
+type Config struct {
+	// Addr is where the harness listens for the candidate.
+	Addr string `yaml:"Addr"`
+	// ChainID is the chain the candidate must connect as.
+	ChainID types.ChainID `yaml:"ChainID"`
+	// Slots is the slot timing advertised at handshake and used for the
+	// scripted slots.
+	Slots slot.Config `yaml:"Slots"`
+	// ConnectTimeout is how long the harness waits for the candidate to
+	// connect. Zero means 30s.
+	ConnectTimeout time.Duration `yaml:"ConnectTimeout"`
+	// ResponseTimeout is how long each scripted step waits for the
+	// candidate's response. Zero means 5s.
+	ResponseTimeout time.Duration `yaml:"ResponseTimeout"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.ConnectTimeout <= 0 {
+		c.ConnectTimeout = 30 * time.Second
+	}
+	if c.ResponseTimeout <= 0 {
+		c.ResponseTimeout = 5 * time.Second
+	}
+	return c
+}
+
+// Harness runs the conformance script against one candidate.
+type Harness struct {
+	logger *zap.Logger
+	cfg    Config
+	server *tcp.Server
+	inbox  chan protocol.Message
+}
+
+// NewHarness creates a harness. The candidate's signing key must be
+// trusted by authManager, exactly as it would be on a real publisher.
+func NewHarness(logger *zap.Logger, cfg Config, authManager *auth.Manager) *Harness {
+	cfg = cfg.withDefaults()
+	h := &Harness{
+		logger: logger.Named("conformance"),
+		cfg:    cfg,
+		inbox:  make(chan protocol.Message, 64),
+	}
+	h.server = tcp.NewServer(logger, tcp.Config{Addr: cfg.Addr}, authManager)
+	h.server.SetSlotParams(types.DefaultNetworkID, cfg.Slots)
+	h.server.SetHandler(func(_ context.Context, conn *tcp.Conn, msg protocol.Message) {
+		if conn.ChainID() != cfg.ChainID {
+			return
+		}
+		select {
+		case h.inbox <- msg:
+		default:
+			h.logger.Warn("conformance inbox full, dropping message",
+				zap.String("type", msg.Type))
+		}
+	})
+	return h
+}
+
+// Addr returns the harness's listen address once Run has started it.
+func (h *Harness) Addr() string {
+	return h.server.Addr().String()
+}
+
+// Start begins listening for the candidate. Call before Run; separate so
+// callers can learn the bound address first.
+func (h *Harness) Start() error {
+	return h.server.Start()
+}
+
+// Run executes the script and returns the report. The server must have
+// been started.
+func (h *Harness) Run(ctx context.Context) (*Report, error) {
+	defer h.server.Stop()
+	report := &Report{ChainID: h.cfg.ChainID}
+
+	if !h.awaitConnection(ctx) {
+		report.Checks = append(report.Checks, Check{
+			Name:   "handshake",
+			Detail: fmt.Sprintf("chain %d did not connect within %s", h.cfg.ChainID, h.cfg.ConnectTimeout),
+		})
+		return report, nil
+	}
+	report.Checks = append(report.Checks, Check{Name: "handshake", Pass: true})
+
+	s := h.cfg.Slots.At(time.Now())
+	report.Checks = append(report.Checks, h.checkStartSlot(ctx, s))
+	voteCheck, xtID := h.checkStartSC(ctx, s)
+	report.Checks = append(report.Checks, voteCheck)
+	report.Checks = append(report.Checks, h.checkDecided(ctx, xtID, s))
+	return report, nil
+}
+
+// awaitConnection waits for the candidate's authenticated connection.
+func (h *Harness) awaitConnection(ctx context.Context) bool {
+	deadline := time.Now().Add(h.cfg.ConnectTimeout)
+	for time.Now().Before(deadline) {
+		for _, chain := range h.server.ConnectedChains(types.DefaultNetworkID) {
+			if chain == h.cfg.ChainID {
+				return true
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return false
+}
+
+// checkStartSlot announces a slot and expects a well-formed L2 block for
+// it.
+func (h *Harness) checkStartSlot(ctx context.Context, s types.Slot) Check {
+	check := Check{Name: "start_slot produces l2_block"}
+	err := h.server.SendTo(types.DefaultNetworkID, h.cfg.ChainID, protocol.TypeStartSlot,
+		protocol.StartSlot{Slot: s, Timestamp: time.Now().UnixNano()})
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	msg, ok := h.await(ctx, protocol.TypeL2Block)
+	if !ok {
+		check.Detail = fmt.Sprintf("no l2_block within %s", h.cfg.ResponseTimeout)
+		return check
+	}
+	var block types.L2Block
+	if err := msg.Decode(&block); err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	switch {
+	case block.ChainID != h.cfg.ChainID:
+		check.Detail = fmt.Sprintf("block carries chain %d, want %d", block.ChainID, h.cfg.ChainID)
+	case block.Hash.IsZero():
+		check.Detail = "block hash is zero"
+	case block.Number == 0:
+		check.Detail = "block number is zero"
+	case block.Slot != s:
+		check.Detail = fmt.Sprintf("block in slot %d, want %d", block.Slot, s)
+	default:
+		check.Pass = true
+	}
+	return check
+}
+
+// checkStartSC opens a 2PC round and expects a matching vote before the
+// deadline.
+func (h *Harness) checkStartSC(ctx context.Context, s types.Slot) (Check, types.XtID) {
+	check := Check{Name: "start_sc produces vote before deadline"}
+	var xtID types.XtID
+	_, _ = rand.Read(xtID[:])
+	deadline := time.Now().Add(h.cfg.ResponseTimeout)
+	err := h.server.SendTo(types.DefaultNetworkID, h.cfg.ChainID, protocol.TypeStartSC, protocol.StartSC{
+		Request: types.XTRequest{
+			XtID:        xtID,
+			SourceChain: h.cfg.ChainID,
+			Chains:      []types.ChainID{h.cfg.ChainID},
+			ReceivedAt:  uint64(time.Now().Unix()),
+		},
+		Slot:     s,
+		Deadline: deadline.UnixNano(),
+	})
+	if err != nil {
+		check.Detail = err.Error()
+		return check, xtID
+	}
+	msg, ok := h.await(ctx, protocol.TypeVote)
+	if !ok {
+		check.Detail = fmt.Sprintf("no vote within %s", h.cfg.ResponseTimeout)
+		return check, xtID
+	}
+	var vote protocol.Vote
+	if err := msg.Decode(&vote); err != nil {
+		check.Detail = err.Error()
+		return check, xtID
+	}
+	switch {
+	case vote.XtID != xtID:
+		check.Detail = fmt.Sprintf("vote for %s, want %s", vote.XtID, xtID)
+	case vote.ChainID != h.cfg.ChainID:
+		check.Detail = fmt.Sprintf("vote from chain %d, want %d", vote.ChainID, h.cfg.ChainID)
+	case time.Now().After(deadline):
+		check.Detail = "vote arrived after the deadline"
+	default:
+		check.Pass = true
+	}
+	return check, xtID
+}
+
+// checkDecided announces the XT's commit and verifies any CIRC messages
+// the candidate relays during execution are well-formed. Emitting CIRC
+// messages is optional; emitting malformed ones is not.
+func (h *Harness) checkDecided(ctx context.Context, xtID types.XtID, s types.Slot) Check {
+	check := Check{Name: "decided handled, circ messages well-formed"}
+	err := h.server.SendTo(types.DefaultNetworkID, h.cfg.ChainID, protocol.TypeDecided, protocol.Decided{
+		Decision: types.Decision{XtID: xtID, Committed: true, Slot: s},
+	})
+	if err != nil {
+		check.Detail = err.Error()
+		return check
+	}
+	// Collect whatever the candidate emits until the window closes; only
+	// CIRC messages are judged here.
+	windowEnd := time.After(h.cfg.ResponseTimeout / 2)
+	for {
+		select {
+		case <-ctx.Done():
+			check.Detail = ctx.Err().Error()
+			return check
+		case <-windowEnd:
+			check.Pass = true
+			return check
+		case msg := <-h.inbox:
+			if msg.Type != protocol.TypeCIRC {
+				continue
+			}
+			var circ types.CIRCMessage
+			if err := msg.Decode(&circ); err != nil {
+				check.Detail = err.Error()
+				return check
+			}
+			switch {
+			case circ.XtID != xtID:
+				check.Detail = fmt.Sprintf("circ for %s, want %s", circ.XtID, xtID)
+				return check
+			case circ.SourceChain != h.cfg.ChainID:
+				check.Detail = fmt.Sprintf("circ from chain %d, want %d", circ.SourceChain, h.cfg.ChainID)
+				return check
+			case circ.DestChain == 0:
+				check.Detail = "circ without dest_chain"
+				return check
+			}
+		}
+	}
+}
+
+// await reads from the inbox until a message of the wanted type arrives
+// or the response timeout passes. Other message types are skipped.
+func (h *Harness) await(ctx context.Context, msgType string) (protocol.Message, bool) {
+	timeout := time.After(h.cfg.ResponseTimeout)
+	for {
+		select {
+		case <-ctx.Done():
+			return protocol.Message{}, false
+		case <-timeout:
+			return protocol.Message{}, false
+		case msg := <-h.inbox:
+			if msg.Type == msgType {
+				return msg, true
+			}
+		}
+	}
+}
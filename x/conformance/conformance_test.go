@@ -0,0 +1,129 @@
+package conformance
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/superblock/slot"
+	"github.com/compose-network/publisher/x/transport/protocol"
+	"github.com/compose-network/publisher/x/transport/tcp"
+)
+
+func testSlots() slot.Config {
+	return slot.Config{
+		GenesisTime: time.Now().Add(-time.Minute),
+		Duration:    time.Second,
+		SealCutover: 750 * time.Millisecond,
+	}
+}
+
+// startCandidate connects a minimal compliant sequencer: one block per
+// start_slot, a commit vote per start_sc, one CIRC message per commit.
+func startCandidate(t *testing.T, addr string, chain types.ChainID, signer *auth.Signer, slots slot.Config) *tcp.Client {
+	t.Helper()
+	candidate := tcp.NewClient(zap.NewNop(), tcp.ClientConfig{
+		Addr: addr, ChainID: chain, Slots: slots,
+	}, signer)
+	candidate.SetHandler(func(msg protocol.Message) {
+		switch msg.Type {
+		case protocol.TypeStartSlot:
+			var start protocol.StartSlot
+			require.NoError(t, msg.Decode(&start))
+			block := &types.L2Block{
+				ChainID: chain, Number: uint64(start.Slot) + 1, Slot: start.Slot,
+				Timestamp: uint64(time.Now().Unix()),
+			}
+			block.Hash = sha256.Sum256([]byte{byte(start.Slot)})
+			require.NoError(t, candidate.SendL2Block(block))
+		case protocol.TypeStartSC:
+			var start protocol.StartSC
+			require.NoError(t, msg.Decode(&start))
+			require.NoError(t, candidate.Send(protocol.TypeVote, protocol.Vote{
+				XtID: start.Request.XtID, ChainID: chain, Commit: true,
+			}))
+		case protocol.TypeDecided:
+			var decided protocol.Decided
+			require.NoError(t, msg.Decode(&decided))
+			if decided.Decision.Committed {
+				require.NoError(t, candidate.Send(protocol.TypeCIRC, types.CIRCMessage{
+					XtID: decided.Decision.XtID, SourceChain: chain,
+					DestChain: chain + 1, Payload: []byte("circ payload"),
+				}))
+			}
+		}
+	})
+	require.NoError(t, candidate.Connect())
+	t.Cleanup(func() { _ = candidate.Close() })
+	return candidate
+}
+
+func TestCompliantSequencerPasses(t *testing.T) {
+	signer, err := auth.GenerateSigner("candidate")
+	require.NoError(t, err)
+	authManager := auth.NewManager()
+	require.NoError(t, authManager.AddTrustedKey("candidate", signer.PublicKeyHex()))
+
+	slots := testSlots()
+	harness := NewHarness(zap.NewNop(), Config{
+		Addr: "127.0.0.1:0", ChainID: 7, Slots: slots,
+		ConnectTimeout: 2 * time.Second, ResponseTimeout: time.Second,
+	}, authManager)
+	require.NoError(t, harness.Start())
+	startCandidate(t, harness.Addr(), 7, signer, slots)
+
+	report, err := harness.Run(context.Background())
+	require.NoError(t, err)
+	var out bytes.Buffer
+	report.Write(&out)
+	require.True(t, report.Passed(), out.String())
+	require.Len(t, report.Checks, 4)
+	require.Contains(t, out.String(), "4/4 checks passed")
+}
+
+func TestSilentSequencerFails(t *testing.T) {
+	signer, err := auth.GenerateSigner("candidate")
+	require.NoError(t, err)
+	authManager := auth.NewManager()
+	require.NoError(t, authManager.AddTrustedKey("candidate", signer.PublicKeyHex()))
+
+	slots := testSlots()
+	harness := NewHarness(zap.NewNop(), Config{
+		Addr: "127.0.0.1:0", ChainID: 7, Slots: slots,
+		ConnectTimeout: 2 * time.Second, ResponseTimeout: 100 * time.Millisecond,
+	}, authManager)
+	require.NoError(t, harness.Start())
+
+	// Connects but never answers anything.
+	candidate := tcp.NewClient(zap.NewNop(), tcp.ClientConfig{
+		Addr: harness.Addr(), ChainID: 7, Slots: slots,
+	}, signer)
+	require.NoError(t, candidate.Connect())
+	t.Cleanup(func() { _ = candidate.Close() })
+
+	report, err := harness.Run(context.Background())
+	require.NoError(t, err)
+	require.False(t, report.Passed())
+	require.True(t, report.Checks[0].Pass, "handshake itself is fine")
+	require.False(t, report.Checks[1].Pass, "no l2_block must fail")
+	require.False(t, report.Checks[2].Pass, "no vote must fail")
+}
+
+func TestAbsentSequencerFailsHandshake(t *testing.T) {
+	harness := NewHarness(zap.NewNop(), Config{
+		Addr: "127.0.0.1:0", ChainID: 7, Slots: testSlots(),
+		ConnectTimeout: 50 * time.Millisecond,
+	}, auth.NewManager())
+	require.NoError(t, harness.Start())
+	report, err := harness.Run(context.Background())
+	require.NoError(t, err)
+	require.False(t, report.Passed())
+	require.Len(t, report.Checks, 1)
+}
@@ -0,0 +1,269 @@
+package consensus
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/metrics"
+	"github.com/compose-network/publisher/types"
+)
+
+// CIRCSender delivers a CIRC message to the destination chain's sequencer
+// connection. Implementations must be safe for concurrent use; a returned
+// error means the message stays pending for redelivery.
+type CIRCSender interface {
+	SendCIRC(dest types.ChainID, msg *types.CIRCMessage) error
+}
+
+// CIRC lifecycle errors.
+var (
+	ErrCIRCPayloadTooLarge = errors.New("consensus: CIRC payload exceeds size limit")
+	ErrCIRCTooManyPerXT    = errors.New("consensus: CIRC message limit per XT exceeded")
+)
+
+// CIRCLimits bounds CIRC message growth in consensus state.
+type CIRCLimits struct {
+	// MaxPayloadSize is the largest accepted payload in bytes.
+	MaxPayloadSize int `yaml:"MaxPayloadSize"`
+	// MaxPerXT caps messages recorded per XT.
+	MaxPerXT int `yaml:"MaxPerXT"`
+	// TTL expires unacknowledged messages; the GC sweep enforces it.
+	TTL time.Duration `yaml:"TTL"`
+}
+
+// DefaultCIRCLimits are the limits used when a field is left zero.
+var DefaultCIRCLimits = CIRCLimits{
+	MaxPayloadSize: 1 << 20, // 1 MiB
+	MaxPerXT:       64,
+	TTL:            5 * time.Minute,
+}
+
+func (l CIRCLimits) withDefaults() CIRCLimits {
+	if l.MaxPayloadSize <= 0 {
+		l.MaxPayloadSize = DefaultCIRCLimits.MaxPayloadSize
+	}
+	if l.MaxPerXT <= 0 {
+		l.MaxPerXT = DefaultCIRCLimits.MaxPerXT
+	}
+	if l.TTL <= 0 {
+		l.TTL = DefaultCIRCLimits.TTL
+	}
+	return l
+}
+
+type pendingCIRC struct {
+	msg        *types.CIRCMessage
+	recordedAt time.Time
+}
+
+// CIRCRelay forwards CIRC messages to destination sequencers with
+// at-least-once delivery: messages stay pending until acknowledged and are
+// redelivered periodically and on reconnect. Pending state is bounded by
+// CIRCLimits and garbage-collected on XT decision and TTL expiry.
+type CIRCRelay struct {
+	logger *zap.Logger
+	sender CIRCSender
+	limits CIRCLimits
+
+	mu      sync.Mutex
+	nextSeq uint64
+	pending map[types.ChainID]map[uint64]*pendingCIRC
+	perXT   map[types.XtID]int
+
+	redeliverEvery time.Duration
+	stopCh         chan struct{}
+	doneCh         chan struct{}
+}
+
+// NewCIRCRelay creates a relay delivering through sender. redeliverEvery
+// bounds how long an unacknowledged message waits before another attempt;
+// zero disables the background redelivery/GC loop (tests, polling
+// consumers). Zero-valued limits fall back to DefaultCIRCLimits.
+func NewCIRCRelay(logger *zap.Logger, sender CIRCSender, limits CIRCLimits, redeliverEvery time.Duration) *CIRCRelay {
+	return &CIRCRelay{
+		logger:         logger.Named("circ"),
+		sender:         sender,
+		limits:         limits.withDefaults(),
+		pending:        make(map[types.ChainID]map[uint64]*pendingCIRC),
+		perXT:          make(map[types.XtID]int),
+		redeliverEvery: redeliverEvery,
+	}
+}
+
+// RecordCIRCMessage validates and stores a message for its destination
+// chain, assigns its sequence number and attempts immediate delivery.
+func (r *CIRCRelay) RecordCIRCMessage(msg *types.CIRCMessage) (uint64, error) {
+	if len(msg.Payload) > r.limits.MaxPayloadSize {
+		metrics.RecordCIRCDropped(uint64(msg.SourceChain), "payload_too_large")
+		return 0, ErrCIRCPayloadTooLarge
+	}
+
+	r.mu.Lock()
+	if r.perXT[msg.XtID] >= r.limits.MaxPerXT {
+		r.mu.Unlock()
+		metrics.RecordCIRCDropped(uint64(msg.SourceChain), "xt_limit")
+		return 0, ErrCIRCTooManyPerXT
+	}
+	r.nextSeq++
+	cp := *msg
+	cp.Seq = r.nextSeq
+	dest := r.pending[cp.DestChain]
+	if dest == nil {
+		dest = make(map[uint64]*pendingCIRC)
+		r.pending[cp.DestChain] = dest
+	}
+	dest[cp.Seq] = &pendingCIRC{msg: &cp, recordedAt: time.Now()}
+	r.perXT[msg.XtID]++
+	r.mu.Unlock()
+
+	r.deliver(&cp)
+	return cp.Seq, nil
+}
+
+// OnXTDecided drops all pending messages belonging to a decided XT; its
+// outcome supersedes any in-flight communication.
+func (r *CIRCRelay) OnXTDecided(xtID types.XtID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, queue := range r.pending {
+		for seq, entry := range queue {
+			if entry.msg.XtID == xtID {
+				metrics.RecordCIRCDropped(uint64(entry.msg.SourceChain), "decided")
+				delete(queue, seq)
+			}
+		}
+	}
+	delete(r.perXT, xtID)
+}
+
+// sweepExpired drops messages older than the TTL.
+func (r *CIRCRelay) sweepExpired() {
+	cutoff := time.Now().Add(-r.limits.TTL)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, queue := range r.pending {
+		for seq, entry := range queue {
+			if entry.recordedAt.Before(cutoff) {
+				metrics.RecordCIRCDropped(uint64(entry.msg.SourceChain), "ttl")
+				r.releaseLocked(entry.msg)
+				delete(queue, seq)
+			}
+		}
+	}
+}
+
+func (r *CIRCRelay) releaseLocked(msg *types.CIRCMessage) {
+	if n := r.perXT[msg.XtID]; n > 1 {
+		r.perXT[msg.XtID] = n - 1
+	} else {
+		delete(r.perXT, msg.XtID)
+	}
+}
+
+// Ack marks a message as delivered; it will not be redelivered.
+func (r *CIRCRelay) Ack(dest types.ChainID, seq uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry, ok := r.pending[dest][seq]; ok {
+		r.releaseLocked(entry.msg)
+		delete(r.pending[dest], seq)
+	}
+}
+
+// OnReconnect redelivers every pending message for the chain, called by the
+// transport when a sequencer connection is re-established.
+func (r *CIRCRelay) OnReconnect(dest types.ChainID) {
+	for _, msg := range r.PendingCIRCMessages(dest) {
+		r.deliver(msg)
+	}
+}
+
+// ConsumeCIRCMessages pops and returns all pending messages for a chain in
+// sequence order, for consumers that poll instead of receiving pushes.
+func (r *CIRCRelay) ConsumeCIRCMessages(dest types.ChainID) []*types.CIRCMessage {
+	r.mu.Lock()
+	msgs := r.collectLocked(dest)
+	for _, msg := range msgs {
+		r.releaseLocked(msg)
+	}
+	delete(r.pending, dest)
+	r.mu.Unlock()
+	return msgs
+}
+
+// PendingCIRCMessages returns the chain's unacknowledged messages in
+// sequence order without consuming them.
+func (r *CIRCRelay) PendingCIRCMessages(dest types.ChainID) []*types.CIRCMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.collectLocked(dest)
+}
+
+func (r *CIRCRelay) collectLocked(dest types.ChainID) []*types.CIRCMessage {
+	queue := r.pending[dest]
+	msgs := make([]*types.CIRCMessage, 0, len(queue))
+	for _, entry := range queue {
+		msgs = append(msgs, entry.msg)
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Seq < msgs[j].Seq })
+	return msgs
+}
+
+func (r *CIRCRelay) deliver(msg *types.CIRCMessage) {
+	if r.sender == nil {
+		return
+	}
+	if err := r.sender.SendCIRC(msg.DestChain, msg); err != nil {
+		r.logger.Debug("CIRC delivery failed, message stays pending",
+			zap.Uint64("dest_chain", uint64(msg.DestChain)),
+			zap.Uint64("seq", msg.Seq),
+			zap.Error(err))
+	}
+}
+
+// Start launches the background redelivery loop.
+func (r *CIRCRelay) Start() {
+	if r.redeliverEvery <= 0 || r.stopCh != nil {
+		return
+	}
+	r.stopCh = make(chan struct{})
+	r.doneCh = make(chan struct{})
+	go func() {
+		defer close(r.doneCh)
+		ticker := time.NewTicker(r.redeliverEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stopCh:
+				return
+			case <-ticker.C:
+				r.sweepExpired()
+				r.mu.Lock()
+				chains := make([]types.ChainID, 0, len(r.pending))
+				for chain := range r.pending {
+					chains = append(chains, chain)
+				}
+				r.mu.Unlock()
+				for _, chain := range chains {
+					for _, msg := range r.PendingCIRCMessages(chain) {
+						r.deliver(msg)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Stop terminates the redelivery loop.
+func (r *CIRCRelay) Stop() {
+	if r.stopCh == nil {
+		return
+	}
+	close(r.stopCh)
+	<-r.doneCh
+	r.stopCh = nil
+}
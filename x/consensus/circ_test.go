@@ -0,0 +1,129 @@
+package consensus
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+type fakeSender struct {
+	mu     sync.Mutex
+	sent   []*types.CIRCMessage
+	failed bool
+}
+
+func (f *fakeSender) SendCIRC(_ types.ChainID, msg *types.CIRCMessage) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failed {
+		return errors.New("disconnected")
+	}
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+func (f *fakeSender) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.sent)
+}
+
+func mustRecord(t *testing.T, relay *CIRCRelay, msg *types.CIRCMessage) uint64 {
+	t.Helper()
+	seq, err := relay.RecordCIRCMessage(msg)
+	require.NoError(t, err)
+	return seq
+}
+
+func TestCIRCRelayDeliveryAndAck(t *testing.T) {
+	sender := &fakeSender{}
+	relay := NewCIRCRelay(zap.NewNop(), sender, CIRCLimits{}, 0)
+
+	seq := mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, SourceChain: 10, DestChain: 20, Payload: []byte("x")})
+	require.Equal(t, 1, sender.count())
+	require.Len(t, relay.PendingCIRCMessages(20), 1)
+
+	relay.Ack(20, seq)
+	require.Empty(t, relay.PendingCIRCMessages(20))
+}
+
+func TestCIRCRelayRedeliverOnReconnect(t *testing.T) {
+	sender := &fakeSender{failed: true}
+	relay := NewCIRCRelay(zap.NewNop(), sender, CIRCLimits{}, 0)
+
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("a")})
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("b")})
+	require.Equal(t, 0, sender.count())
+
+	sender.mu.Lock()
+	sender.failed = false
+	sender.mu.Unlock()
+
+	relay.OnReconnect(20)
+	require.Equal(t, 2, sender.count())
+	// Still pending until acked: at-least-once, not at-most-once.
+	require.Len(t, relay.PendingCIRCMessages(20), 2)
+}
+
+func TestCIRCRelayBackgroundRedelivery(t *testing.T) {
+	sender := &fakeSender{}
+	relay := NewCIRCRelay(zap.NewNop(), sender, CIRCLimits{}, 5*time.Millisecond)
+	relay.Start()
+	defer relay.Stop()
+
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 30, Payload: []byte("a")})
+	require.Eventually(t, func() bool { return sender.count() >= 2 }, time.Second, time.Millisecond)
+}
+
+func TestCIRCRelayConsume(t *testing.T) {
+	relay := NewCIRCRelay(zap.NewNop(), nil, CIRCLimits{}, 0)
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("a")})
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("b")})
+
+	msgs := relay.ConsumeCIRCMessages(20)
+	require.Len(t, msgs, 2)
+	require.Less(t, msgs[0].Seq, msgs[1].Seq)
+	require.Empty(t, relay.ConsumeCIRCMessages(20))
+}
+
+func TestCIRCRelayLimits(t *testing.T) {
+	relay := NewCIRCRelay(zap.NewNop(), nil, CIRCLimits{MaxPayloadSize: 4, MaxPerXT: 2}, 0)
+
+	_, err := relay.RecordCIRCMessage(&types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("too large")})
+	require.ErrorIs(t, err, ErrCIRCPayloadTooLarge)
+
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("a")})
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("b")})
+	_, err = relay.RecordCIRCMessage(&types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("c")})
+	require.ErrorIs(t, err, ErrCIRCTooManyPerXT)
+
+	// Acking frees per-XT budget.
+	relay.Ack(20, 1)
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("c")})
+}
+
+func TestCIRCRelayExpiry(t *testing.T) {
+	relay := NewCIRCRelay(zap.NewNop(), nil, CIRCLimits{TTL: time.Nanosecond}, 0)
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("a")})
+
+	time.Sleep(time.Millisecond)
+	relay.sweepExpired()
+	require.Empty(t, relay.PendingCIRCMessages(20))
+}
+
+func TestCIRCRelayDropOnDecide(t *testing.T) {
+	relay := NewCIRCRelay(zap.NewNop(), nil, CIRCLimits{}, 0)
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{1}, DestChain: 20, Payload: []byte("a")})
+	mustRecord(t, relay, &types.CIRCMessage{XtID: types.Hash{2}, DestChain: 20, Payload: []byte("b")})
+
+	relay.OnXTDecided(types.Hash{1})
+	msgs := relay.PendingCIRCMessages(20)
+	require.Len(t, msgs, 1)
+	require.Equal(t, types.XtID(types.Hash{2}), msgs[0].XtID)
+}
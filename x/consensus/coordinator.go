@@ -0,0 +1,392 @@
+// Package consensus implements the publisher's two-phase commit (2PC)
+// coordination of cross-chain transactions (XTs). For each XT the
+// coordinator runs an SCP (single-commit-protocol) instance: it broadcasts
+// StartSC to the participating chains, collects one vote per chain, and
+// decides commit only if every participant voted commit before the
+// deadline.
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// State is the lifecycle state of an SCP instance.
+type State string
+
+const (
+	StateVoting    State = "voting"
+	StateCommitted State = "committed"
+	StateAborted   State = "aborted"
+)
+
+// DecidedFunc is invoked exactly once per SCP instance when it decides.
+type DecidedFunc func(decision types.Decision)
+
+// CarryFunc is invoked for each instance the carry-over policy moves into
+// the next slot at seal cutover, so the caller can re-broadcast StartSC.
+type CarryFunc func(inst *Instance)
+
+// SlotTimes supplies the seal-cutover deadline for a slot. The coordinator
+// derives every XT's vote deadline from it so that timer-driven aborts and
+// seal-time forced aborts share one deadline source.
+type SlotTimes interface {
+	SealTimeOf(s types.Slot) time.Time
+}
+
+// Instance is one running or recently finished 2PC round. The immutable
+// identity fields are safe to read without locking; the voting state below
+// mu is guarded per instance, so votes for different XTs never contend.
+type Instance struct {
+	XtID         types.XtID
+	Request      *types.XTRequest
+	Slot         types.Slot
+	Participants []types.ChainID
+
+	mu        sync.Mutex
+	state     State
+	votes     map[types.ChainID]bool
+	voteTimes map[types.ChainID]time.Time
+	startedAt time.Time
+	decidedAt time.Time
+	deadline  *time.Timer
+}
+
+// decidedLocked reports whether the instance has decided; i.mu held.
+func (i *Instance) decidedLocked() bool {
+	return i.state != StateVoting
+}
+
+// Coordinator drives all SCP instances for the publisher. Its own lock
+// only guards the instance registry (the active map and the recent ring);
+// per-XT voting state lives behind each Instance's lock, so the vote hot
+// path and inspection snapshots contend on the registry only briefly.
+type Coordinator struct {
+	logger    *zap.Logger
+	slotTimes SlotTimes
+	onDecided DecidedFunc
+
+	// carryOver, when set, moves vote-less instances to the next slot at
+	// seal cutover instead of aborting them; onCarried reports each move.
+	carryOver bool
+	onCarried CarryFunc
+
+	mu          sync.RWMutex
+	active      map[types.XtID]*Instance
+	recent      []*Instance
+	recentLimit int
+}
+
+// NewCoordinator returns a Coordinator that reports decisions to onDecided.
+// slotTimes may be nil, in which case instances have no vote deadline and
+// only seal-time forced aborts terminate them.
+func NewCoordinator(logger *zap.Logger, slotTimes SlotTimes, onDecided DecidedFunc) *Coordinator {
+	return &Coordinator{
+		logger:      logger.Named("consensus"),
+		slotTimes:   slotTimes,
+		onDecided:   onDecided,
+		active:      make(map[types.XtID]*Instance),
+		recentLimit: 256,
+	}
+}
+
+// EnableCarryOver turns on the cross-slot carry-over policy: at seal
+// cutover, an instance that has not received a single vote — its StartSC
+// broadcast effectively went unanswered — is restarted in the next slot
+// with a fresh deadline instead of force-aborted. fn, which may be nil,
+// receives each carried instance so the caller can re-broadcast StartSC.
+// Must be called before the coordinator is shared.
+func (c *Coordinator) EnableCarryOver(fn CarryFunc) {
+	c.carryOver = true
+	c.onCarried = fn
+}
+
+// StartSC opens a new SCP instance for the request in the given slot. ctx
+// is the submitting connection's context: a request whose peer has already
+// disconnected is not started.
+func (c *Coordinator) StartSC(ctx context.Context, req *types.XTRequest, slot types.Slot) (*Instance, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("SCP instance for %s not started: %w", req.XtID, err)
+	}
+	inst := &Instance{
+		XtID:         req.XtID,
+		Request:      req,
+		Slot:         slot,
+		Participants: append([]types.ChainID(nil), req.Chains...),
+		state:        StateVoting,
+		votes:        make(map[types.ChainID]bool, len(req.Chains)),
+		voteTimes:    make(map[types.ChainID]time.Time, len(req.Chains)),
+		startedAt:    time.Now(),
+	}
+	c.mu.Lock()
+	if _, ok := c.active[req.XtID]; ok {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("SCP instance for %s already active", req.XtID)
+	}
+	c.active[req.XtID] = inst
+	c.mu.Unlock()
+	c.armDeadline(inst, slot)
+	c.logger.Debug("started SCP instance",
+		zap.String("xt_id", inst.XtID.String()),
+		zap.Uint64("slot", uint64(slot)),
+		zap.Int("participants", len(inst.Participants)))
+	return inst, nil
+}
+
+// OnVote records a participant's vote. The decision, if the vote completes
+// the instance, is reported through the DecidedFunc before OnVote returns.
+// ctx is the voting connection's context: a vote read just before its peer
+// disconnected is dropped rather than recorded posthumously.
+func (c *Coordinator) OnVote(ctx context.Context, chainID types.ChainID, xtID types.XtID, commit bool) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("vote from chain %d for %s dropped: %w", chainID, xtID, err)
+	}
+	c.mu.RLock()
+	inst, ok := c.active[xtID]
+	c.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no active SCP instance for %s", xtID)
+	}
+	if !c.isParticipant(inst, chainID) {
+		return fmt.Errorf("chain %d is not a participant of %s", chainID, xtID)
+	}
+
+	inst.mu.Lock()
+	if inst.decidedLocked() {
+		// Decided between the registry lookup and taking the instance lock.
+		inst.mu.Unlock()
+		return fmt.Errorf("no active SCP instance for %s", xtID)
+	}
+	if _, voted := inst.votes[chainID]; voted {
+		inst.mu.Unlock()
+		return fmt.Errorf("duplicate vote from chain %d for %s", chainID, xtID)
+	}
+	inst.votes[chainID] = commit
+	inst.voteTimes[chainID] = time.Now()
+	complete := len(inst.votes) == len(inst.Participants)
+	var pending []types.ChainID
+	for _, p := range inst.Participants {
+		if _, voted := inst.votes[p]; !voted {
+			pending = append(pending, p)
+		}
+	}
+	inst.mu.Unlock()
+
+	if ce := c.logger.Check(zap.DebugLevel, "vote recorded"); ce != nil {
+		fields := []zap.Field{
+			zap.String("xt_id", xtID.String()),
+			zap.Uint64("chain_id", uint64(chainID)),
+			zap.Bool("commit", commit),
+			zap.Any("pending_chains", pending),
+		}
+		if c.slotTimes != nil {
+			fields = append(fields, zap.Duration("slot_time_left",
+				time.Until(c.slotTimes.SealTimeOf(inst.Slot))))
+		}
+		ce.Write(fields...)
+	}
+
+	var decision *types.Decision
+	switch {
+	case !commit:
+		decision = c.tryDecide(inst, false, types.AbortReasonVote)
+	case complete:
+		decision = c.tryDecide(inst, true, "")
+	}
+
+	if decision != nil && c.onDecided != nil {
+		c.onDecided(*decision)
+	}
+	return nil
+}
+
+// armDeadline schedules the instance's timeout abort for the slot's seal
+// cutover. Armed under the instance lock: the timer could fire before the
+// assignment otherwise, and deciding stops the timer it finds here.
+func (c *Coordinator) armDeadline(inst *Instance, slot types.Slot) {
+	if c.slotTimes == nil {
+		return
+	}
+	inst.mu.Lock()
+	inst.deadline = time.AfterFunc(time.Until(c.slotTimes.SealTimeOf(slot)), func() {
+		c.abortIfUndecided(inst, types.AbortReasonTimeout)
+	})
+	inst.mu.Unlock()
+}
+
+// ForceAbortUndecided aborts every still-voting instance belonging to the
+// slot, used at seal cutover. It is idempotent with timer-driven aborts:
+// instances already decided by their deadline timer are untouched. With
+// the carry-over policy enabled, instances that collected no votes at all
+// are moved to the next slot instead of aborted.
+func (c *Coordinator) ForceAbortUndecided(slot types.Slot) []types.Decision {
+	var decisions []types.Decision
+	for _, inst := range c.activeInstances() {
+		if inst.Slot != slot {
+			continue
+		}
+		if c.carryOver {
+			if carried := c.tryCarry(inst, slot+1); carried != nil {
+				if c.onCarried != nil {
+					c.onCarried(carried)
+				}
+				continue
+			}
+		}
+		if d := c.tryDecide(inst, false, types.AbortReasonSealCutover); d != nil {
+			decisions = append(decisions, *d)
+		}
+	}
+
+	if c.onDecided != nil {
+		for _, d := range decisions {
+			c.onDecided(d)
+		}
+	}
+	return decisions
+}
+
+// abortIfUndecided is the deadline-timer path. It is a no-op if the
+// instance already decided (vote completion or seal-time forced abort) or
+// was carried to a later slot.
+func (c *Coordinator) abortIfUndecided(inst *Instance, reason types.AbortReason) {
+	decision := c.tryDecide(inst, false, reason)
+	if decision == nil {
+		return
+	}
+	c.logger.Debug("aborted undecided SCP instance",
+		zap.String("xt_id", inst.XtID.String()), zap.String("reason", string(reason)))
+	if c.onDecided != nil {
+		c.onDecided(*decision)
+	}
+}
+
+// tryCarry moves a vote-less instance into nextSlot, replacing it in the
+// registry with a fresh instance carrying the same request. It returns nil
+// — and the seal-cutover abort proceeds — when the round already has votes
+// or decided concurrently. The replaced instance is retired silently: the
+// round is not ending, it is moving.
+func (c *Coordinator) tryCarry(inst *Instance, nextSlot types.Slot) *Instance {
+	inst.mu.Lock()
+	if inst.decidedLocked() || len(inst.votes) > 0 {
+		inst.mu.Unlock()
+		return nil
+	}
+	if inst.deadline != nil {
+		inst.deadline.Stop()
+	}
+	inst.state = StateAborted
+	inst.mu.Unlock()
+
+	next := &Instance{
+		XtID:         inst.XtID,
+		Request:      inst.Request,
+		Slot:         nextSlot,
+		Participants: inst.Participants,
+		state:        StateVoting,
+		votes:        make(map[types.ChainID]bool, len(inst.Participants)),
+		voteTimes:    make(map[types.ChainID]time.Time, len(inst.Participants)),
+		startedAt:    time.Now(),
+	}
+	c.mu.Lock()
+	if c.active[inst.XtID] != inst {
+		// Decided and unregistered between the snapshot and here.
+		c.mu.Unlock()
+		return nil
+	}
+	c.active[inst.XtID] = next
+	c.mu.Unlock()
+	c.armDeadline(next, nextSlot)
+	c.logger.Info("carried undecided XT to next slot",
+		zap.String("xt_id", inst.XtID.String()),
+		zap.Uint64("slot", uint64(nextSlot)))
+	return next
+}
+
+// AbortAllUndecided aborts every still-voting instance regardless of slot,
+// used when the publisher shuts down so sequencers are not left waiting on
+// rounds the restarted process no longer remembers.
+func (c *Coordinator) AbortAllUndecided() []types.Decision {
+	var decisions []types.Decision
+	for _, inst := range c.activeInstances() {
+		if d := c.tryDecide(inst, false, types.AbortReasonRestart); d != nil {
+			decisions = append(decisions, *d)
+		}
+	}
+
+	if c.onDecided != nil {
+		for _, d := range decisions {
+			c.onDecided(d)
+		}
+	}
+	return decisions
+}
+
+// activeInstances snapshots the active set, so callers can work through it
+// without holding the registry lock.
+func (c *Coordinator) activeInstances() []*Instance {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	insts := make([]*Instance, 0, len(c.active))
+	for _, inst := range c.active {
+		insts = append(insts, inst)
+	}
+	return insts
+}
+
+// tryDecide finalizes an instance unless it already decided, in which case
+// it returns nil. The state transition happens under the instance's own
+// lock — so concurrent votes, deadline timers and forced aborts decide at
+// most once — and only the registry move takes the coordinator lock.
+func (c *Coordinator) tryDecide(inst *Instance, committed bool, reason types.AbortReason) *types.Decision {
+	inst.mu.Lock()
+	if inst.decidedLocked() {
+		inst.mu.Unlock()
+		return nil
+	}
+	if inst.deadline != nil {
+		inst.deadline.Stop()
+	}
+	if committed {
+		inst.state = StateCommitted
+	} else {
+		inst.state = StateAborted
+	}
+	inst.decidedAt = time.Now()
+	took := inst.decidedAt.Sub(inst.startedAt)
+	inst.mu.Unlock()
+
+	c.mu.Lock()
+	// Only unregister the instance that actually decided: the registry slot
+	// may already hold this XT's carried-over successor.
+	if c.active[inst.XtID] == inst {
+		delete(c.active, inst.XtID)
+	}
+	c.recent = append(c.recent, inst)
+	if len(c.recent) > c.recentLimit {
+		c.recent = c.recent[len(c.recent)-c.recentLimit:]
+	}
+	c.mu.Unlock()
+
+	c.logger.Info("SCP instance decided",
+		zap.String("xt_id", inst.XtID.String()),
+		zap.Bool("committed", committed),
+		zap.String("abort_reason", string(reason)),
+		zap.Duration("took", took))
+	return &types.Decision{XtID: inst.XtID, Committed: committed, Slot: inst.Slot, AbortReason: reason}
+}
+
+func (c *Coordinator) isParticipant(inst *Instance, chainID types.ChainID) bool {
+	for _, p := range inst.Participants {
+		if p == chainID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package consensus
+
+import (
+	"context"
+	"encoding/binary"
+	"sync/atomic"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// BenchmarkCoordinatorDecide measures full 2PC rounds: StartSC plus one
+// commit vote per participant, through to the decision callback.
+func BenchmarkCoordinatorDecide(b *testing.B) {
+	chains := []types.ChainID{1, 2, 3}
+	c := NewCoordinator(zap.NewNop(), nil, func(types.Decision) {})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var xtID types.XtID
+		xtID[0], xtID[1], xtID[2], xtID[3] = byte(i), byte(i>>8), byte(i>>16), byte(i>>24)
+		req := &types.XTRequest{XtID: xtID, SourceChain: 1, Chains: chains}
+		if _, err := c.StartSC(context.Background(), req, 1); err != nil {
+			b.Fatal(err)
+		}
+		for _, chain := range chains {
+			if err := c.OnVote(context.Background(), chain, xtID, true); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkCoordinatorContention runs full rounds from parallel goroutines
+// while another goroutine scrapes Transactions, the pattern a metrics
+// endpoint imposes on a busy coordinator. Run with -race in CI to catch
+// locking regressions.
+func BenchmarkCoordinatorContention(b *testing.B) {
+	chains := []types.ChainID{1, 2, 3}
+	c := NewCoordinator(zap.NewNop(), nil, func(types.Decision) {})
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				c.Transactions()
+			}
+		}
+	}()
+
+	var seq atomic.Uint64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			i := seq.Add(1)
+			var xtID types.XtID
+			binary.BigEndian.PutUint64(xtID[:8], i)
+			req := &types.XTRequest{XtID: xtID, SourceChain: 1, Chains: chains}
+			if _, err := c.StartSC(context.Background(), req, 1); err != nil {
+				b.Fatal(err)
+			}
+			for _, chain := range chains {
+				if err := c.OnVote(context.Background(), chain, xtID, true); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,167 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+func newTestRequest(id byte, chains ...types.ChainID) *types.XTRequest {
+	return &types.XTRequest{XtID: types.Hash{id}, SourceChain: chains[0], Chains: chains}
+}
+
+func TestCommitWhenAllVote(t *testing.T) {
+	var decisions []types.Decision
+	c := NewCoordinator(zap.NewNop(), nil, func(d types.Decision) { decisions = append(decisions, d) })
+
+	req := newTestRequest(1, 10, 20)
+	_, err := c.StartSC(context.Background(), req, 5)
+	require.NoError(t, err)
+
+	require.NoError(t, c.OnVote(context.Background(), 10, req.XtID, true))
+	require.Empty(t, decisions)
+	require.NoError(t, c.OnVote(context.Background(), 20, req.XtID, true))
+	require.Len(t, decisions, 1)
+	require.True(t, decisions[0].Committed)
+	require.Equal(t, types.Slot(5), decisions[0].Slot)
+}
+
+func TestAbortOnFirstAbortVote(t *testing.T) {
+	var decisions []types.Decision
+	c := NewCoordinator(zap.NewNop(), nil, func(d types.Decision) { decisions = append(decisions, d) })
+
+	req := newTestRequest(2, 10, 20, 30)
+	_, err := c.StartSC(context.Background(), req, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, c.OnVote(context.Background(), 20, req.XtID, false))
+	require.Len(t, decisions, 1)
+	require.False(t, decisions[0].Committed)
+	require.Equal(t, types.AbortReasonVote, decisions[0].AbortReason)
+
+	// The instance is gone; late votes are rejected.
+	require.Error(t, c.OnVote(context.Background(), 10, req.XtID, true))
+}
+
+func TestVoteValidation(t *testing.T) {
+	c := NewCoordinator(zap.NewNop(), nil, nil)
+	req := newTestRequest(3, 10, 20)
+	_, err := c.StartSC(context.Background(), req, 1)
+	require.NoError(t, err)
+
+	require.Error(t, c.OnVote(context.Background(), 99, req.XtID, true), "non-participant")
+	require.NoError(t, c.OnVote(context.Background(), 10, req.XtID, true))
+	require.Error(t, c.OnVote(context.Background(), 10, req.XtID, true), "duplicate vote")
+
+	_, err = c.StartSC(context.Background(), req, 2)
+	require.Error(t, err, "already active")
+}
+
+func TestForceAbortUndecided(t *testing.T) {
+	var decisions []types.Decision
+	c := NewCoordinator(zap.NewNop(), nil, func(d types.Decision) { decisions = append(decisions, d) })
+
+	reqA := newTestRequest(4, 10, 20)
+	reqB := newTestRequest(5, 10)
+	_, err := c.StartSC(context.Background(), reqA, 7)
+	require.NoError(t, err)
+	_, err = c.StartSC(context.Background(), reqB, 8)
+	require.NoError(t, err)
+
+	forced := c.ForceAbortUndecided(7)
+	require.Len(t, forced, 1)
+	require.Equal(t, reqA.XtID, forced[0].XtID)
+	require.False(t, forced[0].Committed)
+	require.Equal(t, types.AbortReasonSealCutover, forced[0].AbortReason)
+	require.Len(t, decisions, 1)
+
+	// Slot 8 instance is still alive.
+	views := c.Transactions()
+	var active int
+	for _, view := range views {
+		if view.State == StateVoting {
+			active++
+		}
+	}
+	require.Equal(t, 1, active)
+}
+
+func TestAbortAllUndecidedOnRestart(t *testing.T) {
+	var decisions []types.Decision
+	c := NewCoordinator(zap.NewNop(), nil, func(d types.Decision) { decisions = append(decisions, d) })
+
+	_, err := c.StartSC(context.Background(), newTestRequest(6, 10), 7)
+	require.NoError(t, err)
+	_, err = c.StartSC(context.Background(), newTestRequest(7, 20), 8)
+	require.NoError(t, err)
+
+	aborted := c.AbortAllUndecided()
+	require.Len(t, aborted, 2)
+	for _, d := range aborted {
+		require.False(t, d.Committed)
+		require.Equal(t, types.AbortReasonRestart, d.AbortReason)
+	}
+	require.Len(t, decisions, 2)
+	require.Empty(t, c.AbortAllUndecided())
+}
+
+func TestCarryOverMovesVotelessXT(t *testing.T) {
+	var decisions []types.Decision
+	c := NewCoordinator(zap.NewNop(), nil, func(d types.Decision) { decisions = append(decisions, d) })
+	var carried []*Instance
+	c.EnableCarryOver(func(inst *Instance) { carried = append(carried, inst) })
+
+	answered := newTestRequest(1, 10, 20)
+	voteless := newTestRequest(2, 10, 20)
+	_, err := c.StartSC(context.Background(), answered, 3)
+	require.NoError(t, err)
+	_, err = c.StartSC(context.Background(), voteless, 3)
+	require.NoError(t, err)
+	require.NoError(t, c.OnVote(context.Background(), 10, answered.XtID, true))
+
+	// The round with a vote aborts at cutover; the unanswered one moves on.
+	aborts := c.ForceAbortUndecided(3)
+	require.Len(t, aborts, 1)
+	require.Equal(t, answered.XtID, aborts[0].XtID)
+	require.Len(t, carried, 1)
+	require.Equal(t, voteless.XtID, carried[0].XtID)
+	require.Equal(t, types.Slot(4), carried[0].Slot)
+
+	decisions = nil
+	require.NoError(t, c.OnVote(context.Background(), 10, voteless.XtID, true))
+	require.NoError(t, c.OnVote(context.Background(), 20, voteless.XtID, true))
+	require.Len(t, decisions, 1)
+	require.True(t, decisions[0].Committed)
+	require.Equal(t, types.Slot(4), decisions[0].Slot)
+}
+
+func TestViewsExposeVoteProgress(t *testing.T) {
+	timing := slot.Config{
+		GenesisTime: time.Now(),
+		Duration:    time.Minute,
+		SealCutover: 50 * time.Second,
+	}
+	c := NewCoordinator(zap.NewNop(), timing, nil)
+	req := newTestRequest(6, 10, 20, 30)
+	_, err := c.StartSC(context.Background(), req, 0)
+	require.NoError(t, err)
+	require.NoError(t, c.OnVote(context.Background(), 20, req.XtID, true))
+
+	views := c.Transactions()
+	require.Len(t, views, 1)
+	require.Equal(t, []types.ChainID{10, 30}, views[0].PendingChains)
+	require.NotNil(t, views[0].SlotTimeLeftMS)
+	require.Positive(t, *views[0].SlotTimeLeftMS)
+
+	// Once decided the remaining-time field goes away.
+	require.NoError(t, c.OnVote(context.Background(), 10, req.XtID, false))
+	views = c.Transactions()
+	require.Len(t, views, 1)
+	require.Nil(t, views[0].SlotTimeLeftMS)
+}
@@ -0,0 +1,67 @@
+package consensus
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+func TestDeadlineAbortFromSealTime(t *testing.T) {
+	timing := slot.Config{
+		GenesisTime: time.Now().Add(-10 * time.Millisecond),
+		Duration:    100 * time.Millisecond,
+		SealCutover: 50 * time.Millisecond,
+	}
+
+	var (
+		mu        sync.Mutex
+		decisions []types.Decision
+	)
+	c := NewCoordinator(zap.NewNop(), timing, func(d types.Decision) {
+		mu.Lock()
+		decisions = append(decisions, d)
+		mu.Unlock()
+	})
+
+	req := newTestRequest(1, 10, 20)
+	current := timing.At(time.Now())
+	_, err := c.StartSC(context.Background(), req, current)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(decisions) == 1 && !decisions[0].Committed
+	}, time.Second, 5*time.Millisecond)
+
+	// The seal-time forced abort is idempotent with the expired timer.
+	forced := c.ForceAbortUndecided(current)
+	require.Empty(t, forced)
+	mu.Lock()
+	require.Len(t, decisions, 1)
+	mu.Unlock()
+}
+
+func TestVotesBeatDeadline(t *testing.T) {
+	timing := slot.Config{
+		GenesisTime: time.Now(),
+		Duration:    time.Minute,
+		SealCutover: 30 * time.Second,
+	}
+	var decisions []types.Decision
+	c := NewCoordinator(zap.NewNop(), timing, func(d types.Decision) { decisions = append(decisions, d) })
+
+	req := newTestRequest(2, 10)
+	_, err := c.StartSC(context.Background(), req, 0)
+	require.NoError(t, err)
+	require.NoError(t, c.OnVote(context.Background(), 10, req.XtID, true))
+	require.Len(t, decisions, 1)
+	require.True(t, decisions[0].Committed)
+}
@@ -0,0 +1,94 @@
+package consensus
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+// PersistUndecided snapshots every still-voting instance into pending, for
+// a shutdown that wants the rounds to survive the restart instead of
+// evaporating. It returns the persisted records so the caller can tell the
+// participating sequencers resolution is pending before the transport
+// drains. Instances that decide while the snapshot runs are skipped.
+func (c *Coordinator) PersistUndecided(pending store.PendingSCPStore) ([]*store.PendingSCP, error) {
+	var recs []*store.PendingSCP
+	for _, inst := range c.activeInstances() {
+		inst.mu.Lock()
+		if inst.decidedLocked() {
+			inst.mu.Unlock()
+			continue
+		}
+		votes := make(map[types.ChainID]bool, len(inst.votes))
+		for chainID, commit := range inst.votes {
+			votes[chainID] = commit
+		}
+		inst.mu.Unlock()
+
+		rec := &store.PendingSCP{
+			Request: inst.Request,
+			Slot:    inst.Slot,
+			Votes:   votes,
+			SavedAt: uint64(time.Now().Unix()),
+		}
+		if err := pending.PutPendingSCP(rec); err != nil {
+			return recs, err
+		}
+		recs = append(recs, rec)
+	}
+	c.logger.Info("persisted undecided SCP instances for restart",
+		zap.Int("count", len(recs)))
+	return recs, nil
+}
+
+// Recover consumes the pending set written by a previous shutdown. Rounds
+// whose slot can still decide — the seal cutover has not passed — are
+// restarted with their recorded votes replayed; the rest abort with
+// AbortReasonRestart, reported through the DecidedFunc like any other
+// decision so sequencers stop waiting. It returns how many rounds resumed
+// and the abort decisions issued.
+func (c *Coordinator) Recover(ctx context.Context, pending store.PendingSCPStore) (int, []types.Decision, error) {
+	recs, err := pending.ListPendingSCPs()
+	if err != nil {
+		return 0, nil, err
+	}
+	resumed := 0
+	var aborted []types.Decision
+	for _, rec := range recs {
+		xtID := rec.Request.XtID
+		if c.slotTimes != nil && time.Now().After(c.slotTimes.SealTimeOf(rec.Slot)) {
+			decision := types.Decision{
+				XtID: xtID, Committed: false, Slot: rec.Slot,
+				AbortReason: types.AbortReasonRestart,
+			}
+			aborted = append(aborted, decision)
+			if c.onDecided != nil {
+				c.onDecided(decision)
+			}
+		} else if _, err := c.StartSC(ctx, rec.Request, rec.Slot); err != nil {
+			c.logger.Warn("pending SCP instance not resumed",
+				zap.String("xt_id", xtID.String()), zap.Error(err))
+		} else {
+			// Replay the votes cast before the shutdown. A replayed abort
+			// vote (or the last missing commit) decides immediately.
+			for chainID, commit := range rec.Votes {
+				if err := c.OnVote(ctx, chainID, xtID, commit); err != nil {
+					break
+				}
+			}
+			resumed++
+		}
+		if err := pending.DeletePendingSCP(xtID); err != nil {
+			return resumed, aborted, err
+		}
+	}
+	if len(recs) > 0 {
+		c.logger.Info("recovered persisted SCP instances",
+			zap.Int("resumed", resumed), zap.Int("aborted", len(aborted)))
+	}
+	return resumed, aborted, nil
+}
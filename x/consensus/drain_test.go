@@ -0,0 +1,72 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+func TestPersistAndRecoverResumesRound(t *testing.T) {
+	timing := slot.Config{
+		GenesisTime: time.Now().Add(-10 * time.Millisecond),
+		Duration:    time.Minute,
+		SealCutover: 50 * time.Second,
+	}
+	pending := store.NewMemoryPendingSCPStore()
+
+	var decisions []types.Decision
+	c := NewCoordinator(zap.NewNop(), timing, func(d types.Decision) { decisions = append(decisions, d) })
+	req := &types.XTRequest{XtID: types.Hash{1}, Chains: []types.ChainID{10, 11}}
+	_, err := c.StartSC(context.Background(), req, 0)
+	require.NoError(t, err)
+	require.NoError(t, c.OnVote(context.Background(), 10, req.XtID, true))
+
+	recs, err := c.PersistUndecided(pending)
+	require.NoError(t, err)
+	require.Len(t, recs, 1)
+	require.Equal(t, map[types.ChainID]bool{10: true}, recs[0].Votes)
+
+	// "Restart": a fresh coordinator recovers the round with the recorded
+	// vote replayed, so only chain 11 is still owed.
+	restarted := NewCoordinator(zap.NewNop(), timing, func(d types.Decision) { decisions = append(decisions, d) })
+	resumed, aborted, err := restarted.Recover(context.Background(), pending)
+	require.NoError(t, err)
+	require.Equal(t, 1, resumed)
+	require.Empty(t, aborted)
+	left, err := pending.ListPendingSCPs()
+	require.NoError(t, err)
+	require.Empty(t, left, "consumed records must be deleted")
+
+	require.NoError(t, restarted.OnVote(context.Background(), 11, req.XtID, true))
+	require.Len(t, decisions, 1)
+	require.True(t, decisions[0].Committed)
+}
+
+func TestRecoverAbortsExpiredRounds(t *testing.T) {
+	// Genesis far in the past: slot 0's seal cutover is long gone.
+	timing := slot.Config{
+		GenesisTime: time.Now().Add(-time.Hour),
+		Duration:    time.Second,
+		SealCutover: 500 * time.Millisecond,
+	}
+	pending := store.NewMemoryPendingSCPStore()
+	req := &types.XTRequest{XtID: types.Hash{2}, Chains: []types.ChainID{10}}
+	require.NoError(t, pending.PutPendingSCP(&store.PendingSCP{Request: req, Slot: 0}))
+
+	var decisions []types.Decision
+	c := NewCoordinator(zap.NewNop(), timing, func(d types.Decision) { decisions = append(decisions, d) })
+	resumed, aborted, err := c.Recover(context.Background(), pending)
+	require.NoError(t, err)
+	require.Zero(t, resumed)
+	require.Len(t, aborted, 1)
+	require.Len(t, decisions, 1)
+	require.False(t, decisions[0].Committed)
+	require.Equal(t, types.AbortReasonRestart, decisions[0].AbortReason)
+}
@@ -0,0 +1,85 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// VoteView is one participant's vote as exposed to inspection endpoints.
+type VoteView struct {
+	ChainID    types.ChainID `json:"chain_id"`
+	Commit     bool          `json:"commit"`
+	ReceivedAt time.Time     `json:"received_at"`
+}
+
+// InstanceView is a read-only snapshot of an SCP instance. For open
+// instances it also names the chains still owed a vote and, when the
+// coordinator has slot timing, how long they have left before seal
+// cutover — the fields operators need to spot the consistently slow
+// voter.
+type InstanceView struct {
+	XtID          types.XtID      `json:"xt_id"`
+	Slot          types.Slot      `json:"slot"`
+	State         State           `json:"state"`
+	Participants  []types.ChainID `json:"participants"`
+	Votes         []VoteView      `json:"votes"`
+	PendingChains []types.ChainID `json:"pending_chains,omitempty"`
+	StartedAt     time.Time       `json:"started_at"`
+	DecidedAt     *time.Time      `json:"decided_at,omitempty"`
+	// SlotTimeLeftMS is the time until the slot's seal cutover at snapshot
+	// time, in milliseconds; negative once the cutover has passed. Only set
+	// for undecided instances on a coordinator with slot timing.
+	SlotTimeLeftMS *int64 `json:"slot_time_left_ms,omitempty"`
+}
+
+// Transactions returns snapshots of all active instances followed by the
+// most recently decided ones (newest last). The registry lock is held only
+// to copy the instance pointers; each instance is then snapshotted under
+// its own lock, so a scrape never stalls vote recording across the board.
+func (c *Coordinator) Transactions() []InstanceView {
+	c.mu.RLock()
+	insts := make([]*Instance, 0, len(c.active)+len(c.recent))
+	for _, inst := range c.active {
+		insts = append(insts, inst)
+	}
+	insts = append(insts, c.recent...)
+	c.mu.RUnlock()
+
+	views := make([]InstanceView, 0, len(insts))
+	for _, inst := range insts {
+		views = append(views, c.snapshot(inst))
+	}
+	return views
+}
+
+func (c *Coordinator) snapshot(inst *Instance) InstanceView {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	view := InstanceView{
+		XtID:         inst.XtID,
+		Slot:         inst.Slot,
+		State:        inst.state,
+		Participants: append([]types.ChainID(nil), inst.Participants...),
+		StartedAt:    inst.startedAt,
+	}
+	for _, chain := range inst.Participants {
+		if commit, ok := inst.votes[chain]; ok {
+			view.Votes = append(view.Votes, VoteView{
+				ChainID:    chain,
+				Commit:     commit,
+				ReceivedAt: inst.voteTimes[chain],
+			})
+		} else {
+			view.PendingChains = append(view.PendingChains, chain)
+		}
+	}
+	if inst.decidedLocked() {
+		decidedAt := inst.decidedAt
+		view.DecidedAt = &decidedAt
+	} else if c.slotTimes != nil {
+		left := time.Until(c.slotTimes.SealTimeOf(inst.Slot)).Milliseconds()
+		view.SlotTimeLeftMS = &left
+	}
+	return view
+}
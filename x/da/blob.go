@@ -0,0 +1,35 @@
+package da
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// blobSize is the EIP-4844 blob payload size.
+const blobSize = 128 * 1024
+
+// maxBlobsPerTx is the protocol cap on blob sidecars per transaction.
+const maxBlobsPerTx = 6
+
+// blobDA targets EIP-4844 blob sidecars. The content commitment is
+// computed here; the KZG commitments and versioned hashes are produced by
+// the L1 submitter when it builds the blob transaction, since they depend
+// on the final sidecar layout.
+type blobDA struct{}
+
+func (d *blobDA) Name() string { return ProviderBlobs }
+
+func (d *blobDA) Post(_ context.Context, data []byte) (*types.DACommitment, error) {
+	blobs := (len(data) + blobSize - 1) / blobSize
+	if blobs > maxBlobsPerTx {
+		return nil, fmt.Errorf("da: block data needs %d blobs, max %d per transaction", blobs, maxBlobsPerTx)
+	}
+	return &types.DACommitment{
+		Provider:   ProviderBlobs,
+		Commitment: contentHash(data),
+		Pointer:    fmt.Sprintf("blobs=%d", blobs),
+		Size:       len(data),
+	}, nil
+}
@@ -0,0 +1,22 @@
+package da
+
+import (
+	"context"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// calldataDA inlines the block data into the superblock's L1 transaction.
+// Nothing is posted here; the commitment pins the bytes the L1 submitter
+// must carry.
+type calldataDA struct{}
+
+func (d *calldataDA) Name() string { return ProviderCalldata }
+
+func (d *calldataDA) Post(_ context.Context, data []byte) (*types.DACommitment, error) {
+	return &types.DACommitment{
+		Provider:   ProviderCalldata,
+		Commitment: contentHash(data),
+		Size:       len(data),
+	}, nil
+}
@@ -0,0 +1,91 @@
+// Package da abstracts where a superblock's L2 block data is made
+// available: inlined into L1 calldata, posted as EIP-4844 blobs, or pushed
+// to an external DA service. The chosen provider's commitment is recorded
+// in the superblock so verifiers can locate and check the data.
+package da
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// Provider names for Config.Provider.
+const (
+	ProviderCalldata = "calldata"
+	ProviderBlobs    = "blobs"
+	ProviderHTTP     = "http"
+)
+
+// DataAvailability posts encoded block data and returns the commitment
+// recorded in the superblock.
+type DataAvailability interface {
+	// Name returns the provider name.
+	Name() string
+	// Post makes data available and returns its commitment.
+	Post(ctx context.Context, data []byte) (*types.DACommitment, error)
+}
+
+// HTTPConfig parameterizes the external DA provider.
+type HTTPConfig struct {
+	// URL is the DA service's blob submission endpoint.
+	URL string `yaml:"URL"`
+	// Namespace scopes the network's blobs at the service.
+	Namespace string        `yaml:"Namespace"`
+	Timeout   time.Duration `yaml:"Timeout"`
+}
+
+// Config selects and parameterizes the DA provider.
+type Config struct {
+	Provider string     `yaml:"Provider"`
+	HTTP     HTTPConfig `yaml:"HTTP"`
+}
+
+// New builds the configured provider. An empty provider defaults to
+// calldata.
+func New(logger *zap.Logger, cfg Config) (DataAvailability, error) {
+	switch cfg.Provider {
+	case "", ProviderCalldata:
+		return &calldataDA{}, nil
+	case ProviderBlobs:
+		return &blobDA{}, nil
+	case ProviderHTTP:
+		if cfg.HTTP.URL == "" {
+			return nil, errors.New("da: http provider requires URL")
+		}
+		return newHTTPDA(logger, cfg.HTTP), nil
+	default:
+		return nil, fmt.Errorf("da: unknown provider %q", cfg.Provider)
+	}
+}
+
+// PostBlocks encodes a superblock's L2 blocks, posts them to the provider
+// and records the commitment on the superblock.
+func PostBlocks(ctx context.Context, provider DataAvailability, sb *types.Superblock) error {
+	data, err := json.Marshal(sb.L2Blocks)
+	if err != nil {
+		return fmt.Errorf("da: encode block data: %w", err)
+	}
+	commitment, err := provider.Post(ctx, data)
+	if err != nil {
+		return fmt.Errorf("da: post superblock %d data: %w", sb.Number, err)
+	}
+	sb.DA = commitment
+	return nil
+}
+
+// contentHash is the keccak256 commitment shared by the local providers.
+func contentHash(data []byte) types.Hash {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	var out types.Hash
+	h.Sum(out[:0])
+	return out
+}
@@ -0,0 +1,88 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestNewSelectsProvider(t *testing.T) {
+	for provider, name := range map[string]string{
+		"":               ProviderCalldata,
+		ProviderCalldata: ProviderCalldata,
+		ProviderBlobs:    ProviderBlobs,
+	} {
+		d, err := New(zap.NewNop(), Config{Provider: provider})
+		require.NoError(t, err)
+		require.Equal(t, name, d.Name())
+	}
+
+	_, err := New(zap.NewNop(), Config{Provider: "carrier-pigeon"})
+	require.Error(t, err)
+	_, err = New(zap.NewNop(), Config{Provider: ProviderHTTP})
+	require.Error(t, err, "http provider requires a URL")
+}
+
+func TestCalldataCommitment(t *testing.T) {
+	d := &calldataDA{}
+	a, err := d.Post(context.Background(), []byte("block data"))
+	require.NoError(t, err)
+	b, err := d.Post(context.Background(), []byte("block data"))
+	require.NoError(t, err)
+	require.Equal(t, a.Commitment, b.Commitment)
+	require.Equal(t, 10, a.Size)
+	require.Empty(t, a.Pointer)
+
+	other, err := d.Post(context.Background(), []byte("different"))
+	require.NoError(t, err)
+	require.NotEqual(t, a.Commitment, other.Commitment)
+}
+
+func TestBlobProviderEnforcesBlobCap(t *testing.T) {
+	d := &blobDA{}
+	ok, err := d.Post(context.Background(), bytes.Repeat([]byte{1}, 3*blobSize))
+	require.NoError(t, err)
+	require.Equal(t, "blobs=3", ok.Pointer)
+
+	_, err = d.Post(context.Background(), bytes.Repeat([]byte{1}, (maxBlobsPerTx+1)*blobSize))
+	require.Error(t, err)
+}
+
+func TestHTTPProvider(t *testing.T) {
+	var gotNamespace string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req submitRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		gotNamespace = req.Namespace
+		_ = json.NewEncoder(w).Encode(submitResponse{ID: "height=42,index=1"})
+	}))
+	defer srv.Close()
+
+	d, err := New(zap.NewNop(), Config{Provider: ProviderHTTP, HTTP: HTTPConfig{URL: srv.URL, Namespace: "compose"}})
+	require.NoError(t, err)
+
+	commitment, err := d.Post(context.Background(), []byte("payload"))
+	require.NoError(t, err)
+	require.Equal(t, "compose", gotNamespace)
+	require.Equal(t, "height=42,index=1", commitment.Pointer)
+	require.Equal(t, contentHash([]byte("payload")), commitment.Commitment)
+}
+
+func TestPostBlocksRecordsCommitment(t *testing.T) {
+	sb := &types.Superblock{
+		Number:   3,
+		L2Blocks: []*types.L2Block{{ChainID: 1, Number: 2, Hash: types.Hash{1}}},
+	}
+	require.NoError(t, PostBlocks(context.Background(), &calldataDA{}, sb))
+	require.NotNil(t, sb.DA)
+	require.Equal(t, ProviderCalldata, sb.DA.Provider)
+	require.False(t, sb.DA.Commitment.IsZero())
+}
@@ -0,0 +1,83 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// httpDA posts block data to a Celestia-style external DA service over
+// HTTP. The service stores the blob under the configured namespace and
+// returns a pointer for later retrieval.
+type httpDA struct {
+	logger *zap.Logger
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+func newHTTPDA(logger *zap.Logger, cfg HTTPConfig) *httpDA {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &httpDA{
+		logger: logger.Named("da-http"),
+		cfg:    cfg,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (d *httpDA) Name() string { return ProviderHTTP }
+
+// submitRequest is the service's blob submission body.
+type submitRequest struct {
+	Namespace string `json:"namespace,omitempty"`
+	Data      []byte `json:"data"` // base64 via encoding/json
+}
+
+// submitResponse is the service's answer: an opaque pointer to the stored
+// blob (e.g. height/index at the DA network).
+type submitResponse struct {
+	ID string `json:"id"`
+}
+
+func (d *httpDA) Post(ctx context.Context, data []byte) (*types.DACommitment, error) {
+	body, err := json.Marshal(submitRequest{Namespace: d.cfg.Namespace, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("da: submit blob: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("da: service returned %s", resp.Status)
+	}
+	var submitted submitResponse
+	if err := json.NewDecoder(resp.Body).Decode(&submitted); err != nil {
+		return nil, fmt.Errorf("da: decode submit response: %w", err)
+	}
+	if submitted.ID == "" {
+		return nil, fmt.Errorf("da: service returned no blob ID")
+	}
+	d.logger.Debug("blob posted", zap.String("id", submitted.ID), zap.Int("size", len(data)))
+	return &types.DACommitment{
+		Provider:   ProviderHTTP,
+		Commitment: contentHash(data),
+		Pointer:    submitted.ID,
+		Size:       len(data),
+	}, nil
+}
@@ -0,0 +1,241 @@
+// Package explorer periodically exports superblock summaries as static
+// JSON, cheap to serve from any web server or object store, so an explorer
+// frontend can be powered without exposing the publisher API publicly.
+package explorer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/bus"
+	"github.com/compose-network/publisher/x/prover"
+)
+
+// Summary is the per-superblock record the exporter writes. It carries
+// what an explorer list view needs; the full superblock stays behind the
+// API.
+type Summary struct {
+	Number      uint64                 `json:"number"`
+	Slot        types.Slot             `json:"slot"`
+	Hash        types.Hash             `json:"hash"`
+	Status      types.SuperblockStatus `json:"status"`
+	Chains      int                    `json:"chains"`
+	XTs         int                    `json:"xts"`
+	L1TxHash    types.Hash             `json:"l1_tx_hash,omitempty"`
+	ProofStatus string                 `json:"proof_status"`
+}
+
+// Proof statuses in Summary. The exporter only sees proofs completed while
+// it is running; historical superblocks report pending until re-proven.
+const (
+	ProofPending   = "pending"
+	ProofCompleted = "completed"
+)
+
+// index.json layout: the latest number plus the most recent summaries, so
+// a frontend needs one fetch for its landing page.
+type index struct {
+	Latest uint64    `json:"latest"`
+	Recent []Summary `json:"recent"`
+}
+
+// recentWindow bounds how many summaries index.json repeats and how far
+// back each sweep re-exports, so status transitions (sealed, submitted,
+// confirmed) on recent superblocks are picked up.
+const recentWindow = 100
+
+// Sink receives exported files. The default writes a directory tree; an
+// object-store sink can be swapped in for bucket hosting.
+type Sink interface {
+	// Put stores data under the slash-separated name, overwriting any
+	// previous version.
+	Put(name string, data []byte) error
+}
+
+// fileSink writes under a root directory, creating parents as needed.
+type fileSink struct {
+	root string
+}
+
+// NewFileSink returns a Sink writing below root.
+func NewFileSink(root string) Sink {
+	return &fileSink{root: root}
+}
+
+func (s *fileSink) Put(name string, data []byte) error {
+	path := filepath.Join(s.root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Config parameterizes the exporter.
+type Config struct {
+	// Interval between export sweeps. Zero means 15 seconds.
+	Interval time.Duration `yaml:"Interval"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.Interval <= 0 {
+		c.Interval = 15 * time.Second
+	}
+	return c
+}
+
+// Exporter sweeps the superblock store and writes one summary file per
+// superblock (superblocks/<number>.json) plus an index.json.
+type Exporter struct {
+	logger   *zap.Logger
+	store    store.SuperblockStore
+	sink     Sink
+	interval time.Duration
+	mu       sync.Mutex
+	proven   map[uint64]bool
+	proofSub *bus.Subscription
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewExporter creates an exporter sweeping superblocks into sink.
+func NewExporter(logger *zap.Logger, superblocks store.SuperblockStore, sink Sink, cfg Config) *Exporter {
+	cfg = cfg.withDefaults()
+	return &Exporter{
+		logger:   logger.Named("explorer"),
+		store:    superblocks,
+		sink:     sink,
+		interval: cfg.Interval,
+		proven:   make(map[uint64]bool),
+	}
+}
+
+// SetBus subscribes to proof completions so summaries can report
+// proof_status without polling the prover.
+func (e *Exporter) SetBus(b *bus.Bus) {
+	e.proofSub = b.Subscribe(bus.TopicProofCompleted, 0)
+	go func() {
+		for event := range e.proofSub.C {
+			if input, ok := event.Payload.(*prover.ProofJobInput); ok {
+				e.mu.Lock()
+				e.proven[input.SuperblockNumber] = true
+				e.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// Start launches the export loop.
+func (e *Exporter) Start() {
+	if e.stopCh != nil {
+		return
+	}
+	e.stopCh = make(chan struct{})
+	e.doneCh = make(chan struct{})
+	go func() {
+		defer close(e.doneCh)
+		ticker := time.NewTicker(e.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-e.stopCh:
+				return
+			case <-ticker.C:
+				if err := e.Export(); err != nil {
+					e.logger.Warn("export sweep failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Stop terminates the export loop.
+func (e *Exporter) Stop() {
+	if e.stopCh == nil {
+		return
+	}
+	close(e.stopCh)
+	<-e.doneCh
+	e.stopCh = nil
+	if e.proofSub != nil {
+		e.proofSub.Close()
+	}
+}
+
+// Export runs one sweep: the most recent superblocks (recentWindow deep,
+// so late status transitions are re-exported) and a fresh index.json.
+func (e *Exporter) Export() error {
+	latest, err := e.store.LatestSuperblock()
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	from := uint64(0)
+	if latest.Number >= recentWindow {
+		from = latest.Number - recentWindow + 1
+	}
+	sbs, err := e.store.ListSuperblocks(from, latest.Number)
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]Summary, 0, len(sbs))
+	for _, sb := range sbs {
+		summary := e.summarize(sb)
+		summaries = append(summaries, summary)
+		data, err := json.Marshal(summary)
+		if err != nil {
+			return err
+		}
+		if err := e.sink.Put(fmt.Sprintf("superblocks/%d.json", sb.Number), data); err != nil {
+			return fmt.Errorf("explorer: superblock %d: %w", sb.Number, err)
+		}
+	}
+
+	// Newest first, the order a landing page shows them.
+	for i, j := 0, len(summaries)-1; i < j; i, j = i+1, j-1 {
+		summaries[i], summaries[j] = summaries[j], summaries[i]
+	}
+	data, err := json.Marshal(index{Latest: latest.Number, Recent: summaries})
+	if err != nil {
+		return err
+	}
+	if err := e.sink.Put("index.json", data); err != nil {
+		return fmt.Errorf("explorer: index: %w", err)
+	}
+	return nil
+}
+
+func (e *Exporter) summarize(sb *types.Superblock) Summary {
+	e.mu.Lock()
+	proven := e.proven[sb.Number]
+	e.mu.Unlock()
+	status := ProofPending
+	if proven {
+		status = ProofCompleted
+	}
+	return Summary{
+		Number:      sb.Number,
+		Slot:        sb.Slot,
+		Hash:        sb.Hash,
+		Status:      sb.Status,
+		Chains:      len(sb.L2Blocks),
+		XTs:         len(sb.Decisions),
+		L1TxHash:    sb.L1TxHash,
+		ProofStatus: status,
+	}
+}
@@ -0,0 +1,73 @@
+package explorer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/bus"
+	"github.com/compose-network/publisher/x/prover"
+)
+
+func TestExportWritesSummariesAndIndex(t *testing.T) {
+	superblocks := store.NewMemorySuperblockStore()
+	for n := uint64(1); n <= 3; n++ {
+		require.NoError(t, superblocks.PutSuperblock(&types.Superblock{
+			Number: n,
+			Slot:   types.Slot(n),
+			Hash:   types.Hash{byte(n)},
+			Status: types.SuperblockStatusSealed,
+			L2Blocks: []*types.L2Block{
+				{ChainID: 1, Number: n},
+			},
+		}))
+	}
+
+	dir := t.TempDir()
+	exporter := NewExporter(zap.NewNop(), superblocks, NewFileSink(dir), Config{})
+
+	eventBus := bus.New(zap.NewNop())
+	exporter.SetBus(eventBus)
+	eventBus.Publish(bus.TopicProofCompleted, &prover.ProofJobInput{SuperblockNumber: 2})
+	require.Eventually(t, func() bool {
+		exporter.mu.Lock()
+		defer exporter.mu.Unlock()
+		return exporter.proven[2]
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, exporter.Export())
+
+	var summary Summary
+	content, err := os.ReadFile(filepath.Join(dir, "superblocks", "2.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(content, &summary))
+	require.Equal(t, uint64(2), summary.Number)
+	require.Equal(t, 1, summary.Chains)
+	require.Equal(t, ProofCompleted, summary.ProofStatus)
+
+	content, err = os.ReadFile(filepath.Join(dir, "index.json"))
+	require.NoError(t, err)
+	var idx index
+	require.NoError(t, json.Unmarshal(content, &idx))
+	require.Equal(t, uint64(3), idx.Latest)
+	require.Len(t, idx.Recent, 3)
+	require.Equal(t, uint64(3), idx.Recent[0].Number) // newest first
+	require.Equal(t, ProofPending, idx.Recent[0].ProofStatus)
+
+	exporter.Stop() // no-op when never started
+}
+
+func TestExportEmptyStoreIsNoop(t *testing.T) {
+	dir := t.TempDir()
+	exporter := NewExporter(zap.NewNop(), store.NewMemorySuperblockStore(), NewFileSink(dir), Config{})
+	require.NoError(t, exporter.Export())
+	_, err := os.Stat(filepath.Join(dir, "index.json"))
+	require.True(t, os.IsNotExist(err))
+}
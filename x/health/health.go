@@ -0,0 +1,259 @@
+// Package health scores per-chain reliability from observed faults —
+// missed L2 blocks, late votes, invalid messages — and optionally
+// quarantines chains whose score drops below a threshold, excluding them
+// from new XT participation until they recover or an operator overrides
+// the decision.
+package health
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// Fault classifies a reliability incident attributed to a chain.
+type Fault string
+
+// The fault kinds the tracker scores. Severities differ: a missed block
+// stalls the whole slot, a late vote delays one XT, an invalid message is
+// usually a sequencer bug.
+const (
+	FaultMissedBlock    Fault = "missed_block"
+	FaultLateVote       Fault = "late_vote"
+	FaultInvalidMessage Fault = "invalid_message"
+)
+
+// severity is how much of a single observation each fault erases from the
+// score; 1 means the observation counts as fully failed.
+var severity = map[Fault]float64{
+	FaultMissedBlock:    1.0,
+	FaultLateVote:       0.4,
+	FaultInvalidMessage: 0.7,
+}
+
+// Override is an operator decision that pins a chain's quarantine state
+// regardless of its score.
+type Override string
+
+const (
+	// OverrideNone leaves the quarantine decision to the score.
+	OverrideNone Override = ""
+	// OverrideAllow keeps the chain participating whatever its score.
+	OverrideAllow Override = "allow"
+	// OverrideQuarantine excludes the chain whatever its score.
+	OverrideQuarantine Override = "quarantine"
+)
+
+// Config tunes the scoring and quarantine thresholds. Zero values take the
+// defaults below.
+type Config struct {
+	// QuarantineBelow is the score under which a chain is auto-quarantined.
+	QuarantineBelow float64 `yaml:"QuarantineBelow"`
+	// ReinstateAbove is the score a quarantined chain must recover to
+	// before it is readmitted; keeping it above QuarantineBelow stops a
+	// chain on the edge from flapping in and out.
+	ReinstateAbove float64 `yaml:"ReinstateAbove"`
+	// Weight is how much one observation moves the score (exponential
+	// moving average coefficient in (0, 1]).
+	Weight float64 `yaml:"Weight"`
+	// AutoQuarantine enables acting on the score. When false the tracker
+	// only reports; nothing is excluded without an operator override.
+	AutoQuarantine bool `yaml:"AutoQuarantine"`
+}
+
+// Defaults for Config's zero values.
+const (
+	DefaultQuarantineBelow = 0.5
+	DefaultReinstateAbove  = 0.8
+	DefaultWeight          = 0.1
+)
+
+func (c Config) withDefaults() Config {
+	if c.QuarantineBelow == 0 {
+		c.QuarantineBelow = DefaultQuarantineBelow
+	}
+	if c.ReinstateAbove == 0 {
+		c.ReinstateAbove = DefaultReinstateAbove
+	}
+	if c.Weight == 0 {
+		c.Weight = DefaultWeight
+	}
+	return c
+}
+
+// ChainHealth is one chain's reliability snapshot.
+type ChainHealth struct {
+	ChainID       types.ChainID    `json:"chain_id"`
+	Score         float64          `json:"score"`
+	Quarantined   bool             `json:"quarantined"`
+	Override      Override         `json:"override,omitempty"`
+	Faults        map[Fault]uint64 `json:"faults,omitempty"`
+	HealthyEvents uint64           `json:"healthy_events"`
+	LastEventAt   time.Time        `json:"last_event_at"`
+}
+
+type chainRecord struct {
+	score       float64
+	faults      map[Fault]uint64
+	healthy     uint64
+	quarantined bool
+	override    Override
+	lastEvent   time.Time
+}
+
+// Tracker maintains per-chain health scores. Scores start at 1 and move as
+// an exponential average over observations: healthy events pull toward 1,
+// faults pull down by their severity, so one bad slot fades once the chain
+// behaves again.
+type Tracker struct {
+	logger *zap.Logger
+	cfg    Config
+
+	mu     sync.RWMutex
+	chains map[types.ChainID]*chainRecord
+}
+
+// NewTracker creates a tracker with cfg's thresholds (zero fields take the
+// package defaults).
+func NewTracker(logger *zap.Logger, cfg Config) *Tracker {
+	return &Tracker{
+		logger: logger.Named("health"),
+		cfg:    cfg.withDefaults(),
+		chains: make(map[types.ChainID]*chainRecord),
+	}
+}
+
+func (t *Tracker) record(chainID types.ChainID) *chainRecord {
+	rec, ok := t.chains[chainID]
+	if !ok {
+		rec = &chainRecord{score: 1, faults: make(map[Fault]uint64)}
+		t.chains[chainID] = rec
+	}
+	return rec
+}
+
+// RecordFault attributes one incident to the chain and re-evaluates its
+// quarantine state. Unknown fault kinds count with full severity.
+func (t *Tracker) RecordFault(chainID types.ChainID, fault Fault) {
+	sev, ok := severity[fault]
+	if !ok {
+		sev = 1
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rec := t.record(chainID)
+	rec.faults[fault]++
+	rec.lastEvent = time.Now()
+	t.observe(chainID, rec, 1-sev)
+}
+
+// RecordHealthy credits the chain with one clean observation — an on-time
+// block or vote — pulling its score back toward 1.
+func (t *Tracker) RecordHealthy(chainID types.ChainID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	rec := t.record(chainID)
+	rec.healthy++
+	rec.lastEvent = time.Now()
+	t.observe(chainID, rec, 1)
+}
+
+// observe folds one outcome in [0, 1] into the score and flips the
+// quarantine flag across the thresholds. Callers hold t.mu.
+func (t *Tracker) observe(chainID types.ChainID, rec *chainRecord, outcome float64) {
+	rec.score = rec.score*(1-t.cfg.Weight) + outcome*t.cfg.Weight
+	switch {
+	case t.cfg.AutoQuarantine && !rec.quarantined && rec.score < t.cfg.QuarantineBelow:
+		rec.quarantined = true
+		t.logger.Warn("chain quarantined on low health score",
+			zap.Uint64("chain_id", uint64(chainID)),
+			zap.Float64("score", rec.score))
+	case rec.quarantined && rec.score > t.cfg.ReinstateAbove:
+		rec.quarantined = false
+		t.logger.Info("chain reinstated after health recovery",
+			zap.Uint64("chain_id", uint64(chainID)),
+			zap.Float64("score", rec.score))
+	}
+}
+
+// Score returns the chain's current health score; chains never observed
+// score 1.
+func (t *Tracker) Score(chainID types.ChainID) float64 {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if rec, ok := t.chains[chainID]; ok {
+		return rec.score
+	}
+	return 1
+}
+
+// Quarantined reports whether the chain is currently excluded from new XT
+// participation. An operator override always wins over the score.
+func (t *Tracker) Quarantined(chainID types.ChainID) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	rec, ok := t.chains[chainID]
+	if !ok {
+		return false
+	}
+	switch rec.override {
+	case OverrideAllow:
+		return false
+	case OverrideQuarantine:
+		return true
+	}
+	return rec.quarantined
+}
+
+// SetOverride pins or releases the chain's quarantine state by operator
+// decision. OverrideNone returns the chain to score-based handling.
+func (t *Tracker) SetOverride(chainID types.ChainID, override Override) error {
+	switch override {
+	case OverrideNone, OverrideAllow, OverrideQuarantine:
+	default:
+		return fmt.Errorf("health: unknown override %q", override)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.record(chainID).override = override
+	t.logger.Info("chain health override set",
+		zap.Uint64("chain_id", uint64(chainID)),
+		zap.String("override", string(override)))
+	return nil
+}
+
+// Snapshot returns every observed chain's health, ordered by chain ID.
+func (t *Tracker) Snapshot() []ChainHealth {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	out := make([]ChainHealth, 0, len(t.chains))
+	for chainID, rec := range t.chains {
+		faults := make(map[Fault]uint64, len(rec.faults))
+		for fault, n := range rec.faults {
+			faults[fault] = n
+		}
+		quarantined := rec.quarantined
+		switch rec.override {
+		case OverrideAllow:
+			quarantined = false
+		case OverrideQuarantine:
+			quarantined = true
+		}
+		out = append(out, ChainHealth{
+			ChainID:       chainID,
+			Score:         rec.score,
+			Quarantined:   quarantined,
+			Override:      rec.override,
+			Faults:        faults,
+			HealthyEvents: rec.healthy,
+			LastEventAt:   rec.lastEvent,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ChainID < out[j].ChainID })
+	return out
+}
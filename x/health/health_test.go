@@ -0,0 +1,67 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestScoreDeclineAndQuarantine(t *testing.T) {
+	tracker := NewTracker(zap.NewNop(), Config{AutoQuarantine: true, Weight: 0.5})
+
+	require.Equal(t, 1.0, tracker.Score(7), "unobserved chains are healthy")
+	require.False(t, tracker.Quarantined(7))
+
+	tracker.RecordFault(7, FaultMissedBlock)
+	tracker.RecordFault(7, FaultMissedBlock)
+	require.True(t, tracker.Quarantined(7), "score %v", tracker.Score(7))
+
+	// Recovery: clean observations pull the score back over the reinstate
+	// threshold, which sits above the quarantine one (hysteresis).
+	for i := 0; i < 5; i++ {
+		tracker.RecordHealthy(7)
+	}
+	require.False(t, tracker.Quarantined(7), "score %v", tracker.Score(7))
+}
+
+func TestLesserFaultsWeighLess(t *testing.T) {
+	tracker := NewTracker(zap.NewNop(), Config{Weight: 0.5})
+	tracker.RecordFault(1, FaultMissedBlock)
+	tracker.RecordFault(2, FaultLateVote)
+	require.Less(t, tracker.Score(1), tracker.Score(2))
+}
+
+func TestOverrideWinsOverScore(t *testing.T) {
+	tracker := NewTracker(zap.NewNop(), Config{AutoQuarantine: true, Weight: 0.5})
+	tracker.RecordFault(7, FaultMissedBlock)
+	tracker.RecordFault(7, FaultMissedBlock)
+	require.True(t, tracker.Quarantined(7))
+
+	require.NoError(t, tracker.SetOverride(7, OverrideAllow))
+	require.False(t, tracker.Quarantined(7))
+
+	require.NoError(t, tracker.SetOverride(7, OverrideNone))
+	require.True(t, tracker.Quarantined(7))
+
+	require.NoError(t, tracker.SetOverride(8, OverrideQuarantine))
+	require.True(t, tracker.Quarantined(8), "healthy chain pinned out")
+
+	require.Error(t, tracker.SetOverride(8, Override("banish")))
+}
+
+func TestSnapshotReflectsOverride(t *testing.T) {
+	tracker := NewTracker(zap.NewNop(), Config{})
+	tracker.RecordHealthy(2)
+	tracker.RecordFault(1, FaultInvalidMessage)
+	require.NoError(t, tracker.SetOverride(1, OverrideQuarantine))
+
+	snap := tracker.Snapshot()
+	require.Len(t, snap, 2)
+	require.Equal(t, uint64(1), uint64(snap[0].ChainID))
+	require.True(t, snap[0].Quarantined)
+	require.Equal(t, OverrideQuarantine, snap[0].Override)
+	require.Equal(t, uint64(1), snap[0].Faults[FaultInvalidMessage])
+	require.False(t, snap[1].Quarantined)
+	require.Equal(t, uint64(1), snap[1].HealthyEvents)
+}
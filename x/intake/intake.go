@@ -0,0 +1,302 @@
+// Package intake is the submission adapter between the transport and the
+// pending XT queue: it validates sequencer-submitted cross-chain
+// transaction requests and turns them into queue entries, including
+// splitting batched submissions into independent entries with per-XT
+// results.
+package intake
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/metrics"
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// Validation errors for submitted requests.
+var (
+	ErrMissingXtID = errors.New("intake: request has no XtID")
+	ErrNoChains    = errors.New("intake: request names no participating chains")
+	// ErrAlreadyDecided rejects a resubmitted XtID that was recently
+	// decided; the caller can fetch the prior outcome with DecisionFor and
+	// replay it instead of opening a second SCP instance.
+	ErrAlreadyDecided = errors.New("intake: XT already decided")
+	// ErrChainNotAllowed rejects a request naming a chain outside the
+	// configured allowlist.
+	ErrChainNotAllowed = errors.New("intake: chain not in allowlist")
+	// ErrChainQuarantined rejects a request naming a chain currently
+	// quarantined by the health tracker.
+	ErrChainQuarantined = errors.New("intake: chain is quarantined")
+)
+
+// decidedCacheLimit bounds the recently-decided cache, matching the
+// coordinator's recent-instance window.
+const decidedCacheLimit = 256
+
+// Adapter accepts XT submissions and feeds the pending queue.
+type Adapter struct {
+	logger *zap.Logger
+	queue  store.XTQueue
+
+	mu           sync.Mutex
+	decided      map[types.XtID]types.Decision
+	decidedOrder []types.XtID
+
+	allowMu sync.RWMutex
+	// allowed is nil until an allowlist is configured; nil admits every
+	// chain, preserving the pre-allowlist behavior.
+	allowed    map[types.ChainID]struct{}
+	fetchAllow func() []types.ChainID
+	allowStop  chan struct{}
+	allowDone  chan struct{}
+	// quarantined, when set, rejects requests naming a chain the health
+	// tracker has excluded from new XT participation.
+	quarantined func(types.ChainID) bool
+}
+
+// NewAdapter creates an adapter enqueueing into queue.
+func NewAdapter(logger *zap.Logger, queue store.XTQueue) *Adapter {
+	return &Adapter{
+		logger:  logger.Named("intake"),
+		queue:   queue,
+		decided: make(map[types.XtID]types.Decision),
+	}
+}
+
+// RecordDecision remembers an XT outcome so client retries of the same
+// XtID are short-circuited instead of starting a fresh round in a later
+// slot, and drops the decided request from the pending queue. Wire it to
+// the coordinator's DecidedFunc. The cache is bounded; the oldest entries
+// fall out first.
+func (a *Adapter) RecordDecision(decision types.Decision) {
+	if err := a.queue.Remove(decision.XtID); err != nil {
+		a.logger.Warn("decided XT not removed from queue",
+			zap.String("xt_id", decision.XtID.String()), zap.Error(err))
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.decided[decision.XtID]; !ok {
+		a.decidedOrder = append(a.decidedOrder, decision.XtID)
+	}
+	a.decided[decision.XtID] = decision
+	if len(a.decidedOrder) > decidedCacheLimit {
+		delete(a.decided, a.decidedOrder[0])
+		a.decidedOrder = a.decidedOrder[1:]
+	}
+}
+
+// Reconcile replays the persistent state after a restart: queued requests
+// whose XtID already has a ledger decision are removed (and the decision
+// cached for retry short-circuiting), and requests older than maxAge are
+// dead-lettered as expired instead of being revived into a round nobody is
+// waiting on. A zero maxAge disables expiry.
+func (a *Adapter) Reconcile(ledger store.DecisionLedger, maxAge time.Duration) (decided, expired int, err error) {
+	queued, err := a.queue.List()
+	if err != nil {
+		return 0, 0, fmt.Errorf("intake: reconcile: %w", err)
+	}
+	now := time.Now()
+	for _, req := range queued {
+		record, err := ledger.LatestDecision(req.XtID)
+		switch {
+		case err == nil:
+			a.RecordDecision(record.Decision)
+			decided++
+			continue
+		case !errors.Is(err, store.ErrNotFound):
+			return decided, expired, fmt.Errorf("intake: reconcile %s: %w", req.XtID, err)
+		}
+		if maxAge > 0 && req.ReceivedAt > 0 {
+			received := time.Unix(int64(req.ReceivedAt), 0)
+			if now.Sub(received) > maxAge {
+				if err := a.queue.DeadLetter(req, "expired before restart reconcile"); err != nil {
+					return decided, expired, fmt.Errorf("intake: reconcile %s: %w", req.XtID, err)
+				}
+				expired++
+			}
+		}
+	}
+	if decided > 0 || expired > 0 {
+		a.logger.Info("reconciled pending XTs after restart",
+			zap.Int("already_decided", decided),
+			zap.Int("expired", expired))
+	}
+	return decided, expired, nil
+}
+
+// DecisionFor returns the cached decision for a recently-decided XtID.
+func (a *Adapter) DecisionFor(xtID types.XtID) (types.Decision, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	decision, ok := a.decided[xtID]
+	return decision, ok
+}
+
+// SubmitXTRequest validates and enqueues one request. Duplicates of a
+// still-queued XtID surface store.ErrDuplicate; resubmissions of a
+// recently-decided XtID surface ErrAlreadyDecided. Rejections are counted
+// per chain and reason.
+func (a *Adapter) SubmitXTRequest(req *types.XTRequest) error {
+	err := a.submit(req)
+	if err != nil {
+		metrics.RecordIntakeRejected(uint64(req.SourceChain), RejectReason(err))
+	}
+	return err
+}
+
+func (a *Adapter) submit(req *types.XTRequest) error {
+	if err := a.validate(req); err != nil {
+		return err
+	}
+	if _, ok := a.DecisionFor(req.XtID); ok {
+		return fmt.Errorf("%w: %s", ErrAlreadyDecided, req.XtID)
+	}
+	if err := a.queue.Enqueue(req); err != nil {
+		return fmt.Errorf("intake: enqueue %s: %w", req.XtID, err)
+	}
+	a.logger.Debug("XT request queued", zap.String("xt_id", req.XtID.String()))
+	return nil
+}
+
+// RejectReason maps a submission error to its metrics label and Nack
+// reason code.
+func RejectReason(err error) string {
+	switch {
+	case errors.Is(err, ErrMissingXtID):
+		return "missing_xt_id"
+	case errors.Is(err, ErrNoChains):
+		return "no_chains"
+	case errors.Is(err, ErrChainNotAllowed):
+		return "chain_not_allowed"
+	case errors.Is(err, ErrChainQuarantined):
+		return "chain_quarantined"
+	case errors.Is(err, ErrAlreadyDecided):
+		return "already_decided"
+	case errors.Is(err, store.ErrDuplicate):
+		return "duplicate"
+	default:
+		return "enqueue_failed"
+	}
+}
+
+// Nack builds the xt_nack payload for a rejected request, for the
+// transport handler to send back to the submitting sequencer.
+func Nack(req *types.XTRequest, err error) protocol.XTNack {
+	return protocol.XTNack{
+		XtID:   req.XtID,
+		Reason: RejectReason(err),
+		Error:  err.Error(),
+	}
+}
+
+// SubmitXTBatch splits a batched submission into separate queue entries.
+// Requests are processed in order and independently: one rejected request
+// does not affect the rest. The ack carries one result per request, in the
+// order submitted.
+func (a *Adapter) SubmitXTBatch(batch protocol.XTBatch) protocol.XTBatchAck {
+	ack := protocol.XTBatchAck{Results: make([]protocol.XTBatchResult, 0, len(batch.Requests))}
+	for i := range batch.Requests {
+		req := batch.Requests[i]
+		result := protocol.XTBatchResult{XtID: req.XtID, Queued: true}
+		if err := a.SubmitXTRequest(&req); err != nil {
+			result.Queued = false
+			result.Error = err.Error()
+		}
+		ack.Results = append(ack.Results, result)
+	}
+	return ack
+}
+
+func (a *Adapter) validate(req *types.XTRequest) error {
+	if req.XtID.IsZero() {
+		return ErrMissingXtID
+	}
+	if len(req.Chains) == 0 {
+		return ErrNoChains
+	}
+	a.allowMu.RLock()
+	defer a.allowMu.RUnlock()
+	for _, chain := range req.Chains {
+		if a.allowed != nil {
+			if _, ok := a.allowed[chain]; !ok {
+				return fmt.Errorf("%w: %d", ErrChainNotAllowed, chain)
+			}
+		}
+		if a.quarantined != nil && a.quarantined(chain) {
+			return fmt.Errorf("%w: %d", ErrChainQuarantined, chain)
+		}
+	}
+	return nil
+}
+
+// SetQuarantineCheck rejects submissions naming a chain the check reports
+// as quarantined. Wire it to the health tracker's Quarantined method.
+func (a *Adapter) SetQuarantineCheck(quarantined func(types.ChainID) bool) {
+	a.allowMu.Lock()
+	a.quarantined = quarantined
+	a.allowMu.Unlock()
+}
+
+// SetChainAllowlist restricts submissions to chains fetch returns. The
+// list is built immediately and, with a positive interval, refreshed
+// periodically so newly activated rollups are admitted without a restart.
+// Registry-change subscriptions can force an immediate rebuild through
+// RefreshChainAllowlist.
+func (a *Adapter) SetChainAllowlist(fetch func() []types.ChainID, refreshEvery time.Duration) {
+	a.allowMu.Lock()
+	a.fetchAllow = fetch
+	a.allowMu.Unlock()
+	a.RefreshChainAllowlist()
+
+	if refreshEvery <= 0 || a.allowStop != nil {
+		return
+	}
+	a.allowStop = make(chan struct{})
+	a.allowDone = make(chan struct{})
+	go func() {
+		defer close(a.allowDone)
+		ticker := time.NewTicker(refreshEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-a.allowStop:
+				return
+			case <-ticker.C:
+				a.RefreshChainAllowlist()
+			}
+		}
+	}()
+}
+
+// RefreshChainAllowlist rebuilds the allowlist from the configured fetch
+// function, for wiring to registry-change notifications.
+func (a *Adapter) RefreshChainAllowlist() {
+	a.allowMu.Lock()
+	defer a.allowMu.Unlock()
+	if a.fetchAllow == nil {
+		return
+	}
+	chains := a.fetchAllow()
+	allowed := make(map[types.ChainID]struct{}, len(chains))
+	for _, chain := range chains {
+		allowed[chain] = struct{}{}
+	}
+	a.allowed = allowed
+	a.logger.Debug("chain allowlist refreshed", zap.Int("chains", len(allowed)))
+}
+
+// Close stops the allowlist refresh loop, if one is running.
+func (a *Adapter) Close() {
+	if a.allowStop == nil {
+		return
+	}
+	close(a.allowStop)
+	<-a.allowDone
+	a.allowStop = nil
+}
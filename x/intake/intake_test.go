@@ -0,0 +1,183 @@
+package intake
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+func TestSubmitXTRequestValidates(t *testing.T) {
+	a := NewAdapter(zap.NewNop(), store.NewMemoryXTQueue())
+
+	require.ErrorIs(t, a.SubmitXTRequest(&types.XTRequest{Chains: []types.ChainID{1}}), ErrMissingXtID)
+	require.ErrorIs(t, a.SubmitXTRequest(&types.XTRequest{XtID: types.Hash{1}}), ErrNoChains)
+
+	req := &types.XTRequest{XtID: types.Hash{1}, Chains: []types.ChainID{1, 2}}
+	require.NoError(t, a.SubmitXTRequest(req))
+	require.ErrorIs(t, a.SubmitXTRequest(req), store.ErrDuplicate)
+}
+
+func TestResubmittedDecidedXTIsShortCircuited(t *testing.T) {
+	a := NewAdapter(zap.NewNop(), store.NewMemoryXTQueue())
+
+	outcome := types.Decision{XtID: types.Hash{9}, Committed: true, Slot: 4}
+	a.RecordDecision(outcome)
+
+	err := a.SubmitXTRequest(&types.XTRequest{XtID: types.Hash{9}, Chains: []types.ChainID{1}})
+	require.ErrorIs(t, err, ErrAlreadyDecided)
+
+	cached, ok := a.DecisionFor(types.Hash{9})
+	require.True(t, ok)
+	require.Equal(t, outcome, cached)
+
+	// Undecided XtIDs are unaffected.
+	require.NoError(t, a.SubmitXTRequest(&types.XTRequest{XtID: types.Hash{10}, Chains: []types.ChainID{1}}))
+}
+
+func TestNackCarriesReasonCode(t *testing.T) {
+	a := NewAdapter(zap.NewNop(), store.NewMemoryXTQueue())
+
+	req := &types.XTRequest{XtID: types.Hash{7}, SourceChain: 3, Chains: []types.ChainID{3}}
+	require.NoError(t, a.SubmitXTRequest(req))
+	err := a.SubmitXTRequest(req)
+	require.Error(t, err)
+
+	nack := Nack(req, err)
+	require.Equal(t, types.XtID(types.Hash{7}), nack.XtID)
+	require.Equal(t, "duplicate", nack.Reason)
+	require.NotEmpty(t, nack.Error)
+
+	require.Equal(t, "missing_xt_id", RejectReason(ErrMissingXtID))
+	require.Equal(t, "chain_not_allowed", RejectReason(ErrChainNotAllowed))
+	require.Equal(t, "enqueue_failed", RejectReason(errors.New("disk full")))
+}
+
+func TestChainAllowlistHotRefresh(t *testing.T) {
+	a := NewAdapter(zap.NewNop(), store.NewMemoryXTQueue())
+	defer a.Close()
+
+	var (
+		mu     sync.Mutex
+		chains = []types.ChainID{1}
+	)
+	a.SetChainAllowlist(func() []types.ChainID {
+		mu.Lock()
+		defer mu.Unlock()
+		return chains
+	}, 0)
+
+	require.NoError(t, a.SubmitXTRequest(&types.XTRequest{XtID: types.Hash{1}, Chains: []types.ChainID{1}}))
+	err := a.SubmitXTRequest(&types.XTRequest{XtID: types.Hash{2}, Chains: []types.ChainID{1, 2}})
+	require.ErrorIs(t, err, ErrChainNotAllowed)
+
+	// A newly activated rollup is admitted after a refresh.
+	mu.Lock()
+	chains = []types.ChainID{1, 2}
+	mu.Unlock()
+	a.RefreshChainAllowlist()
+	require.NoError(t, a.SubmitXTRequest(&types.XTRequest{XtID: types.Hash{2}, Chains: []types.ChainID{1, 2}}))
+}
+
+func TestReconcileAfterRestart(t *testing.T) {
+	queue := store.NewMemoryXTQueue()
+	ledger := store.NewMemoryDecisionLedger()
+	a := NewAdapter(zap.NewNop(), queue)
+
+	now := uint64(time.Now().Unix())
+	decidedReq := &types.XTRequest{XtID: types.Hash{1}, Chains: []types.ChainID{1}, ReceivedAt: now}
+	staleReq := &types.XTRequest{XtID: types.Hash{2}, Chains: []types.ChainID{1}, ReceivedAt: now - 3600}
+	freshReq := &types.XTRequest{XtID: types.Hash{3}, Chains: []types.ChainID{1}, ReceivedAt: now}
+	for _, req := range []*types.XTRequest{decidedReq, staleReq, freshReq} {
+		require.NoError(t, queue.Enqueue(req))
+	}
+	_, err := ledger.AppendDecision(types.Decision{XtID: types.Hash{1}, Committed: true, Slot: 2}, 7)
+	require.NoError(t, err)
+
+	decided, expired, err := a.Reconcile(ledger, 10*time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 1, decided)
+	require.Equal(t, 1, expired)
+
+	queued, err := queue.List()
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	require.Equal(t, types.XtID(types.Hash{3}), queued[0].XtID)
+
+	// The ledger decision is now cached, so a client retry short-circuits.
+	require.ErrorIs(t, a.SubmitXTRequest(decidedReq), ErrAlreadyDecided)
+
+	letters, err := queue.DeadLetters()
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+}
+
+func TestDecidedXTIsRemovedFromQueue(t *testing.T) {
+	queue := store.NewMemoryXTQueue()
+	a := NewAdapter(zap.NewNop(), queue)
+
+	req := &types.XTRequest{XtID: types.Hash{5}, Chains: []types.ChainID{1}}
+	require.NoError(t, a.SubmitXTRequest(req))
+	a.RecordDecision(types.Decision{XtID: types.Hash{5}, Committed: false, AbortReason: types.AbortReasonTimeout})
+
+	queued, err := queue.List()
+	require.NoError(t, err)
+	require.Empty(t, queued)
+}
+
+func TestDecidedCacheIsBounded(t *testing.T) {
+	a := NewAdapter(zap.NewNop(), store.NewMemoryXTQueue())
+	for i := 0; i < decidedCacheLimit+1; i++ {
+		a.RecordDecision(types.Decision{XtID: types.Hash{byte(i), byte(i >> 8)}})
+	}
+	_, ok := a.DecisionFor(types.Hash{0, 0})
+	require.False(t, ok, "oldest entry evicted")
+	_, ok = a.DecisionFor(types.Hash{1, 0})
+	require.True(t, ok)
+}
+
+func TestSubmitXTBatchSplitsIntoQueueEntries(t *testing.T) {
+	queue := store.NewMemoryXTQueue()
+	a := NewAdapter(zap.NewNop(), queue)
+
+	batch := protocol.XTBatch{Requests: []types.XTRequest{
+		{XtID: types.Hash{1}, Chains: []types.ChainID{1}},
+		{Chains: []types.ChainID{2}}, // missing XtID
+		{XtID: types.Hash{3}, Chains: []types.ChainID{1, 2}},
+		{XtID: types.Hash{1}, Chains: []types.ChainID{1}}, // duplicate
+	}}
+	ack := a.SubmitXTBatch(batch)
+	require.Len(t, ack.Results, 4)
+
+	require.True(t, ack.Results[0].Queued)
+	require.False(t, ack.Results[1].Queued)
+	require.NotEmpty(t, ack.Results[1].Error)
+	require.True(t, ack.Results[2].Queued)
+	require.False(t, ack.Results[3].Queued)
+	require.Equal(t, types.Hash{3}, ack.Results[2].XtID)
+
+	queued, err := queue.List()
+	require.NoError(t, err)
+	require.Len(t, queued, 2)
+	require.Equal(t, types.XtID(types.Hash{1}), queued[0].XtID)
+	require.Equal(t, types.XtID(types.Hash{3}), queued[1].XtID)
+}
+
+func TestQuarantinedChainIsRejected(t *testing.T) {
+	a := NewAdapter(zap.NewNop(), store.NewMemoryXTQueue())
+	quarantined := map[types.ChainID]bool{2: true}
+	a.SetQuarantineCheck(func(chain types.ChainID) bool { return quarantined[chain] })
+
+	req := &types.XTRequest{XtID: types.Hash{1}, Chains: []types.ChainID{1, 2}}
+	require.ErrorIs(t, a.SubmitXTRequest(req), ErrChainQuarantined)
+
+	quarantined[2] = false
+	require.NoError(t, a.SubmitXTRequest(req))
+}
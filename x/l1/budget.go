@@ -0,0 +1,131 @@
+package l1
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/metrics"
+	"github.com/compose-network/publisher/store"
+)
+
+// ErrBudgetExhausted is returned by Publish while the daily fee budget is
+// spent; publishing resumes automatically on the next UTC day.
+var ErrBudgetExhausted = errors.New("l1: daily gas budget exhausted")
+
+// BudgetConfig bounds L1 publication spending.
+type BudgetConfig struct {
+	// DailyGas pauses publishing once a UTC day's accumulated gas reaches
+	// it. Zero disables the daily cap.
+	DailyGas uint64 `yaml:"DailyGas"`
+	// SuperblockGas flags (but does not block) any single publication
+	// consuming more than this much gas. Zero disables the check.
+	SuperblockGas uint64 `yaml:"SuperblockGas"`
+}
+
+// Budget enforces BudgetConfig against spend accounting persisted in a
+// GasSpendStore, so the cap holds across restarts and gas spikes cannot
+// run away with the fee wallet.
+type Budget struct {
+	logger *zap.Logger
+	cfg    BudgetConfig
+	spend  store.GasSpendStore
+
+	mu        sync.Mutex
+	pausedDay string
+}
+
+// NewBudget creates a budget persisting accounting in spend.
+func NewBudget(logger *zap.Logger, cfg BudgetConfig, spend store.GasSpendStore) *Budget {
+	return &Budget{
+		logger: logger.Named("l1.budget"),
+		cfg:    cfg,
+		spend:  spend,
+	}
+}
+
+func day(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// Allow reports whether a new publication may be sent. A nil Budget allows
+// everything.
+func (b *Budget) Allow() error {
+	if b == nil || b.cfg.DailyGas == 0 {
+		return nil
+	}
+	today := day(time.Now())
+
+	b.mu.Lock()
+	paused := b.pausedDay == today
+	b.mu.Unlock()
+	if paused {
+		return fmt.Errorf("%w (day %s)", ErrBudgetExhausted, today)
+	}
+
+	record, err := b.spend.Spend(today)
+	if errors.Is(err, store.ErrNotFound) {
+		b.setPaused("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("l1: budget lookup: %w", err)
+	}
+	if record.Gas >= b.cfg.DailyGas {
+		b.pause(today, record.Gas)
+		return fmt.Errorf("%w (day %s)", ErrBudgetExhausted, today)
+	}
+	b.setPaused("")
+	return nil
+}
+
+// Record accounts one publication's gas. Call it when the receipt arrives;
+// it raises the daily pause and per-superblock alert as needed.
+func (b *Budget) Record(superblockNumber, gasUsed uint64) {
+	if b == nil || gasUsed == 0 {
+		return
+	}
+	metrics.RecordL1GasSpent(gasUsed)
+	if b.cfg.SuperblockGas > 0 && gasUsed > b.cfg.SuperblockGas {
+		metrics.RecordL1BudgetExceeded("superblock")
+		b.logger.Warn("superblock publication exceeded per-superblock gas budget",
+			zap.Uint64("superblock", superblockNumber),
+			zap.Uint64("gas_used", gasUsed),
+			zap.Uint64("budget", b.cfg.SuperblockGas))
+	}
+
+	today := day(time.Now())
+	record, err := b.spend.AddSpend(today, gasUsed)
+	if err != nil {
+		b.logger.Error("gas spend not persisted", zap.Error(err))
+		return
+	}
+	if b.cfg.DailyGas > 0 && record.Gas >= b.cfg.DailyGas {
+		b.pause(today, record.Gas)
+	}
+}
+
+func (b *Budget) pause(today string, spent uint64) {
+	b.mu.Lock()
+	alreadyPaused := b.pausedDay == today
+	b.pausedDay = today
+	b.mu.Unlock()
+	metrics.SetL1PublishPaused(true)
+	if !alreadyPaused {
+		metrics.RecordL1BudgetExceeded("daily")
+		b.logger.Error("daily L1 gas budget exhausted, publishing paused",
+			zap.String("day", today),
+			zap.Uint64("spent", spent),
+			zap.Uint64("budget", b.cfg.DailyGas))
+	}
+}
+
+func (b *Budget) setPaused(dayKey string) {
+	b.mu.Lock()
+	b.pausedDay = dayKey
+	b.mu.Unlock()
+	metrics.SetL1PublishPaused(dayKey != "")
+}
@@ -0,0 +1,51 @@
+package l1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+func TestDailyBudgetPausesPublishing(t *testing.T) {
+	spend := store.NewMemoryGasSpendStore()
+	budget := NewBudget(zap.NewNop(), BudgetConfig{DailyGas: 100_000}, spend)
+
+	client := &fakeClient{}
+	s := NewSubmitter(zap.NewNop(), client, store.NewMemoryTrackedTxStore())
+	s.SetBudget(budget)
+
+	_, err := s.Publish(context.Background(), &types.Superblock{Number: 1})
+	require.NoError(t, err)
+
+	// Inclusion receipt spends the whole budget: the next publish pauses.
+	require.NoError(t, s.UpdateTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 1, State: types.TxStateIncluded, GasUsed: 120_000,
+	}))
+	_, err = s.Publish(context.Background(), &types.Superblock{Number: 2})
+	require.ErrorIs(t, err, ErrBudgetExhausted)
+	require.Equal(t, 1, client.submitted)
+}
+
+func TestBudgetSurvivesRestart(t *testing.T) {
+	spend := store.NewMemoryGasSpendStore()
+	old := NewBudget(zap.NewNop(), BudgetConfig{DailyGas: 100}, spend)
+	old.Record(1, 150)
+
+	// A new Budget over the same store sees the day already exhausted.
+	fresh := NewBudget(zap.NewNop(), BudgetConfig{DailyGas: 100}, spend)
+	require.ErrorIs(t, fresh.Allow(), ErrBudgetExhausted)
+}
+
+func TestNilAndUnlimitedBudgetsAllow(t *testing.T) {
+	var b *Budget
+	require.NoError(t, b.Allow())
+	b.Record(1, 10)
+
+	unlimited := NewBudget(zap.NewNop(), BudgetConfig{}, store.NewMemoryGasSpendStore())
+	require.NoError(t, unlimited.Allow())
+}
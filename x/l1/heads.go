@@ -0,0 +1,161 @@
+package l1
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// HeadNumberClient is the HTTP fallback surface for following L1 heads:
+// a plain latest-block-number query, available on every endpoint.
+type HeadNumberClient interface {
+	// LatestHead returns the current head block number.
+	LatestHead(ctx context.Context) (uint64, error)
+}
+
+// HeadFeedConfig tunes the head feed's fallback behaviour.
+type HeadFeedConfig struct {
+	// PollInterval is the HTTP polling cadence while the websocket
+	// subscription is down. Zero means 5s.
+	PollInterval time.Duration `yaml:"PollInterval"`
+	// RetryInterval is how long the feed polls before trying the
+	// websocket subscription again. Zero means 30s.
+	RetryInterval time.Duration `yaml:"RetryInterval"`
+}
+
+func (c HeadFeedConfig) withDefaults() HeadFeedConfig {
+	if c.PollInterval <= 0 {
+		c.PollInterval = 5 * time.Second
+	}
+	if c.RetryInterval <= 0 {
+		c.RetryInterval = 30 * time.Second
+	}
+	return c
+}
+
+// HeadFeed is a HeadSource that follows an eth_subscribe-style websocket
+// subscription while it is healthy and degrades to HTTP polling when it
+// is not. A cursor over the last delivered head keeps the switchover
+// seamless: consumers see each head at most once, strictly increasing,
+// whichever transport produced it. The websocket side is retried every
+// RetryInterval, so a recovered endpoint is picked back up at runtime.
+//
+// ws may be nil for deployments without a websocket endpoint; the feed
+// then polls permanently.
+type HeadFeed struct {
+	logger *zap.Logger
+	ws     HeadSource
+	client HeadNumberClient
+	cfg    HeadFeedConfig
+}
+
+// NewHeadFeed creates a head feed over the given transports.
+func NewHeadFeed(logger *zap.Logger, ws HeadSource, client HeadNumberClient, cfg HeadFeedConfig) *HeadFeed {
+	return &HeadFeed{
+		logger: logger.Named("l1-heads"),
+		ws:     ws,
+		client: client,
+		cfg:    cfg.withDefaults(),
+	}
+}
+
+// SubscribeHeads implements HeadSource. The returned channel closes when
+// ctx ends.
+func (f *HeadFeed) SubscribeHeads(ctx context.Context) (<-chan uint64, error) {
+	out := make(chan uint64, 1)
+	go f.run(ctx, out)
+	return out, nil
+}
+
+func (f *HeadFeed) run(ctx context.Context, out chan<- uint64) {
+	defer close(out)
+	var cursor uint64
+	for {
+		if f.ws != nil && !f.stream(ctx, out, &cursor) {
+			return
+		}
+		if !f.poll(ctx, out, &cursor) {
+			return
+		}
+	}
+}
+
+// stream forwards websocket heads until the subscription degrades. It
+// returns true to hand over to polling and false when ctx ended.
+func (f *HeadFeed) stream(ctx context.Context, out chan<- uint64, cursor *uint64) bool {
+	ch, err := f.ws.SubscribeHeads(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		f.logger.Warn("head subscription failed, falling back to HTTP polling",
+			zap.Error(err))
+		return true
+	}
+	f.logger.Info("following L1 heads over websocket subscription")
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case head, ok := <-ch:
+			if !ok {
+				f.logger.Warn("head subscription dropped, falling back to HTTP polling")
+				return true
+			}
+			if !f.deliver(ctx, out, cursor, head) {
+				return false
+			}
+		}
+	}
+}
+
+// poll queries the latest head over HTTP until it is time to retry the
+// websocket subscription. It returns false when ctx ended.
+func (f *HeadFeed) poll(ctx context.Context, out chan<- uint64, cursor *uint64) bool {
+	ticker := time.NewTicker(f.cfg.PollInterval)
+	defer ticker.Stop()
+	var retry <-chan time.Time
+	if f.ws != nil {
+		timer := time.NewTimer(f.cfg.RetryInterval)
+		defer timer.Stop()
+		retry = timer.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-retry:
+			return true
+		case <-ticker.C:
+			reqCtx, cancel := context.WithTimeout(ctx, f.cfg.PollInterval)
+			head, err := f.client.LatestHead(reqCtx)
+			cancel()
+			if err != nil {
+				if ctx.Err() != nil {
+					return false
+				}
+				f.logger.Warn("head poll failed", zap.Error(err))
+				continue
+			}
+			if !f.deliver(ctx, out, cursor, head) {
+				return false
+			}
+		}
+	}
+}
+
+// deliver forwards a head if it advances the cursor, so replays and the
+// transport switchover never repeat a head downstream.
+func (f *HeadFeed) deliver(ctx context.Context, out chan<- uint64, cursor *uint64, head uint64) bool {
+	if head <= *cursor {
+		return true
+	}
+	*cursor = head
+	select {
+	case <-ctx.Done():
+		return false
+	case out <- head:
+		return true
+	}
+}
@@ -0,0 +1,124 @@
+package l1
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeWSHeads hands out a fresh channel per subscription so tests can
+// drop one mid-stream and observe the retry.
+type fakeWSHeads struct {
+	mu         sync.Mutex
+	channels   []chan uint64
+	subscribes int
+}
+
+func (s *fakeWSHeads) SubscribeHeads(context.Context) (<-chan uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := make(chan uint64, 8)
+	s.channels = append(s.channels, ch)
+	s.subscribes++
+	return ch, nil
+}
+
+// current waits for the feed's latest subscription and returns its
+// channel.
+func (s *fakeWSHeads) current(t *testing.T, nth int) chan uint64 {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.subscribes >= nth
+	}, time.Second, time.Millisecond, "waiting for subscription %d", nth)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.channels[len(s.channels)-1]
+}
+
+type fakeHeadNumber struct {
+	mu   sync.Mutex
+	head uint64
+}
+
+func (c *fakeHeadNumber) advance(to uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head = to
+}
+
+func (c *fakeHeadNumber) LatestHead(context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.head, nil
+}
+
+func collectHeads(t *testing.T, heads <-chan uint64, n int) []uint64 {
+	t.Helper()
+	got := make([]uint64, 0, n)
+	for len(got) < n {
+		select {
+		case head := <-heads:
+			got = append(got, head)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out after %v of %d heads", got, n)
+		}
+	}
+	return got
+}
+
+func TestHeadFeedFallsBackToPollingAndRecovers(t *testing.T) {
+	ws := &fakeWSHeads{}
+	httpHeads := &fakeHeadNumber{}
+	feed := NewHeadFeed(zap.NewNop(), ws, httpHeads, HeadFeedConfig{
+		PollInterval: 5 * time.Millisecond, RetryInterval: 20 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	heads, err := feed.SubscribeHeads(ctx)
+	require.NoError(t, err)
+
+	first := ws.current(t, 1)
+	first <- 5
+	require.Equal(t, []uint64{5}, collectHeads(t, heads, 1))
+
+	// Drop the subscription; the feed must keep delivering over HTTP.
+	close(first)
+	httpHeads.advance(6)
+	require.Equal(t, []uint64{6}, collectHeads(t, heads, 1))
+
+	// After RetryInterval the websocket is resubscribed and takes over.
+	ws.current(t, 2) <- 8
+	require.Equal(t, []uint64{8}, collectHeads(t, heads, 1))
+}
+
+func TestHeadFeedCursorSuppressesReplays(t *testing.T) {
+	// Polling only: the same latest head must be delivered once, and a
+	// lower head (reorged or lagging endpoint) not at all.
+	httpHeads := &fakeHeadNumber{}
+	httpHeads.advance(10)
+	feed := NewHeadFeed(zap.NewNop(), nil, httpHeads, HeadFeedConfig{
+		PollInterval: time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	heads, err := feed.SubscribeHeads(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, []uint64{10}, collectHeads(t, heads, 1))
+	httpHeads.advance(9)
+	httpHeads.advance(11)
+	require.Equal(t, []uint64{11}, collectHeads(t, heads, 1))
+	select {
+	case head := <-heads:
+		t.Fatalf("unexpected duplicate head %d", head)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
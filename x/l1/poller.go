@@ -0,0 +1,339 @@
+package l1
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+// Receipt is what the settlement chain reports for a publication
+// transaction once it is included.
+type Receipt struct {
+	// Failed is true when the transaction reverted.
+	Failed bool
+	// GasUsed is the gas the transaction consumed.
+	GasUsed uint64
+	// BlockNumber is the L1 block that included the transaction.
+	BlockNumber uint64
+	// Confirmations is how many blocks have been built on top of it.
+	Confirmations uint64
+}
+
+// ErrReceiptNotFound is returned by ReceiptClient implementations while a
+// transaction is still pending.
+var ErrReceiptNotFound = errors.New("l1: receipt not found")
+
+// ReceiptClient is the receipt-lookup surface the poller needs.
+type ReceiptClient interface {
+	// TxReceipt returns the transaction's receipt, or ErrReceiptNotFound
+	// while it is pending.
+	TxReceipt(ctx context.Context, txHash types.Hash) (*Receipt, error)
+}
+
+// PollerConfig tunes the receipt poller.
+type PollerConfig struct {
+	// FastInterval is the polling cadence while a transaction has no
+	// receipt yet — inclusion is the transition worth catching quickly.
+	// Zero means 2s.
+	FastInterval time.Duration `yaml:"FastInterval"`
+	// Interval is the cadence once a transaction is included, when only
+	// confirmations are accumulating. Zero means 10s. With BlockTime set
+	// the poller spaces these polls by the confirmations still owed
+	// instead.
+	Interval time.Duration `yaml:"Interval"`
+	// BlockTime is the L1 block cadence. When set, a transaction waiting
+	// on N more confirmations is not polled again for N block times —
+	// finality takes minutes and nothing changes in between.
+	BlockTime time.Duration `yaml:"BlockTime"`
+	// ConfirmDepth is the confirmation count at which a transaction moves
+	// to confirmed. Zero means 6.
+	ConfirmDepth uint64 `yaml:"ConfirmDepth"`
+	// FinalizeDepth is the confirmation count at which a transaction is
+	// final and leaves the watch set. Zero means 64.
+	FinalizeDepth uint64 `yaml:"FinalizeDepth"`
+}
+
+func (c PollerConfig) withDefaults() PollerConfig {
+	if c.FastInterval <= 0 {
+		c.FastInterval = 2 * time.Second
+	}
+	if c.Interval <= 0 {
+		c.Interval = 10 * time.Second
+	}
+	if c.ConfirmDepth == 0 {
+		c.ConfirmDepth = 6
+	}
+	if c.FinalizeDepth == 0 {
+		c.FinalizeDepth = 64
+	}
+	return c
+}
+
+// HeadSource delivers new L1 head notifications, typically over a
+// websocket subscription. When the poller has one, included transactions
+// are re-checked on new heads instead of on a timer.
+type HeadSource interface {
+	// SubscribeHeads starts delivering head block numbers until ctx ends.
+	SubscribeHeads(ctx context.Context) (<-chan uint64, error)
+}
+
+// Poller watches submitted publication transactions and advances their
+// tracked state as receipts land and confirmations accumulate. State
+// transitions go through the submitter so persistence, budget accounting
+// and notifications stay in one place.
+type Poller struct {
+	logger    *zap.Logger
+	client    ReceiptClient
+	submitter *Submitter
+	tracked   store.TrackedTxStore
+	cfg       PollerConfig
+
+	mu    sync.Mutex
+	watch map[uint64]*watchedTx
+
+	heads HeadSource
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// watchedTx pairs a tracked transaction with when it is next worth
+// polling.
+type watchedTx struct {
+	tx         *types.TrackedTx
+	nextPollAt time.Time
+}
+
+// NewPoller creates a poller advancing tracked transactions via submitter.
+func NewPoller(logger *zap.Logger, client ReceiptClient, submitter *Submitter, tracked store.TrackedTxStore, cfg PollerConfig) *Poller {
+	return &Poller{
+		logger:    logger.Named("l1-poller"),
+		client:    client,
+		submitter: submitter,
+		tracked:   tracked,
+		cfg:       cfg.withDefaults(),
+		watch:     make(map[uint64]*watchedTx),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Track adds a freshly submitted transaction to the watch set. Wire it to
+// the worker's published callback.
+func (p *Poller) Track(tracked *types.TrackedTx) {
+	cp := *tracked
+	p.mu.Lock()
+	p.watch[cp.SuperblockNumber] = &watchedTx{tx: &cp}
+	p.mu.Unlock()
+}
+
+// SetHeadSource attaches a new-head subscription; included transactions
+// are then re-checked per head instead of per timer tick. Must be called
+// before Start.
+func (p *Poller) SetHeadSource(heads HeadSource) {
+	p.heads = heads
+}
+
+// Resume reloads unfinished transactions from the tracked store into the
+// watch set, so superblocks submitted before a restart keep being polled
+// to finality. Call it before Start. It returns how many transactions were
+// restored.
+func (p *Poller) Resume() (int, error) {
+	all, err := p.tracked.ListTrackedTxs()
+	if err != nil {
+		return 0, err
+	}
+	restored := 0
+	p.mu.Lock()
+	for _, tx := range all {
+		if terminalTxState(tx.State) {
+			continue
+		}
+		cp := *tx
+		p.watch[cp.SuperblockNumber] = &watchedTx{tx: &cp}
+		restored++
+	}
+	p.mu.Unlock()
+	if restored > 0 {
+		p.logger.Info("resumed watching unfinished L1 transactions",
+			zap.Int("count", restored))
+	}
+	return restored, nil
+}
+
+// Start launches the poll loop. Transactions without a receipt are polled
+// at FastInterval; once included they are re-checked on new heads when a
+// HeadSource is attached, or on the spaced schedule confirmationDelay
+// computes.
+func (p *Poller) Start() {
+	go func() {
+		defer close(p.done)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var headCh <-chan uint64
+		if p.heads != nil {
+			ch, err := p.heads.SubscribeHeads(ctx)
+			if err != nil {
+				p.logger.Warn("head subscription unavailable, falling back to timer polling",
+					zap.Error(err))
+			} else {
+				headCh = ch
+			}
+		}
+		ticker := time.NewTicker(p.cfg.FastInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				p.pollDue(false)
+			case _, ok := <-headCh:
+				if !ok {
+					headCh = nil
+					continue
+				}
+				p.pollDue(true)
+			}
+		}
+	}()
+}
+
+// Stop halts the poll loop and waits for it to exit.
+func (p *Poller) Stop() {
+	close(p.stop)
+	<-p.done
+}
+
+// Watching returns how many transactions are currently being polled.
+func (p *Poller) Watching() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.watch)
+}
+
+// pollDue advances every watched transaction whose next poll is due. On a
+// new head, transactions with a known receipt are due regardless of their
+// schedule: the head is exactly the event that changes their confirmation
+// count.
+func (p *Poller) pollDue(newHead bool) {
+	now := time.Now()
+	p.mu.Lock()
+	batch := make([]*watchedTx, 0, len(p.watch))
+	for _, w := range p.watch {
+		if now.Before(w.nextPollAt) && !(newHead && w.tx.State != types.TxStateSubmitted) {
+			continue
+		}
+		batch = append(batch, w)
+	}
+	p.mu.Unlock()
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.cfg.Interval)
+	defer cancel()
+	for _, w := range batch {
+		p.pollOne(ctx, w)
+	}
+}
+
+// pollOne fetches the transaction's receipt, applies any state transition
+// it warrants and reschedules the transaction.
+func (p *Poller) pollOne(ctx context.Context, w *watchedTx) {
+	tx := w.tx
+	receipt, err := p.client.TxReceipt(ctx, tx.TxHash)
+	if err != nil {
+		if !errors.Is(err, ErrReceiptNotFound) {
+			p.logger.Warn("receipt lookup failed",
+				zap.Uint64("superblock", tx.SuperblockNumber),
+				zap.String("tx_hash", tx.TxHash.String()),
+				zap.Error(err))
+		}
+		p.schedule(w, p.cfg.FastInterval)
+		return
+	}
+	defer p.schedule(w, p.confirmationDelay(receipt))
+
+	next := nextTxState(tx.State, receipt, p.cfg)
+	tx.GasUsed = receipt.GasUsed
+	tx.BlockNumber = receipt.BlockNumber
+	tx.Confirmations = receipt.Confirmations
+	if next == tx.State {
+		return
+	}
+	tx.State = next
+	if err := p.submitter.UpdateTrackedTx(tx); err != nil {
+		p.logger.Error("tracked tx update failed",
+			zap.Uint64("superblock", tx.SuperblockNumber), zap.Error(err))
+		return
+	}
+	p.logger.Info("L1 transaction advanced",
+		zap.Uint64("superblock", tx.SuperblockNumber),
+		zap.String("state", string(next)),
+		zap.Uint64("confirmations", receipt.Confirmations))
+	if terminalTxState(next) {
+		p.mu.Lock()
+		delete(p.watch, tx.SuperblockNumber)
+		p.mu.Unlock()
+	}
+}
+
+// schedule sets when the transaction is next worth polling.
+func (p *Poller) schedule(w *watchedTx, delay time.Duration) {
+	p.mu.Lock()
+	w.nextPollAt = time.Now().Add(delay)
+	p.mu.Unlock()
+}
+
+// confirmationDelay spaces polls for an included transaction. With
+// BlockTime set, a transaction owed N more confirmations before its next
+// state change is left alone for N block times; without it, the fixed
+// Interval applies.
+func (p *Poller) confirmationDelay(receipt *Receipt) time.Duration {
+	if p.cfg.BlockTime <= 0 {
+		return p.cfg.Interval
+	}
+	target := p.cfg.ConfirmDepth
+	if receipt.Confirmations >= p.cfg.ConfirmDepth {
+		target = p.cfg.FinalizeDepth
+	}
+	remaining := uint64(1)
+	if target > receipt.Confirmations {
+		remaining = target - receipt.Confirmations
+	}
+	return p.cfg.BlockTime * time.Duration(remaining)
+}
+
+// nextTxState derives the state a receipt moves a transaction to; it never
+// moves backwards.
+func nextTxState(current types.TransactionState, receipt *Receipt, cfg PollerConfig) types.TransactionState {
+	if receipt.Failed {
+		return types.TxStateFailed
+	}
+	switch {
+	case receipt.Confirmations >= cfg.FinalizeDepth:
+		return types.TxStateFinalized
+	case receipt.Confirmations >= cfg.ConfirmDepth:
+		if current == types.TxStateFinalized {
+			return current
+		}
+		return types.TxStateConfirmed
+	default:
+		if current == types.TxStateSubmitted {
+			return types.TxStateIncluded
+		}
+		return current
+	}
+}
+
+// terminalTxState reports whether a state ends the watch.
+func terminalTxState(state types.TransactionState) bool {
+	return state == types.TxStateFinalized || state == types.TxStateFailed
+}
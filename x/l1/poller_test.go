@@ -0,0 +1,228 @@
+package l1
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+type fakeReceiptClient struct {
+	mu       sync.Mutex
+	receipts map[types.Hash]*Receipt
+	lookups  int
+}
+
+func (c *fakeReceiptClient) set(hash types.Hash, receipt *Receipt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.receipts == nil {
+		c.receipts = make(map[types.Hash]*Receipt)
+	}
+	c.receipts[hash] = receipt
+}
+
+func (c *fakeReceiptClient) lookupCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookups
+}
+
+func (c *fakeReceiptClient) TxReceipt(_ context.Context, hash types.Hash) (*Receipt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lookups++
+	receipt, ok := c.receipts[hash]
+	if !ok {
+		return nil, ErrReceiptNotFound
+	}
+	cp := *receipt
+	return &cp, nil
+}
+
+func TestPollerAdvancesToFinality(t *testing.T) {
+	tracked := store.NewMemoryTrackedTxStore()
+	submitter := NewSubmitter(zap.NewNop(), &fakeClient{}, tracked)
+	receipts := &fakeReceiptClient{}
+	poller := NewPoller(zap.NewNop(), receipts, submitter, tracked, PollerConfig{
+		FastInterval: 5 * time.Millisecond, Interval: 5 * time.Millisecond,
+		ConfirmDepth: 2, FinalizeDepth: 4,
+	})
+
+	hash := types.Hash{0xaa}
+	poller.Track(&types.TrackedTx{SuperblockNumber: 1, TxHash: hash, State: types.TxStateSubmitted})
+	poller.Start()
+	defer poller.Stop()
+
+	waitForState := func(state types.TransactionState) {
+		require.Eventually(t, func() bool {
+			tx, err := tracked.GetTrackedTx(1)
+			return err == nil && tx.State == state
+		}, time.Second, time.Millisecond, "waiting for %s", state)
+	}
+
+	receipts.set(hash, &Receipt{GasUsed: 21000, BlockNumber: 100})
+	waitForState(types.TxStateIncluded)
+	receipts.set(hash, &Receipt{GasUsed: 21000, BlockNumber: 100, Confirmations: 2})
+	waitForState(types.TxStateConfirmed)
+	receipts.set(hash, &Receipt{GasUsed: 21000, BlockNumber: 100, Confirmations: 4})
+	waitForState(types.TxStateFinalized)
+
+	require.Eventually(t, func() bool { return poller.Watching() == 0 },
+		time.Second, time.Millisecond, "finalized txs leave the watch set")
+}
+
+func TestPollerAccountsGasOnConfirmationJump(t *testing.T) {
+	tracked := store.NewMemoryTrackedTxStore()
+	spend := store.NewMemoryGasSpendStore()
+	submitter := NewSubmitter(zap.NewNop(), &fakeClient{}, tracked)
+	submitter.SetBudget(NewBudget(zap.NewNop(), BudgetConfig{DailyGas: 100_000}, spend))
+
+	// The first observed receipt already has ConfirmDepth confirmations —
+	// e.g. after a restart and Resume — so the transaction jumps
+	// Submitted -> Confirmed without ever being Included.
+	receipts := &fakeReceiptClient{}
+	hash := types.Hash{0xee}
+	receipts.set(hash, &Receipt{GasUsed: 21000, BlockNumber: 100, Confirmations: 2})
+
+	poller := NewPoller(zap.NewNop(), receipts, submitter, tracked, PollerConfig{
+		FastInterval: 5 * time.Millisecond, Interval: 5 * time.Millisecond,
+		ConfirmDepth: 2, FinalizeDepth: 4,
+	})
+	poller.Track(&types.TrackedTx{SuperblockNumber: 8, TxHash: hash, State: types.TxStateSubmitted})
+	poller.Start()
+	defer poller.Stop()
+
+	require.Eventually(t, func() bool {
+		tx, err := tracked.GetTrackedTx(8)
+		return err == nil && tx.State == types.TxStateConfirmed
+	}, time.Second, time.Millisecond)
+	record, err := spend.Spend(day(time.Now()))
+	require.NoError(t, err)
+	require.Equal(t, uint64(21000), record.Gas, "gas is budgeted despite skipping Included")
+
+	// Finalizing afterwards must not double-count the same gas.
+	receipts.set(hash, &Receipt{GasUsed: 21000, BlockNumber: 100, Confirmations: 4})
+	require.Eventually(t, func() bool {
+		tx, err := tracked.GetTrackedTx(8)
+		return err == nil && tx.State == types.TxStateFinalized
+	}, time.Second, time.Millisecond)
+	record, err = spend.Spend(day(time.Now()))
+	require.NoError(t, err)
+	require.Equal(t, uint64(21000), record.Gas)
+}
+
+func TestPollerResumeRestoresUnfinished(t *testing.T) {
+	tracked := store.NewMemoryTrackedTxStore()
+	require.NoError(t, tracked.PutTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 1, TxHash: types.Hash{1}, State: types.TxStateSubmitted}))
+	require.NoError(t, tracked.PutTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 2, TxHash: types.Hash{2}, State: types.TxStateConfirmed}))
+	require.NoError(t, tracked.PutTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 3, TxHash: types.Hash{3}, State: types.TxStateFinalized}))
+	require.NoError(t, tracked.PutTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 4, TxHash: types.Hash{4}, State: types.TxStateFailed}))
+
+	submitter := NewSubmitter(zap.NewNop(), &fakeClient{}, tracked)
+	poller := NewPoller(zap.NewNop(), &fakeReceiptClient{}, submitter, tracked, PollerConfig{})
+	restored, err := poller.Resume()
+	require.NoError(t, err)
+	require.Equal(t, 2, restored, "only unfinished txs are watched again")
+	require.Equal(t, 2, poller.Watching())
+}
+
+func TestPollerMarksRevertedTxFailed(t *testing.T) {
+	tracked := store.NewMemoryTrackedTxStore()
+	submitter := NewSubmitter(zap.NewNop(), &fakeClient{}, tracked)
+	receipts := &fakeReceiptClient{}
+	hash := types.Hash{0xbb}
+	receipts.set(hash, &Receipt{Failed: true, BlockNumber: 7})
+
+	poller := NewPoller(zap.NewNop(), receipts, submitter, tracked, PollerConfig{
+		FastInterval: 5 * time.Millisecond, Interval: 5 * time.Millisecond,
+	})
+	poller.Track(&types.TrackedTx{SuperblockNumber: 9, TxHash: hash, State: types.TxStateSubmitted})
+	poller.Start()
+	defer poller.Stop()
+
+	require.Eventually(t, func() bool {
+		tx, err := tracked.GetTrackedTx(9)
+		return err == nil && tx.State == types.TxStateFailed
+	}, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return poller.Watching() == 0 },
+		time.Second, time.Millisecond)
+}
+
+func TestPollerSpacesConfirmationPollsByBlockTime(t *testing.T) {
+	tracked := store.NewMemoryTrackedTxStore()
+	submitter := NewSubmitter(zap.NewNop(), &fakeClient{}, tracked)
+	receipts := &fakeReceiptClient{}
+	hash := types.Hash{0xcc}
+	receipts.set(hash, &Receipt{BlockNumber: 50})
+
+	// An hour per owed confirmation: after the first receipt lands, the
+	// timer must leave the transaction alone for the rest of the test.
+	poller := NewPoller(zap.NewNop(), receipts, submitter, tracked, PollerConfig{
+		FastInterval: time.Millisecond, BlockTime: time.Hour,
+		ConfirmDepth: 2, FinalizeDepth: 4,
+	})
+	poller.Track(&types.TrackedTx{SuperblockNumber: 5, TxHash: hash, State: types.TxStateSubmitted})
+	poller.Start()
+	defer poller.Stop()
+
+	require.Eventually(t, func() bool {
+		tx, err := tracked.GetTrackedTx(5)
+		return err == nil && tx.State == types.TxStateIncluded
+	}, time.Second, time.Millisecond)
+
+	seen := receipts.lookupCount()
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, seen, receipts.lookupCount(),
+		"included tx must not be polled again within the block-time spacing")
+}
+
+type fakeHeadSource struct {
+	heads chan uint64
+}
+
+func (s *fakeHeadSource) SubscribeHeads(context.Context) (<-chan uint64, error) {
+	return s.heads, nil
+}
+
+func TestPollerRechecksIncludedOnNewHead(t *testing.T) {
+	tracked := store.NewMemoryTrackedTxStore()
+	submitter := NewSubmitter(zap.NewNop(), &fakeClient{}, tracked)
+	receipts := &fakeReceiptClient{}
+	hash := types.Hash{0xdd}
+	receipts.set(hash, &Receipt{BlockNumber: 60})
+
+	heads := &fakeHeadSource{heads: make(chan uint64, 1)}
+	poller := NewPoller(zap.NewNop(), receipts, submitter, tracked, PollerConfig{
+		FastInterval: time.Millisecond, BlockTime: time.Hour,
+		ConfirmDepth: 2, FinalizeDepth: 4,
+	})
+	poller.SetHeadSource(heads)
+	poller.Track(&types.TrackedTx{SuperblockNumber: 6, TxHash: hash, State: types.TxStateSubmitted})
+	poller.Start()
+	defer poller.Stop()
+
+	require.Eventually(t, func() bool {
+		tx, err := tracked.GetTrackedTx(6)
+		return err == nil && tx.State == types.TxStateIncluded
+	}, time.Second, time.Millisecond)
+
+	// The block-time schedule would wait an hour; a new head re-checks the
+	// included transaction immediately.
+	receipts.set(hash, &Receipt{BlockNumber: 60, Confirmations: 2})
+	heads.heads <- 62
+	require.Eventually(t, func() bool {
+		tx, err := tracked.GetTrackedTx(6)
+		return err == nil && tx.State == types.TxStateConfirmed
+	}, time.Second, time.Millisecond)
+}
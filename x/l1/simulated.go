@@ -0,0 +1,195 @@
+package l1
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// Modes for the l1.mode deployment knob. ModeRPC is the normal
+// composition against a real settlement-chain endpoint; ModeSimulated
+// swaps every L1 surface for an embedded Simulated backend, so
+// integration tests and local-compose run without an anvil node or
+// pre-deployed contracts.
+const (
+	ModeRPC       = "rpc"
+	ModeSimulated = "simulated"
+)
+
+// SimulatedConfig tunes the embedded settlement chain.
+type SimulatedConfig struct {
+	// BlockTime is the automatic mining cadence once Start is called.
+	// Zero means 1s; tests usually skip Start and call MineBlock instead.
+	BlockTime time.Duration `yaml:"BlockTime"`
+}
+
+func (c SimulatedConfig) withDefaults() SimulatedConfig {
+	if c.BlockTime <= 0 {
+		c.BlockTime = time.Second
+	}
+	return c
+}
+
+// Simulated is an in-process settlement chain. It satisfies Client,
+// ReceiptClient, HeadNumberClient and HeadSource, so one instance wires
+// the submitter, the receipt poller and the head feed. The superblock
+// contract is "deployed" at genesis — construction is the deployment —
+// and every transaction is gasless: receipts report zero gas, so the fee
+// budget never trips in test runs.
+//
+// Submissions are included in the next mined block. Blocks are mined by
+// Start's timer or explicitly with MineBlock, which gives tests precise
+// control over inclusion and confirmation depth.
+type Simulated struct {
+	logger *zap.Logger
+	cfg    SimulatedConfig
+
+	mu        sync.Mutex
+	head      uint64
+	nextNonce uint64
+	pending   []types.Hash
+	txs       map[types.Hash]*simulatedTx
+	published map[uint64]types.Hash
+	subs      []chan uint64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// simulatedTx is one accepted submission.
+type simulatedTx struct {
+	superblock uint64
+	block      uint64 // 0 while pending
+}
+
+// NewSimulated creates the embedded settlement chain at genesis.
+func NewSimulated(logger *zap.Logger, cfg SimulatedConfig) *Simulated {
+	s := &Simulated{
+		logger:    logger.Named("l1-sim"),
+		cfg:       cfg.withDefaults(),
+		txs:       make(map[types.Hash]*simulatedTx),
+		published: make(map[uint64]types.Hash),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	s.logger.Info("simulated L1 ready, superblock contract deployed at genesis")
+	return s
+}
+
+// Start mines blocks at the configured block time until Stop.
+func (s *Simulated) Start() {
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.cfg.BlockTime)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.MineBlock()
+			}
+		}
+	}()
+}
+
+// Stop halts automatic mining.
+func (s *Simulated) Stop() {
+	close(s.stop)
+	<-s.done
+}
+
+// MineBlock advances the chain one block, including every pending
+// submission, and notifies head subscribers. It returns the new head.
+func (s *Simulated) MineBlock() uint64 {
+	s.mu.Lock()
+	s.head++
+	head := s.head
+	for _, txHash := range s.pending {
+		tx := s.txs[txHash]
+		tx.block = head
+		s.published[tx.superblock] = txHash
+	}
+	s.pending = s.pending[:0]
+	subs := append([]chan uint64(nil), s.subs...)
+	s.mu.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case sub <- head:
+		default: // a stalled subscriber misses heads, as over a real socket
+		}
+	}
+	return head
+}
+
+// SubmitSuperblock implements Client. The transaction is accepted
+// immediately and included in the next mined block.
+func (s *Simulated) SubmitSuperblock(_ context.Context, sb *types.Superblock) (types.Hash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextNonce++
+	var txHash types.Hash
+	binary.BigEndian.PutUint64(txHash[:8], s.nextNonce)
+	binary.BigEndian.PutUint64(txHash[8:16], sb.Number)
+	s.txs[txHash] = &simulatedTx{superblock: sb.Number}
+	s.pending = append(s.pending, txHash)
+	return txHash, nil
+}
+
+// PublishedTx implements Client: a superblock counts as published once
+// its transaction is included.
+func (s *Simulated) PublishedTx(_ context.Context, superblockNumber uint64) (types.Hash, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txHash, ok := s.published[superblockNumber]
+	return txHash, ok, nil
+}
+
+// TxReceipt implements ReceiptClient. Pending transactions report
+// ErrReceiptNotFound until their block is mined.
+func (s *Simulated) TxReceipt(_ context.Context, txHash types.Hash) (*Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx, ok := s.txs[txHash]
+	if !ok || tx.block == 0 {
+		return nil, ErrReceiptNotFound
+	}
+	return &Receipt{
+		BlockNumber:   tx.block,
+		Confirmations: s.head - tx.block,
+	}, nil
+}
+
+// LatestHead implements HeadNumberClient.
+func (s *Simulated) LatestHead(context.Context) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.head, nil
+}
+
+// SubscribeHeads implements HeadSource. The channel closes when ctx ends.
+func (s *Simulated) SubscribeHeads(ctx context.Context) (<-chan uint64, error) {
+	ch := make(chan uint64, 8)
+	s.mu.Lock()
+	s.subs = append(s.subs, ch)
+	s.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		for i, sub := range s.subs {
+			if sub == ch {
+				s.subs = append(s.subs[:i], s.subs[i+1:]...)
+				break
+			}
+		}
+		s.mu.Unlock()
+		close(ch)
+	}()
+	return ch, nil
+}
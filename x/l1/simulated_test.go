@@ -0,0 +1,100 @@
+package l1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+func TestSimulatedInclusionAndConfirmations(t *testing.T) {
+	sim := NewSimulated(zap.NewNop(), SimulatedConfig{})
+
+	txHash, err := sim.SubmitSuperblock(context.Background(), &types.Superblock{Number: 1})
+	require.NoError(t, err)
+
+	_, err = sim.TxReceipt(context.Background(), txHash)
+	require.ErrorIs(t, err, ErrReceiptNotFound, "pending until mined")
+	_, published, err := sim.PublishedTx(context.Background(), 1)
+	require.NoError(t, err)
+	require.False(t, published)
+
+	require.Equal(t, uint64(1), sim.MineBlock())
+	receipt, err := sim.TxReceipt(context.Background(), txHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), receipt.BlockNumber)
+	require.Zero(t, receipt.Confirmations)
+	require.Zero(t, receipt.GasUsed, "simulated mode is gasless")
+
+	sim.MineBlock()
+	sim.MineBlock()
+	receipt, err = sim.TxReceipt(context.Background(), txHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), receipt.Confirmations)
+
+	gotHash, published, err := sim.PublishedTx(context.Background(), 1)
+	require.NoError(t, err)
+	require.True(t, published)
+	require.Equal(t, txHash, gotHash)
+}
+
+func TestSimulatedHeadSubscription(t *testing.T) {
+	sim := NewSimulated(zap.NewNop(), SimulatedConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	heads, err := sim.SubscribeHeads(ctx)
+	require.NoError(t, err)
+
+	sim.MineBlock()
+	sim.MineBlock()
+	require.Equal(t, []uint64{1, 2}, collectHeads(t, heads, 2))
+
+	head, err := sim.LatestHead(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), head)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, open := <-heads
+		return !open
+	}, time.Second, time.Millisecond, "channel closes with ctx")
+}
+
+func TestSimulatedDrivesFullPublicationPath(t *testing.T) {
+	// One Simulated instance stands in for the whole settlement chain:
+	// submitter, receipt poller and head feed all wired to it, no
+	// pre-deployed contracts.
+	sim := NewSimulated(zap.NewNop(), SimulatedConfig{})
+	tracked := store.NewMemoryTrackedTxStore()
+	submitter := NewSubmitter(zap.NewNop(), sim, tracked)
+	poller := NewPoller(zap.NewNop(), sim, submitter, tracked, PollerConfig{
+		FastInterval: time.Millisecond, BlockTime: time.Hour,
+		ConfirmDepth: 2, FinalizeDepth: 3,
+	})
+	poller.SetHeadSource(sim)
+
+	txHash, err := submitter.Publish(context.Background(), &types.Superblock{Number: 7})
+	require.NoError(t, err)
+	poller.Track(&types.TrackedTx{SuperblockNumber: 7, TxHash: txHash, State: types.TxStateSubmitted})
+	poller.Start()
+	defer poller.Stop()
+
+	waitForState := func(state types.TransactionState) {
+		require.Eventually(t, func() bool {
+			tx, err := tracked.GetTrackedTx(7)
+			return err == nil && tx.State == state
+		}, time.Second, time.Millisecond, "waiting for %s", state)
+	}
+
+	sim.MineBlock()
+	waitForState(types.TxStateIncluded)
+	sim.MineBlock()
+	sim.MineBlock()
+	waitForState(types.TxStateConfirmed)
+	sim.MineBlock()
+	waitForState(types.TxStateFinalized)
+}
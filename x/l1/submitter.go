@@ -0,0 +1,199 @@
+// Package l1 publishes sealed superblocks to the settlement chain and
+// tracks the resulting transactions. The submitter sits between the slot
+// loop and a Client implementation; it owns idempotency, so callers can
+// retry freely (direct publish, proof-gated publish, WAL recovery) without
+// double-submitting.
+package l1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+// Client is the minimal settlement-chain surface the submitter needs.
+type Client interface {
+	// SubmitSuperblock sends the publication transaction and returns its
+	// hash. It must not be retried by the implementation; retries are the
+	// submitter's job.
+	SubmitSuperblock(ctx context.Context, sb *types.Superblock) (types.Hash, error)
+	// PublishedTx asks the superblock contract whether the numbered
+	// superblock was already published, returning the publication
+	// transaction hash if so. Used to reconcile after WAL recovery, when
+	// the local tracked-transaction store may lag the chain.
+	PublishedTx(ctx context.Context, superblockNumber uint64) (types.Hash, bool, error)
+}
+
+// ErrPublishInFlight is returned when a second publish of the same
+// superblock races a first that has not finished submitting.
+var ErrPublishInFlight = errors.New("l1: publish already in flight")
+
+// Submitter publishes superblocks exactly once.
+type Submitter struct {
+	logger  *zap.Logger
+	client  Client
+	tracked store.TrackedTxStore
+
+	mu       sync.Mutex
+	inflight map[uint64]struct{}
+	webhook  *Webhook
+	budget   *Budget
+	onStatus StatusFunc
+}
+
+// StatusFunc receives every tracked-transaction transition. The transport
+// layer uses it to broadcast finality updates to connected sequencers.
+type StatusFunc func(tracked *types.TrackedTx)
+
+// SuperblockStatusFor maps an L1 transaction state to the superblock
+// status sequencers should derive from it. The second result is false for
+// transitions not worth announcing (inclusion churn before confirmation).
+func SuperblockStatusFor(state types.TransactionState) (types.SuperblockStatus, bool) {
+	switch state {
+	case types.TxStateSubmitted:
+		return types.SuperblockStatusSubmitted, true
+	case types.TxStateConfirmed:
+		return types.SuperblockStatusConfirmed, true
+	case types.TxStateFinalized:
+		return types.SuperblockStatusFinalized, true
+	case types.TxStateFailed:
+		return types.SuperblockStatusRolledBack, true
+	}
+	return "", false
+}
+
+// SetBudget wires fee budget enforcement. Must be called before the
+// submitter is shared.
+func (s *Submitter) SetBudget(b *Budget) {
+	s.budget = b
+}
+
+// SetWebhook wires state-transition notifications. Must be called before
+// the submitter is shared.
+func (s *Submitter) SetWebhook(wh *Webhook) {
+	s.webhook = wh
+}
+
+// SetStatusFunc wires the sequencer-facing finality notifications. Must be
+// called before the submitter is shared.
+func (s *Submitter) SetStatusFunc(fn StatusFunc) {
+	s.onStatus = fn
+}
+
+// UpdateTrackedTx persists a transaction's new state (from the receipt
+// poller) and delivers any webhook notification it warrants. Gas is
+// accounted against the fee budget on the first transition that carries a
+// receipt — usually inclusion, but a slow poller or a restart can jump
+// straight to confirmed or finalized — and the GasAccounted flag keeps it
+// from being counted twice.
+func (s *Submitter) UpdateTrackedTx(tracked *types.TrackedTx) error {
+	tracked.UpdatedAt = uint64(time.Now().Unix())
+	account := !tracked.GasAccounted && tracked.State != types.TxStateSubmitted && tracked.GasUsed > 0
+	if account {
+		tracked.GasAccounted = true
+	}
+	if err := s.tracked.PutTrackedTx(tracked); err != nil {
+		tracked.GasAccounted = false
+		return fmt.Errorf("l1: track tx for %d: %w", tracked.SuperblockNumber, err)
+	}
+	if account {
+		s.budget.Record(tracked.SuperblockNumber, tracked.GasUsed)
+	}
+	s.webhook.Notify(tracked)
+	if s.onStatus != nil {
+		s.onStatus(tracked)
+	}
+	return nil
+}
+
+// NewSubmitter creates a submitter recording publications in tracked.
+func NewSubmitter(logger *zap.Logger, client Client, tracked store.TrackedTxStore) *Submitter {
+	return &Submitter{
+		logger:   logger.Named("l1"),
+		client:   client,
+		tracked:  tracked,
+		inflight: make(map[uint64]struct{}),
+	}
+}
+
+// Publish sends the superblock's publication transaction unless it was
+// already sent. The guard consults, in order: in-process in-flight state,
+// the tracked-transaction store, and finally the contract itself, so a
+// superblock survives being offered by both the direct path and the
+// proof-gated path, and re-offered after WAL recovery. It returns the
+// transaction hash — new or previously recorded.
+func (s *Submitter) Publish(ctx context.Context, sb *types.Superblock) (types.Hash, error) {
+	if err := s.budget.Allow(); err != nil {
+		return types.Hash{}, err
+	}
+	s.mu.Lock()
+	if _, ok := s.inflight[sb.Number]; ok {
+		s.mu.Unlock()
+		return types.Hash{}, fmt.Errorf("%w: superblock %d", ErrPublishInFlight, sb.Number)
+	}
+	s.inflight[sb.Number] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.inflight, sb.Number)
+		s.mu.Unlock()
+	}()
+
+	if tracked, err := s.tracked.GetTrackedTx(sb.Number); err == nil {
+		if tracked.State != types.TxStateFailed {
+			s.logger.Debug("superblock already submitted, skipping",
+				zap.Uint64("superblock", sb.Number),
+				zap.String("tx_hash", tracked.TxHash.String()))
+			return tracked.TxHash, nil
+		}
+	} else if !errors.Is(err, store.ErrNotFound) {
+		return types.Hash{}, fmt.Errorf("l1: tracked tx lookup for %d: %w", sb.Number, err)
+	}
+
+	if txHash, published, err := s.client.PublishedTx(ctx, sb.Number); err != nil {
+		return types.Hash{}, fmt.Errorf("l1: publication check for %d: %w", sb.Number, err)
+	} else if published {
+		s.logger.Info("superblock already on L1, recording without resubmitting",
+			zap.Uint64("superblock", sb.Number),
+			zap.String("tx_hash", txHash.String()))
+		if err := s.record(sb.Number, txHash, types.TxStateIncluded); err != nil {
+			return types.Hash{}, err
+		}
+		return txHash, nil
+	}
+
+	txHash, err := s.client.SubmitSuperblock(ctx, sb)
+	if err != nil {
+		return types.Hash{}, fmt.Errorf("l1: submit superblock %d: %w", sb.Number, err)
+	}
+	if err := s.record(sb.Number, txHash, types.TxStateSubmitted); err != nil {
+		return types.Hash{}, err
+	}
+	s.logger.Info("superblock submitted to L1",
+		zap.Uint64("superblock", sb.Number),
+		zap.String("tx_hash", txHash.String()))
+	return txHash, nil
+}
+
+func (s *Submitter) record(number uint64, txHash types.Hash, state types.TransactionState) error {
+	tracked := &types.TrackedTx{
+		SuperblockNumber: number,
+		TxHash:           txHash,
+		State:            state,
+		UpdatedAt:        uint64(time.Now().Unix()),
+	}
+	if err := s.tracked.PutTrackedTx(tracked); err != nil {
+		return fmt.Errorf("l1: track tx for %d: %w", number, err)
+	}
+	if s.onStatus != nil {
+		s.onStatus(tracked)
+	}
+	return nil
+}
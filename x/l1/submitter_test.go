@@ -0,0 +1,117 @@
+package l1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+type fakeClient struct {
+	submitted int
+	published map[uint64]types.Hash
+}
+
+func (c *fakeClient) SubmitSuperblock(_ context.Context, sb *types.Superblock) (types.Hash, error) {
+	c.submitted++
+	return types.Hash{byte(sb.Number), 0xaa}, nil
+}
+
+func (c *fakeClient) PublishedTx(_ context.Context, number uint64) (types.Hash, bool, error) {
+	hash, ok := c.published[number]
+	return hash, ok, nil
+}
+
+func TestPublishIsIdempotent(t *testing.T) {
+	client := &fakeClient{}
+	s := NewSubmitter(zap.NewNop(), client, store.NewMemoryTrackedTxStore())
+
+	sb := &types.Superblock{Number: 3, Status: types.SuperblockStatusSealed}
+	first, err := s.Publish(context.Background(), sb)
+	require.NoError(t, err)
+	require.Equal(t, 1, client.submitted)
+
+	// A second offer (direct path vs proof path) reuses the tracked tx.
+	second, err := s.Publish(context.Background(), sb)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+	require.Equal(t, 1, client.submitted)
+}
+
+func TestPublishReconcilesWithContract(t *testing.T) {
+	onChain := types.Hash{0xbb}
+	client := &fakeClient{published: map[uint64]types.Hash{7: onChain}}
+	tracked := store.NewMemoryTrackedTxStore()
+	s := NewSubmitter(zap.NewNop(), client, tracked)
+
+	// The tracked store is empty (lost WAL tail), but the contract already
+	// has the superblock: no new transaction is sent.
+	txHash, err := s.Publish(context.Background(), &types.Superblock{Number: 7})
+	require.NoError(t, err)
+	require.Equal(t, onChain, txHash)
+	require.Zero(t, client.submitted)
+
+	rec, err := tracked.GetTrackedTx(7)
+	require.NoError(t, err)
+	require.Equal(t, onChain, rec.TxHash)
+	require.Equal(t, types.TxStateIncluded, rec.State)
+}
+
+func TestFailedPublicationIsRetried(t *testing.T) {
+	client := &fakeClient{}
+	tracked := store.NewMemoryTrackedTxStore()
+	s := NewSubmitter(zap.NewNop(), client, tracked)
+
+	require.NoError(t, tracked.PutTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 4,
+		TxHash:           types.Hash{0xcc},
+		State:            types.TxStateFailed,
+	}))
+
+	txHash, err := s.Publish(context.Background(), &types.Superblock{Number: 4})
+	require.NoError(t, err)
+	require.Equal(t, 1, client.submitted)
+	require.NotEqual(t, types.Hash{0xcc}, txHash)
+}
+
+func TestStatusFuncSeesEveryTransition(t *testing.T) {
+	client := &fakeClient{}
+	s := NewSubmitter(zap.NewNop(), client, store.NewMemoryTrackedTxStore())
+
+	var states []types.TransactionState
+	s.SetStatusFunc(func(tracked *types.TrackedTx) {
+		states = append(states, tracked.State)
+	})
+
+	sb := &types.Superblock{Number: 5, Status: types.SuperblockStatusSealed}
+	txHash, err := s.Publish(context.Background(), sb)
+	require.NoError(t, err)
+	require.NoError(t, s.UpdateTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 5, TxHash: txHash, State: types.TxStateConfirmed,
+	}))
+	require.NoError(t, s.UpdateTrackedTx(&types.TrackedTx{
+		SuperblockNumber: 5, TxHash: txHash, State: types.TxStateFinalized,
+	}))
+	require.Equal(t, []types.TransactionState{
+		types.TxStateSubmitted, types.TxStateConfirmed, types.TxStateFinalized,
+	}, states)
+}
+
+func TestSuperblockStatusFor(t *testing.T) {
+	for state, want := range map[types.TransactionState]types.SuperblockStatus{
+		types.TxStateSubmitted: types.SuperblockStatusSubmitted,
+		types.TxStateConfirmed: types.SuperblockStatusConfirmed,
+		types.TxStateFinalized: types.SuperblockStatusFinalized,
+		types.TxStateFailed:    types.SuperblockStatusRolledBack,
+	} {
+		got, ok := SuperblockStatusFor(state)
+		require.True(t, ok)
+		require.Equal(t, want, got)
+	}
+	_, ok := SuperblockStatusFor(types.TxStateIncluded)
+	require.False(t, ok)
+}
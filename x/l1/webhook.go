@@ -0,0 +1,97 @@
+package l1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// WebhookConfig parameterizes publication-state notifications.
+type WebhookConfig struct {
+	// URL receives a POST per notified transition; empty disables the
+	// webhook.
+	URL string `yaml:"URL"`
+	// Timeout bounds each delivery attempt. Zero means 10 seconds.
+	Timeout time.Duration `yaml:"Timeout"`
+}
+
+// notifiedStates are the terminal-ish transitions operators care about;
+// submitted/included churn is not delivered.
+var notifiedStates = map[types.TransactionState]struct{}{
+	types.TxStateConfirmed: {},
+	types.TxStateFinalized: {},
+	types.TxStateFailed:    {},
+}
+
+// Webhook posts tracked-transaction transitions to an external endpoint.
+// Delivery is best effort: failures are logged, not retried, since the
+// status API remains the source of truth.
+type Webhook struct {
+	logger *zap.Logger
+	url    string
+	client *http.Client
+}
+
+// NewWebhook creates a notifier for cfg. A nil result (empty URL) is safe
+// to call Notify on.
+func NewWebhook(logger *zap.Logger, cfg WebhookConfig) *Webhook {
+	if cfg.URL == "" {
+		return nil
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &Webhook{
+		logger: logger.Named("l1.webhook"),
+		url:    cfg.URL,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// Notify delivers a state transition if it is one operators subscribe to
+// (confirmed, finalized, failed). Other states are ignored.
+func (wh *Webhook) Notify(tracked *types.TrackedTx) {
+	if wh == nil {
+		return
+	}
+	if _, ok := notifiedStates[tracked.State]; !ok {
+		return
+	}
+	body, err := json.Marshal(tracked)
+	if err != nil {
+		wh.logger.Warn("webhook payload not encodable", zap.Error(err))
+		return
+	}
+	resp, err := wh.client.Post(wh.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		wh.logger.Warn("webhook delivery failed",
+			zap.Uint64("superblock", tracked.SuperblockNumber),
+			zap.Error(err))
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		wh.logger.Warn("webhook delivery rejected",
+			zap.Uint64("superblock", tracked.SuperblockNumber),
+			zap.String("status", resp.Status))
+		return
+	}
+	wh.logger.Debug("webhook delivered",
+		zap.Uint64("superblock", tracked.SuperblockNumber),
+		zap.String("state", string(tracked.State)))
+}
+
+// String implements fmt.Stringer for diagnostics.
+func (wh *Webhook) String() string {
+	if wh == nil {
+		return "<disabled>"
+	}
+	return fmt.Sprintf("webhook(%s)", wh.url)
+}
@@ -0,0 +1,55 @@
+package l1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+func TestWebhookNotifiesTerminalStates(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []types.TrackedTx
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var tx types.TrackedTx
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&tx))
+		mu.Lock()
+		received = append(received, tx)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	s := NewSubmitter(zap.NewNop(), &fakeClient{}, store.NewMemoryTrackedTxStore())
+	s.SetWebhook(NewWebhook(zap.NewNop(), WebhookConfig{URL: srv.URL}))
+
+	// Submitted/included transitions are silent; confirmed and failed fire.
+	for _, state := range []types.TransactionState{
+		types.TxStateSubmitted, types.TxStateIncluded,
+		types.TxStateConfirmed, types.TxStateFailed,
+	} {
+		require.NoError(t, s.UpdateTrackedTx(&types.TrackedTx{
+			SuperblockNumber: 5, TxHash: types.Hash{1}, State: state,
+		}))
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 2)
+	require.Equal(t, types.TxStateConfirmed, received[0].State)
+	require.Equal(t, types.TxStateFailed, received[1].State)
+}
+
+func TestWebhookDisabledIsSafe(t *testing.T) {
+	require.Nil(t, NewWebhook(zap.NewNop(), WebhookConfig{}))
+	s := NewSubmitter(zap.NewNop(), &fakeClient{}, store.NewMemoryTrackedTxStore())
+	require.NoError(t, s.UpdateTrackedTx(&types.TrackedTx{SuperblockNumber: 1, State: types.TxStateConfirmed}))
+}
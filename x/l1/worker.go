@@ -0,0 +1,164 @@
+package l1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// ErrQueueFull is returned by Enqueue when the worker's backlog is at
+// capacity. The superblock is not lost: the submitter's idempotency guard
+// makes re-offering it (on the next seal, or via WAL recovery) safe.
+var ErrQueueFull = errors.New("l1: publish queue full")
+
+// WorkerConfig tunes the asynchronous publish worker.
+type WorkerConfig struct {
+	// QueueSize bounds the backlog of superblocks waiting to publish.
+	QueueSize int `yaml:"QueueSize"`
+	// MaxAttempts is how many times one superblock is tried before it is
+	// given up on (until re-offered).
+	MaxAttempts int `yaml:"MaxAttempts"`
+	// RetryBackoff is the wait after a failed attempt, doubled each retry.
+	RetryBackoff time.Duration `yaml:"RetryBackoff"`
+}
+
+func (c WorkerConfig) withDefaults() WorkerConfig {
+	if c.QueueSize <= 0 {
+		c.QueueSize = 64
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 5
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = 2 * time.Second
+	}
+	return c
+}
+
+// PublishedFunc is notified after a superblock's publication transaction
+// is accepted, with the transaction hash.
+type PublishedFunc func(sb *types.Superblock, txHash types.Hash)
+
+// Worker publishes superblocks off the slot loop: the sealed path enqueues
+// and returns immediately, and a dedicated goroutine drives Submitter.Publish
+// with retries, so a slow or flaky L1 RPC never delays the next slot.
+type Worker struct {
+	logger      *zap.Logger
+	cfg         WorkerConfig
+	submitter   *Submitter
+	onPublished PublishedFunc
+
+	queue  chan *types.Superblock
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewWorker creates a worker publishing through submitter.
+func NewWorker(logger *zap.Logger, submitter *Submitter, cfg WorkerConfig) *Worker {
+	cfg = cfg.withDefaults()
+	return &Worker{
+		logger:    logger.Named("l1-worker"),
+		cfg:       cfg,
+		submitter: submitter,
+		queue:     make(chan *types.Superblock, cfg.QueueSize),
+	}
+}
+
+// SetPublishedFunc installs the publication callback. Must be called before
+// Start.
+func (w *Worker) SetPublishedFunc(fn PublishedFunc) {
+	w.onPublished = fn
+}
+
+// Start launches the publish loop.
+func (w *Worker) Start() {
+	if w.stopCh != nil {
+		return
+	}
+	w.stopCh = make(chan struct{})
+	w.doneCh = make(chan struct{})
+	go w.run()
+}
+
+// Stop terminates the publish loop, abandoning any in-flight retry wait.
+// Queued superblocks that never published are recovered by the usual
+// re-offer paths; the submitter deduplicates.
+func (w *Worker) Stop() {
+	if w.stopCh == nil {
+		return
+	}
+	close(w.stopCh)
+	<-w.doneCh
+	w.stopCh = nil
+}
+
+// Enqueue hands a sealed superblock to the worker without blocking.
+func (w *Worker) Enqueue(sb *types.Superblock) error {
+	select {
+	case w.queue <- sb:
+		return nil
+	default:
+		return fmt.Errorf("%w: superblock %d", ErrQueueFull, sb.Number)
+	}
+}
+
+// Backlog returns the number of superblocks waiting to publish.
+func (w *Worker) Backlog() int {
+	return len(w.queue)
+}
+
+func (w *Worker) run() {
+	defer close(w.doneCh)
+	for {
+		select {
+		case <-w.stopCh:
+			return
+		case sb := <-w.queue:
+			w.publish(sb)
+		}
+	}
+}
+
+// publish drives one superblock through Publish with exponential backoff.
+// Budget exhaustion is terminal for the attempt series — retrying cannot
+// help until the window resets — while transient RPC failures are retried.
+func (w *Worker) publish(sb *types.Superblock) {
+	backoff := w.cfg.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		txHash, err := w.submitter.Publish(context.Background(), sb)
+		if err == nil {
+			if w.onPublished != nil {
+				w.onPublished(sb, txHash)
+			}
+			return
+		}
+		if errors.Is(err, ErrBudgetExhausted) || errors.Is(err, ErrPublishInFlight) {
+			w.logger.Warn("superblock publish abandoned",
+				zap.Uint64("superblock", sb.Number), zap.Error(err))
+			return
+		}
+		if attempt >= w.cfg.MaxAttempts {
+			w.logger.Error("superblock publish failed after retries",
+				zap.Uint64("superblock", sb.Number),
+				zap.Int("attempts", attempt), zap.Error(err))
+			return
+		}
+		w.logger.Warn("superblock publish failed, retrying",
+			zap.Uint64("superblock", sb.Number),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff), zap.Error(err))
+		timer := time.NewTimer(backoff)
+		select {
+		case <-w.stopCh:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,82 @@
+package l1
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+// flakyClient fails the first failures submissions, then succeeds.
+type flakyClient struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+}
+
+func (c *flakyClient) SubmitSuperblock(_ context.Context, sb *types.Superblock) (types.Hash, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.attempts++
+	if c.attempts <= c.failures {
+		return types.Hash{}, errors.New("rpc timeout")
+	}
+	return types.Hash{byte(sb.Number), 0xcc}, nil
+}
+
+func (c *flakyClient) PublishedTx(context.Context, uint64) (types.Hash, bool, error) {
+	return types.Hash{}, false, nil
+}
+
+func TestWorkerPublishesWithRetries(t *testing.T) {
+	client := &flakyClient{failures: 2}
+	submitter := NewSubmitter(zap.NewNop(), client, store.NewMemoryTrackedTxStore())
+	w := NewWorker(zap.NewNop(), submitter, WorkerConfig{RetryBackoff: time.Millisecond})
+
+	published := make(chan types.Hash, 1)
+	w.SetPublishedFunc(func(_ *types.Superblock, txHash types.Hash) { published <- txHash })
+	w.Start()
+	defer w.Stop()
+
+	require.NoError(t, w.Enqueue(&types.Superblock{Number: 9, Status: types.SuperblockStatusSealed}))
+	select {
+	case txHash := <-published:
+		require.Equal(t, types.Hash{9, 0xcc}, txHash)
+	case <-time.After(2 * time.Second):
+		t.Fatal("superblock never published")
+	}
+	require.Equal(t, 3, client.attempts)
+}
+
+func TestWorkerGivesUpAfterMaxAttempts(t *testing.T) {
+	client := &flakyClient{failures: 100}
+	submitter := NewSubmitter(zap.NewNop(), client, store.NewMemoryTrackedTxStore())
+	w := NewWorker(zap.NewNop(), submitter, WorkerConfig{MaxAttempts: 2, RetryBackoff: time.Millisecond})
+
+	w.SetPublishedFunc(func(*types.Superblock, types.Hash) { t.Error("unexpected publish") })
+	w.Start()
+	defer w.Stop()
+
+	require.NoError(t, w.Enqueue(&types.Superblock{Number: 4}))
+	require.Eventually(t, func() bool {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		return client.attempts == 2
+	}, 2*time.Second, time.Millisecond)
+}
+
+func TestWorkerQueueBounded(t *testing.T) {
+	submitter := NewSubmitter(zap.NewNop(), &fakeClient{}, store.NewMemoryTrackedTxStore())
+	w := NewWorker(zap.NewNop(), submitter, WorkerConfig{QueueSize: 1})
+	// Not started: the queue holds one entry and the second is refused.
+	require.NoError(t, w.Enqueue(&types.Superblock{Number: 1}))
+	require.ErrorIs(t, w.Enqueue(&types.Superblock{Number: 2}), ErrQueueFull)
+	require.Equal(t, 1, w.Backlog())
+}
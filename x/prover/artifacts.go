@@ -0,0 +1,139 @@
+package prover
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNoArtifact reports that an artifact store holds nothing under the
+// requested reference.
+var ErrNoArtifact = errors.New("prover: no such proof artifact")
+
+// ArtifactStore persists raw proof payloads outside the in-memory collector
+// and the superblock store, which keep only references. Implementations must
+// be safe for concurrent use; the filesystem store below covers single-node
+// deployments and object storage (S3 and friends) plugs in behind the same
+// three methods.
+type ArtifactStore interface {
+	// Put stores the payload under ref, replacing any previous content.
+	Put(ref string, r io.Reader) error
+	// Open streams the payload stored under ref. The caller closes the
+	// reader. Returns ErrNoArtifact when nothing is stored under ref.
+	Open(ref string) (io.ReadCloser, error)
+	// Delete removes the payload under ref; deleting a missing ref is
+	// not an error.
+	Delete(ref string) error
+}
+
+// fsArtifactStore keeps one file per artifact under a root directory.
+type fsArtifactStore struct {
+	dir string
+}
+
+// NewFSArtifactStore creates a filesystem-backed artifact store rooted at
+// dir, creating it if needed.
+func NewFSArtifactStore(dir string) (ArtifactStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create artifact dir: %w", err)
+	}
+	return &fsArtifactStore{dir: dir}, nil
+}
+
+// path maps a ref onto a file inside the root, refusing refs that would
+// escape it.
+func (s *fsArtifactStore) path(ref string) (string, error) {
+	if ref == "" || strings.Contains(ref, "..") || strings.ContainsAny(ref, `/\`) {
+		return "", fmt.Errorf("invalid artifact ref %q", ref)
+	}
+	return filepath.Join(s.dir, ref), nil
+}
+
+func (s *fsArtifactStore) Put(ref string, r io.Reader) error {
+	path, err := s.path(ref)
+	if err != nil {
+		return err
+	}
+	// Write-then-rename so readers never see a partial payload.
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *fsArtifactStore) Open(ref string) (io.ReadCloser, error) {
+	path, err := s.path(ref)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoArtifact
+	}
+	return f, err
+}
+
+func (s *fsArtifactStore) Delete(ref string) error {
+	path, err := s.path(ref)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// memoryArtifactStore is an in-memory ArtifactStore for tests.
+type memoryArtifactStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+// NewMemoryArtifactStore creates an in-memory artifact store.
+func NewMemoryArtifactStore() ArtifactStore {
+	return &memoryArtifactStore{blobs: make(map[string][]byte)}
+}
+
+func (s *memoryArtifactStore) Put(ref string, r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[ref] = data
+	return nil
+}
+
+func (s *memoryArtifactStore) Open(ref string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	data, ok := s.blobs[ref]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrNoArtifact
+	}
+	return io.NopCloser(strings.NewReader(string(data))), nil
+}
+
+func (s *memoryArtifactStore) Delete(ref string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, ref)
+	return nil
+}
@@ -0,0 +1,67 @@
+package prover
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestFSArtifactStoreRoundTrip(t *testing.T) {
+	artifacts, err := NewFSArtifactStore(t.TempDir())
+	require.NoError(t, err)
+
+	payload := bytes.Repeat([]byte{0xAB}, 1<<16)
+	require.NoError(t, artifacts.Put("proof-1", bytes.NewReader(payload)))
+
+	rc, err := artifacts.Open("proof-1")
+	require.NoError(t, err)
+	got, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, payload, got)
+
+	require.NoError(t, artifacts.Delete("proof-1"))
+	_, err = artifacts.Open("proof-1")
+	require.ErrorIs(t, err, ErrNoArtifact)
+	// Deleting again is not an error.
+	require.NoError(t, artifacts.Delete("proof-1"))
+
+	// Refs must not escape the root directory.
+	require.Error(t, artifacts.Put("../escape", bytes.NewReader(payload)))
+}
+
+func TestCollectorOffloadsProofBytes(t *testing.T) {
+	artifacts := NewMemoryArtifactStore()
+	c := NewCollector(zap.NewNop(), nil)
+	c.SetArtifactStore(artifacts)
+
+	payload := []byte("a multi-megabyte proof, in spirit")
+	require.NoError(t, c.Submit(ProofSubmission{
+		ChainID: 1, SuperblockNumber: 7, ProofHash: types.Hash{7}, Proof: payload,
+	}))
+
+	// Only the reference stays in memory.
+	kept, ok := c.Accepted(1, 7)
+	require.True(t, ok)
+	require.Nil(t, kept.Proof)
+	require.NotEmpty(t, kept.ArtifactRef)
+
+	rc, err := c.OpenProof(1, 7)
+	require.NoError(t, err)
+	got, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, payload, got)
+
+	// Releasing the superblock drops the blob too.
+	c.Release(7)
+	_, err = artifacts.Open(kept.ArtifactRef)
+	require.ErrorIs(t, err, ErrNoArtifact)
+	_, err = c.OpenProof(1, 7)
+	require.ErrorIs(t, err, ErrNoArtifact)
+}
@@ -0,0 +1,134 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrProverUnavailable is returned while the circuit is open: the prover
+// kept failing and calls are short-circuited until a probe succeeds.
+var ErrProverUnavailable = errors.New("prover: circuit open, prover unavailable")
+
+// Breaker states, as reported by State.
+const (
+	BreakerClosed  = "closed"
+	BreakerOpen    = "open"
+	BreakerProbing = "probing"
+)
+
+// BreakerConfig tunes the prover circuit breaker.
+type BreakerConfig struct {
+	// FailureThreshold is how many consecutive failures open the circuit.
+	// Zero means 5.
+	FailureThreshold int `yaml:"FailureThreshold"`
+	// ProbeInterval is how long the circuit stays open before one probe
+	// call is let through. Zero means 30s.
+	ProbeInterval time.Duration `yaml:"ProbeInterval"`
+}
+
+func (c BreakerConfig) withDefaults() BreakerConfig {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = 5
+	}
+	if c.ProbeInterval <= 0 {
+		c.ProbeInterval = 30 * time.Second
+	}
+	return c
+}
+
+// Breaker wraps a ProveFunc with a circuit breaker: after consecutive
+// failures it stops hammering the prover, fails fast, and lets one probe
+// through per interval until the prover recovers. Wrap the backend before
+// handing it to NewPipeline.
+type Breaker struct {
+	logger *zap.Logger
+	cfg    BreakerConfig
+	next   ProveFunc
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	open     bool
+	probing  bool
+}
+
+// NewBreaker wraps next with a circuit breaker.
+func NewBreaker(logger *zap.Logger, cfg BreakerConfig, next ProveFunc) *Breaker {
+	return &Breaker{
+		logger: logger.Named("prover-breaker"),
+		cfg:    cfg.withDefaults(),
+		next:   next,
+	}
+}
+
+// Prove forwards to the wrapped backend unless the circuit is open, in
+// which case it fails fast with ErrProverUnavailable. Use it as the
+// pipeline's ProveFunc.
+func (b *Breaker) Prove(ctx context.Context, stage string, input *ProofJobInput) error {
+	b.mu.Lock()
+	if b.open {
+		if b.probing || time.Since(b.openedAt) < b.cfg.ProbeInterval {
+			b.mu.Unlock()
+			return ErrProverUnavailable
+		}
+		// One call probes the prover; everyone else keeps failing fast.
+		b.probing = true
+	}
+	b.mu.Unlock()
+
+	err := b.next(ctx, stage, input)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil {
+		b.failures++
+		if b.open {
+			// The probe failed; stay open for another interval.
+			b.openedAt = time.Now()
+			b.probing = false
+			return err
+		}
+		if b.failures >= b.cfg.FailureThreshold {
+			b.open = true
+			b.openedAt = time.Now()
+			b.logger.Error("circuit opened: prover failing",
+				zap.Int("consecutive_failures", b.failures),
+				zap.Duration("probe_interval", b.cfg.ProbeInterval),
+				zap.Error(err))
+		}
+		return err
+	}
+	if b.open {
+		b.logger.Info("circuit closed: prover recovered")
+	}
+	b.failures = 0
+	b.open = false
+	b.probing = false
+	return nil
+}
+
+// Healthy reports whether the circuit is closed. Readiness checks use it
+// to mark the pipeline degraded.
+func (b *Breaker) Healthy() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.open
+}
+
+// State returns the current breaker state for diagnostics.
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case !b.open:
+		return BreakerClosed
+	case b.probing:
+		return BreakerProbing
+	default:
+		return BreakerOpen
+	}
+}
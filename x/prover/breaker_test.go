@@ -0,0 +1,58 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	backendErr := errors.New("prover: 503")
+	calls := 0
+	breaker := NewBreaker(zap.NewNop(), BreakerConfig{FailureThreshold: 3, ProbeInterval: time.Hour}, func(context.Context, string, *ProofJobInput) error {
+		calls++
+		return backendErr
+	})
+
+	input := &ProofJobInput{SuperblockNumber: 1}
+	for i := 0; i < 3; i++ {
+		require.ErrorIs(t, breaker.Prove(context.Background(), StageRangeProof, input), backendErr)
+	}
+	require.Equal(t, BreakerOpen, breaker.State())
+	require.False(t, breaker.Healthy())
+
+	// Open circuit fails fast without touching the backend.
+	require.ErrorIs(t, breaker.Prove(context.Background(), StageRangeProof, input), ErrProverUnavailable)
+	require.Equal(t, 3, calls)
+}
+
+func TestBreakerProbesAndRecovers(t *testing.T) {
+	fail := true
+	breaker := NewBreaker(zap.NewNop(), BreakerConfig{FailureThreshold: 1, ProbeInterval: 10 * time.Millisecond}, func(context.Context, string, *ProofJobInput) error {
+		if fail {
+			return errors.New("prover: 429")
+		}
+		return nil
+	})
+
+	input := &ProofJobInput{SuperblockNumber: 2}
+	require.Error(t, breaker.Prove(context.Background(), StageRangeProof, input))
+	require.Equal(t, BreakerOpen, breaker.State())
+
+	// A failed probe keeps the circuit open for another interval.
+	time.Sleep(15 * time.Millisecond)
+	require.Error(t, breaker.Prove(context.Background(), StageRangeProof, input))
+	require.Equal(t, BreakerOpen, breaker.State())
+	require.ErrorIs(t, breaker.Prove(context.Background(), StageRangeProof, input), ErrProverUnavailable)
+
+	// A successful probe closes it again.
+	fail = false
+	time.Sleep(15 * time.Millisecond)
+	require.NoError(t, breaker.Prove(context.Background(), StageRangeProof, input))
+	require.Equal(t, BreakerClosed, breaker.State())
+	require.True(t, breaker.Healthy())
+}
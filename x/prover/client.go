@@ -0,0 +1,274 @@
+package prover
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrUnknownTransport is returned when the configured prover transport has
+// no implementation.
+var ErrUnknownTransport = errors.New("prover: unknown client transport")
+
+// Job states a prover service reports.
+const (
+	JobStateRunning   = "running"
+	JobStateCompleted = "completed"
+	JobStateFailed    = "failed"
+)
+
+// JobStatus is one status update for a submitted proof job.
+type JobStatus struct {
+	JobID string `json:"job_id"`
+	State string `json:"state"`
+	// Error carries the failure reason when State is failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Client executes proof stages against a prover service. Prove blocks
+// until the stage completes or fails, making it pluggable as the
+// pipeline's ProveFunc.
+type Client interface {
+	Prove(ctx context.Context, stage string, input *ProofJobInput) error
+	Close() error
+}
+
+// ClientConfig selects and tunes the prover transport.
+type ClientConfig struct {
+	// Transport is "http" (the default) or a registered transport such as
+	// "grpc".
+	Transport string `yaml:"Transport"`
+	// URL is the prover service base URL or target address.
+	URL string `yaml:"URL"`
+	// PollInterval is how often the http transport polls job status when
+	// the service offers no watch stream. Zero means 10s.
+	PollInterval time.Duration `yaml:"PollInterval"`
+	// RequestTimeout bounds individual submit and poll requests. Zero
+	// means 30s. Watch streams are exempt: they stay open for the job.
+	RequestTimeout time.Duration `yaml:"RequestTimeout"`
+}
+
+func (c ClientConfig) withDefaults() ClientConfig {
+	if c.Transport == "" {
+		c.Transport = "http"
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = 10 * time.Second
+	}
+	if c.RequestTimeout <= 0 {
+		c.RequestTimeout = 30 * time.Second
+	}
+	return c
+}
+
+var (
+	clientTransportMu sync.RWMutex
+	// clientTransports holds externally registered transports. The
+	// grpc-backed client lives in x/prover/grpctransport, its own module
+	// carrying the grpc dependency; blank-import it and it registers
+	// itself here at init, so this module stays dependency-light.
+	clientTransports = map[string]func(*zap.Logger, ClientConfig) (Client, error){}
+)
+
+// RegisterClientTransport makes a named transport selectable through
+// ClientConfig.Transport. Registering "http" or the same name twice is an
+// error.
+func RegisterClientTransport(name string, fn func(*zap.Logger, ClientConfig) (Client, error)) error {
+	if name == "" || name == "http" {
+		return fmt.Errorf("prover: transport %q is built in", name)
+	}
+	clientTransportMu.Lock()
+	defer clientTransportMu.Unlock()
+	if _, ok := clientTransports[name]; ok {
+		return fmt.Errorf("prover: transport %q already registered", name)
+	}
+	clientTransports[name] = fn
+	return nil
+}
+
+// NewClient builds the configured prover client.
+func NewClient(logger *zap.Logger, cfg ClientConfig) (Client, error) {
+	cfg = cfg.withDefaults()
+	if cfg.Transport == "http" {
+		return newHTTPClient(logger, cfg), nil
+	}
+	clientTransportMu.RLock()
+	fn, ok := clientTransports[cfg.Transport]
+	clientTransportMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (external transports must be registered via RegisterClientTransport)",
+			ErrUnknownTransport, cfg.Transport)
+	}
+	return fn(logger, cfg)
+}
+
+// httpClient submits jobs with POST /jobs and follows them through the
+// service's watch stream (GET /jobs/{id}/watch, one JSON status per line)
+// when it has one, falling back to polling GET /jobs/{id} at PollInterval
+// for services that predate streaming.
+type httpClient struct {
+	logger  *zap.Logger
+	cfg     ClientConfig
+	base    string
+	client  *http.Client
+	watcher *http.Client
+}
+
+func newHTTPClient(logger *zap.Logger, cfg ClientConfig) *httpClient {
+	return &httpClient{
+		logger: logger.Named("prover-client"),
+		cfg:    cfg,
+		base:   strings.TrimSuffix(cfg.URL, "/"),
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+		// No client timeout on the watcher: the stream lives as long as
+		// the job; the request context bounds it instead.
+		watcher: &http.Client{},
+	}
+}
+
+func (c *httpClient) Close() error {
+	c.client.CloseIdleConnections()
+	c.watcher.CloseIdleConnections()
+	return nil
+}
+
+// submitRequest is the POST /jobs body.
+type submitRequest struct {
+	Stage string         `json:"stage"`
+	Input *ProofJobInput `json:"input"`
+}
+
+func (c *httpClient) Prove(ctx context.Context, stage string, input *ProofJobInput) error {
+	body, err := json.Marshal(submitRequest{Stage: stage, Input: input})
+	if err != nil {
+		return fmt.Errorf("prover: encode job: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.base+"/jobs", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("prover: submit job: %w", err)
+	}
+	var status JobStatus
+	err = decodeJSONResponse(resp, &status)
+	if err != nil {
+		return fmt.Errorf("prover: submit job: %w", err)
+	}
+	if done, err := jobDone(status); done {
+		return err
+	}
+
+	if err := c.watch(ctx, status.JobID); err == nil {
+		return nil
+	} else if !errors.Is(err, errWatchUnsupported) {
+		return err
+	}
+	return c.poll(ctx, status.JobID)
+}
+
+// errWatchUnsupported routes the client onto the polling fallback.
+var errWatchUnsupported = errors.New("prover: watch stream unsupported")
+
+// watch follows the job's status stream until a terminal state. Services
+// without the endpoint surface errWatchUnsupported; mid-stream failures do
+// too, so a dropped stream degrades to polling instead of failing the job.
+func (c *httpClient) watch(ctx context.Context, jobID string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+"/jobs/"+jobID+"/watch", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.watcher.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return errWatchUnsupported
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errWatchUnsupported
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var status JobStatus
+		if err := json.Unmarshal(line, &status); err != nil {
+			c.logger.Warn("malformed status update on watch stream",
+				zap.String("job_id", jobID), zap.Error(err))
+			return errWatchUnsupported
+		}
+		if done, err := jobDone(status); done {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	c.logger.Debug("watch stream ended before job completion, polling",
+		zap.String("job_id", jobID))
+	return errWatchUnsupported
+}
+
+// poll queries the job's status at PollInterval until a terminal state.
+func (c *httpClient) poll(ctx context.Context, jobID string) error {
+	ticker := time.NewTicker(c.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.base+"/jobs/"+jobID, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("prover: poll job %s: %w", jobID, err)
+		}
+		var status JobStatus
+		if err := decodeJSONResponse(resp, &status); err != nil {
+			return fmt.Errorf("prover: poll job %s: %w", jobID, err)
+		}
+		if done, err := jobDone(status); done {
+			return err
+		}
+	}
+}
+
+// jobDone reports whether a status is terminal and the error to surface.
+func jobDone(status JobStatus) (bool, error) {
+	switch status.State {
+	case JobStateCompleted:
+		return true, nil
+	case JobStateFailed:
+		return true, fmt.Errorf("prover: job %s failed: %s", status.JobID, status.Error)
+	default:
+		return false, nil
+	}
+}
+
+func decodeJSONResponse(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,103 @@
+package prover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHTTPClientFollowsWatchStream(t *testing.T) {
+	var polls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/jobs":
+			var sub submitRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&sub))
+			require.Equal(t, StageRangeProof, sub.Stage)
+			_ = json.NewEncoder(w).Encode(JobStatus{JobID: "j1", State: JobStateRunning})
+		case strings.HasSuffix(r.URL.Path, "/watch"):
+			flusher := w.(http.Flusher)
+			fmt.Fprintln(w, `{"job_id":"j1","state":"running"}`)
+			flusher.Flush()
+			fmt.Fprintln(w, `{"job_id":"j1","state":"completed"}`)
+		default:
+			polls.Add(1)
+			_ = json.NewEncoder(w).Encode(JobStatus{JobID: "j1", State: JobStateCompleted})
+		}
+	}))
+	defer srv.Close()
+
+	// A poll interval far beyond the test timeout: completion must come
+	// from the stream, not the fallback.
+	client, err := NewClient(zap.NewNop(), ClientConfig{URL: srv.URL, PollInterval: time.Hour})
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, client.Prove(ctx, StageRangeProof, &ProofJobInput{SuperblockNumber: 1}))
+	require.Zero(t, polls.Load())
+}
+
+func TestHTTPClientFallsBackToPolling(t *testing.T) {
+	var polls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/jobs":
+			_ = json.NewEncoder(w).Encode(JobStatus{JobID: "j2", State: JobStateRunning})
+		case strings.HasSuffix(r.URL.Path, "/watch"):
+			http.NotFound(w, r)
+		default:
+			if polls.Add(1) < 2 {
+				_ = json.NewEncoder(w).Encode(JobStatus{JobID: "j2", State: JobStateRunning})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(JobStatus{JobID: "j2", State: JobStateFailed, Error: "constraint unsatisfied"})
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(zap.NewNop(), ClientConfig{URL: srv.URL, PollInterval: 10 * time.Millisecond})
+	require.NoError(t, err)
+	defer client.Close()
+
+	err = client.Prove(context.Background(), StageAggregation, &ProofJobInput{SuperblockNumber: 2})
+	require.ErrorContains(t, err, "constraint unsatisfied")
+	require.GreaterOrEqual(t, polls.Load(), int64(2))
+}
+
+func TestHTTPClientImmediateCompletion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(JobStatus{JobID: "j3", State: JobStateCompleted})
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(zap.NewNop(), ClientConfig{URL: srv.URL})
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.Prove(context.Background(), StageRangeProof, &ProofJobInput{}))
+}
+
+func TestClientTransportSelection(t *testing.T) {
+	_, err := NewClient(zap.NewNop(), ClientConfig{Transport: "carrier-pigeon"})
+	require.ErrorIs(t, err, ErrUnknownTransport)
+
+	require.Error(t, RegisterClientTransport("http", nil), "built-ins are reserved")
+	require.NoError(t, RegisterClientTransport("test-fake", func(logger *zap.Logger, cfg ClientConfig) (Client, error) {
+		return newHTTPClient(logger, cfg), nil
+	}))
+	require.Error(t, RegisterClientTransport("test-fake", nil))
+
+	client, err := NewClient(zap.NewNop(), ClientConfig{Transport: "test-fake"})
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+}
@@ -0,0 +1,204 @@
+package prover
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/metrics"
+	"github.com/compose-network/publisher/types"
+)
+
+// Collector errors.
+var (
+	// ErrDuplicateProof rejects a resubmission of a proof the collector
+	// already holds (same chain, superblock and proof hash).
+	ErrDuplicateProof = errors.New("prover: duplicate proof submission")
+	// ErrProofSuperseded rejects a different proof for a slot the
+	// collector already accepted one for; redundant provers race and the
+	// first valid submission wins.
+	ErrProofSuperseded = errors.New("prover: proof already accepted for this superblock")
+	// ErrInvalidProof rejects a submission the verifier refused.
+	ErrInvalidProof = errors.New("prover: invalid proof submission")
+)
+
+// ProofSubmission is one proof received from a chain's prover.
+type ProofSubmission struct {
+	ChainID          types.ChainID `json:"chain_id"`
+	SuperblockNumber uint64        `json:"superblock_number"`
+	// ProofHash identifies the proof bytes; together with chain and
+	// superblock it forms the deduplication key.
+	ProofHash types.Hash `json:"proof_hash"`
+	Proof     []byte     `json:"proof,omitempty"`
+	// ArtifactRef locates the proof bytes in the collector's ArtifactStore
+	// once they have been offloaded; Proof is nil in that case.
+	ArtifactRef string `json:"artifact_ref,omitempty"`
+}
+
+// VerifyProofFunc checks a submission's proof bytes. Returning an error
+// rejects the submission without recording it.
+type VerifyProofFunc func(sub ProofSubmission) error
+
+// proofKey identifies the slot a proof fills: one proof per chain per
+// superblock.
+type proofKey struct {
+	chain  types.ChainID
+	number uint64
+}
+
+// Collector accepts proof submissions from possibly-redundant provers,
+// keeping the first valid proof per (chain, superblock) and rejecting the
+// rest, so downstream aggregation never sees two proofs for one slot.
+type Collector struct {
+	logger    *zap.Logger
+	verify    VerifyProofFunc
+	artifacts ArtifactStore
+
+	mu       sync.Mutex
+	accepted map[proofKey]ProofSubmission
+}
+
+// NewCollector creates a collector verifying submissions with verify; nil
+// accepts everything.
+func NewCollector(logger *zap.Logger, verify VerifyProofFunc) *Collector {
+	return &Collector{
+		logger:   logger.Named("proof-collector"),
+		verify:   verify,
+		accepted: make(map[proofKey]ProofSubmission),
+	}
+}
+
+// SetArtifactStore makes the collector offload accepted proof bytes to
+// blob storage, keeping only a reference in memory. Must be called before
+// the first Submit.
+func (c *Collector) SetArtifactStore(artifacts ArtifactStore) {
+	c.artifacts = artifacts
+}
+
+// Submit records a proof. Exactly one concurrent submitter per (chain,
+// superblock) wins; the others get ErrDuplicateProof for byte-identical
+// proofs or ErrProofSuperseded for competing ones.
+func (c *Collector) Submit(sub ProofSubmission) error {
+	if c.verify != nil {
+		if err := c.verify(sub); err != nil {
+			metrics.RecordProofSubmission(uint64(sub.ChainID), "invalid")
+			return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+		}
+	}
+
+	key := proofKey{chain: sub.ChainID, number: sub.SuperblockNumber}
+	c.mu.Lock()
+	existing, ok := c.accepted[key]
+	if !ok {
+		if err := c.offload(&sub); err != nil {
+			c.mu.Unlock()
+			return fmt.Errorf("offload proof: %w", err)
+		}
+		c.accepted[key] = sub
+	}
+	c.mu.Unlock()
+
+	if ok {
+		if existing.ProofHash == sub.ProofHash {
+			metrics.RecordProofSubmission(uint64(sub.ChainID), "duplicate")
+			return fmt.Errorf("%w: chain %d superblock %d", ErrDuplicateProof, sub.ChainID, sub.SuperblockNumber)
+		}
+		metrics.RecordProofSubmission(uint64(sub.ChainID), "superseded")
+		return fmt.Errorf("%w: chain %d superblock %d keeps %s", ErrProofSuperseded,
+			sub.ChainID, sub.SuperblockNumber, existing.ProofHash)
+	}
+
+	metrics.RecordProofSubmission(uint64(sub.ChainID), "accepted")
+	c.logger.Debug("proof accepted",
+		zap.Uint64("chain", uint64(sub.ChainID)),
+		zap.Uint64("superblock", sub.SuperblockNumber),
+		zap.String("proof_hash", sub.ProofHash.String()))
+	return nil
+}
+
+// offload moves the proof bytes into blob storage, replacing them with a
+// reference. Called with the collector lock held so the winning
+// submission's artifact write and slot claim stay atomic.
+func (c *Collector) offload(sub *ProofSubmission) error {
+	if c.artifacts == nil || len(sub.Proof) == 0 {
+		return nil
+	}
+	ref := fmt.Sprintf("proof-%d-%d-%s", sub.SuperblockNumber, sub.ChainID, sub.ProofHash)
+	if err := c.artifacts.Put(ref, bytes.NewReader(sub.Proof)); err != nil {
+		return err
+	}
+	sub.ArtifactRef = ref
+	sub.Proof = nil
+	return nil
+}
+
+// OpenProof streams the accepted proof bytes for a (chain, superblock),
+// whether held in memory or offloaded to the artifact store. Returns
+// ErrNoArtifact when no proof has been accepted.
+func (c *Collector) OpenProof(chainID types.ChainID, superblockNumber uint64) (io.ReadCloser, error) {
+	sub, ok := c.Accepted(chainID, superblockNumber)
+	if !ok {
+		return nil, ErrNoArtifact
+	}
+	if sub.ArtifactRef != "" {
+		return c.artifacts.Open(sub.ArtifactRef)
+	}
+	return io.NopCloser(bytes.NewReader(sub.Proof)), nil
+}
+
+// Accepted returns the winning proof for a (chain, superblock), if any.
+func (c *Collector) Accepted(chainID types.ChainID, superblockNumber uint64) (ProofSubmission, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sub, ok := c.accepted[proofKey{chain: chainID, number: superblockNumber}]
+	return sub, ok
+}
+
+// SuperblockProofs is the collector's state for one superblock: which
+// chains have an accepted proof.
+type SuperblockProofs struct {
+	SuperblockNumber uint64          `json:"superblock_number"`
+	Chains           []types.ChainID `json:"chains"`
+}
+
+// Stats lists the superblocks the collector currently holds proofs for,
+// ordered by number, with the chains covered so far.
+func (c *Collector) Stats() []SuperblockProofs {
+	c.mu.Lock()
+	byNumber := make(map[uint64][]types.ChainID)
+	for key := range c.accepted {
+		byNumber[key.number] = append(byNumber[key.number], key.chain)
+	}
+	c.mu.Unlock()
+
+	out := make([]SuperblockProofs, 0, len(byNumber))
+	for number, chains := range byNumber {
+		sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+		out = append(out, SuperblockProofs{SuperblockNumber: number, Chains: chains})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SuperblockNumber < out[j].SuperblockNumber })
+	return out
+}
+
+// Release forgets the proofs for a superblock once it is published, so
+// the accepted set does not grow without bound.
+func (c *Collector) Release(superblockNumber uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, sub := range c.accepted {
+		if key.number == superblockNumber {
+			if sub.ArtifactRef != "" {
+				if err := c.artifacts.Delete(sub.ArtifactRef); err != nil {
+					c.logger.Warn("dropping proof artifact failed",
+						zap.String("ref", sub.ArtifactRef), zap.Error(err))
+				}
+			}
+			delete(c.accepted, key)
+		}
+	}
+}
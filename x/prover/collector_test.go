@@ -0,0 +1,90 @@
+package prover
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestCollectorKeepsFirstValidProof(t *testing.T) {
+	c := NewCollector(zap.NewNop(), func(sub ProofSubmission) error {
+		if len(sub.Proof) == 0 {
+			return errors.New("empty proof")
+		}
+		return nil
+	})
+
+	first := ProofSubmission{ChainID: 1, SuperblockNumber: 5, ProofHash: types.Hash{1}, Proof: []byte{1}}
+	require.NoError(t, c.Submit(first))
+
+	// Byte-identical resubmission.
+	require.ErrorIs(t, c.Submit(first), ErrDuplicateProof)
+
+	// A competing proof from a redundant prover loses.
+	competing := ProofSubmission{ChainID: 1, SuperblockNumber: 5, ProofHash: types.Hash{2}, Proof: []byte{2}}
+	require.ErrorIs(t, c.Submit(competing), ErrProofSuperseded)
+
+	// Invalid proofs never occupy the slot.
+	require.ErrorIs(t, c.Submit(ProofSubmission{ChainID: 2, SuperblockNumber: 5}), ErrInvalidProof)
+	_, ok := c.Accepted(2, 5)
+	require.False(t, ok)
+
+	kept, ok := c.Accepted(1, 5)
+	require.True(t, ok)
+	require.Equal(t, first.ProofHash, kept.ProofHash)
+
+	c.Release(5)
+	_, ok = c.Accepted(1, 5)
+	require.False(t, ok)
+}
+
+func TestCollectorConcurrentSubmissionsOneWinner(t *testing.T) {
+	c := NewCollector(zap.NewNop(), nil)
+
+	const racers = 16
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		accepted int
+	)
+	for i := 0; i < racers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := c.Submit(ProofSubmission{
+				ChainID: 3, SuperblockNumber: 9,
+				ProofHash: types.Hash{byte(i)}, Proof: []byte{byte(i)},
+			})
+			if err == nil {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, 1, accepted)
+	_, ok := c.Accepted(3, 9)
+	require.True(t, ok)
+}
+
+func TestCollectorStatsGroupBySuperblock(t *testing.T) {
+	c := NewCollector(zap.NewNop(), nil)
+	require.NoError(t, c.Submit(ProofSubmission{ChainID: 2, SuperblockNumber: 5, ProofHash: types.Hash{1}}))
+	require.NoError(t, c.Submit(ProofSubmission{ChainID: 1, SuperblockNumber: 5, ProofHash: types.Hash{2}}))
+	require.NoError(t, c.Submit(ProofSubmission{ChainID: 1, SuperblockNumber: 6, ProofHash: types.Hash{3}}))
+
+	stats := c.Stats()
+	require.Len(t, stats, 2)
+	require.Equal(t, uint64(5), stats[0].SuperblockNumber)
+	require.Equal(t, []types.ChainID{1, 2}, stats[0].Chains)
+	require.Equal(t, []types.ChainID{1}, stats[1].Chains)
+
+	c.Release(5)
+	require.Len(t, c.Stats(), 1)
+}
@@ -0,0 +1,82 @@
+package prover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// InputSchemaVersion is the version of ProofJobInput this publisher emits.
+// Bump it together with the golden files whenever the job input changes
+// shape; the prover service advertises the version it expects.
+const InputSchemaVersion = "1"
+
+// ErrIncompatibleProver is returned when the prover service expects a
+// different input schema or verifying key than the publisher is built for.
+var ErrIncompatibleProver = errors.New("prover: incompatible prover service")
+
+// CompatInfo is the compatibility contract a prover service advertises.
+type CompatInfo struct {
+	// SchemaVersion is the ProofJobInput schema the service expects.
+	SchemaVersion string `json:"schema_version"`
+	// VKeyDigest identifies the verifying key the service proves against;
+	// it must match the key registered with the L1 verifier.
+	VKeyDigest string `json:"vkey_digest"`
+}
+
+// FetchCompat queries a prover service's GET /compat endpoint.
+func FetchCompat(ctx context.Context, baseURL string) (CompatInfo, error) {
+	url := strings.TrimSuffix(baseURL, "/") + "/compat"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return CompatInfo{}, err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return CompatInfo{}, fmt.Errorf("prover: compat query: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return CompatInfo{}, fmt.Errorf("prover: compat query: %s: %s", resp.Status, body)
+	}
+	var info CompatInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return CompatInfo{}, fmt.Errorf("prover: compat query: %w", err)
+	}
+	return info, nil
+}
+
+// CheckCompat verifies the advertised contract against this publisher:
+// the schema version must equal InputSchemaVersion, and, when a vkey
+// digest is configured, it must match the service's. Failing fast here
+// beats producing proofs the L1 verifier rejects.
+func CheckCompat(info CompatInfo, expectedVKeyDigest string) error {
+	if info.SchemaVersion != InputSchemaVersion {
+		return fmt.Errorf("%w: service expects input schema %q, publisher emits %q",
+			ErrIncompatibleProver, info.SchemaVersion, InputSchemaVersion)
+	}
+	if expectedVKeyDigest != "" && !strings.EqualFold(info.VKeyDigest, expectedVKeyDigest) {
+		return fmt.Errorf("%w: service vkey digest %s, configured %s",
+			ErrIncompatibleProver, info.VKeyDigest, expectedVKeyDigest)
+	}
+	return nil
+}
+
+// VerifyService is the startup check: fetch and compare in one call.
+func VerifyService(ctx context.Context, baseURL, expectedVKeyDigest string) (CompatInfo, error) {
+	info, err := FetchCompat(ctx, baseURL)
+	if err != nil {
+		return CompatInfo{}, err
+	}
+	if err := CheckCompat(info, expectedVKeyDigest); err != nil {
+		return info, err
+	}
+	return info, nil
+}
@@ -0,0 +1,42 @@
+package prover
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func compatServer(t *testing.T, info CompatInfo) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/compat", r.URL.Path)
+		require.NoError(t, json.NewEncoder(w).Encode(info))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVerifyServiceCompatible(t *testing.T) {
+	srv := compatServer(t, CompatInfo{SchemaVersion: InputSchemaVersion, VKeyDigest: "0xABC"})
+	info, err := VerifyService(context.Background(), srv.URL, "0xabc")
+	require.NoError(t, err)
+	require.Equal(t, "0xABC", info.VKeyDigest)
+
+	// No configured vkey skips the digest comparison.
+	_, err = VerifyService(context.Background(), srv.URL, "")
+	require.NoError(t, err)
+}
+
+func TestVerifyServiceRejectsMismatches(t *testing.T) {
+	srv := compatServer(t, CompatInfo{SchemaVersion: "999", VKeyDigest: "0xabc"})
+	_, err := VerifyService(context.Background(), srv.URL, "0xabc")
+	require.ErrorIs(t, err, ErrIncompatibleProver)
+
+	srv2 := compatServer(t, CompatInfo{SchemaVersion: InputSchemaVersion, VKeyDigest: "0xother"})
+	_, err = VerifyService(context.Background(), srv2.URL, "0xabc")
+	require.ErrorIs(t, err, ErrIncompatibleProver)
+}
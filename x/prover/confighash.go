@@ -0,0 +1,44 @@
+package prover
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// RollupConfigHash commits to a network's full rollup configuration: the
+// rollup.json and genesis.json pair its verifier was parameterized with.
+// Both documents are canonicalized before hashing — decoded and
+// re-encoded with sorted keys — so formatting and key order do not change
+// the hash, only the configuration itself does. The publisher CLI's
+// confighash command computes it for operators; the value rides proof job
+// inputs so provers bind their proofs to the exact configuration.
+func RollupConfigHash(rollupJSON, genesisJSON []byte) (types.Hash, error) {
+	rollup, err := canonicalJSON(rollupJSON)
+	if err != nil {
+		return types.Hash{}, fmt.Errorf("prover: rollup config: %w", err)
+	}
+	genesis, err := canonicalJSON(genesisJSON)
+	if err != nil {
+		return types.Hash{}, fmt.Errorf("prover: genesis config: %w", err)
+	}
+	h := sha256.New()
+	h.Write(rollup)
+	h.Write([]byte{0}) // document separator: no content can shift across it
+	h.Write(genesis)
+	var out types.Hash
+	copy(out[:], h.Sum(nil))
+	return out, nil
+}
+
+// canonicalJSON re-encodes a JSON document deterministically: object keys
+// sorted, no insignificant whitespace.
+func canonicalJSON(data []byte) ([]byte, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
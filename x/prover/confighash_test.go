@@ -0,0 +1,42 @@
+package prover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestRollupConfigHashIgnoresFormatting(t *testing.T) {
+	a, err := RollupConfigHash(
+		[]byte(`{"l2_chain_id": 10, "block_time": 2}`),
+		[]byte(`{"config": {"chainId": 10}}`))
+	require.NoError(t, err)
+
+	b, err := RollupConfigHash(
+		[]byte("{\n  \"block_time\": 2,\n  \"l2_chain_id\": 10\n}"),
+		[]byte(`{ "config": { "chainId": 10 } }`))
+	require.NoError(t, err)
+	require.Equal(t, a, b, "key order and whitespace must not matter")
+
+	c, err := RollupConfigHash(
+		[]byte(`{"l2_chain_id": 10, "block_time": 3}`),
+		[]byte(`{"config": {"chainId": 10}}`))
+	require.NoError(t, err)
+	require.NotEqual(t, a, c, "configuration changes must change the hash")
+
+	_, err = RollupConfigHash([]byte(`{`), []byte(`{}`))
+	require.Error(t, err)
+}
+
+func TestPolicyCarriesRollupConfigHash(t *testing.T) {
+	sb := &types.Superblock{
+		Number:   3,
+		L2Blocks: []*types.L2Block{{ChainID: 1, Number: 5}},
+	}
+	policy := Policy{RequireProof: true, RollupConfigHash: types.Hash{0xc0}}
+	input := JobInputForPolicy(sb, policy)
+	require.NotNil(t, input)
+	require.Equal(t, policy.RollupConfigHash, input.RollupConfigHash)
+}
@@ -0,0 +1,72 @@
+package prover
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// Golden files pin the exact JSON handed to the external prover. A failing
+// diff means the prover contract changed: either fix the regression or,
+// for an intentional change, regenerate with `go test -run Golden -update`
+// and review the diff alongside the prover team.
+var update = flag.Bool("update", false, "rewrite golden files")
+
+// fixtureSuperblock is a fully-populated superblock with fixed values, so
+// the serialized job input is stable across runs.
+func fixtureSuperblock() *types.Superblock {
+	return &types.Superblock{
+		Number:       42,
+		Slot:         7,
+		Hash:         types.Hash{0x01, 0x02},
+		ParentHash:   types.Hash{0x03, 0x04},
+		MerkleRoot:   types.Hash{0x05, 0x06},
+		MerkleScheme: "binary",
+		Timestamp:    1700000000,
+		L2Blocks: []*types.L2Block{
+			{ChainID: 1, Number: 100, Hash: types.Hash{0x11}, ParentHash: types.Hash{0x10}, Slot: 7, Timestamp: 1700000001, Payload: []byte("chain-1-block")},
+			{ChainID: 2, Number: 200, Hash: types.Hash{0x22}, ParentHash: types.Hash{0x21}, Slot: 7, Timestamp: 1700000002, Payload: []byte("chain-2-block")},
+		},
+		Decisions: []types.Decision{
+			{XtID: types.Hash{0xaa}, Committed: true, Slot: 7},
+			{XtID: types.Hash{0xbb}, Committed: false, Slot: 7, AbortReason: types.AbortReasonTimeout},
+		},
+		DecisionsRoot: types.Hash{0x07, 0x08},
+		Status:        types.SuperblockStatusSealed,
+	}
+}
+
+func checkGolden(t *testing.T, name string, v any) {
+	t.Helper()
+	got, err := json.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "golden file missing; run with -update")
+	require.Equal(t, string(want), string(got), "prover input changed; if intentional, regenerate with -update")
+}
+
+func TestJobInputGolden(t *testing.T) {
+	checkGolden(t, "job_input.golden.json", JobInput(fixtureSuperblock()))
+}
+
+func TestJobInputForPolicyGolden(t *testing.T) {
+	input := JobInputForPolicy(fixtureSuperblock(), Policy{
+		RequireProof: true,
+		Chains:       map[types.ChainID]bool{2: false},
+	})
+	checkGolden(t, "job_input_policy.golden.json", input)
+}
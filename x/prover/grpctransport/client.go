@@ -0,0 +1,189 @@
+// Package grpctransport is the grpc-backed prover client. It lives in its
+// own module so the core publisher stays free of the grpc dependency;
+// blank-importing it registers the "grpc" transport with x/prover:
+//
+//	import _ "github.com/compose-network/publisher/x/prover/grpctransport"
+//
+// The service keeps the HTTP API's JSON message shapes instead of a
+// protobuf schema: SubmitJob is a unary call answering with the initial
+// JobStatus, and WatchJob streams status updates until the job reaches a
+// terminal state, replacing the HTTP client's polling fallback.
+package grpctransport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	grpcstatus "google.golang.org/grpc/status"
+
+	"github.com/compose-network/publisher/x/prover"
+)
+
+// CodecName is the grpc content-subtype under which the JSON codec is
+// registered; every call on the connection uses it.
+const CodecName = "prover-json"
+
+// Full method names of the prover service.
+const (
+	methodSubmitJob = "/prover.Prover/SubmitJob"
+	methodWatchJob  = "/prover.Prover/WatchJob"
+)
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+	if err := prover.RegisterClientTransport("grpc", New); err != nil {
+		panic(err)
+	}
+}
+
+// jsonCodec encodes grpc messages as JSON so the service shares its wire
+// shapes with the HTTP API.
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                       { return CodecName }
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// SubmitRequest is the SubmitJob call's request body.
+type SubmitRequest struct {
+	Stage string                `json:"stage"`
+	Input *prover.ProofJobInput `json:"input"`
+}
+
+// WatchRequest selects the job whose status updates WatchJob streams.
+type WatchRequest struct {
+	JobID string `json:"job_id"`
+}
+
+// watchStreamDesc describes the server-streaming WatchJob call.
+var watchStreamDesc = &grpc.StreamDesc{
+	StreamName:    "WatchJob",
+	ServerStreams: true,
+}
+
+// client implements prover.Client over a grpc connection.
+type client struct {
+	logger *zap.Logger
+	cfg    prover.ClientConfig
+	conn   *grpc.ClientConn
+}
+
+// New builds the grpc prover client; prover.NewClient dispatches here for
+// ClientConfig.Transport "grpc", with URL as the grpc target address.
+func New(logger *zap.Logger, cfg prover.ClientConfig) (prover.Client, error) {
+	return newClient(logger, cfg)
+}
+
+func newClient(logger *zap.Logger, cfg prover.ClientConfig, extra ...grpc.DialOption) (prover.Client, error) {
+	opts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.CallContentSubtype(CodecName)),
+	}, extra...)
+	conn, err := grpc.NewClient(cfg.URL, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("prover: grpc dial %s: %w", cfg.URL, err)
+	}
+	return &client{logger: logger.Named("prover-grpc"), cfg: cfg, conn: conn}, nil
+}
+
+// Prove submits the job and follows its status stream to completion.
+func (c *client) Prove(ctx context.Context, stage string, input *prover.ProofJobInput) error {
+	submitCtx, cancel := context.WithTimeout(ctx, c.cfg.RequestTimeout)
+	defer cancel()
+	var status prover.JobStatus
+	if err := c.conn.Invoke(submitCtx, methodSubmitJob, &SubmitRequest{Stage: stage, Input: input}, &status); err != nil {
+		return fmt.Errorf("prover: submit job: %w", err)
+	}
+	c.logger.Info("proof job submitted",
+		zap.String("stage", stage),
+		zap.String("job_id", status.JobID),
+		zap.Uint64("superblock", input.SuperblockNumber))
+	if done, err := jobDone(status); done {
+		return err
+	}
+	return c.watch(ctx, status.JobID)
+}
+
+// Close releases the underlying connection.
+func (c *client) Close() error { return c.conn.Close() }
+
+// watch follows the job's status stream to a terminal state. A broken
+// stream is re-opened after PollInterval — the job keeps running server
+// side — so transient connection loss degrades into a delay rather than a
+// failed job.
+func (c *client) watch(ctx context.Context, jobID string) error {
+	for {
+		done, err := c.watchOnce(ctx, jobID)
+		if done {
+			return err
+		}
+		c.logger.Warn("watch stream interrupted, re-opening",
+			zap.String("job_id", jobID), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.cfg.PollInterval):
+		}
+	}
+}
+
+// watchOnce runs a single WatchJob stream. done reports whether the job's
+// outcome is decided (err then carries it); a false done means the stream
+// broke and is worth re-opening.
+func (c *client) watchOnce(ctx context.Context, jobID string) (done bool, err error) {
+	stream, err := c.conn.NewStream(ctx, watchStreamDesc, methodWatchJob, grpc.CallContentSubtype(CodecName))
+	if err != nil {
+		return c.watchFatal(ctx, jobID, err)
+	}
+	if err := stream.SendMsg(&WatchRequest{JobID: jobID}); err != nil {
+		return c.watchFatal(ctx, jobID, err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		return false, err
+	}
+	for {
+		var status prover.JobStatus
+		if err := stream.RecvMsg(&status); err != nil {
+			if errors.Is(err, io.EOF) {
+				return false, errors.New("stream closed before a terminal state")
+			}
+			return c.watchFatal(ctx, jobID, err)
+		}
+		if done, err := jobDone(status); done {
+			return true, err
+		}
+	}
+}
+
+// watchFatal classifies a stream error: a cancelled context or a server
+// without WatchJob ends the watch for good, anything else is retriable.
+func (c *client) watchFatal(ctx context.Context, jobID string, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return true, ctx.Err()
+	}
+	if grpcstatus.Code(err) == codes.Unimplemented {
+		return true, fmt.Errorf("prover: watch job %s: %w", jobID, err)
+	}
+	return false, err
+}
+
+// jobDone mirrors the prover package's terminal-state handling.
+func jobDone(status prover.JobStatus) (bool, error) {
+	switch status.State {
+	case prover.JobStateCompleted:
+		return true, nil
+	case prover.JobStateFailed:
+		return true, fmt.Errorf("prover: job %s failed: %s", status.JobID, status.Error)
+	default:
+		return false, nil
+	}
+}
@@ -0,0 +1,150 @@
+package grpctransport
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/compose-network/publisher/x/prover"
+)
+
+// proverServer is the service surface the test server implements; it
+// mirrors the wire contract in client.go.
+type proverServer interface {
+	submit(context.Context, *SubmitRequest) (*prover.JobStatus, error)
+	watch(*WatchRequest, grpc.ServerStream) error
+}
+
+var proverServiceDesc = grpc.ServiceDesc{
+	ServiceName: "prover.Prover",
+	HandlerType: (*proverServer)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "SubmitJob",
+		Handler: func(srv any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+			req := new(SubmitRequest)
+			if err := dec(req); err != nil {
+				return nil, err
+			}
+			return srv.(proverServer).submit(ctx, req)
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "WatchJob",
+		ServerStreams: true,
+		Handler: func(srv any, stream grpc.ServerStream) error {
+			req := new(WatchRequest)
+			if err := stream.RecvMsg(req); err != nil {
+				return err
+			}
+			return srv.(proverServer).watch(req, stream)
+		},
+	}},
+}
+
+// scriptedProver answers SubmitJob with a running status whose job ID is
+// the stage name, then plays the scripted updates back on WatchJob.
+type scriptedProver struct {
+	mu      sync.Mutex
+	scripts map[string][]prover.JobStatus
+	// breaks counts how many watch streams per job to tear down after the
+	// first update, exercising the client's re-open path.
+	breaks map[string]int
+}
+
+func (p *scriptedProver) submit(_ context.Context, req *SubmitRequest) (*prover.JobStatus, error) {
+	return &prover.JobStatus{JobID: req.Stage, State: prover.JobStateRunning}, nil
+}
+
+func (p *scriptedProver) watch(req *WatchRequest, stream grpc.ServerStream) error {
+	p.mu.Lock()
+	updates := append([]prover.JobStatus(nil), p.scripts[req.JobID]...)
+	broken := p.breaks[req.JobID] > 0
+	if broken {
+		p.breaks[req.JobID]--
+	}
+	p.mu.Unlock()
+	if broken {
+		if len(updates) > 0 {
+			if err := stream.SendMsg(&updates[0]); err != nil {
+				return err
+			}
+		}
+		return errors.New("stream torn down")
+	}
+	for i := range updates {
+		if err := stream.SendMsg(&updates[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startProver(t *testing.T, impl *scriptedProver) prover.Client {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	srv.RegisterService(&proverServiceDesc, impl)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	c, err := newClient(zap.NewNop(), prover.ClientConfig{
+		URL:            "passthrough:///prover",
+		PollInterval:   10 * time.Millisecond,
+		RequestTimeout: time.Second,
+	}, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestProveFollowsWatchStream(t *testing.T) {
+	impl := &scriptedProver{scripts: map[string][]prover.JobStatus{
+		"range_proof": {
+			{JobID: "range_proof", State: prover.JobStateRunning},
+			{JobID: "range_proof", State: prover.JobStateCompleted},
+		},
+	}}
+	c := startProver(t, impl)
+	require.NoError(t, c.Prove(context.Background(), "range_proof", &prover.ProofJobInput{SuperblockNumber: 1}))
+}
+
+func TestProveSurfacesJobFailure(t *testing.T) {
+	impl := &scriptedProver{scripts: map[string][]prover.JobStatus{
+		"aggregation": {
+			{JobID: "aggregation", State: prover.JobStateFailed, Error: "circuit mismatch"},
+		},
+	}}
+	c := startProver(t, impl)
+	err := c.Prove(context.Background(), "aggregation", &prover.ProofJobInput{SuperblockNumber: 2})
+	require.ErrorContains(t, err, "circuit mismatch")
+}
+
+func TestWatchReopensBrokenStream(t *testing.T) {
+	impl := &scriptedProver{
+		scripts: map[string][]prover.JobStatus{
+			"range_proof": {
+				{JobID: "range_proof", State: prover.JobStateRunning},
+				{JobID: "range_proof", State: prover.JobStateCompleted},
+			},
+		},
+		breaks: map[string]int{"range_proof": 1},
+	}
+	c := startProver(t, impl)
+	require.NoError(t, c.Prove(context.Background(), "range_proof", &prover.ProofJobInput{SuperblockNumber: 3}))
+}
+
+func TestTransportRegistered(t *testing.T) {
+	c, err := prover.NewClient(zap.NewNop(), prover.ClientConfig{Transport: "grpc", URL: "dns:///localhost:0"})
+	require.NoError(t, err)
+	require.NoError(t, c.Close())
+}
@@ -0,0 +1,12 @@
+module github.com/compose-network/publisher/x/prover/grpctransport
+
+go 1.21
+
+require (
+	github.com/compose-network/publisher v0.0.0
+	github.com/stretchr/testify v1.9.0
+	go.uber.org/zap v1.28.0
+	google.golang.org/grpc v1.65.0
+)
+
+replace github.com/compose-network/publisher => ../../..
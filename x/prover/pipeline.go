@@ -0,0 +1,357 @@
+package prover
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/metrics"
+	"github.com/compose-network/publisher/x/bus"
+)
+
+// Pipeline stages, in execution order. Each superblock's job runs the
+// per-chain range proofs first, then the network-wide aggregation.
+const (
+	StageRangeProof  = "range_proof"
+	StageAggregation = "aggregation"
+)
+
+// stageOrder drives stage advancement.
+var stageOrder = []string{StageRangeProof, StageAggregation}
+
+// maxQueuedJobs bounds the total number of jobs waiting across stages.
+const maxQueuedJobs = 256
+
+// ProveFunc executes one stage of a proof job against the prover backend.
+type ProveFunc func(ctx context.Context, stage string, input *ProofJobInput) error
+
+// PipelineConfig tunes the proof pipeline.
+type PipelineConfig struct {
+	// Workers is the default concurrency for range proofs. Zero means 2.
+	Workers int `yaml:"Workers"`
+	// StageConcurrency caps concurrent jobs per stage. Stages absent from
+	// the map default to Workers for range proofs and 1 for aggregation,
+	// which must run alone.
+	StageConcurrency map[string]int `yaml:"StageConcurrency"`
+	// MaxAttempts is how often a stage is tried before the job is
+	// abandoned. Zero means 3.
+	MaxAttempts int `yaml:"MaxAttempts"`
+	// RetryDelay is the pause before a failed stage is retried. Zero means
+	// 10s.
+	RetryDelay time.Duration `yaml:"RetryDelay"`
+}
+
+func (c PipelineConfig) withDefaults() PipelineConfig {
+	if c.Workers <= 0 {
+		c.Workers = 2
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 3
+	}
+	if c.RetryDelay <= 0 {
+		c.RetryDelay = 10 * time.Second
+	}
+	return c
+}
+
+// limits resolves the effective per-stage concurrency caps.
+func (c PipelineConfig) limits() map[string]int {
+	limits := map[string]int{
+		StageRangeProof:  c.Workers,
+		StageAggregation: 1,
+	}
+	for stage, n := range c.StageConcurrency {
+		if n > 0 {
+			limits[stage] = n
+		}
+	}
+	return limits
+}
+
+// job is one superblock moving through the stages.
+type job struct {
+	input    *ProofJobInput
+	stage    int // index into stageOrder
+	attempts int
+	entered  time.Time // when the job entered the current stage
+}
+
+// Pipeline runs proof jobs through the stages under per-stage concurrency
+// caps, with retries and Prometheus instrumentation. A single scheduler
+// dispatches ready jobs the moment a stage slot frees up; workers never
+// poll.
+type Pipeline struct {
+	logger *zap.Logger
+	cfg    PipelineConfig
+	prove  ProveFunc
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queues  map[string][]*job
+	running map[string]int
+	limits  map[string]int
+	queued  int
+	stopped bool
+
+	lastCompletedAt  time.Time
+	lastCompletedNum uint64
+
+	bus    *bus.Bus
+	status *StatusTracker
+
+	wg     sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewPipeline creates a pipeline executing stages via prove.
+func NewPipeline(logger *zap.Logger, cfg PipelineConfig, prove ProveFunc) *Pipeline {
+	cfg = cfg.withDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Pipeline{
+		logger:  logger.Named("proof-pipeline"),
+		cfg:     cfg,
+		prove:   prove,
+		queues:  make(map[string][]*job),
+		running: make(map[string]int),
+		limits:  cfg.limits(),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// SetBus attaches the event bus; each finished job is then published on
+// TopicProofCompleted. Must be called before Start.
+func (p *Pipeline) SetBus(eventBus *bus.Bus) {
+	p.bus = eventBus
+}
+
+// SetStatusTracker makes the pipeline record every job's lifecycle
+// transitions — queued, proving, retries, completion or abandonment — in
+// the tracker. Must be called before Start.
+func (p *Pipeline) SetStatusTracker(tracker *StatusTracker) {
+	p.status = tracker
+}
+
+// trackStatus records a lifecycle transition when a tracker is attached.
+// The pipeline only performs legal moves, so a rejection is a bug worth a
+// log line rather than a failed job.
+func (p *Pipeline) trackStatus(superblockNumber uint64, to Status, detail string) {
+	if p.status == nil {
+		return
+	}
+	if err := p.status.Transition(superblockNumber, to, detail); err != nil {
+		p.logger.Warn("status transition rejected", zap.Error(err))
+	}
+}
+
+// Start launches the scheduler.
+func (p *Pipeline) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.schedule()
+	}()
+}
+
+// Stop cancels in-flight jobs and waits for the scheduler and workers.
+func (p *Pipeline) Stop() {
+	p.cancel()
+	p.mu.Lock()
+	p.stopped = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// Enqueue submits a superblock for proving, starting at the first stage.
+func (p *Pipeline) Enqueue(input *ProofJobInput) error {
+	j := &job{input: input, entered: time.Now()}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.queued >= maxQueuedJobs {
+		return fmt.Errorf("prover: pipeline queue full, dropping superblock %d", input.SuperblockNumber)
+	}
+	p.push(j)
+	metrics.RecordPipelineJobStart(stageOrder[j.stage])
+	p.trackStatus(input.SuperblockNumber, StatusQueued, "")
+	return nil
+}
+
+// push appends a job to its stage queue and wakes the scheduler. Callers
+// hold p.mu.
+func (p *Pipeline) push(j *job) {
+	stage := stageOrder[j.stage]
+	p.queues[stage] = append(p.queues[stage], j)
+	p.queued++
+	metrics.SetPipelineQueueDepth(stage, len(p.queues[stage]))
+	p.cond.Signal()
+}
+
+// schedule dispatches queued jobs whenever their stage has a free slot,
+// blocking on the condition variable otherwise.
+func (p *Pipeline) schedule() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for {
+		for !p.stopped && !p.dispatchable() {
+			p.cond.Wait()
+		}
+		if p.stopped {
+			return
+		}
+		// Later stages first so finished range proofs aggregate promptly.
+		for i := len(stageOrder) - 1; i >= 0; i-- {
+			stage := stageOrder[i]
+			for len(p.queues[stage]) > 0 && p.running[stage] < p.limits[stage] {
+				j := p.queues[stage][0]
+				p.queues[stage] = p.queues[stage][1:]
+				p.queued--
+				metrics.SetPipelineQueueDepth(stage, len(p.queues[stage]))
+				p.running[stage]++
+				p.wg.Add(1)
+				go func() {
+					defer p.wg.Done()
+					p.run(j)
+				}()
+			}
+		}
+	}
+}
+
+// dispatchable reports whether any stage has both a queued job and a free
+// slot. Callers hold p.mu.
+func (p *Pipeline) dispatchable() bool {
+	for _, stage := range stageOrder {
+		if len(p.queues[stage]) > 0 && p.running[stage] < p.limits[stage] {
+			return true
+		}
+	}
+	return false
+}
+
+// run executes the job's current stage and advances, retries or abandons
+// it depending on the outcome.
+func (p *Pipeline) run(j *job) {
+	stage := stageOrder[j.stage]
+	p.trackStatus(j.input.SuperblockNumber, StatusProving, stage)
+	start := time.Now()
+	err := p.prove(p.ctx, stage, j.input)
+	metrics.ObserveProverRequestLatency(stage, time.Since(start))
+
+	p.mu.Lock()
+	p.running[stage]--
+	p.cond.Signal()
+	p.mu.Unlock()
+
+	if err != nil {
+		j.attempts++
+		if j.attempts >= p.cfg.MaxAttempts {
+			metrics.RecordPipelineJobEnd(stage, time.Since(j.entered))
+			metrics.RecordProofFailed(stage)
+			p.trackStatus(j.input.SuperblockNumber, StatusFailed,
+				fmt.Sprintf("%s abandoned after %d attempts: %v", stage, j.attempts, err))
+			p.logger.Error("proof job abandoned",
+				zap.Uint64("superblock", j.input.SuperblockNumber),
+				zap.String("stage", stage),
+				zap.Int("attempts", j.attempts),
+				zap.Error(err))
+			return
+		}
+		metrics.RecordPipelineRetry(stage)
+		p.trackStatus(j.input.SuperblockNumber, StatusQueued,
+			fmt.Sprintf("%s retry %d: %v", stage, j.attempts, err))
+		p.logger.Warn("proof stage failed, retrying",
+			zap.Uint64("superblock", j.input.SuperblockNumber),
+			zap.String("stage", stage),
+			zap.Int("attempt", j.attempts),
+			zap.Error(err))
+		p.requeue(j, p.cfg.RetryDelay)
+		return
+	}
+
+	metrics.RecordPipelineJobEnd(stage, time.Since(j.entered))
+	if j.stage+1 == len(stageOrder) {
+		now := time.Now()
+		p.mu.Lock()
+		p.lastCompletedAt = now
+		p.lastCompletedNum = j.input.SuperblockNumber
+		p.mu.Unlock()
+		metrics.RecordProofCompleted()
+		metrics.SetLastProofCompleted(now)
+		p.trackStatus(j.input.SuperblockNumber, StatusComplete, "")
+		p.logger.Info("proof job completed",
+			zap.Uint64("superblock", j.input.SuperblockNumber))
+		if p.bus != nil {
+			p.bus.Publish(bus.TopicProofCompleted, j.input)
+		}
+		return
+	}
+	j.stage++
+	j.attempts = 0
+	j.entered = time.Now()
+	metrics.RecordPipelineJobStart(stageOrder[j.stage])
+	p.trackStatus(j.input.SuperblockNumber, StatusQueued, stageOrder[j.stage]+" queued")
+	p.requeue(j, 0)
+}
+
+// PipelineStats is a point-in-time view of the proving backlog, for the
+// stats endpoint; the same numbers feed the Prometheus gauges.
+type PipelineStats struct {
+	// Queued is the number of jobs waiting per stage, excluding running.
+	Queued map[string]int `json:"queued"`
+	// Running is the number of jobs executing per stage.
+	Running map[string]int `json:"running"`
+	// TotalQueued is the queue total counted against the pipeline cap.
+	TotalQueued int `json:"total_queued"`
+	// LastCompletedAt is when the last job cleared every stage; zero if no
+	// job has completed yet.
+	LastCompletedAt time.Time `json:"last_completed_at,omitempty"`
+	// LastCompletedNumber is the superblock of that job.
+	LastCompletedNumber uint64 `json:"last_completed_number,omitempty"`
+}
+
+// Stats snapshots the pipeline's queues and completion watermark.
+func (p *Pipeline) Stats() PipelineStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := PipelineStats{
+		Queued:              make(map[string]int, len(stageOrder)),
+		Running:             make(map[string]int, len(stageOrder)),
+		TotalQueued:         p.queued,
+		LastCompletedAt:     p.lastCompletedAt,
+		LastCompletedNumber: p.lastCompletedNum,
+	}
+	for _, stage := range stageOrder {
+		stats.Queued[stage] = len(p.queues[stage])
+		stats.Running[stage] = p.running[stage]
+	}
+	return stats
+}
+
+// requeue puts the job back on its stage queue after delay, dropping it if
+// the pipeline is stopping.
+func (p *Pipeline) requeue(j *job, delay time.Duration) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		if delay > 0 {
+			select {
+			case <-p.ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.stopped {
+			return
+		}
+		p.push(j)
+	}()
+}
@@ -0,0 +1,138 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPipelineRunsStagesInOrder(t *testing.T) {
+	var mu sync.Mutex
+	var stages []string
+	pipeline := NewPipeline(zap.NewNop(), PipelineConfig{Workers: 1}, func(_ context.Context, stage string, input *ProofJobInput) error {
+		mu.Lock()
+		stages = append(stages, stage)
+		mu.Unlock()
+		return nil
+	})
+	pipeline.Start()
+	defer pipeline.Stop()
+
+	require.NoError(t, pipeline.Enqueue(&ProofJobInput{SuperblockNumber: 1}))
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(stages) == 2
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	require.Equal(t, []string{StageRangeProof, StageAggregation}, stages)
+	mu.Unlock()
+}
+
+func TestPipelineRetriesThenAbandons(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+	pipeline := NewPipeline(zap.NewNop(), PipelineConfig{Workers: 1, MaxAttempts: 3, RetryDelay: time.Millisecond}, func(_ context.Context, stage string, _ *ProofJobInput) error {
+		mu.Lock()
+		attempts++
+		mu.Unlock()
+		return errors.New("prover unavailable")
+	})
+	pipeline.Start()
+	defer pipeline.Stop()
+
+	require.NoError(t, pipeline.Enqueue(&ProofJobInput{SuperblockNumber: 2}))
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return attempts == 3
+	}, time.Second, time.Millisecond)
+	// The job was abandoned after MaxAttempts; no further tries happen.
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	require.Equal(t, 3, attempts)
+	mu.Unlock()
+}
+
+func TestPipelineStageConcurrencyCaps(t *testing.T) {
+	var mu sync.Mutex
+	inflight := map[string]int{}
+	peak := map[string]int{}
+	release := make(chan struct{})
+	pipeline := NewPipeline(zap.NewNop(), PipelineConfig{
+		Workers:          4,
+		StageConcurrency: map[string]int{StageAggregation: 1},
+	}, func(_ context.Context, stage string, _ *ProofJobInput) error {
+		mu.Lock()
+		inflight[stage]++
+		if inflight[stage] > peak[stage] {
+			peak[stage] = inflight[stage]
+		}
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		inflight[stage]--
+		mu.Unlock()
+		return nil
+	})
+	pipeline.Start()
+	defer pipeline.Stop()
+
+	for i := 0; i < 6; i++ {
+		require.NoError(t, pipeline.Enqueue(&ProofJobInput{SuperblockNumber: uint64(i)}))
+	}
+	// Range proofs fan out to the worker limit while aggregation stays
+	// serialized.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return inflight[StageRangeProof] == 4
+	}, time.Second, time.Millisecond)
+	close(release)
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return peak[StageAggregation] >= 1 && inflight[StageRangeProof] == 0 && inflight[StageAggregation] == 0
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	require.Equal(t, 4, peak[StageRangeProof])
+	require.Equal(t, 1, peak[StageAggregation])
+	mu.Unlock()
+}
+
+func TestPipelineStatsTrackBacklogAndCompletion(t *testing.T) {
+	release := make(chan struct{})
+	pipeline := NewPipeline(zap.NewNop(), PipelineConfig{Workers: 1}, func(ctx context.Context, stage string, _ *ProofJobInput) error {
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	pipeline.Start()
+	defer pipeline.Stop()
+
+	stats := pipeline.Stats()
+	require.Zero(t, stats.TotalQueued)
+	require.True(t, stats.LastCompletedAt.IsZero())
+
+	require.NoError(t, pipeline.Enqueue(&ProofJobInput{SuperblockNumber: 7}))
+	require.NoError(t, pipeline.Enqueue(&ProofJobInput{SuperblockNumber: 8}))
+	require.Eventually(t, func() bool {
+		return pipeline.Stats().Running[StageRangeProof] == 1
+	}, time.Second, time.Millisecond)
+	// One running (Workers: 1), the other still queued.
+	require.Equal(t, 1, pipeline.Stats().Queued[StageRangeProof])
+
+	close(release)
+	require.Eventually(t, func() bool {
+		stats := pipeline.Stats()
+		return stats.LastCompletedNumber == 8 && !stats.LastCompletedAt.IsZero()
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,47 @@
+package prover
+
+import (
+	"github.com/compose-network/publisher/types"
+)
+
+// Policy says which chains' blocks must be covered by a validity proof.
+// Chains bootstrapping a new rollup often run proofless for a while; their
+// blocks are published but excluded from aggregation inputs.
+type Policy struct {
+	// RequireProof is the network-wide default.
+	RequireProof bool `yaml:"RequireProof"`
+	// Chains overrides the default per chain ID.
+	Chains map[types.ChainID]bool `yaml:"Chains"`
+	// RollupConfigHash commits to the network's rollup.json + genesis.json
+	// pair (see RollupConfigHash the function, or the CLI's confighash
+	// command). It is copied into every proof job input; left zero, provers
+	// that check it will reject the jobs.
+	RollupConfigHash types.Hash `yaml:"RollupConfigHash"`
+}
+
+// Requires reports whether blocks from the chain need proving.
+func (p Policy) Requires(chainID types.ChainID) bool {
+	if required, ok := p.Chains[chainID]; ok {
+		return required
+	}
+	return p.RequireProof
+}
+
+// JobInputForPolicy builds the proving input covering only the chains the
+// policy requires proofs for. It returns nil when no included block needs
+// proving, in which case the superblock publishes without a proof job.
+func JobInputForPolicy(sb *types.Superblock, policy Policy) *ProofJobInput {
+	input := JobInput(sb)
+	input.RollupConfigHash = policy.RollupConfigHash
+	blocks := make([]*types.L2Block, 0, len(sb.L2Blocks))
+	for _, block := range sb.L2Blocks {
+		if policy.Requires(block.ChainID) {
+			blocks = append(blocks, block)
+		}
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	input.L2Blocks = blocks
+	return input
+}
@@ -0,0 +1,40 @@
+package prover
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestPolicyRequires(t *testing.T) {
+	p := Policy{RequireProof: true, Chains: map[types.ChainID]bool{2: false}}
+	require.True(t, p.Requires(1))
+	require.False(t, p.Requires(2), "per-chain override wins")
+
+	proofless := Policy{Chains: map[types.ChainID]bool{3: true}}
+	require.False(t, proofless.Requires(1))
+	require.True(t, proofless.Requires(3))
+}
+
+func TestJobInputForPolicyFiltersChains(t *testing.T) {
+	sb := &types.Superblock{
+		Number: 4,
+		L2Blocks: []*types.L2Block{
+			{ChainID: 1, Number: 10},
+			{ChainID: 2, Number: 20},
+		},
+	}
+
+	input := JobInputForPolicy(sb, Policy{RequireProof: true, Chains: map[types.ChainID]bool{2: false}})
+	require.NotNil(t, input)
+	require.Len(t, input.L2Blocks, 1)
+	require.Equal(t, types.ChainID(1), input.L2Blocks[0].ChainID)
+
+	// Nothing to prove: no job.
+	require.Nil(t, JobInputForPolicy(sb, Policy{}))
+
+	// The superblock itself is untouched.
+	require.Len(t, sb.L2Blocks, 2)
+}
@@ -0,0 +1,44 @@
+// Package prover defines the job payloads exchanged with external provers.
+// The publisher exports the exact input a prover receives so proofs can be
+// reproduced and debugged offline.
+package prover
+
+import (
+	"github.com/compose-network/publisher/types"
+)
+
+// ProofJobInput is the payload sent to a prover for one superblock. It
+// carries everything needed to re-derive the commitments independently.
+type ProofJobInput struct {
+	SuperblockNumber uint64     `json:"superblock_number"`
+	Slot             types.Slot `json:"slot"`
+	SuperblockHash   types.Hash `json:"superblock_hash"`
+	ParentHash       types.Hash `json:"parent_hash"`
+	MerkleRoot       types.Hash `json:"merkle_root"`
+	// MerkleScheme names the construction behind MerkleRoot.
+	MerkleScheme string `json:"merkle_scheme,omitempty"`
+	// RollupConfigHash binds the proof to the network's rollup
+	// configuration; see RollupConfigHash for how it is derived.
+	RollupConfigHash types.Hash       `json:"rollup_config_hash,omitempty"`
+	DecisionsRoot    types.Hash       `json:"decisions_root,omitempty"`
+	L2Blocks         []*types.L2Block `json:"l2_blocks"`
+	Decisions        []types.Decision `json:"decisions,omitempty"`
+}
+
+// JobInput builds the proving input for a superblock. Blocks keep the
+// superblock's canonical ordering, so the same superblock always yields
+// the same input and the export endpoint can serve it for batches that
+// were never actually sent to a prover.
+func JobInput(sb *types.Superblock) *ProofJobInput {
+	return &ProofJobInput{
+		SuperblockNumber: sb.Number,
+		Slot:             sb.Slot,
+		SuperblockHash:   sb.Hash,
+		ParentHash:       sb.ParentHash,
+		MerkleRoot:       sb.MerkleRoot,
+		MerkleScheme:     sb.MerkleScheme,
+		DecisionsRoot:    sb.DecisionsRoot,
+		L2Blocks:         sb.L2Blocks,
+		Decisions:        sb.Decisions,
+	}
+}
@@ -0,0 +1,159 @@
+package prover
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status is a superblock's position in the proving lifecycle. A
+// superblock starts collecting while its inputs are gathered, is queued
+// when handed to the pipeline, proving while a stage executes, and ends
+// complete or failed.
+type Status string
+
+// Proving lifecycle statuses.
+const (
+	StatusCollecting Status = "collecting"
+	StatusQueued     Status = "queued"
+	StatusProving    Status = "proving"
+	StatusComplete   Status = "complete"
+	StatusFailed     Status = "failed"
+)
+
+// ErrBadTransition rejects a status change the lifecycle does not allow,
+// catching out-of-order updates instead of silently recording them.
+var ErrBadTransition = errors.New("prover: invalid status transition")
+
+// statusTransitions lists the legal moves. Proving falls back to queued
+// between stages and on retries; failed jobs may be requeued.
+var statusTransitions = map[Status]map[Status]bool{
+	StatusCollecting: {StatusQueued: true},
+	StatusQueued:     {StatusProving: true},
+	StatusProving:    {StatusQueued: true, StatusComplete: true, StatusFailed: true},
+	StatusFailed:     {StatusQueued: true},
+}
+
+// maxStatusHistory bounds the transitions retained per superblock; a job
+// stuck in a retry loop keeps the most recent ones.
+const maxStatusHistory = 64
+
+// StatusChange is one recorded transition.
+type StatusChange struct {
+	From Status    `json:"from,omitempty"`
+	To   Status    `json:"to"`
+	At   time.Time `json:"at"`
+	// Detail explains the transition when it is not self-evident: the
+	// failing stage, the retry reason.
+	Detail string `json:"detail,omitempty"`
+}
+
+// SuperblockStatus is one superblock's current status and its history.
+type SuperblockStatus struct {
+	SuperblockNumber uint64 `json:"superblock_number"`
+	Status           Status `json:"status"`
+	// Since is when the current status was entered.
+	Since   time.Time      `json:"since"`
+	History []StatusChange `json:"history"`
+}
+
+// StatusTracker is the proving lifecycle state machine: it validates
+// every transition and retains the timestamped history per superblock for
+// the stats API. All methods are safe for concurrent use.
+type StatusTracker struct {
+	mu      sync.Mutex
+	entries map[uint64]*SuperblockStatus
+}
+
+// NewStatusTracker returns an empty tracker.
+func NewStatusTracker() *StatusTracker {
+	return &StatusTracker{entries: make(map[uint64]*SuperblockStatus)}
+}
+
+// Begin records a superblock entering the lifecycle in StatusCollecting.
+// Calling it again for a known superblock is a no-op, so sealing and
+// enqueueing paths need not coordinate.
+func (t *StatusTracker) Begin(superblockNumber uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.begin(superblockNumber)
+}
+
+// begin creates the entry if absent. Callers hold t.mu.
+func (t *StatusTracker) begin(superblockNumber uint64) *SuperblockStatus {
+	e := t.entries[superblockNumber]
+	if e == nil {
+		now := time.Now()
+		e = &SuperblockStatus{
+			SuperblockNumber: superblockNumber,
+			Status:           StatusCollecting,
+			Since:            now,
+			History:          []StatusChange{{To: StatusCollecting, At: now}},
+		}
+		t.entries[superblockNumber] = e
+	}
+	return e
+}
+
+// Transition moves a superblock to the given status, recording the
+// timestamped change. Unknown superblocks implicitly begin in
+// StatusCollecting first. Moves the lifecycle does not allow return
+// ErrBadTransition and change nothing.
+func (t *StatusTracker) Transition(superblockNumber uint64, to Status, detail string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e := t.begin(superblockNumber)
+	if !statusTransitions[e.Status][to] {
+		return fmt.Errorf("%w: superblock %d cannot go %s -> %s",
+			ErrBadTransition, superblockNumber, e.Status, to)
+	}
+	now := time.Now()
+	e.History = append(e.History, StatusChange{From: e.Status, To: to, At: now, Detail: detail})
+	if len(e.History) > maxStatusHistory {
+		e.History = e.History[len(e.History)-maxStatusHistory:]
+	}
+	e.Status = to
+	e.Since = now
+	return nil
+}
+
+// Get returns a superblock's status and history, if tracked.
+func (t *StatusTracker) Get(superblockNumber uint64) (SuperblockStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.entries[superblockNumber]
+	if !ok {
+		return SuperblockStatus{}, false
+	}
+	return copyStatus(e), true
+}
+
+// Statuses lists all tracked superblocks ordered by number.
+func (t *StatusTracker) Statuses() []SuperblockStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]SuperblockStatus, 0, len(t.entries))
+	for _, e := range t.entries {
+		out = append(out, copyStatus(e))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SuperblockNumber < out[j].SuperblockNumber })
+	return out
+}
+
+// Release forgets a superblock once it is published, mirroring the
+// collector's Release so tracked state does not grow without bound.
+func (t *StatusTracker) Release(superblockNumber uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.entries, superblockNumber)
+}
+
+// copyStatus snapshots an entry so callers never share the live history
+// slice. Callers hold t.mu.
+func copyStatus(e *SuperblockStatus) SuperblockStatus {
+	cp := *e
+	cp.History = append([]StatusChange(nil), e.History...)
+	return cp
+}
@@ -0,0 +1,150 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStatusTrackerValidatesTransitions(t *testing.T) {
+	tracker := NewStatusTracker()
+	tracker.Begin(5)
+
+	st, ok := tracker.Get(5)
+	require.True(t, ok)
+	require.Equal(t, StatusCollecting, st.Status)
+	require.Len(t, st.History, 1)
+
+	// Collecting cannot jump straight to proving or complete.
+	require.ErrorIs(t, tracker.Transition(5, StatusProving, ""), ErrBadTransition)
+	require.ErrorIs(t, tracker.Transition(5, StatusComplete, ""), ErrBadTransition)
+
+	require.NoError(t, tracker.Transition(5, StatusQueued, ""))
+	require.NoError(t, tracker.Transition(5, StatusProving, StageRangeProof))
+	require.NoError(t, tracker.Transition(5, StatusQueued, "aggregation queued"))
+	require.NoError(t, tracker.Transition(5, StatusProving, StageAggregation))
+	require.NoError(t, tracker.Transition(5, StatusComplete, ""))
+
+	// Complete is terminal.
+	require.ErrorIs(t, tracker.Transition(5, StatusQueued, ""), ErrBadTransition)
+
+	st, ok = tracker.Get(5)
+	require.True(t, ok)
+	require.Equal(t, StatusComplete, st.Status)
+	require.Len(t, st.History, 6)
+	require.Equal(t, StatusProving, st.History[5].From)
+	require.Equal(t, StageRangeProof, st.History[2].Detail)
+	for i := 1; i < len(st.History); i++ {
+		require.Equal(t, st.History[i-1].To, st.History[i].From)
+		require.False(t, st.History[i].At.Before(st.History[i-1].At))
+	}
+	require.Equal(t, st.History[5].At, st.Since)
+}
+
+func TestStatusTrackerImplicitBeginAndFailedRequeue(t *testing.T) {
+	tracker := NewStatusTracker()
+
+	// Unknown superblocks implicitly begin collecting on first transition.
+	require.NoError(t, tracker.Transition(9, StatusQueued, ""))
+	require.NoError(t, tracker.Transition(9, StatusProving, StageRangeProof))
+	require.NoError(t, tracker.Transition(9, StatusFailed, "range_proof abandoned after 3 attempts"))
+
+	// A failed superblock may be requeued by an operator.
+	require.NoError(t, tracker.Transition(9, StatusQueued, "manual requeue"))
+
+	// Begin stays idempotent and does not reset the status.
+	tracker.Begin(9)
+	st, ok := tracker.Get(9)
+	require.True(t, ok)
+	require.Equal(t, StatusQueued, st.Status)
+	require.Equal(t, StatusCollecting, st.History[0].To)
+}
+
+func TestStatusTrackerHistoryCapAndRelease(t *testing.T) {
+	tracker := NewStatusTracker()
+	require.NoError(t, tracker.Transition(3, StatusQueued, ""))
+	for i := 0; i < maxStatusHistory; i++ {
+		require.NoError(t, tracker.Transition(3, StatusProving, StageRangeProof))
+		require.NoError(t, tracker.Transition(3, StatusQueued, "retry"))
+	}
+	st, ok := tracker.Get(3)
+	require.True(t, ok)
+	require.Len(t, st.History, maxStatusHistory)
+	require.Equal(t, StatusQueued, st.Status)
+
+	tracker.Release(3)
+	_, ok = tracker.Get(3)
+	require.False(t, ok)
+	require.Empty(t, tracker.Statuses())
+}
+
+func TestStatusTrackerStatusesSortedSnapshot(t *testing.T) {
+	tracker := NewStatusTracker()
+	tracker.Begin(12)
+	tracker.Begin(4)
+	tracker.Begin(8)
+
+	out := tracker.Statuses()
+	require.Len(t, out, 3)
+	require.Equal(t, uint64(4), out[0].SuperblockNumber)
+	require.Equal(t, uint64(8), out[1].SuperblockNumber)
+	require.Equal(t, uint64(12), out[2].SuperblockNumber)
+
+	// Snapshots do not alias the tracker's live history.
+	out[0].History[0].To = StatusFailed
+	st, _ := tracker.Get(4)
+	require.Equal(t, StatusCollecting, st.History[0].To)
+}
+
+func TestPipelineRecordsStatusLifecycle(t *testing.T) {
+	tracker := NewStatusTracker()
+	pipeline := NewPipeline(zap.NewNop(), PipelineConfig{Workers: 1}, func(_ context.Context, _ string, _ *ProofJobInput) error {
+		return nil
+	})
+	pipeline.SetStatusTracker(tracker)
+	pipeline.Start()
+	defer pipeline.Stop()
+
+	tracker.Begin(21)
+	require.NoError(t, pipeline.Enqueue(&ProofJobInput{SuperblockNumber: 21}))
+	require.Eventually(t, func() bool {
+		st, ok := tracker.Get(21)
+		return ok && st.Status == StatusComplete
+	}, time.Second, time.Millisecond)
+
+	st, _ := tracker.Get(21)
+	var visited []Status
+	for _, change := range st.History {
+		visited = append(visited, change.To)
+	}
+	require.Equal(t, []Status{
+		StatusCollecting, StatusQueued,
+		StatusProving, StatusQueued, StatusProving,
+		StatusComplete,
+	}, visited)
+}
+
+func TestPipelineRecordsStatusFailure(t *testing.T) {
+	tracker := NewStatusTracker()
+	pipeline := NewPipeline(zap.NewNop(), PipelineConfig{Workers: 1, MaxAttempts: 2, RetryDelay: time.Millisecond}, func(_ context.Context, _ string, _ *ProofJobInput) error {
+		return errors.New("prover unavailable")
+	})
+	pipeline.SetStatusTracker(tracker)
+	pipeline.Start()
+	defer pipeline.Stop()
+
+	require.NoError(t, pipeline.Enqueue(&ProofJobInput{SuperblockNumber: 22}))
+	require.Eventually(t, func() bool {
+		st, ok := tracker.Get(22)
+		return ok && st.Status == StatusFailed
+	}, time.Second, time.Millisecond)
+
+	st, _ := tracker.Get(22)
+	last := st.History[len(st.History)-1]
+	require.Equal(t, StatusFailed, last.To)
+	require.Contains(t, last.Detail, "abandoned after 2 attempts")
+}
@@ -0,0 +1,171 @@
+// Package registry describes the rollup set participating in a network:
+// which chains exist and each chain's operational metadata — operator
+// contact, stake weight, block size limit, proof requirement. The intake
+// allowlist, validation limits, proof policy and API responses all read
+// from one Service so the answers cannot drift apart.
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/prover"
+)
+
+// ErrUnknownChain is returned when no registered rollup has the chain ID.
+var ErrUnknownChain = errors.New("registry: unknown chain")
+
+// ChainInfo is one rollup's registry entry.
+type ChainInfo struct {
+	ChainID types.ChainID `yaml:"ChainID" json:"chain_id"`
+	// Name is the rollup's human-readable name.
+	Name string `yaml:"Name" json:"name"`
+	// OperatorContact is how to reach whoever runs the rollup's sequencer
+	// — an email or pager handle, surfaced in API responses so an operator
+	// chasing a stuck chain does not have to look it up elsewhere.
+	OperatorContact string `yaml:"OperatorContact" json:"operator_contact,omitempty"`
+	// Weight is the chain's stake-derived weight for consensus weighting
+	// and explorer display. Zero means unweighted.
+	Weight uint64 `yaml:"Weight" json:"weight,omitempty"`
+	// MaxBlockBytes caps the chain's encoded L2 block size at intake; zero
+	// means no per-chain limit.
+	MaxBlockBytes int `yaml:"MaxBlockBytes" json:"max_block_bytes,omitempty"`
+	// RequiresProof says whether the chain's blocks need validity proofs
+	// before superblock aggregation.
+	RequiresProof bool `yaml:"RequiresProof" json:"requires_proof"`
+}
+
+// Service answers what rollups a network is composed of and their
+// metadata. Implementations may be backed by a registry file or an
+// on-chain registry contract; consumers should not assume the set is
+// static.
+type Service interface {
+	// Chains lists every registered rollup, ordered by chain ID.
+	Chains() []ChainInfo
+	// ChainInfo returns one rollup's entry, or ErrUnknownChain.
+	ChainInfo(chainID types.ChainID) (ChainInfo, error)
+}
+
+// static is an in-memory Service with a fixed chain set.
+type static struct {
+	mu     sync.RWMutex
+	chains map[types.ChainID]ChainInfo
+}
+
+// NewStatic returns a Service serving the given entries.
+func NewStatic(chains ...ChainInfo) Service {
+	s := &static{chains: make(map[types.ChainID]ChainInfo, len(chains))}
+	for _, info := range chains {
+		s.chains[info.ChainID] = info
+	}
+	return s
+}
+
+func (s *static) Chains() []ChainInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]ChainInfo, 0, len(s.chains))
+	for _, info := range s.chains {
+		out = append(out, info)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ChainID < out[j].ChainID })
+	return out
+}
+
+func (s *static) ChainInfo(chainID types.ChainID) (ChainInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	info, ok := s.chains[chainID]
+	if !ok {
+		return ChainInfo{}, fmt.Errorf("%w: %d", ErrUnknownChain, chainID)
+	}
+	return info, nil
+}
+
+// FileService serves a registry file and can reload it in place.
+type FileService struct {
+	path string
+	static
+}
+
+// registryFile is the on-disk document: a Chains list, leaving room for
+// top-level registry metadata later.
+type registryFile struct {
+	Chains []ChainInfo `yaml:"Chains"`
+}
+
+// NewFileService loads the YAML registry file at path. Reload picks up
+// edits without a restart.
+func NewFileService(path string) (*FileService, error) {
+	s := &FileService{path: path}
+	s.chains = make(map[types.ChainID]ChainInfo)
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload re-reads the registry file, replacing the served set wholesale.
+// On error the previously loaded set keeps serving.
+func (s *FileService) Reload() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("registry: read %s: %w", s.path, err)
+	}
+	var doc registryFile
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("registry: parse %s: %w", s.path, err)
+	}
+	chains := make(map[types.ChainID]ChainInfo, len(doc.Chains))
+	for _, info := range doc.Chains {
+		if info.ChainID == 0 {
+			return fmt.Errorf("registry: %s: entry %q has no ChainID", s.path, info.Name)
+		}
+		if _, ok := chains[info.ChainID]; ok {
+			return fmt.Errorf("registry: %s: duplicate chain %d", s.path, info.ChainID)
+		}
+		chains[info.ChainID] = info
+	}
+	s.mu.Lock()
+	s.chains = chains
+	s.mu.Unlock()
+	return nil
+}
+
+// ChainIDs lists the registered chain IDs, in the shape the intake
+// allowlist fetch expects.
+func ChainIDs(s Service) []types.ChainID {
+	chains := s.Chains()
+	ids := make([]types.ChainID, 0, len(chains))
+	for _, info := range chains {
+		ids = append(ids, info.ChainID)
+	}
+	return ids
+}
+
+// ProofPolicy derives the per-chain proof requirements from the registry,
+// for tenants that source their prover policy from it instead of static
+// configuration.
+func ProofPolicy(s Service) prover.Policy {
+	policy := prover.Policy{Chains: make(map[types.ChainID]bool)}
+	for _, info := range s.Chains() {
+		policy.Chains[info.ChainID] = info.RequiresProof
+	}
+	return policy
+}
+
+// TotalWeight sums the registered chains' stake weights, the denominator
+// for consensus weighting and quorum display.
+func TotalWeight(s Service) uint64 {
+	var total uint64
+	for _, info := range s.Chains() {
+		total += info.Weight
+	}
+	return total
+}
@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestStaticService(t *testing.T) {
+	svc := NewStatic(
+		ChainInfo{ChainID: 20, Name: "beta", Weight: 30},
+		ChainInfo{ChainID: 10, Name: "alpha", Weight: 70, RequiresProof: true},
+	)
+
+	chains := svc.Chains()
+	require.Len(t, chains, 2)
+	require.Equal(t, "alpha", chains[0].Name, "ordered by chain ID")
+
+	info, err := svc.ChainInfo(10)
+	require.NoError(t, err)
+	require.True(t, info.RequiresProof)
+	_, err = svc.ChainInfo(99)
+	require.ErrorIs(t, err, ErrUnknownChain)
+
+	require.Equal(t, []types.ChainID{10, 20}, ChainIDs(svc))
+	require.Equal(t, uint64(100), TotalWeight(svc))
+
+	policy := ProofPolicy(svc)
+	require.True(t, policy.Requires(10))
+	require.False(t, policy.Requires(20))
+}
+
+func TestFileServiceLoadAndReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	write := func(doc string) {
+		require.NoError(t, os.WriteFile(path, []byte(doc), 0o600))
+	}
+	write(`
+Chains:
+  - ChainID: 10
+    Name: alpha
+    OperatorContact: ops@alpha.example
+    MaxBlockBytes: 1048576
+`)
+	svc, err := NewFileService(path)
+	require.NoError(t, err)
+	info, err := svc.ChainInfo(10)
+	require.NoError(t, err)
+	require.Equal(t, "ops@alpha.example", info.OperatorContact)
+	require.Equal(t, 1048576, info.MaxBlockBytes)
+
+	write(`
+Chains:
+  - ChainID: 10
+    Name: alpha
+  - ChainID: 20
+    Name: beta
+`)
+	require.NoError(t, svc.Reload())
+	require.Len(t, svc.Chains(), 2)
+
+	// A broken edit keeps the last good set serving.
+	write(`
+Chains:
+  - Name: no-id
+`)
+	require.Error(t, svc.Reload())
+	require.Len(t, svc.Chains(), 2)
+}
+
+func TestFileServiceRejectsDuplicates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	doc := `
+Chains:
+  - ChainID: 10
+  - ChainID: 10
+`
+	require.NoError(t, os.WriteFile(path, []byte(doc), 0o600))
+	_, err := NewFileService(path)
+	require.Error(t, err)
+}
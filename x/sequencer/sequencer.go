@@ -0,0 +1,235 @@
+// Package sequencer is the high-level client library for rollup
+// sequencers. It wraps the raw transport client and implements the
+// protocol choreography every sequencer repeats: answering StartSC rounds
+// with votes from an application-provided validator and surfacing Decided
+// outcomes, so applications register callbacks instead of switching on
+// message types.
+package sequencer
+
+import (
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/protocol"
+	"github.com/compose-network/publisher/x/transport/tcp"
+)
+
+// ValidateXTFunc decides the sequencer's 2PC vote for one XT request:
+// true commits, false aborts. It runs on the read loop, so it must be
+// fast; slow validation should be done against local state ahead of time.
+type ValidateXTFunc func(request types.XTRequest) bool
+
+// DecidedFunc receives every finalized XT outcome. The SDK deduplicates
+// by XtID, so the callback sees each decision at most once even when the
+// publisher redelivers it across reconnects.
+type DecidedFunc func(decision types.Decision)
+
+// MessageFunc receives messages the library does not handle itself.
+type MessageFunc func(msg protocol.Message)
+
+// ConfigUpdateFunc receives publisher configuration pushes. The SDK drops
+// updates with a stale sequence number, so the callback only ever sees the
+// parameters move forward.
+type ConfigUpdateFunc func(update protocol.ConfigUpdate)
+
+// SuperblockStatusFunc receives L1 finality updates for published
+// superblocks, so the application can move its safe and finalized heads
+// (and unwind them when a superblock rolls back).
+type SuperblockStatusFunc func(update protocol.SuperblockStatusUpdate)
+
+// Client is the high-level sequencer connection.
+type Client struct {
+	logger     *zap.Logger
+	tcp        *tcp.Client
+	chainID    types.ChainID
+	validate   ValidateXTFunc
+	onDecided  DecidedFunc
+	onMessage  MessageFunc
+	onBatchAck func(ack protocol.XTBatchAck)
+	onConfig   ConfigUpdateFunc
+	onSbStatus SuperblockStatusFunc
+
+	mu            sync.Mutex
+	applied       map[types.XtID]struct{}
+	appliedOrder  []types.XtID
+	lastConfigSeq uint64
+}
+
+// appliedLimit bounds the replay-protection window. Reconnect storms
+// redeliver recent decisions, so the window only needs to cover those.
+const appliedLimit = 1024
+
+// New creates a client voting with validate. Wire the optional callbacks
+// before Connect.
+func New(logger *zap.Logger, cfg tcp.ClientConfig, signer *auth.Signer, validate ValidateXTFunc) *Client {
+	c := &Client{
+		logger:   logger.Named("sequencer"),
+		tcp:      tcp.NewClient(logger, cfg, signer),
+		chainID:  cfg.ChainID,
+		validate: validate,
+		applied:  make(map[types.XtID]struct{}),
+	}
+	c.tcp.SetHandler(c.dispatch)
+	return c
+}
+
+// OnDecided installs the decision callback. Must be called before Connect.
+func (c *Client) OnDecided(fn DecidedFunc) {
+	c.onDecided = fn
+}
+
+// OnMessage installs a fallback handler for message types the library does
+// not consume. Must be called before Connect.
+func (c *Client) OnMessage(fn MessageFunc) {
+	c.onMessage = fn
+}
+
+// OnConfigUpdate installs the callback receiving publisher configuration
+// pushes. Must be called before Connect.
+func (c *Client) OnConfigUpdate(fn ConfigUpdateFunc) {
+	c.onConfig = fn
+}
+
+// OnSuperblockStatus installs the callback receiving superblock finality
+// updates. Must be called before Connect.
+func (c *Client) OnSuperblockStatus(fn SuperblockStatusFunc) {
+	c.onSbStatus = fn
+}
+
+// Connect dials the publisher.
+func (c *Client) Connect() error {
+	if c.validate == nil {
+		return errors.New("sequencer: ValidateXT callback is required")
+	}
+	return c.tcp.Connect()
+}
+
+// Close terminates the connection.
+func (c *Client) Close() error {
+	return c.tcp.Close()
+}
+
+// Send forwards a raw message, for payloads without a dedicated helper.
+func (c *Client) Send(msgType string, payload any) error {
+	return c.tcp.Send(msgType, payload)
+}
+
+// SendL2Block submits a block, chunking transparently when needed.
+func (c *Client) SendL2Block(block *types.L2Block) error {
+	return c.tcp.SendL2Block(block)
+}
+
+// SubmitXT queues a cross-chain transaction with the publisher.
+func (c *Client) SubmitXT(request types.XTRequest) error {
+	return c.tcp.Send(protocol.TypeXTRequest, request)
+}
+
+// SubmitXTBatch queues several independent XTs in one round-trip. The
+// publisher answers with an xt_batch_ack carrying per-XT results, surfaced
+// through OnBatchAck.
+func (c *Client) SubmitXTBatch(requests []types.XTRequest) error {
+	return c.tcp.Send(protocol.TypeXTBatch, protocol.XTBatch{Requests: requests})
+}
+
+// OnBatchAck installs the callback receiving per-XT batch results. Must be
+// called before Connect.
+func (c *Client) OnBatchAck(fn func(ack protocol.XTBatchAck)) {
+	c.onBatchAck = fn
+}
+
+// markApplied records a decision's XtID, reporting false when it was
+// already applied. The window is bounded; the oldest IDs fall out first.
+func (c *Client) markApplied(xtID types.XtID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.applied[xtID]; ok {
+		return false
+	}
+	c.applied[xtID] = struct{}{}
+	c.appliedOrder = append(c.appliedOrder, xtID)
+	if len(c.appliedOrder) > appliedLimit {
+		delete(c.applied, c.appliedOrder[0])
+		c.appliedOrder = c.appliedOrder[1:]
+	}
+	return true
+}
+
+func (c *Client) dispatch(msg protocol.Message) {
+	switch msg.Type {
+	case protocol.TypeStartSC:
+		var start protocol.StartSC
+		if err := msg.Decode(&start); err != nil {
+			c.logger.Warn("bad start_sc payload", zap.Error(err))
+			return
+		}
+		vote := protocol.Vote{
+			XtID:    start.Request.XtID,
+			ChainID: c.chainID,
+			Commit:  c.validate(start.Request),
+		}
+		if err := c.tcp.Send(protocol.TypeVote, vote); err != nil {
+			c.logger.Warn("vote not sent",
+				zap.String("xt_id", vote.XtID.String()),
+				zap.Error(err))
+		}
+	case protocol.TypeXTBatchAck:
+		var ack protocol.XTBatchAck
+		if err := msg.Decode(&ack); err != nil {
+			c.logger.Warn("bad xt_batch_ack payload", zap.Error(err))
+			return
+		}
+		if c.onBatchAck != nil {
+			c.onBatchAck(ack)
+		}
+	case protocol.TypeSuperblockStatus:
+		var update protocol.SuperblockStatusUpdate
+		if err := msg.Decode(&update); err != nil {
+			c.logger.Warn("bad superblock_status payload", zap.Error(err))
+			return
+		}
+		if c.onSbStatus != nil {
+			c.onSbStatus(update)
+		}
+	case protocol.TypeConfigUpdate:
+		var update protocol.ConfigUpdate
+		if err := msg.Decode(&update); err != nil {
+			c.logger.Warn("bad config_update payload", zap.Error(err))
+			return
+		}
+		c.mu.Lock()
+		stale := update.Seq <= c.lastConfigSeq
+		if !stale {
+			c.lastConfigSeq = update.Seq
+		}
+		c.mu.Unlock()
+		if stale {
+			c.logger.Debug("ignoring stale config update", zap.Uint64("seq", update.Seq))
+			return
+		}
+		if c.onConfig != nil {
+			c.onConfig(update)
+		}
+	case protocol.TypeDecided:
+		var decided protocol.Decided
+		if err := msg.Decode(&decided); err != nil {
+			c.logger.Warn("bad decided payload", zap.Error(err))
+			return
+		}
+		if !c.markApplied(decided.Decision.XtID) {
+			c.logger.Debug("ignoring replayed decision",
+				zap.String("xt_id", decided.Decision.XtID.String()))
+			return
+		}
+		if c.onDecided != nil {
+			c.onDecided(decided.Decision)
+		}
+	default:
+		if c.onMessage != nil {
+			c.onMessage(msg)
+		}
+	}
+}
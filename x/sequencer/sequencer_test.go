@@ -0,0 +1,193 @@
+package sequencer
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/protocol"
+	"github.com/compose-network/publisher/x/transport/tcp"
+)
+
+func TestAutomaticVotingAndDecided(t *testing.T) {
+	authManager := auth.NewManager()
+	srv := tcp.NewServer(zap.NewNop(), tcp.Config{Addr: "127.0.0.1:0"}, authManager)
+
+	var (
+		mu    sync.Mutex
+		votes []protocol.Vote
+	)
+	srv.SetHandler(func(_ context.Context, conn *tcp.Conn, msg protocol.Message) {
+		if msg.Type != protocol.TypeVote {
+			return
+		}
+		var vote protocol.Vote
+		require.NoError(t, msg.Decode(&vote))
+		mu.Lock()
+		votes = append(votes, vote)
+		mu.Unlock()
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer, err := auth.GenerateSigner("seq-5")
+	require.NoError(t, err)
+	require.NoError(t, authManager.AddTrustedKey("seq-5", signer.PublicKeyHex()))
+
+	var decided []types.Decision
+	client := New(zap.NewNop(), tcp.ClientConfig{Addr: srv.Addr().String(), ChainID: 5}, signer, func(request types.XTRequest) bool {
+		// Commit XTs with a payload, abort empty ones.
+		return len(request.Payload) > 0
+	})
+	client.OnDecided(func(decision types.Decision) {
+		mu.Lock()
+		decided = append(decided, decision)
+		mu.Unlock()
+	})
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	commitXT := types.XTRequest{XtID: types.Hash{1}, Chains: []types.ChainID{5}, Payload: []byte{1}}
+	abortXT := types.XTRequest{XtID: types.Hash{2}, Chains: []types.ChainID{5}}
+	for _, request := range []types.XTRequest{commitXT, abortXT} {
+		require.NoError(t, srv.SendTo(types.DefaultNetworkID, 5, protocol.TypeStartSC, protocol.StartSC{Request: request}))
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(votes) == 2
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	require.Equal(t, types.Hash{1}, votes[0].XtID)
+	require.True(t, votes[0].Commit)
+	require.False(t, votes[1].Commit)
+	require.Equal(t, types.ChainID(5), votes[0].ChainID)
+	mu.Unlock()
+
+	outcome := types.Decision{XtID: types.Hash{1}, Committed: true, Slot: 9}
+	require.NoError(t, srv.SendTo(types.DefaultNetworkID, 5, protocol.TypeDecided, protocol.Decided{Decision: outcome}))
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(decided) == 1 && decided[0] == outcome
+	}, time.Second, time.Millisecond)
+
+	// Replayed decisions (reconnect redelivery) are applied only once; a
+	// decision for a different XtID still goes through.
+	other := types.Decision{XtID: types.Hash{2}, Committed: false, Slot: 9}
+	require.NoError(t, srv.SendTo(types.DefaultNetworkID, 5, protocol.TypeDecided, protocol.Decided{Decision: outcome}))
+	require.NoError(t, srv.SendTo(types.DefaultNetworkID, 5, protocol.TypeDecided, protocol.Decided{Decision: other}))
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(decided) == 2 && decided[1] == other
+	}, time.Second, time.Millisecond)
+}
+
+func TestConnectRequiresValidator(t *testing.T) {
+	signer, err := auth.GenerateSigner("seq-1")
+	require.NoError(t, err)
+	client := New(zap.NewNop(), tcp.ClientConfig{Addr: "127.0.0.1:1", ChainID: 1}, signer, nil)
+	require.Error(t, client.Connect())
+}
+
+// TestConfigUpdatePush checks the SDK surfaces publisher config pushes in
+// order and drops redelivered stale updates.
+func TestConfigUpdatePush(t *testing.T) {
+	authManager := auth.NewManager()
+	srv := tcp.NewServer(zap.NewNop(), tcp.Config{Addr: "127.0.0.1:0"}, authManager)
+	srv.SetHandler(func(_ context.Context, _ *tcp.Conn, _ protocol.Message) {})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer, err := auth.GenerateSigner("seq-cfg")
+	require.NoError(t, err)
+	require.NoError(t, authManager.AddTrustedKey("seq-cfg", signer.PublicKeyHex()))
+
+	var (
+		mu      sync.Mutex
+		updates []protocol.ConfigUpdate
+	)
+	client := New(zap.NewNop(), tcp.ClientConfig{Addr: srv.Addr().String(), ChainID: 5}, signer, func(types.XTRequest) bool { return true })
+	client.OnConfigUpdate(func(update protocol.ConfigUpdate) {
+		mu.Lock()
+		updates = append(updates, update)
+		mu.Unlock()
+	})
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	srv.PushConfigUpdate(types.DefaultNetworkID, protocol.ConfigUpdate{SealCutoverMS: 9000})
+	srv.PushConfigUpdate(types.DefaultNetworkID, protocol.ConfigUpdate{MaxBlockBytes: 1 << 20})
+	// A replayed stale update must not reach the callback.
+	require.NoError(t, srv.SendTo(types.DefaultNetworkID, 5, protocol.TypeConfigUpdate, protocol.ConfigUpdate{Seq: 1, SealCutoverMS: 9000}))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(updates) == 2
+	}, 2*time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond) // give the stale replay time to arrive
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, updates, 2)
+	require.Equal(t, int64(9000), updates[0].SealCutoverMS)
+	require.Equal(t, uint64(1), updates[0].Seq)
+	require.Equal(t, 1<<20, updates[1].MaxBlockBytes)
+	require.Equal(t, uint64(2), updates[1].Seq)
+}
+
+// TestSuperblockStatusUpdates checks finality broadcasts reach the SDK
+// callback in order.
+func TestSuperblockStatusUpdates(t *testing.T) {
+	authManager := auth.NewManager()
+	srv := tcp.NewServer(zap.NewNop(), tcp.Config{Addr: "127.0.0.1:0"}, authManager)
+	srv.SetHandler(func(_ context.Context, _ *tcp.Conn, _ protocol.Message) {})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer, err := auth.GenerateSigner("seq-fin")
+	require.NoError(t, err)
+	require.NoError(t, authManager.AddTrustedKey("seq-fin", signer.PublicKeyHex()))
+
+	var (
+		mu      sync.Mutex
+		updates []protocol.SuperblockStatusUpdate
+	)
+	client := New(zap.NewNop(), tcp.ClientConfig{Addr: srv.Addr().String(), ChainID: 5}, signer, func(types.XTRequest) bool { return true })
+	client.OnSuperblockStatus(func(update protocol.SuperblockStatusUpdate) {
+		mu.Lock()
+		updates = append(updates, update)
+		mu.Unlock()
+	})
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	for _, status := range []types.SuperblockStatus{
+		types.SuperblockStatusSubmitted,
+		types.SuperblockStatusConfirmed,
+		types.SuperblockStatusFinalized,
+	} {
+		srv.Broadcast(types.DefaultNetworkID, protocol.TypeSuperblockStatus, protocol.SuperblockStatusUpdate{
+			Number: 9, Status: status, L1TxHash: types.Hash{0xf},
+		})
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(updates) == 3
+	}, 2*time.Second, time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, types.SuperblockStatusSubmitted, updates[0].Status)
+	require.Equal(t, types.SuperblockStatusFinalized, updates[2].Status)
+	require.Equal(t, uint64(9), updates[1].Number)
+}
@@ -0,0 +1,226 @@
+// Package sim is a deterministic simulation harness for the publisher's
+// slot and 2PC protocol. It runs N virtual sequencers against a virtual
+// clock with scripted network delays and failures, producing a reproducible
+// event trace and aggregate outcome statistics from a single RNG seed —
+// the tool of choice when weighing seal-cutover and timeout parameters
+// before touching a live network.
+package sim
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+// Clock is a virtual clock driven by an event queue: time advances by
+// jumping to the next scheduled event, so a thousand simulated slots run
+// in milliseconds and identical inputs replay identically.
+type Clock struct {
+	now    time.Time
+	queue  eventQueue
+	nextID int
+}
+
+// NewClock starts a virtual clock at genesis.
+func NewClock(genesis time.Time) *Clock {
+	return &Clock{now: genesis}
+}
+
+// Now returns the current virtual time.
+func (c *Clock) Now() time.Time { return c.now }
+
+// Schedule runs fn at the given virtual time. Events at the same instant
+// run in scheduling order, which keeps replays deterministic.
+func (c *Clock) Schedule(at time.Time, fn func()) {
+	c.nextID++
+	heap.Push(&c.queue, &event{at: at, seq: c.nextID, fn: fn})
+}
+
+// Run processes events in time order until the queue drains.
+func (c *Clock) Run() {
+	for c.queue.Len() > 0 {
+		ev := heap.Pop(&c.queue).(*event)
+		c.now = ev.at
+		ev.fn()
+	}
+}
+
+type event struct {
+	at  time.Time
+	seq int
+	fn  func()
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+func (q eventQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+	return q[i].seq < q[j].seq
+}
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *eventQueue) Push(x any)   { *q = append(*q, x.(*event)) }
+func (q *eventQueue) Pop() any {
+	old := *q
+	n := len(old)
+	ev := old[n-1]
+	*q = old[:n-1]
+	return ev
+}
+
+// Scenario scripts the simulated network. Every hook receives the
+// harness's seeded RNG, so a scenario plus a seed fully determines a run.
+type Scenario struct {
+	// VoteDelay is how long a chain's vote takes to arrive after StartSC.
+	// Nil draws uniformly from [0, SealCutover).
+	VoteDelay func(rng *rand.Rand, chain types.ChainID, s types.Slot) time.Duration
+	// VoteLost drops a chain's vote entirely; the XT then times out.
+	// Nil loses nothing.
+	VoteLost func(rng *rand.Rand, chain types.ChainID, s types.Slot) bool
+	// VoteAbort makes a chain vote abort instead of commit. Nil always
+	// commits.
+	VoteAbort func(rng *rand.Rand, chain types.ChainID, s types.Slot) bool
+}
+
+// Params configures one simulation run.
+type Params struct {
+	Slots      slot.Config
+	NumSlots   int
+	Chains     int
+	XTsPerSlot int
+	Seed       int64
+	Scenario   Scenario
+}
+
+// TraceEvent is one line of the reproducible run trace.
+type TraceEvent struct {
+	At    time.Duration `json:"at"` // offset from genesis
+	Slot  types.Slot    `json:"slot"`
+	Kind  string        `json:"kind"` // "start_sc", "vote", "decided"
+	XT    int           `json:"xt"`
+	Chain types.ChainID `json:"chain,omitempty"`
+	Info  string        `json:"info,omitempty"`
+}
+
+// Result aggregates a run's XT outcomes.
+type Result struct {
+	XTs       int                       `json:"xts"`
+	Committed int                       `json:"committed"`
+	Aborted   map[types.AbortReason]int `json:"aborted"`
+	// DecisionLatency is the mean time from StartSC to decision.
+	DecisionLatency time.Duration `json:"decision_latency"`
+	Trace           []TraceEvent  `json:"trace"`
+}
+
+// xtState is one in-flight simulated 2PC instance.
+type xtState struct {
+	slot    types.Slot
+	started time.Time
+	pending int
+	decided bool
+}
+
+// Run executes the simulation and returns its trace and statistics. The
+// same Params (including Seed) always produce the same Result.
+func Run(params Params) (*Result, error) {
+	if err := params.Slots.Validate(); err != nil {
+		return nil, err
+	}
+	if params.Chains < 1 || params.NumSlots < 1 {
+		return nil, fmt.Errorf("sim: need at least one chain and one slot")
+	}
+
+	rng := rand.New(rand.NewSource(params.Seed))
+	clock := NewClock(params.Slots.GenesisTime)
+	result := &Result{Aborted: make(map[types.AbortReason]int)}
+	var totalLatency time.Duration
+
+	genesis := params.Slots.GenesisTime
+	trace := func(s types.Slot, kind string, xt int, chain types.ChainID, info string) {
+		result.Trace = append(result.Trace, TraceEvent{
+			At: clock.Now().Sub(genesis), Slot: s, Kind: kind, XT: xt, Chain: chain, Info: info,
+		})
+	}
+
+	decide := func(xt int, st *xtState, committed bool, reason types.AbortReason) {
+		if st.decided {
+			return
+		}
+		st.decided = true
+		totalLatency += clock.Now().Sub(st.started)
+		if committed {
+			result.Committed++
+			trace(st.slot, "decided", xt, 0, "commit")
+			return
+		}
+		result.Aborted[reason]++
+		trace(st.slot, "decided", xt, 0, "abort:"+string(reason))
+	}
+
+	nextXT := 0
+	for i := 0; i < params.NumSlots; i++ {
+		s := types.Slot(i)
+		start := params.Slots.StartOf(s)
+		sealAt := params.Slots.SealTimeOf(s)
+		for j := 0; j < params.XTsPerSlot; j++ {
+			xt := nextXT
+			nextXT++
+			clock.Schedule(start, func() {
+				st := &xtState{slot: s, started: clock.Now(), pending: params.Chains}
+				trace(s, "start_sc", xt, 0, "")
+				// Draw every chain's behaviour now, in chain order, so the
+				// trace does not depend on event interleaving.
+				for chain := types.ChainID(1); chain <= types.ChainID(params.Chains); chain++ {
+					if lost := params.Scenario.VoteLost; lost != nil && lost(rng, chain, s) {
+						continue
+					}
+					delay := rng.Int63n(int64(params.Slots.SealCutover))
+					if d := params.Scenario.VoteDelay; d != nil {
+						delay = int64(d(rng, chain, s))
+					}
+					abort := false
+					if a := params.Scenario.VoteAbort; a != nil {
+						abort = a(rng, chain, s)
+					}
+					voteChain := chain
+					voteAt := clock.Now().Add(time.Duration(delay))
+					if !voteAt.Before(sealAt) {
+						continue // arrives after the deadline: as good as lost
+					}
+					clock.Schedule(voteAt, func() {
+						if st.decided {
+							return
+						}
+						if abort {
+							trace(s, "vote", xt, voteChain, "abort")
+							decide(xt, st, false, types.AbortReasonVote)
+							return
+						}
+						trace(s, "vote", xt, voteChain, "commit")
+						st.pending--
+						if st.pending == 0 {
+							decide(xt, st, true, "")
+						}
+					})
+				}
+				// The vote deadline, like the coordinator's, is the seal time.
+				clock.Schedule(sealAt, func() {
+					decide(xt, st, false, types.AbortReasonTimeout)
+				})
+			})
+		}
+	}
+
+	clock.Run()
+	result.XTs = nextXT
+	if nextXT > 0 {
+		result.DecisionLatency = totalLatency / time.Duration(nextXT)
+	}
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package sim
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+func simSlots() slot.Config {
+	return slot.Config{
+		GenesisTime: time.Unix(1700000000, 0),
+		Duration:    2 * time.Second,
+		SealCutover: 1500 * time.Millisecond,
+	}
+}
+
+func TestRunIsDeterministic(t *testing.T) {
+	params := Params{
+		Slots: simSlots(), NumSlots: 50, Chains: 3, XTsPerSlot: 4, Seed: 42,
+	}
+	first, err := Run(params)
+	require.NoError(t, err)
+	second, err := Run(params)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	params.Seed = 43
+	third, err := Run(params)
+	require.NoError(t, err)
+	require.NotEqual(t, first.Trace, third.Trace)
+}
+
+func TestAllVotesCommitAndArrive(t *testing.T) {
+	params := Params{
+		Slots: simSlots(), NumSlots: 10, Chains: 4, XTsPerSlot: 2, Seed: 1,
+		Scenario: Scenario{
+			VoteDelay: func(rng *rand.Rand, chain types.ChainID, s types.Slot) time.Duration {
+				return 100 * time.Millisecond
+			},
+		},
+	}
+	result, err := Run(params)
+	require.NoError(t, err)
+	require.Equal(t, 20, result.XTs)
+	require.Equal(t, 20, result.Committed)
+	require.Empty(t, result.Aborted)
+	require.Equal(t, 100*time.Millisecond, result.DecisionLatency)
+}
+
+func TestScriptedFailures(t *testing.T) {
+	// Chain 2's votes never arrive: every XT times out at the seal cutover.
+	lossy := Params{
+		Slots: simSlots(), NumSlots: 20, Chains: 2, XTsPerSlot: 1, Seed: 7,
+		Scenario: Scenario{
+			VoteLost: func(rng *rand.Rand, chain types.ChainID, s types.Slot) bool {
+				return chain == 2
+			},
+		},
+	}
+	result, err := Run(lossy)
+	require.NoError(t, err)
+	require.Equal(t, 0, result.Committed)
+	require.Equal(t, 20, result.Aborted[types.AbortReasonTimeout])
+	// Timeouts are decided exactly at the seal cutover.
+	require.Equal(t, lossy.Slots.SealCutover, result.DecisionLatency)
+
+	// Chain 1 always votes abort: decisions are fast vote-aborts instead.
+	voting := lossy
+	voting.Scenario = Scenario{
+		VoteAbort: func(rng *rand.Rand, chain types.ChainID, s types.Slot) bool {
+			return chain == 1
+		},
+	}
+	result, err = Run(voting)
+	require.NoError(t, err)
+	require.Equal(t, 20, result.Aborted[types.AbortReasonVote])
+	require.Less(t, result.DecisionLatency, lossy.Slots.SealCutover)
+}
+
+func TestWiderCutoverCommitsMore(t *testing.T) {
+	// Slow votes: a 500ms cutover loses most of them, a 1900ms one almost
+	// none. This is the comparison the harness exists for.
+	slow := Scenario{
+		VoteDelay: func(rng *rand.Rand, chain types.ChainID, s types.Slot) time.Duration {
+			return time.Duration(rng.Int63n(int64(time.Second)))
+		},
+	}
+	narrow := Params{Slots: simSlots(), NumSlots: 100, Chains: 3, XTsPerSlot: 1, Seed: 5, Scenario: slow}
+	narrow.Slots.SealCutover = 500 * time.Millisecond
+	wide := narrow
+	wide.Slots.SealCutover = 1900 * time.Millisecond
+
+	narrowResult, err := Run(narrow)
+	require.NoError(t, err)
+	wideResult, err := Run(wide)
+	require.NoError(t, err)
+	require.Greater(t, wideResult.Committed, narrowResult.Committed)
+}
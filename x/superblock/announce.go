@@ -0,0 +1,47 @@
+package superblock
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// Sender delivers a message to one chain's connection; the tcp server
+// satisfies it.
+type Sender interface {
+	SendTo(networkID types.NetworkID, chainID types.ChainID, msgType string, payload any) error
+}
+
+// AnnounceSealed distributes a sealed superblock to the given chains. Each
+// recipient gets the announcement with inclusion proofs for its own L2
+// blocks attached, enabling local verification and derivation without
+// waiting for L1 finality. Send failures are logged and skipped; a chain
+// that misses the announcement can still derive from L1 later.
+func AnnounceSealed(logger *zap.Logger, sender Sender, networkID types.NetworkID, sb *types.Superblock, sealed *protocol.SuperblockSealed, chains []types.ChainID) {
+	scheme, err := MerkleSchemeByName(sb.MerkleScheme)
+	if err != nil {
+		logger.Error("sealed announcement skipped",
+			zap.Uint64("superblock", sb.Number),
+			zap.Error(err))
+		return
+	}
+	for _, chainID := range chains {
+		proofs, err := InclusionProofsFor(scheme, sb.L2Blocks, chainID)
+		if err != nil {
+			logger.Error("inclusion proof failed",
+				zap.Uint64("superblock", sb.Number),
+				zap.Uint64("chain_id", uint64(chainID)),
+				zap.Error(err))
+			continue
+		}
+		announcement := *sealed
+		announcement.Inclusions = proofs
+		if err := sender.SendTo(networkID, chainID, protocol.TypeSuperblockSealed, announcement); err != nil {
+			logger.Warn("sealed announcement not delivered",
+				zap.Uint64("superblock", sb.Number),
+				zap.Uint64("chain_id", uint64(chainID)),
+				zap.Error(err))
+		}
+	}
+}
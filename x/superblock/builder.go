@@ -0,0 +1,165 @@
+// Package superblock assembles one superblock per slot from the L2 blocks
+// and XT decisions collected during it, journaling every construction step
+// for auditability.
+package superblock
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/bus"
+)
+
+// Construction journal event types, in the order the builder emits them.
+const (
+	EventL2BlockAccepted    = "l2_block_accepted"
+	EventXTIncluded         = "xt_included"
+	EventMerkleRootComputed = "merkle_root_computed"
+	EventHashComputed       = "hash_computed"
+)
+
+// Builder assembles superblocks. Each step is appended to the construction
+// journal so GET /superblocks/{n}/journal can replay how a superblock was
+// put together.
+type Builder struct {
+	logger  *zap.Logger
+	journal store.ConstructionJournal
+	scheme  MerkleScheme
+	bus     *bus.Bus
+}
+
+// NewBuilder creates a Builder recording to journal. A nil scheme selects
+// the default merkle construction.
+func NewBuilder(logger *zap.Logger, journal store.ConstructionJournal, scheme MerkleScheme) *Builder {
+	if scheme == nil {
+		scheme, _ = MerkleSchemeByName(DefaultMerkleScheme)
+	}
+	return &Builder{
+		logger:  logger.Named("builder"),
+		journal: journal,
+		scheme:  scheme,
+	}
+}
+
+// SetBus attaches the event bus; each assembled superblock is then
+// published on TopicSuperblockBuilt.
+func (b *Builder) SetBus(eventBus *bus.Bus) {
+	b.bus = eventBus
+}
+
+// Build assembles the superblock for a slot. Blocks are put into the
+// canonical ordering (types.SortL2Blocks) whatever order the caller
+// collected them in; decisions keep the order the ledger assigned them.
+func (b *Builder) Build(number uint64, slot types.Slot, parentHash types.Hash, blocks []*types.L2Block, decisions []types.Decision) (*types.Superblock, error) {
+	ordered := append([]*types.L2Block(nil), blocks...)
+	types.SortL2Blocks(ordered)
+
+	for _, block := range ordered {
+		if err := b.record(number, slot, EventL2BlockAccepted, map[string]string{
+			"chain_id":   fmt.Sprintf("%d", block.ChainID),
+			"number":     fmt.Sprintf("%d", block.Number),
+			"block_hash": block.Hash.String(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	for _, d := range decisions {
+		if err := b.record(number, slot, EventXTIncluded, map[string]string{
+			"xt_id":     d.XtID.String(),
+			"committed": fmt.Sprintf("%t", d.Committed),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	merkleRoot := b.scheme.Root(blockLeaves(ordered))
+	if err := b.record(number, slot, EventMerkleRootComputed, map[string]string{
+		"merkle_root": merkleRoot.String(),
+		"scheme":      b.scheme.Name(),
+		"leaves":      fmt.Sprintf("%d", len(ordered)),
+	}); err != nil {
+		return nil, err
+	}
+
+	sb := &types.Superblock{
+		Number:        number,
+		Slot:          slot,
+		ParentHash:    parentHash,
+		MerkleRoot:    merkleRoot,
+		MerkleScheme:  b.scheme.Name(),
+		Timestamp:     uint64(time.Now().Unix()),
+		L2Blocks:      ordered,
+		Decisions:     decisions,
+		DecisionsRoot: types.DecisionsCommitment(decisions),
+		Status:        types.SuperblockStatusBuilding,
+	}
+	headerHasher, err := HeaderHasherFor(sb.MerkleScheme)
+	if err != nil {
+		return nil, fmt.Errorf("superblock: %w", err)
+	}
+	sb.Hash = HeaderHashWith(headerHasher, sb.Number, sb.Slot, sb.ParentHash, sb.MerkleRoot, sb.DecisionsRoot)
+	if err := b.record(number, slot, EventHashComputed, map[string]string{
+		"hash":           sb.Hash.String(),
+		"decisions_root": sb.DecisionsRoot.String(),
+	}); err != nil {
+		return nil, err
+	}
+
+	b.logger.Info("superblock assembled",
+		zap.Uint64("number", number),
+		zap.Uint64("slot", uint64(slot)),
+		zap.Int("l2_blocks", len(ordered)),
+		zap.Int("decisions", len(decisions)),
+		zap.String("hash", sb.Hash.String()))
+	if b.bus != nil {
+		b.bus.Publish(bus.TopicSuperblockBuilt, sb)
+	}
+	return sb, nil
+}
+
+func (b *Builder) record(number uint64, slot types.Slot, eventType string, detail map[string]string) error {
+	err := b.journal.AppendEvent(&store.JournalEvent{
+		SuperblockNumber: number,
+		Slot:             slot,
+		Type:             eventType,
+		At:               time.Now(),
+		Detail:           detail,
+	})
+	if err != nil {
+		return fmt.Errorf("superblock: journal %s: %w", eventType, err)
+	}
+	return nil
+}
+
+// HeaderHash commits to the header fields — number, slot, parent hash,
+// merkle root and decisions root — with the default keccak256. Sequencers
+// recompute it to check sealed announcements.
+func HeaderHash(number uint64, slot types.Slot, parentHash, merkleRoot, decisionsRoot types.Hash) types.Hash {
+	return HeaderHashWith(keccakHasher{}, number, slot, parentHash, merkleRoot, decisionsRoot)
+}
+
+// HeaderHashWith is HeaderHash under an explicit hash function, for
+// networks whose verifiers expect something other than keccak256.
+func HeaderHashWith(hasher Hasher, number uint64, slot types.Slot, parentHash, merkleRoot, decisionsRoot types.Hash) types.Hash {
+	var numberBuf, slotBuf [8]byte
+	binary.BigEndian.PutUint64(numberBuf[:], number)
+	binary.BigEndian.PutUint64(slotBuf[:], uint64(slot))
+	return hasher.Sum(numberBuf[:], slotBuf[:], parentHash[:], merkleRoot[:], decisionsRoot[:])
+}
+
+// HeaderHasherFor resolves the header hash implied by a recorded merkle
+// scheme name: an explicit "+hash" suffix selects that hash for the header
+// too, otherwise headers stay on keccak256 whatever the scheme — plain
+// "ssz" superblocks have always hashed their headers with keccak.
+func HeaderHasherFor(schemeName string) (Hasher, error) {
+	if _, hashName, ok := strings.Cut(schemeName, "+"); ok {
+		return HasherByName(hashName)
+	}
+	return keccakHasher{}, nil
+}
@@ -0,0 +1,39 @@
+package superblock
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+// BenchmarkBuilderBuild measures assembling a superblock from a realistic
+// slot: a handful of chains, many blocks, a few decisions.
+func BenchmarkBuilderBuild(b *testing.B) {
+	builder := NewBuilder(zap.NewNop(), store.NewMemoryConstructionJournal(), nil)
+
+	blocks := make([]*types.L2Block, 0, 64)
+	for i := 0; i < 64; i++ {
+		block := &types.L2Block{
+			ChainID: types.ChainID(i%4 + 1), Number: uint64(i / 4), Slot: 1,
+			Payload: make([]byte, 512),
+		}
+		block.Hash[0], block.Hash[1] = byte(i), byte(i>>8)
+		blocks = append(blocks, block)
+	}
+	decisions := make([]types.Decision, 8)
+	for i := range decisions {
+		decisions[i] = types.Decision{Committed: i%2 == 0, Slot: 1}
+		decisions[i].XtID[0] = byte(i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := builder.Build(uint64(i+1), 1, types.Hash{}, blocks, decisions); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
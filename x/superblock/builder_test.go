@@ -0,0 +1,120 @@
+package superblock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+)
+
+func TestBuildJournalsEveryStep(t *testing.T) {
+	journal := store.NewMemoryConstructionJournal()
+	builder := NewBuilder(zap.NewNop(), journal, nil)
+
+	blocks := []*types.L2Block{
+		{ChainID: 2, Number: 9, Hash: types.Hash{2}},
+		{ChainID: 1, Number: 4, Hash: types.Hash{1}},
+	}
+	decisions := []types.Decision{
+		{XtID: types.Hash{0xaa}, Committed: true, Slot: 7},
+	}
+	sb, err := builder.Build(3, 7, types.Hash{0xbb}, blocks, decisions)
+	require.NoError(t, err)
+
+	// Blocks are reordered canonically and the header committed.
+	require.Equal(t, types.ChainID(1), sb.L2Blocks[0].ChainID)
+	require.Equal(t, types.ChainID(2), sb.L2Blocks[1].ChainID)
+	require.False(t, sb.Hash.IsZero())
+	require.False(t, sb.MerkleRoot.IsZero())
+	require.Equal(t, types.DecisionsCommitment(decisions), sb.DecisionsRoot)
+	require.Equal(t, types.SuperblockStatusBuilding, sb.Status)
+
+	events, err := journal.Events(3)
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+	require.Equal(t, EventL2BlockAccepted, events[0].Type)
+	require.Equal(t, EventL2BlockAccepted, events[1].Type)
+	require.Equal(t, EventXTIncluded, events[2].Type)
+	require.Equal(t, EventMerkleRootComputed, events[3].Type)
+	require.Equal(t, EventHashComputed, events[4].Type)
+	require.Equal(t, types.Slot(7), events[0].Slot)
+}
+
+func TestBuildIsDeterministicModuloTimestamp(t *testing.T) {
+	journal := store.NewMemoryConstructionJournal()
+	builder := NewBuilder(zap.NewNop(), journal, nil)
+
+	blocks := []*types.L2Block{
+		{ChainID: 1, Number: 1, Hash: types.Hash{1}},
+		{ChainID: 2, Number: 1, Hash: types.Hash{2}},
+		{ChainID: 3, Number: 1, Hash: types.Hash{3}},
+	}
+	a, err := builder.Build(5, 9, types.Hash{9}, blocks, nil)
+	require.NoError(t, err)
+	// Same inputs in a different order produce the same commitment.
+	shuffled := []*types.L2Block{blocks[2], blocks[0], blocks[1]}
+	b, err := builder.Build(5, 9, types.Hash{9}, shuffled, nil)
+	require.NoError(t, err)
+	require.Equal(t, a.MerkleRoot, b.MerkleRoot)
+	require.Equal(t, a.Hash, b.Hash)
+}
+
+func TestBinarySchemeRoot(t *testing.T) {
+	scheme, err := MerkleSchemeByName(SchemeBinary)
+	require.NoError(t, err)
+	require.Equal(t, types.ZeroHash, scheme.Root(nil))
+	require.Equal(t, types.Hash{7}, scheme.Root([]types.Hash{{7}}))
+
+	// Odd leaf counts promote the unpaired node instead of hashing it with
+	// itself.
+	three := []types.Hash{{1}, {2}, {3}}
+	require.NotEqual(t, scheme.Root(three[:2]), scheme.Root(three))
+}
+
+func TestBuilderRecordsScheme(t *testing.T) {
+	scheme, err := MerkleSchemeByName(SchemeOZ)
+	require.NoError(t, err)
+	builder := NewBuilder(zap.NewNop(), store.NewMemoryConstructionJournal(), scheme)
+	sb, err := builder.Build(1, 1, types.ZeroHash, []*types.L2Block{{ChainID: 1, Hash: types.Hash{1}}}, nil)
+	require.NoError(t, err)
+	require.Equal(t, SchemeOZ, sb.MerkleScheme)
+}
+
+func TestStoredBlocksAreCanonicallyOrdered(t *testing.T) {
+	builder := NewBuilder(zap.NewNop(), store.NewMemoryConstructionJournal(), nil)
+
+	// Map-iteration order from the collector: chains and numbers jumbled.
+	blocks := []*types.L2Block{
+		{ChainID: 3, Number: 1, Hash: types.Hash{4}},
+		{ChainID: 1, Number: 2, Hash: types.Hash{2}},
+		{ChainID: 2, Number: 1, Hash: types.Hash{3}},
+		{ChainID: 1, Number: 1, Hash: types.Hash{1}},
+	}
+	sb, err := builder.Build(1, 0, types.ZeroHash, blocks, nil)
+	require.NoError(t, err)
+	require.True(t, types.L2BlocksCanonical(sb.L2Blocks))
+	require.Equal(t, types.Hash{1}, sb.L2Blocks[0].Hash)
+	require.Equal(t, types.Hash{4}, sb.L2Blocks[3].Hash)
+
+	// The stored order is exactly what the root was computed over.
+	scheme, _ := MerkleSchemeByName(DefaultMerkleScheme)
+	require.Equal(t, scheme.Root(blockLeaves(sb.L2Blocks)), sb.MerkleRoot)
+}
+
+func TestInclusionProofsRejectMisorderedBlocks(t *testing.T) {
+	scheme, _ := MerkleSchemeByName(DefaultMerkleScheme)
+	blocks := []*types.L2Block{
+		{ChainID: 2, Number: 1, Hash: types.Hash{2}},
+		{ChainID: 1, Number: 1, Hash: types.Hash{1}},
+	}
+	_, err := InclusionProofsFor(scheme, blocks, 1)
+	require.Error(t, err)
+
+	types.SortL2Blocks(blocks)
+	proofs, err := InclusionProofsFor(scheme, blocks, 1)
+	require.NoError(t, err)
+	require.Len(t, proofs, 1)
+}
@@ -0,0 +1,170 @@
+package superblock
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/bus"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// Co-signing errors.
+var (
+	ErrUnknownCosignTarget = errors.New("superblock: cosign for untracked superblock")
+	ErrCosignHashMismatch  = errors.New("superblock: cosign hash does not match sealed header")
+)
+
+// maxTrackedCosigns bounds how many recent superblocks accept co-signatures.
+const maxTrackedCosigns = 64
+
+// ThresholdFunc is invoked once a superblock reaches the co-signature
+// threshold.
+type ThresholdFunc func(set *store.CosignatureSet)
+
+// CosignCollector aggregates sequencer co-signatures over sealed superblock
+// headers. Signatures are verified against the trusted key set, deduplicated
+// per chain, persisted, and the threshold callback fires once enough chains
+// have co-signed — strengthening the trust model beyond the single
+// publisher signature.
+type CosignCollector struct {
+	logger      *zap.Logger
+	auth        *auth.Manager
+	store       store.CosignatureStore
+	threshold   int
+	onThreshold ThresholdFunc
+
+	bus *bus.Bus
+
+	mu      sync.Mutex
+	tracked map[uint64]*cosignState
+	order   []uint64
+}
+
+type cosignState struct {
+	hash    types.Hash
+	byChain map[types.ChainID]store.Cosignature
+	reached bool
+}
+
+// NewCosignCollector creates a collector requiring threshold co-signatures
+// per superblock. onThreshold may be nil.
+func NewCosignCollector(logger *zap.Logger, authManager *auth.Manager, cosignStore store.CosignatureStore, threshold int, onThreshold ThresholdFunc) *CosignCollector {
+	return &CosignCollector{
+		logger:      logger.Named("cosign"),
+		auth:        authManager,
+		store:       cosignStore,
+		threshold:   threshold,
+		onThreshold: onThreshold,
+		tracked:     make(map[uint64]*cosignState),
+	}
+}
+
+// SetBus attaches the event bus; each co-signature set that reaches the
+// threshold is then published on TopicSuperblockCosigned.
+func (c *CosignCollector) SetBus(eventBus *bus.Bus) {
+	c.bus = eventBus
+}
+
+// Track registers a sealed superblock as a co-signing target. Only the most
+// recent superblocks stay tracked.
+func (c *CosignCollector) Track(sealed *protocol.SuperblockSealed) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.tracked[sealed.Number]; ok {
+		return
+	}
+	c.tracked[sealed.Number] = &cosignState{
+		hash:    sealed.Hash,
+		byChain: make(map[types.ChainID]store.Cosignature),
+	}
+	c.order = append(c.order, sealed.Number)
+	if len(c.order) > maxTrackedCosigns {
+		delete(c.tracked, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+// OnCosign verifies and records one co-signature. Duplicates from the same
+// chain are ignored.
+func (c *CosignCollector) OnCosign(cosign *protocol.SuperblockCosign) error {
+	c.mu.Lock()
+	state, ok := c.tracked[cosign.Number]
+	c.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrUnknownCosignTarget, cosign.Number)
+	}
+	if cosign.Hash != state.hash {
+		return fmt.Errorf("%w: superblock %d", ErrCosignHashMismatch, cosign.Number)
+	}
+	if err := c.auth.Authorize(cosign.KeyID, cosign.ChainID); err != nil {
+		return fmt.Errorf("superblock: cosign from chain %d: %w", cosign.ChainID, err)
+	}
+	if err := c.auth.Verify(cosign.KeyID, cosign.SigningBytes(), cosign.Signature); err != nil {
+		return fmt.Errorf("superblock: cosign from chain %d: %w", cosign.ChainID, err)
+	}
+
+	c.mu.Lock()
+	if _, dup := state.byChain[cosign.ChainID]; dup {
+		c.mu.Unlock()
+		return nil
+	}
+	state.byChain[cosign.ChainID] = store.Cosignature{
+		ChainID:   cosign.ChainID,
+		KeyID:     cosign.KeyID,
+		Signature: cosign.Signature,
+	}
+	set := c.setLocked(cosign.Number, state)
+	reachedNow := !state.reached && len(state.byChain) >= c.threshold
+	if reachedNow {
+		state.reached = true
+	}
+	c.mu.Unlock()
+
+	if err := c.store.PutCosignatures(set); err != nil {
+		return fmt.Errorf("superblock: persist cosignatures: %w", err)
+	}
+	if reachedNow {
+		c.logger.Info("co-signature threshold reached",
+			zap.Uint64("superblock", cosign.Number),
+			zap.Int("cosignatures", len(set.Cosignatures)),
+			zap.Int("threshold", c.threshold))
+		if c.onThreshold != nil {
+			c.onThreshold(set)
+		}
+		if c.bus != nil {
+			c.bus.Publish(bus.TopicSuperblockCosigned, set)
+		}
+	}
+	return nil
+}
+
+// setLocked snapshots the aggregated set; chains iterate in recorded order
+// is not guaranteed, which is fine for an unordered signature set.
+func (c *CosignCollector) setLocked(number uint64, state *cosignState) *store.CosignatureSet {
+	set := &store.CosignatureSet{SuperblockNumber: number, Hash: state.hash}
+	for _, cosig := range state.byChain {
+		set.Cosignatures = append(set.Cosignatures, cosig)
+	}
+	return set
+}
+
+// Cosign produces a sequencer's co-signature over a sealed header. The
+// signature covers the superblock number, hash, chain ID and key ID, so it
+// only counts for the chain it was issued for. Callers should VerifySealed
+// the announcement first.
+func Cosign(signer *auth.Signer, chainID types.ChainID, sealed *protocol.SuperblockSealed) *protocol.SuperblockCosign {
+	cosign := &protocol.SuperblockCosign{
+		Number:  sealed.Number,
+		Hash:    sealed.Hash,
+		ChainID: chainID,
+		KeyID:   signer.KeyID(),
+	}
+	cosign.Signature = signer.Sign(cosign.SigningBytes())
+	return cosign
+}
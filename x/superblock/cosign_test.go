@@ -0,0 +1,105 @@
+package superblock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+func sealedForTest(t *testing.T) (*protocol.SuperblockSealed, *auth.Signer) {
+	t.Helper()
+	publisher, err := auth.GenerateSigner("publisher")
+	require.NoError(t, err)
+	builder := NewBuilder(zap.NewNop(), store.NewMemoryConstructionJournal(), nil)
+	sb, err := builder.Build(2, 6, types.ZeroHash, []*types.L2Block{{ChainID: 1, Hash: types.Hash{1}}}, nil)
+	require.NoError(t, err)
+	sealed, err := Seal(publisher, sb)
+	require.NoError(t, err)
+	return sealed, publisher
+}
+
+func TestCosignThreshold(t *testing.T) {
+	sealed, _ := sealedForTest(t)
+	authManager := auth.NewManager()
+	cosignStore := store.NewMemoryCosignatureStore()
+
+	var reached []*store.CosignatureSet
+	collector := NewCosignCollector(zap.NewNop(), authManager, cosignStore, 2, func(set *store.CosignatureSet) {
+		reached = append(reached, set)
+	})
+	collector.Track(sealed)
+
+	signers := make(map[types.ChainID]*auth.Signer)
+	for _, chainID := range []types.ChainID{1, 2, 3} {
+		signer, err := auth.GenerateSigner(string(rune('a' + chainID)))
+		require.NoError(t, err)
+		require.NoError(t, authManager.AddTrustedKey(signer.KeyID(), signer.PublicKeyHex()))
+		signers[chainID] = signer
+	}
+
+	require.NoError(t, collector.OnCosign(Cosign(signers[1], 1, sealed)))
+	require.Empty(t, reached)
+
+	// Duplicate from the same chain is ignored and does not count.
+	require.NoError(t, collector.OnCosign(Cosign(signers[1], 1, sealed)))
+	require.Empty(t, reached)
+
+	require.NoError(t, collector.OnCosign(Cosign(signers[2], 2, sealed)))
+	require.Len(t, reached, 1)
+	require.Equal(t, sealed.Hash, reached[0].Hash)
+	require.Len(t, reached[0].Cosignatures, 2)
+
+	// A third signature is recorded but the callback does not refire.
+	require.NoError(t, collector.OnCosign(Cosign(signers[3], 3, sealed)))
+	require.Len(t, reached, 1)
+
+	set, err := cosignStore.GetCosignatures(sealed.Number)
+	require.NoError(t, err)
+	require.Len(t, set.Cosignatures, 3)
+}
+
+func TestCosignRejections(t *testing.T) {
+	sealed, _ := sealedForTest(t)
+	authManager := auth.NewManager()
+	collector := NewCosignCollector(zap.NewNop(), authManager, store.NewMemoryCosignatureStore(), 1, nil)
+	collector.Track(sealed)
+
+	signer, err := auth.GenerateSigner("seq-1")
+	require.NoError(t, err)
+	require.NoError(t, authManager.AddTrustedKey("seq-1", signer.PublicKeyHex()))
+
+	// Untracked superblock.
+	other := *sealed
+	other.Number = 99
+	err = collector.OnCosign(Cosign(signer, 1, &other))
+	require.ErrorIs(t, err, ErrUnknownCosignTarget)
+
+	// Hash mismatch.
+	wrongHash := Cosign(signer, 1, sealed)
+	wrongHash.Hash = types.Hash{0xff}
+	require.ErrorIs(t, collector.OnCosign(wrongHash), ErrCosignHashMismatch)
+
+	// Untrusted key.
+	rogue, err := auth.GenerateSigner("rogue")
+	require.NoError(t, err)
+	require.ErrorIs(t, collector.OnCosign(Cosign(rogue, 2, sealed)), auth.ErrUntrustedKey)
+
+	// A trusted key restricted to chain 1 cannot co-sign as chain 2.
+	require.NoError(t, authManager.AddTrustedKeyWithMetadata("seq-1", signer.PublicKeyHex(), auth.KeyMetadata{
+		AllowedChains: []types.ChainID{1},
+	}))
+	require.ErrorIs(t, collector.OnCosign(Cosign(signer, 2, sealed)), auth.ErrChainDenied)
+
+	// Replaying chain 1's signature under another chain ID fails
+	// verification: the chain ID is part of the signed bytes.
+	replayed := Cosign(signer, 1, sealed)
+	replayed.ChainID = 3
+	require.NoError(t, authManager.AddTrustedKey("seq-1", signer.PublicKeyHex()))
+	require.ErrorIs(t, collector.OnCosign(replayed), auth.ErrBadSignature)
+}
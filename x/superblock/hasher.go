@@ -0,0 +1,100 @@
+package superblock
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/sha3"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// Named hash functions for merkle and header hashing. Networks whose L1
+// verifiers or provers expect a different hash select it per network
+// config instead of patching the publisher.
+const (
+	HashKeccak256 = "keccak256"
+	HashSHA256    = "sha256"
+)
+
+// DefaultHash is used when no hash function is configured.
+const DefaultHash = HashKeccak256
+
+// Hasher is the hash function behind merkle pair hashing and superblock
+// header hashing.
+type Hasher interface {
+	// Name returns the hash name recorded in scheme metadata.
+	Name() string
+	// Sum hashes the concatenation of the chunks.
+	Sum(chunks ...[]byte) types.Hash
+}
+
+type keccakHasher struct{}
+
+func (keccakHasher) Name() string { return HashKeccak256 }
+
+func (keccakHasher) Sum(chunks ...[]byte) types.Hash {
+	h := sha3.NewLegacyKeccak256()
+	for _, chunk := range chunks {
+		h.Write(chunk)
+	}
+	var out types.Hash
+	h.Sum(out[:0])
+	return out
+}
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) Name() string { return HashSHA256 }
+
+func (sha256Hasher) Sum(chunks ...[]byte) types.Hash {
+	h := sha256.New()
+	for _, chunk := range chunks {
+		h.Write(chunk)
+	}
+	var out types.Hash
+	h.Sum(out[:0])
+	return out
+}
+
+var (
+	hasherMu sync.RWMutex
+	// extraHashers holds externally registered constructions — poseidon
+	// and friends live in libraries this module does not depend on, so the
+	// embedding build registers them at init.
+	extraHashers = map[string]func() Hasher{}
+)
+
+// RegisterHasher makes a named hash function resolvable by HasherByName.
+// Registering a built-in name or the same name twice is an error.
+func RegisterHasher(name string, fn func() Hasher) error {
+	switch name {
+	case "", HashKeccak256, HashSHA256:
+		return fmt.Errorf("superblock: hash %q is built in", name)
+	}
+	hasherMu.Lock()
+	defer hasherMu.Unlock()
+	if _, ok := extraHashers[name]; ok {
+		return fmt.Errorf("superblock: hash %q already registered", name)
+	}
+	extraHashers[name] = fn
+	return nil
+}
+
+// HasherByName resolves a named hash function; empty selects the default.
+func HasherByName(name string) (Hasher, error) {
+	switch name {
+	case "", HashKeccak256:
+		return keccakHasher{}, nil
+	case HashSHA256:
+		return sha256Hasher{}, nil
+	}
+	hasherMu.RLock()
+	fn, ok := extraHashers[name]
+	hasherMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("superblock: unknown hash %q", name)
+	}
+	return fn(), nil
+}
@@ -0,0 +1,94 @@
+package superblock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestSchemeNameRoundTripsThroughResolution(t *testing.T) {
+	for _, name := range []string{
+		"binary", "oz", "ssz",
+		"binary+sha256", "oz+sha256", "ssz+keccak256",
+	} {
+		scheme, err := MerkleSchemeByName(name)
+		require.NoError(t, err, name)
+		require.Equal(t, name, scheme.Name())
+
+		// Resolving the recorded name again yields the same construction.
+		again, err := MerkleSchemeByName(scheme.Name())
+		require.NoError(t, err)
+		leaves := []types.Hash{{1}, {2}, {3}}
+		require.Equal(t, scheme.Root(leaves), again.Root(leaves))
+	}
+
+	// Default-hash suffixes collapse to the bare scheme name.
+	scheme, err := MerkleSchemeByName("binary+keccak256")
+	require.NoError(t, err)
+	require.Equal(t, "binary", scheme.Name())
+
+	_, err = MerkleSchemeByName("binary+whirlpool")
+	require.Error(t, err)
+}
+
+func TestHashSelectionChangesRoots(t *testing.T) {
+	keccak, err := MerkleSchemeByName("binary")
+	require.NoError(t, err)
+	sha, err := MerkleSchemeByName("binary+sha256")
+	require.NoError(t, err)
+
+	leaves := []types.Hash{{1}, {2}, {3}, {4}}
+	require.NotEqual(t, keccak.Root(leaves), sha.Root(leaves))
+
+	// Proofs verify under the scheme that built them and fail under the
+	// other hash.
+	root := sha.Root(leaves)
+	steps, err := sha.Proof(leaves, 2)
+	require.NoError(t, err)
+	require.True(t, sha.Verify(root, leaves[2], steps))
+	require.False(t, keccak.Verify(root, leaves[2], steps))
+}
+
+func TestHeaderHasherFollowsSchemeSuffix(t *testing.T) {
+	keccakHeader := HeaderHash(1, 2, types.Hash{1}, types.Hash{2}, types.Hash{3})
+
+	hasher, err := HeaderHasherFor("ssz")
+	require.NoError(t, err)
+	require.Equal(t, keccakHeader,
+		HeaderHashWith(hasher, 1, 2, types.Hash{1}, types.Hash{2}, types.Hash{3}),
+		"bare scheme names keep headers on keccak")
+
+	hasher, err = HeaderHasherFor("binary+sha256")
+	require.NoError(t, err)
+	require.NotEqual(t, keccakHeader,
+		HeaderHashWith(hasher, 1, 2, types.Hash{1}, types.Hash{2}, types.Hash{3}))
+}
+
+func TestRegisterHasher(t *testing.T) {
+	require.Error(t, RegisterHasher(HashKeccak256, nil), "built-ins are reserved")
+
+	require.NoError(t, RegisterHasher("test-xor", func() Hasher { return xorHasher{} }))
+	require.Error(t, RegisterHasher("test-xor", func() Hasher { return xorHasher{} }))
+
+	scheme, err := MerkleSchemeByName("binary+test-xor")
+	require.NoError(t, err)
+	require.Equal(t, "binary+test-xor", scheme.Name())
+	require.NotEqual(t, types.ZeroHash, scheme.Root([]types.Hash{{1}, {2}}))
+}
+
+// xorHasher is a stand-in for an externally registered construction.
+type xorHasher struct{}
+
+func (xorHasher) Name() string { return "test-xor" }
+
+func (xorHasher) Sum(chunks ...[]byte) types.Hash {
+	var out types.Hash
+	for _, chunk := range chunks {
+		for i, b := range chunk {
+			out[i%len(out)] ^= b
+		}
+	}
+	return out
+}
@@ -0,0 +1,292 @@
+package superblock
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// Named merkle schemes. Different L1 verifier contracts expect different
+// constructions, so the scheme is configurable and recorded in the
+// superblock metadata.
+const (
+	// SchemeBinary is the native construction: hash pairs, unpaired nodes
+	// promote to the next level.
+	SchemeBinary = "binary"
+	// SchemeOZ matches OpenZeppelin's MerkleProof: hashing over the sorted
+	// pair, so proofs carry no position bits.
+	SchemeOZ = "oz"
+	// SchemeSSZ merkleizes like SSZ: pairs over leaves zero-padded to the
+	// next power of two.
+	SchemeSSZ = "ssz"
+)
+
+// DefaultMerkleScheme is used when no scheme is configured.
+const DefaultMerkleScheme = SchemeBinary
+
+// MerkleScheme computes roots and inclusion proofs over L2 block hashes.
+type MerkleScheme interface {
+	// Name returns the scheme name recorded in superblock metadata. A
+	// scheme on a non-default hash appends it: "binary+sha256".
+	Name() string
+	// Root commits to the leaves; an empty set commits to the zero hash.
+	Root(leaves []types.Hash) types.Hash
+	// Proof builds the merkle path for the leaf at index.
+	Proof(leaves []types.Hash, index int) ([]protocol.ProofStep, error)
+	// Verify replays a proof against the root.
+	Verify(root, leaf types.Hash, steps []protocol.ProofStep) bool
+}
+
+// defaultHashFor is each scheme's historical hash, kept as the default so
+// existing scheme names resolve to the same construction as before.
+func defaultHashFor(scheme string) string {
+	if scheme == SchemeSSZ {
+		return HashSHA256
+	}
+	return HashKeccak256
+}
+
+// MerkleSchemeByName resolves a named scheme; empty selects the default.
+// A "+hash" suffix selects the pair hash — "binary+sha256", "oz+poseidon"
+// — so the name recorded in superblock metadata round-trips through
+// verification whatever the network configured.
+func MerkleSchemeByName(name string) (MerkleScheme, error) {
+	schemeName, hashName, _ := strings.Cut(name, "+")
+	if schemeName == "" {
+		schemeName = DefaultMerkleScheme
+	}
+	if hashName == "" {
+		hashName = defaultHashFor(schemeName)
+	}
+	hasher, err := HasherByName(hashName)
+	if err != nil {
+		return nil, err
+	}
+	return MerkleSchemeWith(schemeName, hasher)
+}
+
+// MerkleSchemeWith builds a named scheme over an explicit hash function,
+// for networks configuring the two independently.
+func MerkleSchemeWith(schemeName string, hasher Hasher) (MerkleScheme, error) {
+	switch schemeName {
+	case "", SchemeBinary:
+		return binaryScheme{h: hasher}, nil
+	case SchemeOZ:
+		return ozScheme{h: hasher}, nil
+	case SchemeSSZ:
+		return sszScheme{h: hasher}, nil
+	default:
+		return nil, fmt.Errorf("superblock: unknown merkle scheme %q", schemeName)
+	}
+}
+
+// schemeName composes the recorded name: the base scheme, plus the hash
+// when it is not the scheme's default.
+func schemeName(base string, h Hasher) string {
+	if h.Name() == defaultHashFor(base) {
+		return base
+	}
+	return base + "+" + h.Name()
+}
+
+func hashPair(h Hasher, left, right types.Hash) types.Hash {
+	return h.Sum(left[:], right[:])
+}
+
+// binaryScheme is the native construction with odd-node promotion.
+type binaryScheme struct{ h Hasher }
+
+func (s binaryScheme) Name() string { return schemeName(SchemeBinary, s.h) }
+
+func (s binaryScheme) Root(leaves []types.Hash) types.Hash {
+	if len(leaves) == 0 {
+		return types.ZeroHash
+	}
+	level := append([]types.Hash(nil), leaves...)
+	for len(level) > 1 {
+		next := make([]types.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, hashPair(s.h, level[i], level[i+1]))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+func (s binaryScheme) Proof(leaves []types.Hash, index int) ([]protocol.ProofStep, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, fmt.Errorf("superblock: proof index %d out of range", index)
+	}
+	level := append([]types.Hash(nil), leaves...)
+	var steps []protocol.ProofStep
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			steps = append(steps, protocol.ProofStep{
+				Sibling: level[sibling],
+				Left:    sibling < index,
+			})
+		}
+		next := make([]types.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, hashPair(s.h, level[i], level[i+1]))
+		}
+		level = next
+		index /= 2
+	}
+	return steps, nil
+}
+
+func (s binaryScheme) Verify(root, leaf types.Hash, steps []protocol.ProofStep) bool {
+	current := leaf
+	for _, step := range steps {
+		if step.Left {
+			current = hashPair(s.h, step.Sibling, current)
+		} else {
+			current = hashPair(s.h, current, step.Sibling)
+		}
+	}
+	return current == root
+}
+
+// ozScheme hashes each pair in byte order, matching OpenZeppelin's
+// MerkleProof.processProof. Odd nodes promote like the binary scheme.
+type ozScheme struct{ h Hasher }
+
+func (s ozScheme) Name() string { return schemeName(SchemeOZ, s.h) }
+
+func sortedHashPair(h Hasher, a, b types.Hash) types.Hash {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return hashPair(h, a, b)
+}
+
+func (s ozScheme) Root(leaves []types.Hash) types.Hash {
+	if len(leaves) == 0 {
+		return types.ZeroHash
+	}
+	level := append([]types.Hash(nil), leaves...)
+	for len(level) > 1 {
+		next := make([]types.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, sortedHashPair(s.h, level[i], level[i+1]))
+		}
+		level = next
+	}
+	return level[0]
+}
+
+func (s ozScheme) Proof(leaves []types.Hash, index int) ([]protocol.ProofStep, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, fmt.Errorf("superblock: proof index %d out of range", index)
+	}
+	level := append([]types.Hash(nil), leaves...)
+	var steps []protocol.ProofStep
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling < len(level) {
+			// Position is irrelevant for sorted pairs.
+			steps = append(steps, protocol.ProofStep{Sibling: level[sibling]})
+		}
+		next := make([]types.Hash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, sortedHashPair(s.h, level[i], level[i+1]))
+		}
+		level = next
+		index /= 2
+	}
+	return steps, nil
+}
+
+func (s ozScheme) Verify(root, leaf types.Hash, steps []protocol.ProofStep) bool {
+	current := leaf
+	for _, step := range steps {
+		current = sortedHashPair(s.h, current, step.Sibling)
+	}
+	return current == root
+}
+
+// sszScheme merkleizes over leaves zero-padded to a power of two, the
+// construction SSZ-based verifiers expect; its default hash is sha256.
+type sszScheme struct{ h Hasher }
+
+func (s sszScheme) Name() string { return schemeName(SchemeSSZ, s.h) }
+
+func padToPowerOfTwo(leaves []types.Hash) []types.Hash {
+	size := 1
+	for size < len(leaves) {
+		size *= 2
+	}
+	padded := make([]types.Hash, size)
+	copy(padded, leaves)
+	return padded
+}
+
+func (s sszScheme) Root(leaves []types.Hash) types.Hash {
+	if len(leaves) == 0 {
+		return types.ZeroHash
+	}
+	level := padToPowerOfTwo(leaves)
+	for len(level) > 1 {
+		next := make([]types.Hash, len(level)/2)
+		for i := range next {
+			next[i] = hashPair(s.h, level[2*i], level[2*i+1])
+		}
+		level = next
+	}
+	return level[0]
+}
+
+func (s sszScheme) Proof(leaves []types.Hash, index int) ([]protocol.ProofStep, error) {
+	if index < 0 || index >= len(leaves) {
+		return nil, fmt.Errorf("superblock: proof index %d out of range", index)
+	}
+	level := padToPowerOfTwo(leaves)
+	var steps []protocol.ProofStep
+	for len(level) > 1 {
+		sibling := index ^ 1
+		steps = append(steps, protocol.ProofStep{
+			Sibling: level[sibling],
+			Left:    sibling < index,
+		})
+		next := make([]types.Hash, len(level)/2)
+		for i := range next {
+			next[i] = hashPair(s.h, level[2*i], level[2*i+1])
+		}
+		level = next
+		index /= 2
+	}
+	return steps, nil
+}
+
+func (s sszScheme) Verify(root, leaf types.Hash, steps []protocol.ProofStep) bool {
+	current := leaf
+	for _, step := range steps {
+		if step.Left {
+			current = hashPair(s.h, step.Sibling, current)
+		} else {
+			current = hashPair(s.h, current, step.Sibling)
+		}
+	}
+	return current == root
+}
@@ -0,0 +1,55 @@
+package superblock
+
+import (
+	"fmt"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// blockLeaves extracts the leaf hashes in superblock order.
+func blockLeaves(blocks []*types.L2Block) []types.Hash {
+	leaves := make([]types.Hash, len(blocks))
+	for i, block := range blocks {
+		leaves[i] = block.Hash
+	}
+	return leaves
+}
+
+// InclusionProofsFor builds proofs for every block of one chain under the
+// given scheme. Blocks must be in the superblock's canonical order
+// (types.SortL2Blocks); mis-ordered input is rejected rather than
+// re-sorted, because it means the caller is not holding the slice the root
+// was computed over.
+func InclusionProofsFor(scheme MerkleScheme, blocks []*types.L2Block, chainID types.ChainID) ([]protocol.InclusionProof, error) {
+	if !types.L2BlocksCanonical(blocks) {
+		return nil, fmt.Errorf("superblock: blocks not in canonical order")
+	}
+	leaves := blockLeaves(blocks)
+	var proofs []protocol.InclusionProof
+	for i, block := range blocks {
+		if block.ChainID != chainID {
+			continue
+		}
+		steps, err := scheme.Proof(leaves, i)
+		if err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, protocol.InclusionProof{
+			ChainID:   chainID,
+			BlockHash: block.Hash,
+			Steps:     steps,
+		})
+	}
+	return proofs, nil
+}
+
+// VerifyInclusion replays a proof under the named scheme and reports
+// whether it commits leaf under root.
+func VerifyInclusion(schemeName string, root, leaf types.Hash, steps []protocol.ProofStep) bool {
+	scheme, err := MerkleSchemeByName(schemeName)
+	if err != nil {
+		return false
+	}
+	return scheme.Verify(root, leaf, steps)
+}
@@ -0,0 +1,116 @@
+package superblock
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// TestInclusionProofsVerify checks proofs for every leaf across tree sizes
+// and schemes, including the odd-count cases each scheme handles
+// differently.
+func TestInclusionProofsVerify(t *testing.T) {
+	for _, name := range []string{SchemeBinary, SchemeOZ, SchemeSSZ} {
+		scheme, err := MerkleSchemeByName(name)
+		require.NoError(t, err)
+		for _, n := range []int{1, 2, 3, 4, 5, 7, 8} {
+			leaves := make([]types.Hash, n)
+			for i := range leaves {
+				leaves[i] = types.Hash{byte(i + 1)}
+			}
+			root := scheme.Root(leaves)
+			for i, leaf := range leaves {
+				steps, err := scheme.Proof(leaves, i)
+				require.NoError(t, err, "%s n=%d i=%d", name, n, i)
+				require.True(t, VerifyInclusion(name, root, leaf, steps), "%s n=%d i=%d", name, n, i)
+				require.False(t, VerifyInclusion(name, root, types.Hash{0xff}, steps), "%s n=%d i=%d", name, n, i)
+			}
+		}
+	}
+}
+
+func TestMerkleSchemesDisagree(t *testing.T) {
+	leaves := []types.Hash{{1}, {2}, {3}}
+	binary, _ := MerkleSchemeByName(SchemeBinary)
+	oz, _ := MerkleSchemeByName(SchemeOZ)
+	ssz, _ := MerkleSchemeByName(SchemeSSZ)
+	require.NotEqual(t, binary.Root(leaves), oz.Root(leaves))
+	require.NotEqual(t, binary.Root(leaves), ssz.Root(leaves))
+	require.NotEqual(t, oz.Root(leaves), ssz.Root(leaves))
+}
+
+func TestMerkleSchemeByName(t *testing.T) {
+	// Empty selects the default.
+	scheme, err := MerkleSchemeByName("")
+	require.NoError(t, err)
+	require.Equal(t, DefaultMerkleScheme, scheme.Name())
+
+	_, err = MerkleSchemeByName("poseidon")
+	require.Error(t, err)
+	require.False(t, VerifyInclusion("poseidon", types.Hash{1}, types.Hash{1}, nil))
+}
+
+func TestProofRejectsBadIndex(t *testing.T) {
+	leaves := []types.Hash{{1}}
+	for _, name := range []string{SchemeBinary, SchemeOZ, SchemeSSZ} {
+		scheme, err := MerkleSchemeByName(name)
+		require.NoError(t, err)
+		_, err = scheme.Proof(leaves, 1)
+		require.Error(t, err, name)
+		_, err = scheme.Proof(leaves, -1)
+		require.Error(t, err, name)
+	}
+}
+
+type recordingSender struct {
+	sent map[types.ChainID]protocol.SuperblockSealed
+	fail map[types.ChainID]bool
+}
+
+func (s *recordingSender) SendTo(_ types.NetworkID, chainID types.ChainID, _ string, payload any) error {
+	if s.fail[chainID] {
+		return fmt.Errorf("chain %d not connected", chainID)
+	}
+	s.sent[chainID] = payload.(protocol.SuperblockSealed)
+	return nil
+}
+
+func TestAnnounceSealedAttachesPerChainProofs(t *testing.T) {
+	signer, err := auth.GenerateSigner("publisher")
+	require.NoError(t, err)
+	builder := NewBuilder(zap.NewNop(), store.NewMemoryConstructionJournal(), nil)
+
+	blocks := []*types.L2Block{
+		{ChainID: 1, Number: 5, Hash: types.Hash{1}},
+		{ChainID: 2, Number: 8, Hash: types.Hash{2}},
+		{ChainID: 1, Number: 6, Hash: types.Hash{3}},
+	}
+	sb, err := builder.Build(9, 3, types.ZeroHash, blocks, nil)
+	require.NoError(t, err)
+	sealed, err := Seal(signer, sb)
+	require.NoError(t, err)
+	require.Equal(t, sb.MerkleScheme, sealed.MerkleScheme)
+
+	sender := &recordingSender{
+		sent: make(map[types.ChainID]protocol.SuperblockSealed),
+		fail: map[types.ChainID]bool{3: true},
+	}
+	AnnounceSealed(zap.NewNop(), sender, types.DefaultNetworkID, sb, sealed, []types.ChainID{1, 2, 3})
+
+	// Chain 1 has two blocks, chain 2 one; the failed send is skipped.
+	require.Len(t, sender.sent, 2)
+	require.Len(t, sender.sent[1].Inclusions, 2)
+	require.Len(t, sender.sent[2].Inclusions, 1)
+	for _, got := range sender.sent {
+		for _, proof := range got.Inclusions {
+			require.True(t, VerifyInclusion(got.MerkleScheme, got.MerkleRoot, proof.BlockHash, proof.Steps))
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package superblock
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// ErrSealedMismatch is returned when a sealed announcement's hash does not
+// match its header fields.
+var ErrSealedMismatch = errors.New("superblock: sealed header hash mismatch")
+
+// Seal marks a built superblock sealed and produces the signed
+// announcement broadcast to sequencers.
+func Seal(signer *auth.Signer, sb *types.Superblock) (*protocol.SuperblockSealed, error) {
+	if sb.Hash.IsZero() {
+		return nil, errors.New("superblock: cannot seal before the hash is computed")
+	}
+	sb.Status = types.SuperblockStatusSealed
+	return &protocol.SuperblockSealed{
+		Number:        sb.Number,
+		Slot:          sb.Slot,
+		Hash:          sb.Hash,
+		ParentHash:    sb.ParentHash,
+		MerkleRoot:    sb.MerkleRoot,
+		MerkleScheme:  sb.MerkleScheme,
+		DecisionsRoot: sb.DecisionsRoot,
+		KeyID:         signer.KeyID(),
+		Signature:     signer.Sign(sb.Hash[:]),
+	}, nil
+}
+
+// VerifySealed checks a sealed announcement against the publisher's pinned
+// public key (compressed hex): the hash must recompute from the announced
+// header fields and the signature must cover it.
+func VerifySealed(publisherPublicKey string, sealed *protocol.SuperblockSealed) error {
+	hasher, err := HeaderHasherFor(sealed.MerkleScheme)
+	if err != nil {
+		return fmt.Errorf("superblock: sealed announcement: %w", err)
+	}
+	expected := HeaderHashWith(hasher, sealed.Number, sealed.Slot, sealed.ParentHash, sealed.MerkleRoot, sealed.DecisionsRoot)
+	if expected != sealed.Hash {
+		return ErrSealedMismatch
+	}
+	if err := auth.VerifyWithKey(publisherPublicKey, sealed.Hash[:], sealed.Signature); err != nil {
+		return fmt.Errorf("superblock: sealed signature: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package superblock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+)
+
+func TestSealAndVerify(t *testing.T) {
+	signer, err := auth.GenerateSigner("publisher")
+	require.NoError(t, err)
+
+	builder := NewBuilder(zap.NewNop(), store.NewMemoryConstructionJournal(), nil)
+	sb, err := builder.Build(4, 11, types.Hash{1}, []*types.L2Block{{ChainID: 1, Number: 2, Hash: types.Hash{2}}}, nil)
+	require.NoError(t, err)
+
+	sealed, err := Seal(signer, sb)
+	require.NoError(t, err)
+	require.Equal(t, types.SuperblockStatusSealed, sb.Status)
+	require.Equal(t, "publisher", sealed.KeyID)
+
+	require.NoError(t, VerifySealed(signer.PublicKeyHex(), sealed))
+
+	// A tampered header field breaks the hash check.
+	tampered := *sealed
+	tampered.MerkleRoot = types.Hash{0xff}
+	require.ErrorIs(t, VerifySealed(signer.PublicKeyHex(), &tampered), ErrSealedMismatch)
+
+	// A signature from another key is rejected.
+	other, err := auth.GenerateSigner("imposter")
+	require.NoError(t, err)
+	require.Error(t, VerifySealed(other.PublicKeyHex(), sealed))
+}
+
+func TestSealRequiresBuiltSuperblock(t *testing.T) {
+	signer, err := auth.GenerateSigner("publisher")
+	require.NoError(t, err)
+	_, err = Seal(signer, &types.Superblock{Number: 1})
+	require.Error(t, err)
+}
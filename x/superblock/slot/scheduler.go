@@ -0,0 +1,161 @@
+package slot
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// State is a phase of the slot lifecycle. Each slot passes through the
+// states in order; the boundaries come from Config.
+type State string
+
+const (
+	// StateFree begins at the slot start: XT intake and block collection
+	// are open.
+	StateFree State = "free"
+	// StateSealing begins at the seal cutover: undecided XTs are aborted
+	// and the superblock is sealed.
+	StateSealing State = "sealing"
+	// StateSealed begins at the slot end, once the sealed superblock is
+	// final for the slot.
+	StateSealed State = "sealed"
+)
+
+var stateOrder = []State{StateFree, StateSealing, StateSealed}
+
+// HookFunc is invoked when a slot enters a state. Hooks run synchronously
+// on the scheduler goroutine, so slow work (notifying an external
+// scheduler, kicking off a build) should be handed off.
+type HookFunc func(slot types.Slot, at time.Time)
+
+// Scheduler drives the slot state machine off the wall clock and fires
+// registered hooks at each transition, so embedding applications can
+// attach custom logic without forking the slot loop.
+type Scheduler struct {
+	logger *zap.Logger
+	cfg    Config
+
+	mu    sync.RWMutex
+	hooks map[State][]HookFunc
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewScheduler creates a scheduler for the network's slot timing.
+func NewScheduler(logger *zap.Logger, cfg Config) (*Scheduler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &Scheduler{
+		logger: logger.Named("slots"),
+		cfg:    cfg,
+		hooks:  make(map[State][]HookFunc),
+	}, nil
+}
+
+// RegisterSlotHook attaches fn to a state transition. Hooks for the same
+// state run in registration order. Registration is safe while the
+// scheduler runs; the new hook fires from the next transition on.
+func (s *Scheduler) RegisterSlotHook(state State, fn HookFunc) error {
+	switch state {
+	case StateFree, StateSealing, StateSealed:
+	default:
+		return fmt.Errorf("slot: unknown state %q", state)
+	}
+	s.mu.Lock()
+	s.hooks[state] = append(s.hooks[state], fn)
+	s.mu.Unlock()
+	return nil
+}
+
+// C returns a channel receiving the slot number each time a slot enters
+// state, for callers that would rather select on boundaries than register
+// hooks. The channel is buffered; a receiver that falls behind misses
+// transitions instead of stalling the slot loop.
+func (s *Scheduler) C(state State) (<-chan types.Slot, error) {
+	ch := make(chan types.Slot, 4)
+	err := s.RegisterSlotHook(state, func(slot types.Slot, _ time.Time) {
+		select {
+		case ch <- slot:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// Start launches the slot loop, beginning with the slot in progress.
+func (s *Scheduler) Start() {
+	if s.stopCh != nil {
+		return
+	}
+	s.stopCh = make(chan struct{})
+	s.doneCh = make(chan struct{})
+	go s.run()
+}
+
+// Stop terminates the slot loop.
+func (s *Scheduler) Stop() {
+	if s.stopCh == nil {
+		return
+	}
+	close(s.stopCh)
+	<-s.doneCh
+	s.stopCh = nil
+}
+
+func (s *Scheduler) run() {
+	defer close(s.doneCh)
+	current := s.cfg.At(time.Now())
+	caughtUp := false
+	for {
+		boundaries := []struct {
+			state State
+			at    time.Time
+		}{
+			{StateFree, s.cfg.StartOf(current)},
+			{StateSealing, s.cfg.SealTimeOf(current)},
+			{StateSealed, s.cfg.EndOf(current)},
+		}
+		for _, b := range boundaries {
+			if wait := time.Until(b.at); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-s.stopCh:
+					timer.Stop()
+					return
+				case <-timer.C:
+				}
+			} else if !caughtUp {
+				// Started mid-slot: transitions already in the past are
+				// skipped rather than fired late. Once the loop is in step
+				// with the clock, a boundary reached by timer counts even
+				// if the timer overshot it slightly.
+				continue
+			}
+			caughtUp = true
+			s.fire(b.state, current, b.at)
+		}
+		current++
+	}
+}
+
+func (s *Scheduler) fire(state State, slot types.Slot, at time.Time) {
+	s.mu.RLock()
+	hooks := append([]HookFunc(nil), s.hooks[state]...)
+	s.mu.RUnlock()
+	s.logger.Debug("slot state transition",
+		zap.Uint64("slot", uint64(slot)),
+		zap.String("state", string(state)))
+	for _, fn := range hooks {
+		fn(slot, at)
+	}
+}
@@ -0,0 +1,92 @@
+package slot
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestSchedulerFiresHooksInOrder(t *testing.T) {
+	cfg := Config{
+		GenesisTime: time.Now().Add(20 * time.Millisecond),
+		Duration:    60 * time.Millisecond,
+		SealCutover: 30 * time.Millisecond,
+	}
+	s, err := NewScheduler(zap.NewNop(), cfg)
+	require.NoError(t, err)
+
+	type transition struct {
+		state State
+		slot  types.Slot
+	}
+	var (
+		mu  sync.Mutex
+		got []transition
+	)
+	for _, state := range stateOrder {
+		state := state
+		require.NoError(t, s.RegisterSlotHook(state, func(slot types.Slot, _ time.Time) {
+			mu.Lock()
+			got = append(got, transition{state, slot})
+			mu.Unlock()
+		}))
+	}
+	require.Error(t, s.RegisterSlotHook("bogus", func(types.Slot, time.Time) {}))
+
+	s.Start()
+	defer s.Stop()
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(got) >= 4
+	}, 2*time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, transition{StateFree, 0}, got[0])
+	require.Equal(t, transition{StateSealing, 0}, got[1])
+	require.Equal(t, transition{StateSealed, 0}, got[2])
+	require.Equal(t, transition{StateFree, 1}, got[3])
+}
+
+func TestSchedulerRejectsInvalidConfig(t *testing.T) {
+	_, err := NewScheduler(zap.NewNop(), Config{})
+	require.Error(t, err)
+}
+
+func TestSchedulerBoundaryChannel(t *testing.T) {
+	cfg := Config{
+		GenesisTime: time.Now().Add(20 * time.Millisecond),
+		Duration:    60 * time.Millisecond,
+		SealCutover: 30 * time.Millisecond,
+	}
+	s, err := NewScheduler(zap.NewNop(), cfg)
+	require.NoError(t, err)
+
+	sealing, err := s.C(StateSealing)
+	require.NoError(t, err)
+	_, err = s.C("bogus")
+	require.Error(t, err)
+
+	s.Start()
+	defer s.Stop()
+
+	select {
+	case slot := <-sealing:
+		require.Equal(t, types.Slot(0), slot)
+	case <-time.After(2 * time.Second):
+		t.Fatal("seal boundary never fired")
+	}
+	select {
+	case slot := <-sealing:
+		require.Equal(t, types.Slot(1), slot)
+	case <-time.After(2 * time.Second):
+		t.Fatal("next seal boundary never fired")
+	}
+}
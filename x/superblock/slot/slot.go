@@ -0,0 +1,93 @@
+// Package slot holds the network's slot timing math. Every component that
+// needs slot boundaries — the slot loop, consensus deadlines, sequencer
+// tooling — derives them from one Config so they can never disagree.
+package slot
+
+import (
+	"errors"
+	"time"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// Config defines the network's slot timing.
+type Config struct {
+	// GenesisTime is the start of slot 0.
+	GenesisTime time.Time `yaml:"GenesisTime"`
+	// Duration is the length of one slot.
+	Duration time.Duration `yaml:"Duration"`
+	// SealCutover is how far into a slot the superblock is sealed and
+	// undecided XTs are aborted. Must be less than Duration.
+	SealCutover time.Duration `yaml:"SealCutover"`
+}
+
+// Validate checks the timing parameters for consistency.
+func (c Config) Validate() error {
+	if c.GenesisTime.IsZero() {
+		return errors.New("slot: genesis time not set")
+	}
+	if c.Duration <= 0 {
+		return errors.New("slot: duration must be positive")
+	}
+	if c.SealCutover <= 0 || c.SealCutover >= c.Duration {
+		return errors.New("slot: seal cutover must be within the slot")
+	}
+	return nil
+}
+
+// At returns the slot containing t. Times before genesis map to slot 0.
+func (c Config) At(t time.Time) types.Slot {
+	if !t.After(c.GenesisTime) {
+		return 0
+	}
+	return types.Slot(t.Sub(c.GenesisTime) / c.Duration)
+}
+
+// StartOf returns the start time of slot s.
+func (c Config) StartOf(s types.Slot) time.Time {
+	return c.GenesisTime.Add(time.Duration(s) * c.Duration)
+}
+
+// EndOf returns the end time of slot s (the start of s+1).
+func (c Config) EndOf(s types.Slot) time.Time {
+	return c.StartOf(s + 1)
+}
+
+// SealTimeOf returns the seal-cutover time of slot s, the single deadline
+// shared by the superblock builder and XT consensus.
+func (c Config) SealTimeOf(s types.Slot) time.Time {
+	return c.StartOf(s).Add(c.SealCutover)
+}
+
+// NextStart returns the first slot boundary after t; before genesis that is
+// genesis itself. Sequencer tooling uses it to align work to the next slot
+// instead of re-deriving boundaries locally.
+func (c Config) NextStart(t time.Time) time.Time {
+	if !t.After(c.GenesisTime) {
+		return c.GenesisTime
+	}
+	return c.StartOf(c.At(t) + 1)
+}
+
+// TimeInto returns how far t is into its slot; zero before genesis.
+func (c Config) TimeInto(t time.Time) time.Duration {
+	if !t.After(c.GenesisTime) {
+		return 0
+	}
+	return t.Sub(c.StartOf(c.At(t)))
+}
+
+// UntilSeal returns the time remaining until the seal cutover of the slot
+// containing t. It is negative once the slot is already sealing, which
+// callers can use as a cheap "too late to submit" check.
+func (c Config) UntilSeal(t time.Time) time.Duration {
+	return c.SealTimeOf(c.At(t)).Sub(t)
+}
+
+// BuildDeadlineOf returns the latest time the superblock for slot s should
+// finish assembling. Construction starts once s is sealed — the instant
+// slot s+1 begins — so the budget is the quiet stretch until the next
+// slot's seal cutover needs the pipeline again.
+func (c Config) BuildDeadlineOf(s types.Slot) time.Time {
+	return c.SealTimeOf(s + 1)
+}
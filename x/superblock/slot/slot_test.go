@@ -0,0 +1,59 @@
+package slot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func TestSlotMath(t *testing.T) {
+	genesis := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := Config{GenesisTime: genesis, Duration: 12 * time.Second, SealCutover: 8 * time.Second}
+	require.NoError(t, cfg.Validate())
+
+	require.Equal(t, types.Slot(0), cfg.At(genesis))
+	require.Equal(t, types.Slot(0), cfg.At(genesis.Add(-time.Hour)))
+	require.Equal(t, types.Slot(0), cfg.At(genesis.Add(11*time.Second)))
+	require.Equal(t, types.Slot(1), cfg.At(genesis.Add(12*time.Second)))
+	require.Equal(t, types.Slot(5), cfg.At(genesis.Add(60*time.Second)))
+
+	require.Equal(t, genesis.Add(24*time.Second), cfg.StartOf(2))
+	require.Equal(t, cfg.StartOf(3), cfg.EndOf(2))
+	require.Equal(t, genesis.Add(24*time.Second+8*time.Second), cfg.SealTimeOf(2))
+}
+
+func TestConfigValidate(t *testing.T) {
+	genesis := time.Now()
+	require.Error(t, Config{Duration: time.Second, SealCutover: time.Millisecond}.Validate())
+	require.Error(t, Config{GenesisTime: genesis, SealCutover: time.Millisecond}.Validate())
+	require.Error(t, Config{GenesisTime: genesis, Duration: time.Second, SealCutover: time.Second}.Validate())
+	require.NoError(t, Config{GenesisTime: genesis, Duration: time.Second, SealCutover: 500 * time.Millisecond}.Validate())
+}
+
+func TestBuildDeadlineOf(t *testing.T) {
+	genesis := time.Unix(1700000000, 0)
+	cfg := Config{GenesisTime: genesis, Duration: 12 * time.Second, SealCutover: 8 * time.Second}
+	// The build for slot 2 starts at slot 3 and must finish before slot 3's
+	// seal cutover.
+	require.Equal(t, cfg.SealTimeOf(3), cfg.BuildDeadlineOf(2))
+	require.Equal(t, genesis.Add(3*12*time.Second+8*time.Second), cfg.BuildDeadlineOf(2))
+}
+
+func TestSlotAlignmentHelpers(t *testing.T) {
+	genesis := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := Config{GenesisTime: genesis, Duration: 12 * time.Second, SealCutover: 8 * time.Second}
+
+	require.Equal(t, genesis, cfg.NextStart(genesis.Add(-time.Hour)))
+	require.Equal(t, genesis.Add(12*time.Second), cfg.NextStart(genesis.Add(time.Second)))
+	require.Equal(t, genesis.Add(24*time.Second), cfg.NextStart(genesis.Add(12*time.Second)))
+
+	require.Equal(t, time.Duration(0), cfg.TimeInto(genesis))
+	require.Equal(t, 5*time.Second, cfg.TimeInto(genesis.Add(17*time.Second)))
+
+	require.Equal(t, 8*time.Second, cfg.UntilSeal(genesis))
+	require.Equal(t, 3*time.Second, cfg.UntilSeal(genesis.Add(17*time.Second)))
+	require.Equal(t, -time.Second, cfg.UntilSeal(genesis.Add(9*time.Second)))
+}
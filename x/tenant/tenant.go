@@ -0,0 +1,185 @@
+// Package tenant lets one publisher process host several isolated
+// networks. Each tenant owns its own persistent stores, slot timing,
+// consensus coordinator and superblock chain; the transport routes
+// connections to a tenant by the network ID carried in the handshake.
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/store"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/consensus"
+	"github.com/compose-network/publisher/x/prover"
+	"github.com/compose-network/publisher/x/superblock"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+// ErrUnknownNetwork is returned when no tenant hosts the network.
+var ErrUnknownNetwork = errors.New("tenant: unknown network")
+
+// Config describes one hosted network.
+type Config struct {
+	NetworkID types.NetworkID `yaml:"NetworkID"`
+	// DataDir is where the tenant's database lives; the file is named after
+	// the network so tenants never share state.
+	DataDir string      `yaml:"DataDir"`
+	Slots   slot.Config `yaml:"Slots"`
+	// L1ContractAddr is the network's superblock contract on L1.
+	L1ContractAddr string `yaml:"L1ContractAddr"`
+	// MerkleScheme names the merkle construction the network's L1 verifier
+	// expects; empty selects the default binary scheme. A "+hash" suffix
+	// ("binary+sha256") selects the hash function for merkle and header
+	// hashing.
+	MerkleScheme string `yaml:"MerkleScheme"`
+	// ProofPolicy says which of the network's chains require validity
+	// proofs; chains bootstrapping proofless are excluded from aggregation
+	// inputs.
+	ProofPolicy prover.Policy `yaml:"ProofPolicy"`
+}
+
+// Tenant bundles the per-network state of one hosted rollup set.
+type Tenant struct {
+	ID  types.NetworkID
+	cfg Config
+
+	DB          *store.DB
+	Superblocks store.SuperblockStore
+	L2Blocks    store.L2BlockStore
+	Queue       store.XTQueue
+	Decisions   store.DecisionLedger
+	Journal     store.ConstructionJournal
+	Coordinator *consensus.Coordinator
+	Builder     *superblock.Builder
+}
+
+// New opens a tenant's stores and wires its consensus coordinator.
+// onDecided receives the tenant's XT outcomes.
+func New(logger *zap.Logger, cfg Config, onDecided consensus.DecidedFunc) (*Tenant, error) {
+	if cfg.NetworkID == "" {
+		cfg.NetworkID = types.DefaultNetworkID
+	}
+	if err := cfg.Slots.Validate(); err != nil {
+		return nil, fmt.Errorf("tenant %q: %w", cfg.NetworkID, err)
+	}
+	scheme, err := superblock.MerkleSchemeByName(cfg.MerkleScheme)
+	if err != nil {
+		return nil, fmt.Errorf("tenant %q: %w", cfg.NetworkID, err)
+	}
+	logger = logger.Named("tenant").With(zap.String("network_id", string(cfg.NetworkID)))
+
+	db, err := store.Open(filepath.Join(cfg.DataDir, fmt.Sprintf("%s.db", cfg.NetworkID)))
+	if err != nil {
+		return nil, fmt.Errorf("tenant %q: open db: %w", cfg.NetworkID, err)
+	}
+	t := &Tenant{
+		ID:          cfg.NetworkID,
+		cfg:         cfg,
+		DB:          db,
+		Superblocks: store.NewBoltSuperblockStore(db),
+		Queue:       store.NewBoltXTQueue(db),
+	}
+	if t.L2Blocks, err = store.NewBoltL2BlockStore(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("tenant %q: %w", cfg.NetworkID, err)
+	}
+	if t.Decisions, err = store.NewBoltDecisionLedger(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("tenant %q: %w", cfg.NetworkID, err)
+	}
+	if t.Journal, err = store.NewBoltConstructionJournal(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("tenant %q: %w", cfg.NetworkID, err)
+	}
+	t.Coordinator = consensus.NewCoordinator(logger, cfg.Slots, onDecided)
+	t.Builder = superblock.NewBuilder(logger, t.Journal, scheme)
+	return t, nil
+}
+
+// Slots returns the tenant's slot timing configuration.
+func (t *Tenant) Slots() slot.Config {
+	return t.cfg.Slots
+}
+
+// L1ContractAddr returns the tenant's superblock contract address.
+func (t *Tenant) L1ContractAddr() string {
+	return t.cfg.L1ContractAddr
+}
+
+// ProofPolicy returns the tenant's per-chain proof requirements, consulted
+// when composing aggregation inputs for the tenant's superblocks.
+func (t *Tenant) ProofPolicy() prover.Policy {
+	return t.cfg.ProofPolicy
+}
+
+// Close releases the tenant's resources.
+func (t *Tenant) Close() error {
+	return t.DB.Close()
+}
+
+// Registry holds the tenants a publisher process serves, keyed by network
+// ID.
+type Registry struct {
+	mu      sync.RWMutex
+	tenants map[types.NetworkID]*Tenant
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{tenants: make(map[types.NetworkID]*Tenant)}
+}
+
+// Add registers a tenant; adding the same network twice is an error.
+func (r *Registry) Add(t *Tenant) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.tenants[t.ID]; ok {
+		return fmt.Errorf("tenant: network %q already registered", t.ID)
+	}
+	r.tenants[t.ID] = t
+	return nil
+}
+
+// Get returns the tenant hosting the network, or ErrUnknownNetwork.
+func (r *Registry) Get(id types.NetworkID) (*Tenant, error) {
+	if id == "" {
+		id = types.DefaultNetworkID
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tenants[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownNetwork, id)
+	}
+	return t, nil
+}
+
+// Networks lists the hosted network IDs, for the transport's allow list.
+func (r *Registry) Networks() []types.NetworkID {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := make([]types.NetworkID, 0, len(r.tenants))
+	for id := range r.tenants {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// Close closes every tenant, returning the first error.
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var firstErr error
+	for _, t := range r.tenants {
+		if err := t.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	r.tenants = make(map[types.NetworkID]*Tenant)
+	return firstErr
+}
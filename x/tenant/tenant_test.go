@@ -0,0 +1,68 @@
+package tenant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/superblock/slot"
+)
+
+func testSlots() slot.Config {
+	return slot.Config{
+		GenesisTime: time.Unix(1700000000, 0),
+		Duration:    12 * time.Second,
+		SealCutover: 8 * time.Second,
+	}
+}
+
+func TestTenantsAreIsolated(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewRegistry()
+	for _, id := range []types.NetworkID{"alpha", "beta"} {
+		tn, err := New(zap.NewNop(), Config{NetworkID: id, DataDir: dir, Slots: testSlots()}, nil)
+		require.NoError(t, err)
+		require.NoError(t, registry.Add(tn))
+	}
+	defer registry.Close()
+
+	alpha, err := registry.Get("alpha")
+	require.NoError(t, err)
+	beta, err := registry.Get("beta")
+	require.NoError(t, err)
+
+	// State written to one tenant must not leak into the other.
+	require.NoError(t, alpha.Queue.Enqueue(&types.XTRequest{XtID: types.Hash{1}, Chains: []types.ChainID{1}}))
+	queued, err := alpha.Queue.List()
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	queued, err = beta.Queue.List()
+	require.NoError(t, err)
+	require.Empty(t, queued)
+}
+
+func TestRegistry(t *testing.T) {
+	dir := t.TempDir()
+	registry := NewRegistry()
+
+	tn, err := New(zap.NewNop(), Config{NetworkID: "alpha", DataDir: dir, Slots: testSlots()}, nil)
+	require.NoError(t, err)
+	require.NoError(t, registry.Add(tn))
+	require.Error(t, registry.Add(tn))
+
+	_, err = registry.Get("gamma")
+	require.ErrorIs(t, err, ErrUnknownNetwork)
+
+	require.Equal(t, []types.NetworkID{"alpha"}, registry.Networks())
+	require.NoError(t, registry.Close())
+}
+
+func TestNewDefaultsNetworkID(t *testing.T) {
+	tn, err := New(zap.NewNop(), Config{DataDir: t.TempDir(), Slots: testSlots()}, nil)
+	require.NoError(t, err)
+	defer tn.Close()
+	require.Equal(t, types.DefaultNetworkID, tn.ID)
+}
@@ -0,0 +1,55 @@
+package discovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/compose-network/publisher/x/auth"
+)
+
+// Announcer serves the signed bootstrap record over HTTP. The publisher
+// mounts it on the API server and calls SetLeader on startup and on every
+// failover.
+type Announcer struct {
+	signer *auth.Signer
+
+	mu      sync.RWMutex
+	current Record
+}
+
+// NewAnnouncer creates an Announcer signing records with signer.
+func NewAnnouncer(signer *auth.Signer) *Announcer {
+	return &Announcer{signer: signer}
+}
+
+// SetLeader updates and re-signs the served record.
+func (a *Announcer) SetLeader(addr string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	record := Record{
+		Leader:    addr,
+		Version:   a.current.Version + 1,
+		UpdatedAt: time.Now().Unix(),
+	}
+	record.Signature = a.signer.Sign(record.SigningBytes())
+	a.current = record
+}
+
+// ServeHTTP implements http.Handler for GET /discovery.
+func (a *Announcer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "GET only", http.StatusMethodNotAllowed)
+		return
+	}
+	a.mu.RLock()
+	record := a.current
+	a.mu.RUnlock()
+	if record.Leader == "" {
+		http.Error(w, "no leader announced", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(record)
+}
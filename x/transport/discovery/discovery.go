@@ -0,0 +1,139 @@
+// Package discovery lets sequencers locate the current publisher leader
+// without hard-coded addresses. Three mechanisms are supported: a static
+// address list, DNS SRV lookup, and an HTTP bootstrap endpoint whose
+// response is signed by the publisher's key so a client can trust it across
+// leader failover.
+package discovery
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/compose-network/publisher/x/auth"
+)
+
+// Modes for Config.Mode.
+const (
+	ModeStatic = "static"
+	ModeDNS    = "dns"
+	ModeHTTP   = "http"
+)
+
+// Record is the signed leader announcement served by the bootstrap
+// endpoint.
+type Record struct {
+	// Leader is the current leader's transport address (host:port).
+	Leader string `json:"leader"`
+	// Version increases monotonically on every leader change so clients
+	// can discard stale records.
+	Version   uint64 `json:"version"`
+	UpdatedAt int64  `json:"updated_at"` // unix seconds
+	Signature []byte `json:"signature"`
+}
+
+// SigningBytes returns the canonical byte string covered by Signature.
+func (r *Record) SigningBytes() []byte {
+	b := make([]byte, 0, 16+len(r.Leader))
+	b = binary.BigEndian.AppendUint64(b, r.Version)
+	b = binary.BigEndian.AppendUint64(b, uint64(r.UpdatedAt))
+	b = append(b, r.Leader...)
+	return b
+}
+
+// Config selects and parameterizes the discovery mechanism.
+type Config struct {
+	Mode string `yaml:"Mode"`
+	// Static is the address list for ModeStatic; the first entry wins.
+	Static []string `yaml:"Static"`
+	// DNSName is the SRV name for ModeDNS, e.g. _publisher._tcp.example.org.
+	DNSName string `yaml:"DNSName"`
+	// URL is the bootstrap endpoint for ModeHTTP.
+	URL string `yaml:"URL"`
+	// PublisherPublicKey pins the key (compressed hex) that must have
+	// signed the HTTP bootstrap record.
+	PublisherPublicKey string `yaml:"PublisherPublicKey"`
+}
+
+// Resolver resolves the current leader address.
+type Resolver struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewResolver builds a Resolver from config.
+func NewResolver(cfg Config) (*Resolver, error) {
+	switch cfg.Mode {
+	case ModeStatic:
+		if len(cfg.Static) == 0 {
+			return nil, errors.New("discovery: static mode requires at least one address")
+		}
+	case ModeDNS:
+		if cfg.DNSName == "" {
+			return nil, errors.New("discovery: dns mode requires DNSName")
+		}
+	case ModeHTTP:
+		if cfg.URL == "" || cfg.PublisherPublicKey == "" {
+			return nil, errors.New("discovery: http mode requires URL and PublisherPublicKey")
+		}
+	default:
+		return nil, fmt.Errorf("discovery: unknown mode %q", cfg.Mode)
+	}
+	return &Resolver{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}, nil
+}
+
+// Resolve returns the current leader's transport address.
+func (r *Resolver) Resolve(ctx context.Context) (string, error) {
+	switch r.cfg.Mode {
+	case ModeStatic:
+		return r.cfg.Static[0], nil
+	case ModeDNS:
+		return r.resolveDNS(ctx)
+	case ModeHTTP:
+		return r.resolveHTTP(ctx)
+	}
+	return "", fmt.Errorf("discovery: unknown mode %q", r.cfg.Mode)
+}
+
+func (r *Resolver) resolveDNS(ctx context.Context) (string, error) {
+	var resolver net.Resolver
+	_, addrs, err := resolver.LookupSRV(ctx, "", "", r.cfg.DNSName)
+	if err != nil {
+		return "", fmt.Errorf("discovery: SRV lookup %q: %w", r.cfg.DNSName, err)
+	}
+	if len(addrs) == 0 {
+		return "", fmt.Errorf("discovery: no SRV records for %q", r.cfg.DNSName)
+	}
+	// LookupSRV returns records sorted by priority and randomized by
+	// weight; take the first.
+	srv := addrs[0]
+	return net.JoinHostPort(srv.Target, fmt.Sprintf("%d", srv.Port)), nil
+}
+
+func (r *Resolver) resolveHTTP(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.cfg.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("discovery: fetch bootstrap record: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("discovery: bootstrap endpoint returned %s", resp.Status)
+	}
+	var record Record
+	if err := json.NewDecoder(resp.Body).Decode(&record); err != nil {
+		return "", fmt.Errorf("discovery: decode bootstrap record: %w", err)
+	}
+	if err := auth.VerifyWithKey(r.cfg.PublisherPublicKey, record.SigningBytes(), record.Signature); err != nil {
+		return "", fmt.Errorf("discovery: bootstrap record signature: %w", err)
+	}
+	return record.Leader, nil
+}
@@ -0,0 +1,84 @@
+package discovery
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/x/auth"
+)
+
+func TestNewResolverValidatesConfig(t *testing.T) {
+	cases := []Config{
+		{Mode: "gossip"},
+		{Mode: ModeStatic},
+		{Mode: ModeDNS},
+		{Mode: ModeHTTP, URL: "http://example.org"},
+	}
+	for _, cfg := range cases {
+		_, err := NewResolver(cfg)
+		require.Error(t, err, "mode %q", cfg.Mode)
+	}
+}
+
+func TestResolveStatic(t *testing.T) {
+	r, err := NewResolver(Config{Mode: ModeStatic, Static: []string{"10.0.0.1:9000", "10.0.0.2:9000"}})
+	require.NoError(t, err)
+	addr, err := r.Resolve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "10.0.0.1:9000", addr)
+}
+
+func TestResolveHTTP(t *testing.T) {
+	signer, err := auth.GenerateSigner("publisher")
+	require.NoError(t, err)
+
+	announcer := NewAnnouncer(signer)
+	srv := httptest.NewServer(announcer)
+	defer srv.Close()
+
+	r, err := NewResolver(Config{
+		Mode:               ModeHTTP,
+		URL:                srv.URL,
+		PublisherPublicKey: signer.PublicKeyHex(),
+	})
+	require.NoError(t, err)
+
+	// No leader announced yet.
+	_, err = r.Resolve(context.Background())
+	require.Error(t, err)
+
+	announcer.SetLeader("leader-1:9000")
+	addr, err := r.Resolve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "leader-1:9000", addr)
+
+	// Failover re-signs with a higher version.
+	announcer.SetLeader("leader-2:9000")
+	addr, err = r.Resolve(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "leader-2:9000", addr)
+}
+
+func TestResolveHTTPRejectsWrongKey(t *testing.T) {
+	signer, err := auth.GenerateSigner("publisher")
+	require.NoError(t, err)
+	other, err := auth.GenerateSigner("imposter")
+	require.NoError(t, err)
+
+	announcer := NewAnnouncer(other)
+	announcer.SetLeader("leader-1:9000")
+	srv := httptest.NewServer(announcer)
+	defer srv.Close()
+
+	r, err := NewResolver(Config{
+		Mode:               ModeHTTP,
+		URL:                srv.URL,
+		PublisherPublicKey: signer.PublicKeyHex(),
+	})
+	require.NoError(t, err)
+	_, err = r.Resolve(context.Background())
+	require.ErrorIs(t, err, auth.ErrBadSignature)
+}
@@ -0,0 +1,70 @@
+package protocol
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func seedPayload(f *testing.F, payload any) []byte {
+	f.Helper()
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		f.Fatal(err)
+	}
+	return raw
+}
+
+// FuzzHandshakeDecode exercises handshake parsing: any payload a peer
+// sends must either be rejected by Decode or yield a Handshake whose
+// SigningBytes computes without panicking, since the server calls it
+// before any authentication has happened.
+func FuzzHandshakeDecode(f *testing.F) {
+	f.Add(seedPayload(f, Handshake{
+		ChainID: 1, KeyID: "seq-1", Timestamp: time.Now().Unix(), Nonce: 7,
+		Signature: []byte{1, 2, 3},
+	}))
+	f.Add(seedPayload(f, Handshake{
+		ChainID: 2, NetworkID: "testnet", KeyID: "seq-2", Timestamp: 1700000000,
+		Version: HandshakeVersionChallenge, ServerNonce: []byte("server nonce"),
+		Signature: []byte{4, 5, 6}, MaxMessageSize: 1 << 20,
+	}))
+	f.Add(seedPayload(f, Handshake{ChainID: 3, ResumeToken: []byte("resume")}))
+	f.Add([]byte(`{"chain_id":-1}`))
+	f.Add([]byte(`not json`))
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		msg := Message{Type: TypeHandshake, Payload: payload}
+		var hs Handshake
+		if err := msg.Decode(&hs); err != nil {
+			return
+		}
+		_ = hs.SigningBytes()
+	})
+}
+
+// FuzzBasicValidator checks the structural validator over arbitrary
+// envelopes: it must never panic, and every rejection must wrap
+// ErrInvalidPayload so transports can tell schema failures apart.
+func FuzzBasicValidator(f *testing.F) {
+	f.Add(TypeStartSlot, seedPayload(f, StartSlot{Slot: 1, Timestamp: time.Now().UnixNano()}))
+	f.Add(TypeL2Block, seedPayload(f, types.L2Block{ChainID: 10, Number: 1, Hash: types.Hash{1}}))
+	f.Add(TypeXTRequest, seedPayload(f, types.XTRequest{XtID: types.Hash{2}, Chains: []types.ChainID{10}}))
+	f.Add(TypeStartSC, seedPayload(f, StartSC{
+		Request:  types.XTRequest{XtID: types.Hash{3}, Chains: []types.ChainID{10, 20}},
+		Deadline: time.Now().UnixNano(),
+	}))
+	f.Add(TypeVote, seedPayload(f, Vote{XtID: types.Hash{4}, ChainID: 10, Commit: true}))
+	f.Add(TypeVote, []byte("not json"))
+	f.Add("unknown_type", []byte(`{}`))
+
+	f.Fuzz(func(t *testing.T, msgType string, payload []byte) {
+		err := BasicValidator{}.Validate(Message{Type: msgType, Payload: payload})
+		if err != nil && !errors.Is(err, ErrInvalidPayload) {
+			t.Fatalf("rejection does not wrap ErrInvalidPayload: %v", err)
+		}
+	})
+}
@@ -0,0 +1,404 @@
+// Package protocol defines the wire messages exchanged between the
+// publisher and sequencers. Messages travel as a typed envelope with a
+// JSON payload; the tcp package frames them on the socket.
+package protocol
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// Payload types.
+const (
+	TypeHandshake          = "handshake"
+	TypeHandshakeChallenge = "handshake_challenge"
+	TypeHandshakeAck       = "handshake_ack"
+	TypePing               = "ping"
+	TypePong               = "pong"
+	TypeStartSlot          = "start_slot"
+	TypeL2Block            = "l2_block"
+	TypeL2BlockChunk       = "l2_block_chunk"
+	TypeXTRequest          = "xt_request"
+	TypeXTBatch            = "xt_batch"
+	TypeXTBatchAck         = "xt_batch_ack"
+	TypeXTNack             = "xt_nack"
+	TypeStartSC            = "start_sc"
+	TypeVote               = "vote"
+	TypeDecided            = "decided"
+	TypeCIRC               = "circ"
+	TypeCIRCAck            = "circ_ack"
+	TypeSuperblockSealed   = "superblock_sealed"
+	TypeSuperblockCosign   = "superblock_cosign"
+	TypeDisconnect         = "disconnect"
+	TypeDisconnectAck      = "disconnect_ack"
+	TypeConfigUpdate       = "config_update"
+	TypeIdentity           = "identity"
+	TypeSuperblockStatus   = "superblock_status"
+	TypeXTPending          = "xt_pending"
+)
+
+// Priority classes for inbound dispatch. Urgent payloads are the ones a
+// slot deadline is waiting on; everything else is bulk.
+type Priority int
+
+const (
+	// PriorityBulk is the default class: submissions, acks, control traffic.
+	PriorityBulk Priority = iota
+	// PriorityUrgent marks payloads consensus is blocked on — a vote or an
+	// L2 block arriving close to the seal cutover loses its slot if it
+	// queues behind bulk traffic.
+	PriorityUrgent
+)
+
+// PriorityOf classifies a payload type for dispatch ordering.
+func PriorityOf(msgType string) Priority {
+	switch msgType {
+	case TypeVote, TypeL2Block, TypeL2BlockChunk:
+		return PriorityUrgent
+	default:
+		return PriorityBulk
+	}
+}
+
+// Message is the wire envelope.
+type Message struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// NewMessage builds an envelope around a payload struct.
+func NewMessage(msgType string, payload any) (Message, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return Message{}, fmt.Errorf("protocol: encode %s payload: %w", msgType, err)
+	}
+	return Message{Type: msgType, Payload: raw}, nil
+}
+
+// Decode unmarshals the payload into v.
+func (m Message) Decode(v any) error {
+	if err := json.Unmarshal(m.Payload, v); err != nil {
+		return fmt.Errorf("protocol: decode %s payload: %w", m.Type, err)
+	}
+	return nil
+}
+
+// HandshakeVersionChallenge is the handshake revision that adds the
+// server-issued challenge: the client's first Handshake carries Version
+// and no signature, the server answers with a HandshakeChallenge, and the
+// second Handshake signs SigningBytes including the server nonce. Version
+// 0 is the legacy one-shot signed handshake, which the server still
+// accepts; its client-chosen timestamp+nonce permits pre-computing
+// signatures, which the challenge step closes off.
+const HandshakeVersionChallenge = 1
+
+// HandshakeChallenge carries the server nonce a version-1 client must
+// echo, signed, in its second Handshake.
+type HandshakeChallenge struct {
+	ServerNonce []byte `json:"server_nonce"`
+}
+
+// Handshake authenticates a sequencer connection. The client signs
+// SigningBytes with its registered key.
+type Handshake struct {
+	ChainID types.ChainID `json:"chain_id"`
+	// NetworkID selects the hosted network; empty means the default one.
+	NetworkID types.NetworkID `json:"network_id,omitempty"`
+	KeyID     string          `json:"key_id"`
+	// KeyScheme names the signature scheme behind Signature; empty means
+	// secp256k1. The server refuses a scheme differing from the key's
+	// registration.
+	KeyScheme string `json:"key_scheme,omitempty"`
+	Timestamp int64  `json:"timestamp"` // unix seconds
+	Nonce     uint64 `json:"nonce"`
+	// Version is the handshake protocol revision; see
+	// HandshakeVersionChallenge. Zero is the legacy one-shot handshake.
+	Version int `json:"version,omitempty"`
+	// ServerNonce echoes the HandshakeChallenge nonce on a version-1
+	// client's second Handshake; it is covered by Signature.
+	ServerNonce []byte `json:"server_nonce,omitempty"`
+	// ResumeToken, when set, resumes a recently authenticated session
+	// instead of running the signature handshake; Signature may be empty.
+	ResumeToken []byte `json:"resume_token,omitempty"`
+	Signature   []byte `json:"signature"`
+	// MaxMessageSize is the largest frame the client wants to send; the
+	// server answers with its own limit and both use the minimum.
+	MaxMessageSize int `json:"max_message_size,omitempty"`
+}
+
+// SigningBytes returns the canonical byte string covered by Signature.
+func (h *Handshake) SigningBytes() []byte {
+	b := make([]byte, 0, 8+8+8+len(h.KeyID)+1+len(h.NetworkID))
+	b = binary.BigEndian.AppendUint64(b, uint64(h.ChainID))
+	b = binary.BigEndian.AppendUint64(b, uint64(h.Timestamp))
+	b = binary.BigEndian.AppendUint64(b, h.Nonce)
+	b = append(b, h.KeyID...)
+	if h.NetworkID != "" {
+		// Appended conditionally so signatures from single-network peers
+		// stay valid.
+		b = append(b, 0)
+		b = append(b, h.NetworkID...)
+	}
+	if len(h.ServerNonce) > 0 {
+		// Conditional like NetworkID, so legacy version-0 signatures keep
+		// verifying; the tag byte keeps the two extensions unambiguous.
+		b = append(b, 1)
+		b = append(b, h.ServerNonce...)
+	}
+	return b
+}
+
+// ProtocolVersion is the wire protocol revision announced in the
+// publisher's Identity message.
+const ProtocolVersion = 1
+
+// Identity announces the publisher's signing identity to a sequencer
+// right after the handshake ack. SessionBinding echoes bytes unique to
+// this connection — the client's handshake signature, or the resume
+// token it presented — and Signature covers SigningBytes with the
+// publisher's key, so a client pinning that key detects a man in the
+// middle or a misconfigured publisher immediately instead of at the
+// first bad superblock signature.
+type Identity struct {
+	// KeyID names the publisher key behind Signature.
+	KeyID string `json:"key_id"`
+	// PublicKey is the publisher's public key (hex), the value clients pin.
+	PublicKey string `json:"public_key"`
+	// NetworkID is the hosted network the connection belongs to.
+	NetworkID types.NetworkID `json:"network_id,omitempty"`
+	// ProtocolVersion is the wire protocol revision the publisher speaks.
+	ProtocolVersion int `json:"protocol_version"`
+	// SessionBinding ties the announcement to this connection; see above.
+	SessionBinding []byte `json:"session_binding"`
+	Signature      []byte `json:"signature"`
+}
+
+// SigningBytes returns the canonical byte string covered by Signature.
+func (i *Identity) SigningBytes() []byte {
+	b := make([]byte, 0, len(i.KeyID)+len(i.PublicKey)+len(i.NetworkID)+8+len(i.SessionBinding)+3)
+	b = append(b, i.KeyID...)
+	b = append(b, 0)
+	b = append(b, i.PublicKey...)
+	b = append(b, 0)
+	b = append(b, i.NetworkID...)
+	b = append(b, 0)
+	b = binary.BigEndian.AppendUint64(b, uint64(i.ProtocolVersion))
+	b = append(b, i.SessionBinding...)
+	return b
+}
+
+// HandshakeAck reports the handshake outcome.
+type HandshakeAck struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	// MaxMessageSize is the server's frame limit; see Handshake.
+	MaxMessageSize int `json:"max_message_size,omitempty"`
+	// Slots advertises the network's slot timing so a misconfigured peer is
+	// caught at connect time instead of silently misaligning batches.
+	Slots *SlotParams `json:"slots,omitempty"`
+	// ResumeToken lets the client skip the signature handshake on its next
+	// reconnect, if presented within ResumeTTLMS. Tokens are single-use.
+	ResumeToken []byte `json:"resume_token,omitempty"`
+	ResumeTTLMS int64  `json:"resume_ttl_ms,omitempty"`
+}
+
+// SlotParams is the slot timing exchanged at handshake. Durations travel
+// as milliseconds to keep the wire format language-neutral.
+type SlotParams struct {
+	GenesisTime    int64 `json:"genesis_time"` // unix seconds
+	SlotDurationMS int64 `json:"slot_duration_ms"`
+	SealCutoverMS  int64 `json:"seal_cutover_ms"`
+}
+
+// Chunk is one fragment of a chunked L2Block transfer. Payloads larger
+// than the negotiated frame limit are split client-side and reassembled by
+// the server before normal dispatch.
+type Chunk struct {
+	TransferID uint64 `json:"transfer_id"`
+	Index      int    `json:"index"`
+	Total      int    `json:"total"`
+	// Checksum is CRC-32C over Data.
+	Checksum uint32 `json:"checksum"`
+	// PayloadChecksum is CRC-32C over the full reassembled payload, set on
+	// the final chunk only.
+	PayloadChecksum uint32 `json:"payload_checksum,omitempty"`
+	Data            []byte `json:"data"`
+}
+
+// Ping is a server liveness probe; SentAt is echoed back in the Pong.
+type Ping struct {
+	Seq    uint64 `json:"seq"`
+	SentAt int64  `json:"sent_at"` // unix nanoseconds
+}
+
+// Pong answers a Ping, echoing its fields.
+type Pong struct {
+	Seq    uint64 `json:"seq"`
+	SentAt int64  `json:"sent_at"`
+}
+
+// StartSlot announces a new slot to all sequencers.
+type StartSlot struct {
+	Slot      types.Slot `json:"slot"`
+	Timestamp int64      `json:"timestamp"` // unix nanoseconds
+}
+
+// XTBatch submits several independent XT requests in one message; the
+// publisher splits them into separate queue entries and answers with one
+// XTBatchAck carrying a per-XT result.
+type XTBatch struct {
+	Requests []types.XTRequest `json:"requests"`
+}
+
+// XTBatchResult is the outcome for one request of a batch.
+type XTBatchResult struct {
+	XtID   types.XtID `json:"xt_id"`
+	Queued bool       `json:"queued"`
+	// Error explains a rejection; empty when Queued.
+	Error string `json:"error,omitempty"`
+}
+
+// XTBatchAck answers an XTBatch, one result per request in order.
+type XTBatchAck struct {
+	Results []XTBatchResult `json:"results"`
+}
+
+// XTNack tells a sender its xt_request was rejected and why, so a
+// misconfigured sequencer notices instead of waiting for a decision that
+// never comes.
+type XTNack struct {
+	XtID   types.XtID `json:"xt_id"`
+	Reason string     `json:"reason"`
+	// Error carries the full rejection message.
+	Error string `json:"error,omitempty"`
+}
+
+// StartSC opens a 2PC round: participants must vote before the deadline.
+type StartSC struct {
+	Request  types.XTRequest `json:"request"`
+	Slot     types.Slot      `json:"slot"`
+	Deadline int64           `json:"deadline"` // unix nanoseconds
+}
+
+// Vote is a participant's 2PC vote.
+type Vote struct {
+	XtID    types.XtID    `json:"xt_id"`
+	ChainID types.ChainID `json:"chain_id"`
+	Commit  bool          `json:"commit"`
+}
+
+// Decided announces an XT's final outcome.
+type Decided struct {
+	Decision types.Decision `json:"decision"`
+}
+
+// SuperblockSealed announces a sealed superblock header, signed by the
+// publisher's key so sequencers can verify it off-chain before L1 finality.
+type SuperblockSealed struct {
+	Number     uint64     `json:"number"`
+	Slot       types.Slot `json:"slot"`
+	Hash       types.Hash `json:"hash"`
+	ParentHash types.Hash `json:"parent_hash"`
+	MerkleRoot types.Hash `json:"merkle_root"`
+	// MerkleScheme names the construction behind MerkleRoot; empty means
+	// the default binary scheme.
+	MerkleScheme  string     `json:"merkle_scheme,omitempty"`
+	DecisionsRoot types.Hash `json:"decisions_root"`
+	// KeyID names the publisher key that produced Signature over Hash.
+	KeyID     string `json:"key_id"`
+	Signature []byte `json:"signature"`
+	// Inclusions carry merkle proofs for the recipient chain's L2 blocks,
+	// so each sequencer can verify its blocks are committed by MerkleRoot.
+	Inclusions []InclusionProof `json:"inclusions,omitempty"`
+}
+
+// SuperblockCosign is a sequencer's co-signature over a sealed superblock
+// header it observed and verified, returned to the publisher for threshold
+// aggregation.
+type SuperblockCosign struct {
+	Number  uint64        `json:"number"`
+	Hash    types.Hash    `json:"hash"`
+	ChainID types.ChainID `json:"chain_id"`
+	// KeyID names the sequencer key that produced Signature over
+	// SigningBytes.
+	KeyID     string `json:"key_id"`
+	Signature []byte `json:"signature"`
+}
+
+// SigningBytes returns the canonical byte string covered by Signature. It
+// binds the co-signature to the superblock, the claiming chain and the
+// signing key, so one signature cannot be replayed under another chain ID.
+func (sc *SuperblockCosign) SigningBytes() []byte {
+	b := make([]byte, 0, 8+len(sc.Hash)+8+len(sc.KeyID))
+	b = binary.BigEndian.AppendUint64(b, sc.Number)
+	b = append(b, sc.Hash[:]...)
+	b = binary.BigEndian.AppendUint64(b, uint64(sc.ChainID))
+	b = append(b, sc.KeyID...)
+	return b
+}
+
+// InclusionProof proves one L2 block hash is a leaf of a superblock's
+// merkle root.
+type InclusionProof struct {
+	ChainID   types.ChainID `json:"chain_id"`
+	BlockHash types.Hash    `json:"block_hash"`
+	Steps     []ProofStep   `json:"steps"`
+}
+
+// ProofStep is one merkle path element; Left marks the sibling's side.
+// Levels where the node was promoted unpaired contribute no step.
+type ProofStep struct {
+	Sibling types.Hash `json:"sibling"`
+	Left    bool       `json:"left,omitempty"`
+}
+
+// CIRCAck acknowledges delivery of a relayed CIRC message.
+type CIRCAck struct {
+	DestChain types.ChainID `json:"dest_chain"`
+	Seq       uint64        `json:"seq"`
+}
+
+// Disconnect tells the peer why the connection is being closed.
+type Disconnect struct {
+	Reason string `json:"reason"`
+}
+
+// DisconnectAck confirms a Disconnect was processed, so the closing side
+// knows the reason arrived before it tears down the socket.
+type DisconnectAck struct{}
+
+// XTPending tells participants of an undecided round that the publisher is
+// draining for a restart and will resume or abort the round on startup, so
+// they keep holding the XT instead of treating silence as a lost decision.
+type XTPending struct {
+	XtID types.XtID `json:"xt_id"`
+	Slot types.Slot `json:"slot"`
+}
+
+// SuperblockStatusUpdate tells sequencers how a published superblock is
+// faring on L1, so derivation pipelines can advance safe and finalized
+// heads (or unwind them on a rollback) without polling L1 themselves.
+type SuperblockStatusUpdate struct {
+	Number        uint64                 `json:"number"`
+	Status        types.SuperblockStatus `json:"status"`
+	L1TxHash      types.Hash             `json:"l1_tx_hash,omitempty"`
+	Confirmations uint64                 `json:"confirmations,omitempty"`
+}
+
+// ConfigUpdate announces publisher-side parameter changes to connected
+// sequencers, so operators pick them up live instead of rolling config in
+// lockstep. Zero-valued fields mean "unchanged"; Seq increases with every
+// push so consumers can discard updates redelivered out of order.
+type ConfigUpdate struct {
+	Seq uint64 `json:"seq"`
+	// SealCutoverMS is the new slot seal cutover in milliseconds.
+	SealCutoverMS int64 `json:"seal_cutover_ms,omitempty"`
+	// MaxBlockBytes is the new L2 block size limit.
+	MaxBlockBytes int `json:"max_block_bytes,omitempty"`
+	// AddedChains and RemovedChains carry registry membership changes.
+	AddedChains   []types.ChainID `json:"added_chains,omitempty"`
+	RemovedChains []types.ChainID `json:"removed_chains,omitempty"`
+}
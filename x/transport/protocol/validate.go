@@ -0,0 +1,88 @@
+package protocol
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// ErrInvalidPayload wraps every validation failure, so transports can
+// distinguish schema rejections from decode errors.
+var ErrInvalidPayload = errors.New("protocol: invalid payload")
+
+// Validator checks a message's payload against its type's schema.
+type Validator interface {
+	// Validate returns nil for well-formed payloads and for types the
+	// validator has no schema for.
+	Validate(msg Message) error
+}
+
+// BasicValidator performs structural validation of the consensus-critical
+// payload types: required identifiers present, participant lists non-empty,
+// timestamps set. It deliberately knows nothing about payload semantics —
+// whether a vote is plausible is the coordinator's business — and passes
+// message types it has no schema for.
+type BasicValidator struct{}
+
+// Validate implements Validator.
+func (BasicValidator) Validate(msg Message) error {
+	switch msg.Type {
+	case TypeStartSlot:
+		var v StartSlot
+		if err := msg.Decode(&v); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+		if v.Timestamp <= 0 {
+			return fmt.Errorf("%w: start_slot without timestamp", ErrInvalidPayload)
+		}
+	case TypeL2Block:
+		var v types.L2Block
+		if err := msg.Decode(&v); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+		if v.ChainID == 0 {
+			return fmt.Errorf("%w: l2_block without chain_id", ErrInvalidPayload)
+		}
+		if v.Hash.IsZero() {
+			return fmt.Errorf("%w: l2_block without hash", ErrInvalidPayload)
+		}
+	case TypeXTRequest:
+		var v types.XTRequest
+		if err := msg.Decode(&v); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+		if v.XtID.IsZero() {
+			return fmt.Errorf("%w: xt_request without xt_id", ErrInvalidPayload)
+		}
+		if len(v.Chains) == 0 {
+			return fmt.Errorf("%w: xt_request without chains", ErrInvalidPayload)
+		}
+	case TypeStartSC:
+		var v StartSC
+		if err := msg.Decode(&v); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+		if v.Request.XtID.IsZero() {
+			return fmt.Errorf("%w: start_sc without xt_id", ErrInvalidPayload)
+		}
+		if len(v.Request.Chains) == 0 {
+			return fmt.Errorf("%w: start_sc without participants", ErrInvalidPayload)
+		}
+		if v.Deadline <= 0 {
+			return fmt.Errorf("%w: start_sc without deadline", ErrInvalidPayload)
+		}
+	case TypeVote:
+		var v Vote
+		if err := msg.Decode(&v); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+		}
+		if v.XtID.IsZero() {
+			return fmt.Errorf("%w: vote without xt_id", ErrInvalidPayload)
+		}
+		if v.ChainID == 0 {
+			return fmt.Errorf("%w: vote without chain_id", ErrInvalidPayload)
+		}
+	}
+	return nil
+}
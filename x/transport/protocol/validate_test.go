@@ -0,0 +1,52 @@
+package protocol
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/compose-network/publisher/types"
+)
+
+func mustMessage(t *testing.T, msgType string, payload any) Message {
+	t.Helper()
+	msg, err := NewMessage(msgType, payload)
+	require.NoError(t, err)
+	return msg
+}
+
+func TestBasicValidator(t *testing.T) {
+	v := BasicValidator{}
+
+	valid := []Message{
+		mustMessage(t, TypeStartSlot, StartSlot{Slot: 1, Timestamp: time.Now().UnixNano()}),
+		mustMessage(t, TypeL2Block, types.L2Block{ChainID: 10, Number: 1, Hash: types.Hash{1}}),
+		mustMessage(t, TypeXTRequest, types.XTRequest{XtID: types.Hash{2}, Chains: []types.ChainID{10}}),
+		mustMessage(t, TypeStartSC, StartSC{
+			Request:  types.XTRequest{XtID: types.Hash{3}, Chains: []types.ChainID{10, 20}},
+			Deadline: time.Now().UnixNano(),
+		}),
+		mustMessage(t, TypeVote, Vote{XtID: types.Hash{4}, ChainID: 10, Commit: true}),
+		// Types without a schema pass untouched.
+		mustMessage(t, TypePing, Ping{Seq: 1}),
+	}
+	for _, msg := range valid {
+		require.NoError(t, v.Validate(msg), msg.Type)
+	}
+
+	invalid := []Message{
+		mustMessage(t, TypeStartSlot, StartSlot{Slot: 1}),
+		mustMessage(t, TypeL2Block, types.L2Block{Number: 1, Hash: types.Hash{1}}),
+		mustMessage(t, TypeL2Block, types.L2Block{ChainID: 10, Number: 1}),
+		mustMessage(t, TypeXTRequest, types.XTRequest{Chains: []types.ChainID{10}}),
+		mustMessage(t, TypeXTRequest, types.XTRequest{XtID: types.Hash{2}}),
+		mustMessage(t, TypeStartSC, StartSC{Request: types.XTRequest{XtID: types.Hash{3}, Chains: []types.ChainID{10}}}),
+		mustMessage(t, TypeVote, Vote{ChainID: 10}),
+		mustMessage(t, TypeVote, Vote{XtID: types.Hash{4}}),
+		{Type: TypeVote, Payload: []byte("not json")},
+	}
+	for _, msg := range invalid {
+		require.ErrorIs(t, v.Validate(msg), ErrInvalidPayload, msg.Type)
+	}
+}
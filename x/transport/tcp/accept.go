@@ -0,0 +1,71 @@
+package tcp
+
+import (
+	"sync"
+	"time"
+)
+
+// AcceptConfig protects the server from reconnect storms: a restart can
+// bring every sequencer back at once, and unbounded concurrent signature
+// verification is the expensive part. Accepts past the rate are closed
+// immediately; accepted connections wait for a handshake worker slot and
+// are dropped if none frees up within the queue timeout.
+type AcceptConfig struct {
+	// RatePerSec caps new connections accepted per second. Zero disables
+	// rate limiting.
+	RatePerSec int `yaml:"RatePerSec"`
+	// Burst is the rate limiter's bucket size; defaults to RatePerSec.
+	Burst int `yaml:"Burst"`
+	// MaxHandshakes bounds concurrently running handshakes. Zero means 64.
+	MaxHandshakes int `yaml:"MaxHandshakes"`
+	// QueueTimeout is how long a connection may wait for a handshake slot
+	// before being dropped. Zero means 5s.
+	QueueTimeout time.Duration `yaml:"QueueTimeout"`
+}
+
+// acceptLimiter is a token bucket over accepted connections.
+type acceptLimiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// newAcceptLimiter creates a limiter; a nil limiter (rate 0) means
+// unlimited.
+func newAcceptLimiter(ratePerSec, burst int) *acceptLimiter {
+	if ratePerSec <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = ratePerSec
+	}
+	return &acceptLimiter{
+		rate:   float64(ratePerSec),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// allow consumes one token, reporting whether the accept is within rate.
+func (l *acceptLimiter) allow() bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
@@ -0,0 +1,66 @@
+package tcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAcceptLimiterTokenBucket(t *testing.T) {
+	l := newAcceptLimiter(10, 2)
+	require.True(t, l.allow())
+	require.True(t, l.allow())
+	require.False(t, l.allow(), "burst of 2 is spent")
+
+	// 10/s refills one token in 100ms.
+	time.Sleep(120 * time.Millisecond)
+	require.True(t, l.allow())
+	require.False(t, l.allow())
+
+	var unlimited *acceptLimiter
+	for i := 0; i < 100; i++ {
+		require.True(t, unlimited.allow())
+	}
+}
+
+func TestAcceptRateLimitClosesExcessConnections(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{
+		Accept: AcceptConfig{RatePerSec: 1, Burst: 1},
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-1")
+	first := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 1}, signer)
+	require.NoError(t, first.Connect())
+	defer first.Close()
+
+	// The bucket is empty now; the next connection is closed before its
+	// handshake.
+	second := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 2}, signer)
+	require.Error(t, second.Connect())
+}
+
+func TestHandshakeQueueTimeoutDropsWaiters(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{
+		HandshakeTimeout: 2 * time.Second,
+		Accept:           AcceptConfig{MaxHandshakes: 1, QueueTimeout: 50 * time.Millisecond},
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	// A connection that never sends its handshake holds the only worker
+	// slot until the handshake timeout.
+	stalled, err := net.Dial("tcp", srv.Addr().String())
+	require.NoError(t, err)
+	defer stalled.Close()
+	// Give the stalled connection time to claim the worker slot.
+	time.Sleep(100 * time.Millisecond)
+
+	signer := trustedSigner(t, authManager, "seq-1")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 1}, signer)
+	require.Error(t, client.Connect(), "queued past the timeout")
+}
@@ -0,0 +1,99 @@
+package tcp
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// BanConfig enables temporary bans of source IPs that keep failing the
+// handshake, cutting off brute-force and misconfigured peers before the
+// signature check. Zero MaxFailures disables banning; failures are still
+// counted and exported.
+type BanConfig struct {
+	// MaxFailures is how many auth failures within Window trigger a ban.
+	// Zero disables banning.
+	MaxFailures int `yaml:"MaxFailures"`
+	// Window is the failure-counting window; defaults to 1m.
+	Window time.Duration `yaml:"Window"`
+	// BanDuration is how long a banned IP is refused; defaults to 10m.
+	BanDuration time.Duration `yaml:"BanDuration"`
+}
+
+type banEntry struct {
+	windowStart time.Time
+	failures    int
+	total       int
+	bannedUntil time.Time
+}
+
+// banList tracks handshake failures per source IP and answers ban checks.
+type banList struct {
+	cfg BanConfig
+
+	mu      sync.Mutex
+	entries map[string]*banEntry
+}
+
+func newBanList(cfg BanConfig) *banList {
+	if cfg.Window <= 0 {
+		cfg.Window = time.Minute
+	}
+	if cfg.BanDuration <= 0 {
+		cfg.BanDuration = 10 * time.Minute
+	}
+	return &banList{cfg: cfg, entries: make(map[string]*banEntry)}
+}
+
+// banned reports whether the IP is currently refused.
+func (b *banList) banned(ip string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	e := b.entries[ip]
+	return e != nil && time.Now().Before(e.bannedUntil)
+}
+
+// recordFailure counts one auth failure for the IP, reporting whether it
+// just crossed the ban threshold.
+func (b *banList) recordFailure(ip string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	e := b.entries[ip]
+	if e == nil {
+		e = &banEntry{windowStart: now}
+		b.entries[ip] = e
+	}
+	if now.Sub(e.windowStart) >= b.cfg.Window {
+		e.windowStart = now
+		e.failures = 0
+	}
+	e.failures++
+	e.total++
+	if b.cfg.MaxFailures > 0 && e.failures >= b.cfg.MaxFailures && !now.Before(e.bannedUntil) {
+		e.bannedUntil = now.Add(b.cfg.BanDuration)
+		return true
+	}
+	return false
+}
+
+// failuresByIP returns the cumulative handshake failure count per source
+// IP.
+func (b *banList) failuresByIP() map[string]int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make(map[string]int, len(b.entries))
+	for ip, e := range b.entries {
+		out[ip] = e.total
+	}
+	return out
+}
+
+// remoteIP extracts the bare IP from a connection's remote address.
+func remoteIP(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
@@ -0,0 +1,125 @@
+package tcp
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+func TestBanListThresholdAndExpiry(t *testing.T) {
+	b := newBanList(BanConfig{MaxFailures: 3, Window: time.Minute, BanDuration: 50 * time.Millisecond})
+	require.False(t, b.recordFailure("10.0.0.1"))
+	require.False(t, b.recordFailure("10.0.0.1"))
+	require.False(t, b.banned("10.0.0.1"))
+	require.True(t, b.recordFailure("10.0.0.1"), "third failure crosses the threshold")
+	require.True(t, b.banned("10.0.0.1"))
+	require.False(t, b.banned("10.0.0.2"), "other IPs are unaffected")
+
+	// Bans are temporary.
+	time.Sleep(60 * time.Millisecond)
+	require.False(t, b.banned("10.0.0.1"))
+
+	require.Equal(t, map[string]int{"10.0.0.1": 3}, b.failuresByIP())
+}
+
+func TestBanListDisabledOnlyCounts(t *testing.T) {
+	b := newBanList(BanConfig{})
+	for i := 0; i < 100; i++ {
+		require.False(t, b.recordFailure("10.0.0.1"))
+	}
+	require.False(t, b.banned("10.0.0.1"))
+	require.Equal(t, 100, b.failuresByIP()["10.0.0.1"])
+}
+
+func TestHandshakeFailureReasons(t *testing.T) {
+	cases := map[string]error{
+		"untrusted_key":  fmt.Errorf("wrapped: %w", auth.ErrUntrustedKey),
+		"bad_signature":  auth.ErrBadSignature,
+		"revoked_key":    auth.ErrKeyRevoked,
+		"expired_key":    auth.ErrKeyExpired,
+		"chain_denied":   auth.ErrChainDenied,
+		"clock_drift":    errClockDrift,
+		"replay":         errNonceReplay,
+		"resume_expired": errResumeExpired,
+		"other":          errors.New("read handshake: EOF"),
+	}
+	for want, err := range cases {
+		require.Equal(t, want, handshakeFailureReason(err), err.Error())
+	}
+	require.Equal(t, "replay", handshakeFailureReason(errResumeReplay))
+
+	// Auth failures and replays are ban-worthy; read errors and routine
+	// token expiry are not.
+	require.True(t, banworthyReason("replay"))
+	require.True(t, banworthyReason("untrusted_key"))
+	require.False(t, banworthyReason("other"))
+	require.False(t, banworthyReason("resume_expired"))
+}
+
+func TestStaleResumeTokensDoNotAccrueBanStrikes(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{
+		Ban: BanConfig{MaxFailures: 2, Window: time.Minute, BanDuration: time.Minute},
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-stale-token")
+	// Unknown tokens — say a restart wiped the server's table — are refused
+	// but never counted against the source.
+	for i := 0; i < 3; i++ {
+		_, ack := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+			ChainID: 4, KeyID: signer.KeyID(), ResumeToken: []byte("stale token"),
+		})
+		require.False(t, ack.OK)
+	}
+	time.Sleep(20 * time.Millisecond)
+	require.Zero(t, srv.HandshakeFailuresByIP()["127.0.0.1"])
+
+	// The full-handshake fallback still works and is not banned.
+	_, ack := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), Nonce: 1,
+	})
+	require.True(t, ack.OK)
+
+	// Genuine reuse of a consumed token, on the other hand, is a strike.
+	_, first := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), ResumeToken: ack.ResumeToken,
+	})
+	require.True(t, first.OK)
+	_, replay := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), ResumeToken: ack.ResumeToken,
+	})
+	require.False(t, replay.OK)
+	require.Eventually(t, func() bool {
+		return srv.HandshakeFailuresByIP()["127.0.0.1"] == 1
+	}, time.Second, time.Millisecond)
+}
+
+func TestRepeatedAuthFailuresBanTheSource(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{
+		Ban: BanConfig{MaxFailures: 2, Window: time.Minute, BanDuration: time.Minute},
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	rogue, err := auth.GenerateSigner("rogue")
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 1}, rogue)
+		require.ErrorIs(t, client.Connect(), ErrHandshakeRejected)
+	}
+	require.Equal(t, 2, srv.HandshakeFailuresByIP()["127.0.0.1"])
+
+	// The source is banned now: even a trusted key is refused before the
+	// handshake.
+	trusted := trustedSigner(t, authManager, "seq-banned")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 1}, trusted)
+	require.Error(t, client.Connect())
+}
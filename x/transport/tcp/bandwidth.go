@@ -0,0 +1,71 @@
+package tcp
+
+import (
+	"sync"
+	"time"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// BandwidthConfig enables per-chain byte quotas for L2Block submissions,
+// protecting the publisher from oversized blocks. Zero QuotaBytes disables
+// enforcement; accounting metrics are always emitted.
+type BandwidthConfig struct {
+	// QuotaBytes is the maximum L2Block payload bytes a chain may submit
+	// per window.
+	QuotaBytes uint64 `yaml:"QuotaBytes"`
+	// QuotaWindow is the accounting window; defaults to 24h.
+	QuotaWindow time.Duration `yaml:"QuotaWindow"`
+}
+
+type chainUsage struct {
+	windowStart time.Time
+	bytes       uint64
+}
+
+// bandwidthAccountant tracks per-chain L2Block bytes within a rolling
+// window and answers quota checks.
+type bandwidthAccountant struct {
+	cfg BandwidthConfig
+
+	mu    sync.Mutex
+	usage map[types.ChainID]*chainUsage
+}
+
+func newBandwidthAccountant(cfg BandwidthConfig) *bandwidthAccountant {
+	if cfg.QuotaWindow <= 0 {
+		cfg.QuotaWindow = 24 * time.Hour
+	}
+	return &bandwidthAccountant{cfg: cfg, usage: make(map[types.ChainID]*chainUsage)}
+}
+
+// allow accounts size bytes against the chain's window and reports whether
+// the submission stays within quota. With no quota configured it only
+// accounts.
+func (b *bandwidthAccountant) allow(chainID types.ChainID, size int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	u := b.usage[chainID]
+	now := time.Now()
+	if u == nil || now.Sub(u.windowStart) >= b.cfg.QuotaWindow {
+		u = &chainUsage{windowStart: now}
+		b.usage[chainID] = u
+	}
+	if b.cfg.QuotaBytes > 0 && u.bytes+uint64(size) > b.cfg.QuotaBytes {
+		return false
+	}
+	u.bytes += uint64(size)
+	return true
+}
+
+// used returns the bytes accounted in the chain's current window.
+func (b *bandwidthAccountant) used(chainID types.ChainID) uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	u := b.usage[chainID]
+	if u == nil || time.Since(u.windowStart) >= b.cfg.QuotaWindow {
+		return 0
+	}
+	return u.bytes
+}
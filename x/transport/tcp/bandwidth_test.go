@@ -0,0 +1,84 @@
+package tcp
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+func TestBandwidthAccountant(t *testing.T) {
+	b := newBandwidthAccountant(BandwidthConfig{QuotaBytes: 100, QuotaWindow: time.Hour})
+
+	require.True(t, b.allow(10, 60))
+	require.True(t, b.allow(10, 40))
+	require.False(t, b.allow(10, 1), "quota exhausted")
+	require.Equal(t, uint64(100), b.used(10))
+
+	// Other chains have independent budgets.
+	require.True(t, b.allow(20, 100))
+
+	// Window expiry resets the budget.
+	b.mu.Lock()
+	b.usage[10].windowStart = time.Now().Add(-2 * time.Hour)
+	b.mu.Unlock()
+	require.True(t, b.allow(10, 100))
+}
+
+func TestBandwidthAccountantNoQuota(t *testing.T) {
+	b := newBandwidthAccountant(BandwidthConfig{})
+	require.True(t, b.allow(10, 1<<30))
+	require.Equal(t, uint64(1<<30), b.used(10))
+}
+
+func TestServerEnforcesL2BlockQuota(t *testing.T) {
+	// Size the quota so exactly one block frame fits.
+	msg, err := protocol.NewMessage(protocol.TypeL2Block, types.L2Block{ChainID: 10, Number: 1})
+	require.NoError(t, err)
+	raw, err := json.Marshal(msg)
+	require.NoError(t, err)
+	frameSize := uint64(len(raw) + 4)
+
+	srv, authManager := startTestServer(t, Config{
+		Bandwidth: BandwidthConfig{QuotaBytes: frameSize + frameSize/2, QuotaWindow: time.Hour},
+	})
+
+	var (
+		mu     sync.Mutex
+		blocks int
+	)
+	srv.SetHandler(func(_ context.Context, _ *Conn, msg protocol.Message) {
+		if msg.Type == protocol.TypeL2Block {
+			mu.Lock()
+			blocks++
+			mu.Unlock()
+		}
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-10")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	// First block fits in the 200-byte quota; the second exceeds it.
+	require.NoError(t, client.Send(protocol.TypeL2Block, types.L2Block{ChainID: 10, Number: 1}))
+	require.NoError(t, client.Send(protocol.TypeL2Block, types.L2Block{ChainID: 10, Number: 2}))
+
+	require.Eventually(t, func() bool {
+		return srv.BandwidthUsed(10) > 0
+	}, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 1, blocks)
+}
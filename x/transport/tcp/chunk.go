@@ -0,0 +1,169 @@
+package tcp
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"sync"
+	"sync/atomic"
+
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// DefaultMaxChunkedSize bounds a reassembled chunked payload.
+const DefaultMaxChunkedSize = 64 << 20 // 64 MiB
+
+// Chunked-transfer errors.
+var (
+	ErrChunkChecksum = errors.New("tcp: chunk checksum mismatch")
+	ErrChunkTooLarge = errors.New("tcp: chunked payload exceeds size limit")
+)
+
+var chunkCRC = crc32.MakeTable(crc32.Castagnoli)
+
+var transferIDs atomic.Uint64
+
+// splitChunks fragments payload into chunks whose Data is at most
+// chunkData bytes, assigning a fresh transfer ID.
+func splitChunks(payload []byte, chunkData int) []protocol.Chunk {
+	transferID := transferIDs.Add(1)
+	total := (len(payload) + chunkData - 1) / chunkData
+	chunks := make([]protocol.Chunk, 0, total)
+	for i := 0; i < total; i++ {
+		start := i * chunkData
+		end := start + chunkData
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunk := protocol.Chunk{
+			TransferID: transferID,
+			Index:      i,
+			Total:      total,
+			Checksum:   crc32.Checksum(payload[start:end], chunkCRC),
+			Data:       payload[start:end],
+		}
+		if i == total-1 {
+			chunk.PayloadChecksum = crc32.Checksum(payload, chunkCRC)
+		}
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+type transfer struct {
+	parts    [][]byte
+	received int
+	size     int
+	final    *protocol.Chunk
+}
+
+// reassembler rebuilds chunked payloads per connection, accounting every
+// buffered byte against the connection's cap and the server-wide
+// accountant so a flood of half-finished transfers cannot balloon memory.
+type reassembler struct {
+	maxBytes    int
+	maxBuffered int
+	mem         *memoryAccountant
+
+	mu        sync.Mutex
+	transfers map[uint64]*transfer
+	buffered  int
+}
+
+// newReassembler creates a reassembler. maxBuffered <= 0 falls back to
+// maxBytes; a nil mem disables global accounting (tests).
+func newReassembler(maxBytes, maxBuffered int, mem *memoryAccountant) *reassembler {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxChunkedSize
+	}
+	if maxBuffered <= 0 {
+		maxBuffered = maxBytes
+	}
+	return &reassembler{
+		maxBytes:    maxBytes,
+		maxBuffered: maxBuffered,
+		mem:         mem,
+		transfers:   make(map[uint64]*transfer),
+	}
+}
+
+// add ingests a chunk. It returns the full payload once the transfer is
+// complete, nil while it is still in flight, and an error (dropping the
+// transfer) on any inconsistency.
+func (r *reassembler) add(chunk protocol.Chunk) ([]byte, error) {
+	if chunk.Total <= 0 || chunk.Index < 0 || chunk.Index >= chunk.Total {
+		return nil, fmt.Errorf("tcp: invalid chunk index %d/%d", chunk.Index, chunk.Total)
+	}
+	if crc32.Checksum(chunk.Data, chunkCRC) != chunk.Checksum {
+		return nil, ErrChunkChecksum
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tr := r.transfers[chunk.TransferID]
+	if tr == nil {
+		tr = &transfer{parts: make([][]byte, chunk.Total)}
+		r.transfers[chunk.TransferID] = tr
+	}
+	if len(tr.parts) != chunk.Total {
+		r.evict(chunk.TransferID, tr)
+		return nil, fmt.Errorf("tcp: chunk total changed mid-transfer %d", chunk.TransferID)
+	}
+	if tr.parts[chunk.Index] != nil {
+		return nil, nil // duplicate chunk, ignore
+	}
+	if tr.size+len(chunk.Data) > r.maxBytes {
+		r.evict(chunk.TransferID, tr)
+		return nil, ErrChunkTooLarge
+	}
+	if r.buffered+len(chunk.Data) > r.maxBuffered {
+		r.evict(chunk.TransferID, tr)
+		return nil, ErrConnBufferLimit
+	}
+	if r.mem != nil && !r.mem.reserve(len(chunk.Data)) {
+		r.evict(chunk.TransferID, tr)
+		return nil, ErrServerBufferLimit
+	}
+	tr.parts[chunk.Index] = chunk.Data
+	tr.size += len(chunk.Data)
+	tr.received++
+	r.buffered += len(chunk.Data)
+	if chunk.Index == chunk.Total-1 {
+		cp := chunk
+		tr.final = &cp
+	}
+	if tr.received < chunk.Total {
+		return nil, nil
+	}
+
+	r.evict(chunk.TransferID, tr)
+	payload := make([]byte, 0, tr.size)
+	for _, part := range tr.parts {
+		payload = append(payload, part...)
+	}
+	if tr.final == nil || crc32.Checksum(payload, chunkCRC) != tr.final.PayloadChecksum {
+		return nil, ErrChunkChecksum
+	}
+	return payload, nil
+}
+
+// evict drops a transfer and returns its bytes to the accountants. The
+// caller holds r.mu.
+func (r *reassembler) evict(transferID uint64, tr *transfer) {
+	delete(r.transfers, transferID)
+	r.buffered -= tr.size
+	if r.mem != nil {
+		r.mem.release(tr.size)
+	}
+}
+
+// release drops every in-flight transfer, returning all buffered bytes.
+// Called when the connection closes.
+func (r *reassembler) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, tr := range r.transfers {
+		r.evict(id, tr)
+	}
+}
@@ -0,0 +1,96 @@
+package tcp
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+func TestSplitAndReassemble(t *testing.T) {
+	payload := bytes.Repeat([]byte("0123456789"), 100) // 1000 bytes
+	chunks := splitChunks(payload, 300)
+	require.Len(t, chunks, 4)
+
+	r := newReassembler(0, 0, nil)
+	// Deliver out of order with a duplicate.
+	order := []int{2, 0, 3, 0, 1}
+	var got []byte
+	for _, i := range order {
+		result, err := r.add(chunks[i])
+		require.NoError(t, err)
+		if result != nil {
+			got = result
+		}
+	}
+	require.Equal(t, payload, got)
+}
+
+func TestReassemblerRejectsCorruptChunk(t *testing.T) {
+	chunks := splitChunks([]byte("hello world"), 4)
+	chunks[0].Data[0] ^= 0xff
+
+	r := newReassembler(0, 0, nil)
+	_, err := r.add(chunks[0])
+	require.ErrorIs(t, err, ErrChunkChecksum)
+}
+
+func TestReassemblerEnforcesSizeLimit(t *testing.T) {
+	chunks := splitChunks(bytes.Repeat([]byte{1}, 1000), 400)
+	r := newReassembler(500, 0, nil)
+	_, err := r.add(chunks[0])
+	require.NoError(t, err)
+	_, err = r.add(chunks[1])
+	require.ErrorIs(t, err, ErrChunkTooLarge)
+}
+
+// TestChunkedL2BlockTransfer forces a tiny negotiated frame size so a block
+// payload must be chunked end to end.
+func TestChunkedL2BlockTransfer(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{MaxMessageSize: 8 << 10})
+
+	blockCh := make(chan types.L2Block, 1)
+	srv.SetHandler(func(_ context.Context, _ *Conn, msg protocol.Message) {
+		if msg.Type == protocol.TypeL2Block {
+			var block types.L2Block
+			if err := msg.Decode(&block); err == nil {
+				blockCh <- block
+			}
+		}
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-10")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10, MaxMessageSize: 8 << 10}, signer)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+	require.Equal(t, 8<<10, client.NegotiatedMaxMessageSize())
+
+	big := &types.L2Block{
+		ChainID: 10,
+		Number:  7,
+		Payload: bytes.Repeat([]byte{0xab}, 64<<10),
+	}
+	require.NoError(t, client.SendL2Block(big))
+
+	var (
+		got  types.L2Block
+		once sync.Once
+	)
+	select {
+	case b := <-blockCh:
+		once.Do(func() { got = b })
+	case <-time.After(2 * time.Second):
+		t.Fatal("chunked block never arrived")
+	}
+	require.Equal(t, big.Number, got.Number)
+	require.Equal(t, big.Payload, got.Payload)
+}
@@ -0,0 +1,575 @@
+package tcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/superblock/slot"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// ClientConfig configures a sequencer-side connection to the publisher.
+type ClientConfig struct {
+	Addr    string        `yaml:"Addr"`
+	ChainID types.ChainID `yaml:"ChainID"`
+	// NetworkID selects the hosted network; empty means the default one.
+	NetworkID      types.NetworkID `yaml:"NetworkID"`
+	MaxMessageSize int             `yaml:"MaxMessageSize"`
+	DialTimeout    time.Duration   `yaml:"DialTimeout"`
+	// Slots is the sequencer's expected slot timing. When set, Connect
+	// refuses a publisher advertising different genesis or slot parameters;
+	// a silent mismatch would misalign every batch from then on.
+	Slots slot.Config `yaml:"Slots"`
+	// LegacyHandshake uses the version-0 one-shot signed handshake, for
+	// publishers that predate the challenge-response step.
+	LegacyHandshake bool `yaml:"LegacyHandshake"`
+	// SendQueueSize, when positive, turns Send into fire-and-forget: up to
+	// this many messages are buffered across disconnects and flushed in
+	// order once the connection is back.
+	SendQueueSize int `yaml:"SendQueueSize"`
+	// CoalesceTypes lists payload types where only the newest queued
+	// message matters; a fresh message of such a type replaces the queued
+	// one instead of taking another slot.
+	CoalesceTypes []string `yaml:"CoalesceTypes"`
+	// SendRetryInterval is how often the queue flusher re-checks a down
+	// connection. Zero defaults to 100ms.
+	SendRetryInterval time.Duration `yaml:"SendRetryInterval"`
+	// PinnedPublisherKey, when set, is the publisher public key (hex) the
+	// client expects. An Identity announcement carrying a different key —
+	// a man in the middle, or a misconfigured publisher — closes the
+	// connection immediately.
+	PinnedPublisherKey string `yaml:"PinnedPublisherKey"`
+}
+
+// MessageHandler receives every non-heartbeat message from the publisher.
+type MessageHandler func(msg protocol.Message)
+
+// Client is the sequencer side of the transport. It answers the server's
+// heartbeat pings automatically and dispatches everything else to the
+// configured handler.
+type Client struct {
+	logger  *zap.Logger
+	cfg     ClientConfig
+	signer  *auth.Signer
+	handler MessageHandler
+
+	mu         sync.Mutex
+	netConn    net.Conn
+	codec      *Codec
+	negotiated int
+	// resumeToken is the server-issued session resumption token from the
+	// last successful handshake; the next Connect presents it to skip the
+	// signature round trip.
+	resumeToken []byte
+	// sessionBinding is the handshake material (signature or resume token)
+	// a valid Identity announcement must echo.
+	sessionBinding []byte
+	// publisherIdentity is the verified Identity announcement, if one
+	// arrived.
+	publisherIdentity *protocol.Identity
+	sendMu            sync.Mutex
+
+	// queue is non-nil when SendQueueSize is configured; its flusher runs
+	// while flushing is true and exits when done closes.
+	queue    *sendQueue
+	flushing bool
+	// done closes when the client shuts down, stopping the queue flusher;
+	// a reconnect after a close replaces it.
+	done chan struct{}
+
+	// disconnectAck receives a signal when the server acknowledges a
+	// Disconnect sent by CloseWithReason; reset on every connect.
+	disconnectAck chan struct{}
+	// lastDisconnectReason records why the server said it dropped us.
+	lastDisconnectReason string
+
+	wg     sync.WaitGroup
+	closed bool
+}
+
+// NewClient creates a client authenticating with signer.
+func NewClient(logger *zap.Logger, cfg ClientConfig, signer *auth.Signer) *Client {
+	if cfg.DialTimeout <= 0 {
+		cfg.DialTimeout = 10 * time.Second
+	}
+	if cfg.SendRetryInterval <= 0 {
+		cfg.SendRetryInterval = 100 * time.Millisecond
+	}
+	c := &Client{
+		logger: logger.Named("tcp-client"),
+		cfg:    cfg,
+		signer: signer,
+		done:   make(chan struct{}),
+	}
+	if cfg.SendQueueSize > 0 {
+		c.queue = newSendQueue(cfg.SendQueueSize, cfg.CoalesceTypes)
+	}
+	return c
+}
+
+// SetHandler installs the inbound message handler. Must be called before
+// Connect.
+func (c *Client) SetHandler(handler MessageHandler) {
+	c.handler = handler
+}
+
+// Connect dials the publisher and performs the signed handshake. A
+// resumption token from a recent session skips the signature round trip;
+// if the server refuses it, Connect transparently redoes the full
+// handshake.
+func (c *Client) Connect() error {
+	c.mu.Lock()
+	token := c.resumeToken
+	c.mu.Unlock()
+	if len(token) > 0 {
+		if err := c.connect(token); err == nil {
+			return nil
+		}
+		// Expired, already used, or the server restarted: fall back to
+		// the full signature handshake.
+		c.mu.Lock()
+		c.resumeToken = nil
+		c.mu.Unlock()
+	}
+	return c.connect(nil)
+}
+
+func (c *Client) connect(resumeToken []byte) error {
+	netConn, err := net.DialTimeout("tcp", c.cfg.Addr, c.cfg.DialTimeout)
+	if err != nil {
+		return fmt.Errorf("tcp: dial %s: %w", c.cfg.Addr, err)
+	}
+	codec := NewCodec(netConn, c.cfg.MaxMessageSize)
+
+	maxSize := c.cfg.MaxMessageSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxMessageSize
+	}
+	hs := protocol.Handshake{
+		ChainID:        c.cfg.ChainID,
+		NetworkID:      c.cfg.NetworkID,
+		KeyID:          c.signer.KeyID(),
+		KeyScheme:      c.signer.SchemeName(),
+		Timestamp:      time.Now().Unix(),
+		Nonce:          rand.Uint64(),
+		MaxMessageSize: maxSize,
+	}
+	switch {
+	case len(resumeToken) > 0:
+		// Resumption presents the token instead of a signature; the server
+		// acks directly without a challenge round trip.
+		hs.ResumeToken = resumeToken
+	case c.cfg.LegacyHandshake:
+		hs.Signature = c.signer.Sign(hs.SigningBytes())
+	default:
+		// Version-1 opener carries no signature; the server answers with a
+		// challenge nonce the signature must cover.
+		hs.Version = protocol.HandshakeVersionChallenge
+	}
+	msg, err := protocol.NewMessage(protocol.TypeHandshake, hs)
+	if err != nil {
+		_ = netConn.Close()
+		return err
+	}
+	if _, err := codec.WriteMessage(msg); err != nil {
+		_ = netConn.Close()
+		return fmt.Errorf("tcp: send handshake: %w", err)
+	}
+
+	if !c.cfg.LegacyHandshake && len(resumeToken) == 0 {
+		chMsg, _, err := codec.ReadMessage()
+		if err != nil {
+			_ = netConn.Close()
+			return fmt.Errorf("tcp: read handshake challenge: %w", err)
+		}
+		if chMsg.Type != protocol.TypeHandshakeChallenge {
+			_ = netConn.Close()
+			return fmt.Errorf("tcp: expected handshake challenge, got %q", chMsg.Type)
+		}
+		var challenge protocol.HandshakeChallenge
+		if err := chMsg.Decode(&challenge); err != nil {
+			_ = netConn.Close()
+			return err
+		}
+		hs.ServerNonce = challenge.ServerNonce
+		hs.Timestamp = time.Now().Unix()
+		hs.Signature = c.signer.Sign(hs.SigningBytes())
+		respMsg, err := protocol.NewMessage(protocol.TypeHandshake, hs)
+		if err != nil {
+			_ = netConn.Close()
+			return err
+		}
+		if _, err := codec.WriteMessage(respMsg); err != nil {
+			_ = netConn.Close()
+			return fmt.Errorf("tcp: send challenge response: %w", err)
+		}
+	}
+
+	ackMsg, _, err := codec.ReadMessage()
+	if err != nil {
+		_ = netConn.Close()
+		return fmt.Errorf("tcp: read handshake ack: %w", err)
+	}
+	var ack protocol.HandshakeAck
+	if err := ackMsg.Decode(&ack); err != nil {
+		_ = netConn.Close()
+		return err
+	}
+	if !ack.OK {
+		_ = netConn.Close()
+		return fmt.Errorf("%w: %s", ErrHandshakeRejected, ack.Error)
+	}
+	if err := c.checkSlotParams(ack.Slots); err != nil {
+		_ = netConn.Close()
+		return err
+	}
+
+	negotiated := maxSize
+	if ack.MaxMessageSize > 0 && ack.MaxMessageSize < negotiated {
+		negotiated = ack.MaxMessageSize
+	}
+
+	binding := hs.Signature
+	if len(resumeToken) > 0 {
+		binding = resumeToken
+	}
+	c.mu.Lock()
+	c.netConn = netConn
+	c.codec = codec
+	c.negotiated = negotiated
+	c.resumeToken = ack.ResumeToken
+	c.sessionBinding = binding
+	c.publisherIdentity = nil
+	c.closed = false
+	c.disconnectAck = make(chan struct{}, 1)
+	select {
+	case <-c.done:
+		// Reconnecting after a close: the old done channel already fired,
+		// so give the new session a fresh one.
+		c.done = make(chan struct{})
+	default:
+	}
+	c.startFlusherLocked()
+	c.mu.Unlock()
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.readLoop(codec)
+	}()
+	return nil
+}
+
+// checkSlotParams compares the publisher's advertised slot timing against
+// the locally configured one. A mismatch is fatal; running on would
+// misalign batches silently.
+func (c *Client) checkSlotParams(advertised *protocol.SlotParams) error {
+	if c.cfg.Slots.GenesisTime.IsZero() {
+		return nil // nothing configured locally to check against
+	}
+	if advertised == nil {
+		c.logger.Warn("publisher did not advertise slot parameters; skipping genesis check")
+		return nil
+	}
+	if local := wireSlotParams(c.cfg.Slots); *advertised != local {
+		return fmt.Errorf("%w: publisher genesis=%d duration=%dms cutover=%dms, local genesis=%d duration=%dms cutover=%dms",
+			ErrSlotParamsMismatch,
+			advertised.GenesisTime, advertised.SlotDurationMS, advertised.SealCutoverMS,
+			local.GenesisTime, local.SlotDurationMS, local.SealCutoverMS)
+	}
+	return nil
+}
+
+// Send writes a message to the publisher. With a configured send queue it
+// is fire-and-forget: the message is buffered — surviving disconnects —
+// and flushed in order once a connection is up, and only a full queue is
+// an error.
+func (c *Client) Send(msgType string, payload any) error {
+	msg, err := protocol.NewMessage(msgType, payload)
+	if err != nil {
+		return err
+	}
+	if c.queue != nil {
+		if err := c.queue.push(msg); err != nil {
+			return err
+		}
+		c.mu.Lock()
+		c.startFlusherLocked()
+		c.mu.Unlock()
+		return nil
+	}
+	return c.write(msg)
+}
+
+// startFlusherLocked launches the queue flusher unless one is already
+// running or the client is closed; a closed client restarts it on the next
+// Connect. Callers hold c.mu.
+func (c *Client) startFlusherLocked() {
+	if c.queue == nil || c.flushing || c.closed {
+		return
+	}
+	c.flushing = true
+	done := c.done
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		c.flushLoop(done)
+	}()
+}
+
+// write sends one message on the current connection.
+func (c *Client) write(msg protocol.Message) error {
+	c.mu.Lock()
+	codec := c.codec
+	c.mu.Unlock()
+	if codec == nil {
+		return ErrNotConnected
+	}
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	_, err := codec.WriteMessage(msg)
+	return err
+}
+
+// flushLoop drains the send queue until done closes, waiting out
+// disconnects: a message that cannot be written is retried until a
+// reconnect lets it through, preserving queue order.
+func (c *Client) flushLoop(done <-chan struct{}) {
+	defer func() {
+		c.mu.Lock()
+		c.flushing = false
+		c.mu.Unlock()
+	}()
+	for {
+		msg, ok := c.queue.pop(done)
+		if !ok {
+			return
+		}
+		for c.write(msg) != nil {
+			select {
+			case <-done:
+				return
+			case <-time.After(c.cfg.SendRetryInterval):
+			}
+		}
+	}
+}
+
+// PendingSends returns the number of queued outbound messages; zero when
+// no send queue is configured.
+func (c *Client) PendingSends() int {
+	if c.queue == nil {
+		return 0
+	}
+	return c.queue.len()
+}
+
+// NegotiatedMaxMessageSize returns the frame limit agreed at handshake.
+func (c *Client) NegotiatedMaxMessageSize() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.negotiated
+}
+
+// SendL2Block sends a block, transparently falling back to chunked
+// transfer when the encoded block exceeds the negotiated frame limit.
+func (c *Client) SendL2Block(block *types.L2Block) error {
+	msg, err := protocol.NewMessage(protocol.TypeL2Block, block)
+	if err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	limit := c.NegotiatedMaxMessageSize()
+	if limit <= 0 {
+		limit = DefaultMaxMessageSize
+	}
+	if len(encoded)+4 <= limit {
+		return c.Send(protocol.TypeL2Block, block)
+	}
+
+	// Chunk Data is base64-expanded by JSON, so leave headroom for the
+	// envelope and encoding overhead.
+	chunkData := (limit / 4 * 3) - 1024
+	if chunkData < 1024 {
+		chunkData = 1024
+	}
+	for _, chunk := range splitChunks(msg.Payload, chunkData) {
+		if err := c.Send(protocol.TypeL2BlockChunk, chunk); err != nil {
+			return fmt.Errorf("tcp: send chunk %d/%d: %w", chunk.Index+1, chunk.Total, err)
+		}
+	}
+	return nil
+}
+
+// handleIdentity verifies the publisher's identity announcement. Any
+// inconsistency — wrong session binding, a bad signature, or a key other
+// than the pinned one — drops the connection: an identity that cannot be
+// trusted means every subsequent message is suspect.
+func (c *Client) handleIdentity(msg protocol.Message) {
+	var ident protocol.Identity
+	if err := msg.Decode(&ident); err != nil {
+		c.dropConnection("malformed identity announcement", err)
+		return
+	}
+	c.mu.Lock()
+	binding := c.sessionBinding
+	c.mu.Unlock()
+	if !bytes.Equal(ident.SessionBinding, binding) {
+		c.dropConnection("identity announcement not bound to this session", nil)
+		return
+	}
+	if err := auth.VerifyWithKey(ident.PublicKey, ident.SigningBytes(), ident.Signature); err != nil {
+		c.dropConnection("identity announcement signature invalid", err)
+		return
+	}
+	if pin := c.cfg.PinnedPublisherKey; pin != "" && !strings.EqualFold(ident.PublicKey, pin) {
+		c.dropConnection("publisher key does not match the pinned key", nil)
+		return
+	}
+	c.mu.Lock()
+	c.publisherIdentity = &ident
+	c.mu.Unlock()
+}
+
+// dropConnection closes the socket from inside the read loop, without
+// Close's wait on that same loop.
+func (c *Client) dropConnection(reason string, err error) {
+	c.logger.Error("closing connection: "+reason, zap.Error(err))
+	c.mu.Lock()
+	netConn := c.netConn
+	c.netConn = nil
+	c.codec = nil
+	c.closed = true
+	c.signalDoneLocked()
+	c.mu.Unlock()
+	if netConn != nil {
+		_ = netConn.Close()
+	}
+}
+
+// PublisherIdentity returns the verified identity announcement from the
+// current session, if the publisher sent one.
+func (c *Client) PublisherIdentity() (protocol.Identity, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.publisherIdentity == nil {
+		return protocol.Identity{}, false
+	}
+	return *c.publisherIdentity, true
+}
+
+// LastDisconnectReason returns the reason from the most recent Disconnect
+// the server sent, or "" if the server never announced one.
+func (c *Client) LastDisconnectReason() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lastDisconnectReason
+}
+
+// CloseWithReason tells the publisher why the client is going away, waits
+// briefly for its acknowledgement so the reason is not lost in the socket
+// teardown, and then closes the connection.
+func (c *Client) CloseWithReason(reason string) error {
+	c.mu.Lock()
+	connected := c.codec != nil
+	ackCh := c.disconnectAck
+	c.mu.Unlock()
+	if connected {
+		if err := c.Send(protocol.TypeDisconnect, protocol.Disconnect{Reason: reason}); err == nil {
+			select {
+			case <-ackCh:
+			case <-time.After(disconnectAckWait):
+				c.logger.Debug("disconnect ack not received", zap.String("reason", reason))
+			}
+		}
+	}
+	return c.Close()
+}
+
+// Close terminates the connection and stops the queue flusher.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	c.closed = true
+	c.signalDoneLocked()
+	netConn := c.netConn
+	c.netConn = nil
+	c.codec = nil
+	c.mu.Unlock()
+	if netConn != nil {
+		_ = netConn.Close()
+	}
+	c.wg.Wait()
+	return nil
+}
+
+// signalDoneLocked closes the done channel if it is still open. Callers
+// hold c.mu.
+func (c *Client) signalDoneLocked() {
+	select {
+	case <-c.done:
+	default:
+		close(c.done)
+	}
+}
+
+func (c *Client) readLoop(codec *Codec) {
+	for {
+		msg, _, err := codec.ReadMessage()
+		if err != nil {
+			c.mu.Lock()
+			closed := c.closed
+			c.mu.Unlock()
+			if !closed {
+				c.logger.Debug("connection lost", zap.Error(err))
+			}
+			return
+		}
+		switch msg.Type {
+		case protocol.TypePing:
+			var ping protocol.Ping
+			if err := msg.Decode(&ping); err == nil {
+				_ = c.Send(protocol.TypePong, protocol.Pong{Seq: ping.Seq, SentAt: ping.SentAt})
+			}
+		case protocol.TypePong:
+			// Server-side pongs are not expected client-side; ignore.
+		case protocol.TypeIdentity:
+			c.handleIdentity(msg)
+		case protocol.TypeDisconnect:
+			// The server is dropping us; confirm we saw the reason so its
+			// grace wait ends promptly, and surface it for operators.
+			var d protocol.Disconnect
+			if err := msg.Decode(&d); err == nil {
+				c.logger.Info("server closing connection", zap.String("reason", d.Reason))
+				c.mu.Lock()
+				c.lastDisconnectReason = d.Reason
+				c.mu.Unlock()
+			}
+			_ = c.Send(protocol.TypeDisconnectAck, protocol.DisconnectAck{})
+		case protocol.TypeDisconnectAck:
+			c.mu.Lock()
+			ackCh := c.disconnectAck
+			c.mu.Unlock()
+			select {
+			case ackCh <- struct{}{}:
+			default:
+			}
+		default:
+			if c.handler != nil {
+				c.handler(msg)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Package tcp implements the publisher's sequencer-facing transport: a
+// length-prefixed message stream over TCP with a signed handshake,
+// server-driven heartbeats and per-chain connection tracking.
+package tcp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// DefaultMaxMessageSize bounds a single frame when no limit is configured.
+const DefaultMaxMessageSize = 10 << 20 // 10 MiB
+
+// ErrMessageTooLarge is returned when a frame exceeds the size limit.
+var ErrMessageTooLarge = errors.New("tcp: message exceeds size limit")
+
+// Codec frames protocol messages on a stream: 4-byte big-endian length
+// followed by the JSON-encoded envelope.
+type Codec struct {
+	r       *bufio.Reader
+	w       *bufio.Writer
+	maxSize int
+}
+
+// NewCodec wraps a connection. maxSize <= 0 uses DefaultMaxMessageSize.
+func NewCodec(conn net.Conn, maxSize int) *Codec {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxMessageSize
+	}
+	return &Codec{
+		r:       bufio.NewReaderSize(conn, 16<<10),
+		w:       bufio.NewWriterSize(conn, 16<<10),
+		maxSize: maxSize,
+	}
+}
+
+// ReadMessage reads the next frame, returning the frame size in bytes for
+// bandwidth accounting.
+func (c *Codec) ReadMessage() (protocol.Message, int, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return protocol.Message{}, 0, err
+	}
+	length := binary.BigEndian.Uint32(header[:])
+	if int(length) > c.maxSize {
+		return protocol.Message{}, 0, fmt.Errorf("%w: %d bytes", ErrMessageTooLarge, length)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(c.r, body); err != nil {
+		return protocol.Message{}, 0, err
+	}
+	var msg protocol.Message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return protocol.Message{}, 0, fmt.Errorf("tcp: decode frame: %w", err)
+	}
+	return msg, len(header) + len(body), nil
+}
+
+// WriteMessage writes and flushes one frame, returning its size in bytes.
+func (c *Codec) WriteMessage(msg protocol.Message) (int, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return 0, fmt.Errorf("tcp: encode frame: %w", err)
+	}
+	if len(body) > c.maxSize {
+		return 0, fmt.Errorf("%w: %d bytes", ErrMessageTooLarge, len(body))
+	}
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(body)))
+	if _, err := c.w.Write(header[:]); err != nil {
+		return 0, err
+	}
+	if _, err := c.w.Write(body); err != nil {
+		return 0, err
+	}
+	return len(header) + len(body), c.w.Flush()
+}
@@ -0,0 +1,151 @@
+package tcp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/compose-network/publisher/metrics"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// Conn is an authenticated sequencer connection held by the server.
+type Conn struct {
+	chainID   types.ChainID
+	networkID types.NetworkID
+	keyID     string
+	netConn   net.Conn
+	codec     *Codec
+
+	// ctx is canceled when the connection closes, so downstream work
+	// started on behalf of this peer stops instead of outliving it.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// reassembler rebuilds chunked L2Block transfers from this peer.
+	reassembler *reassembler
+
+	// sessionBinding is the session-unique handshake material (signature
+	// or resume token) echoed in the server's Identity announcement.
+	sessionBinding []byte
+
+	sendMu sync.Mutex
+
+	lastRead    atomic.Int64 // unix nanoseconds of the last inbound frame
+	rttNanos    atomic.Int64 // most recent measured round-trip time
+	missedPongs atomic.Int32
+	pingSeq     atomic.Uint64
+
+	// disconnectAcked is closed when the peer acknowledges a Disconnect
+	// message, unblocking CloseWithReason's grace wait.
+	disconnectAcked chan struct{}
+	ackOnce         sync.Once
+
+	closeOnce  sync.Once
+	closeCause atomic.Value // string
+}
+
+// disconnectAckWait bounds how long a graceful close waits for the peer to
+// acknowledge the Disconnect before tearing down the socket anyway.
+const disconnectAckWait = 500 * time.Millisecond
+
+func (c *Conn) setCloseReason(reason string) {
+	c.closeCause.CompareAndSwap(nil, reason)
+}
+
+func (c *Conn) closeReason() string {
+	if v := c.closeCause.Load(); v != nil {
+		return v.(string)
+	}
+	return ""
+}
+
+// ChainID returns the chain the connection authenticated as.
+func (c *Conn) ChainID() types.ChainID {
+	return c.chainID
+}
+
+// NetworkID returns the hosted network the connection belongs to.
+func (c *Conn) NetworkID() types.NetworkID {
+	return c.networkID
+}
+
+// KeyID returns the trusted key the connection authenticated with.
+func (c *Conn) KeyID() string {
+	return c.keyID
+}
+
+// Context returns a context canceled when the connection closes. Handlers
+// should thread it into store and L1 calls so work for a disconnected peer
+// is abandoned.
+func (c *Conn) Context() context.Context {
+	return c.ctx
+}
+
+// RemoteAddr returns the peer address.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.netConn.RemoteAddr()
+}
+
+// RTT returns the last measured heartbeat round-trip time, or zero if no
+// pong has been observed yet.
+func (c *Conn) RTT() time.Duration {
+	return time.Duration(c.rttNanos.Load())
+}
+
+// Send encodes payload into an envelope and writes it to the peer.
+func (c *Conn) Send(msgType string, payload any) error {
+	msg, err := protocol.NewMessage(msgType, payload)
+	if err != nil {
+		return err
+	}
+	return c.send(msg)
+}
+
+func (c *Conn) send(msg protocol.Message) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	n, err := c.codec.WriteMessage(msg)
+	if err != nil {
+		return err
+	}
+	metrics.RecordMessageSent(uint64(c.chainID), msg.Type)
+	metrics.RecordBytesSent(uint64(c.chainID), msg.Type, n)
+	return nil
+}
+
+func (c *Conn) touch() {
+	c.lastRead.Store(time.Now().UnixNano())
+	c.missedPongs.Store(0)
+}
+
+func (c *Conn) ackDisconnect() {
+	c.ackOnce.Do(func() { close(c.disconnectAcked) })
+}
+
+// CloseWithReason tells the peer why it is being dropped, waits up to wait
+// for its acknowledgement so the reason survives the teardown, then closes
+// the connection. A zero wait skips the grace period.
+func (c *Conn) CloseWithReason(reason string, wait time.Duration) {
+	c.setCloseReason(reason)
+	if err := c.Send(protocol.TypeDisconnect, protocol.Disconnect{Reason: reason}); err == nil && wait > 0 {
+		select {
+		case <-c.disconnectAcked:
+		case <-time.After(wait):
+		}
+	}
+	c.close()
+}
+
+func (c *Conn) close() {
+	c.closeOnce.Do(func() {
+		c.cancel()
+		_ = c.netConn.Close()
+		// Half-finished chunked transfers return their buffered bytes to
+		// the server's memory accounting.
+		c.reassembler.release()
+	})
+}
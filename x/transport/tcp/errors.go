@@ -0,0 +1,21 @@
+package tcp
+
+import "errors"
+
+// Sentinel errors callers can match with errors.Is to tell transient
+// transport states apart from permanent refusals.
+var (
+	// ErrNotConnected is returned by Send when no connection is up (and no
+	// send queue is configured to hold the message).
+	ErrNotConnected = errors.New("tcp: not connected")
+	// ErrHandshakeRejected is returned by Connect when the publisher
+	// refused the handshake; the wrapped message carries the server's
+	// reason (untrusted key, bad signature, chain not allowed, ...).
+	ErrHandshakeRejected = errors.New("tcp: handshake rejected")
+	// ErrSlotParamsMismatch is returned by Connect when the publisher
+	// advertises different slot timing than locally configured.
+	ErrSlotParamsMismatch = errors.New("tcp: slot parameters mismatch")
+	// ErrChainNotConnected is returned by SendTo when the chain has no
+	// live connection on the given network.
+	ErrChainNotConnected = errors.New("tcp: chain not connected")
+)
@@ -0,0 +1,99 @@
+package tcp
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// byteConn is a read-only net.Conn over a byte slice, enough to feed the
+// codec arbitrary wire data without a socket.
+type byteConn struct {
+	r *bytes.Reader
+}
+
+func (c *byteConn) Read(p []byte) (int, error)       { return c.r.Read(p) }
+func (c *byteConn) Write(p []byte) (int, error)      { return len(p), nil }
+func (c *byteConn) Close() error                     { return nil }
+func (c *byteConn) LocalAddr() net.Addr              { return nil }
+func (c *byteConn) RemoteAddr() net.Addr             { return nil }
+func (c *byteConn) SetDeadline(time.Time) error      { return nil }
+func (c *byteConn) SetReadDeadline(time.Time) error  { return nil }
+func (c *byteConn) SetWriteDeadline(time.Time) error { return nil }
+
+// frame encodes one message the way WriteMessage does, so the fuzz corpus
+// starts from frames a real peer would send.
+func frame(t *testing.F, msgType string, payload any) []byte {
+	t.Helper()
+	msg, err := protocol.NewMessage(msgType, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	codec := NewCodec(writerConn{&buf}, 0)
+	if _, err := codec.WriteMessage(msg); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// writerConn adapts a buffer as the codec's write side for corpus building.
+type writerConn struct{ w io.Writer }
+
+func (c writerConn) Read(p []byte) (int, error)       { return 0, io.EOF }
+func (c writerConn) Write(p []byte) (int, error)      { return c.w.Write(p) }
+func (c writerConn) Close() error                     { return nil }
+func (c writerConn) LocalAddr() net.Addr              { return nil }
+func (c writerConn) RemoteAddr() net.Addr             { return nil }
+func (c writerConn) SetDeadline(time.Time) error      { return nil }
+func (c writerConn) SetReadDeadline(time.Time) error  { return nil }
+func (c writerConn) SetWriteDeadline(time.Time) error { return nil }
+
+// FuzzCodecReadMessage throws arbitrary byte streams at the framing layer.
+// ReadMessage must never panic and must refuse to allocate past the
+// configured frame limit, whatever the length prefix claims.
+func FuzzCodecReadMessage(f *testing.F) {
+	// Seed with frames recorded from a compliant peer: handshake, block
+	// submission, vote and a chunked transfer fragment.
+	f.Add(frame(f, protocol.TypeHandshake, protocol.Handshake{
+		ChainID: 1, KeyID: "seq-1", Timestamp: 1700000000, Nonce: 42,
+		Signature: []byte{0xde, 0xad, 0xbe, 0xef},
+	}))
+	f.Add(frame(f, protocol.TypeL2Block, types.L2Block{
+		ChainID: 1, Number: 7, Slot: 3, Hash: types.Hash{1}, Payload: []byte("block body"),
+	}))
+	f.Add(frame(f, protocol.TypeVote, protocol.Vote{XtID: types.Hash{2}, ChainID: 1, Commit: true}))
+	f.Add(frame(f, protocol.TypeL2BlockChunk, protocol.Chunk{
+		TransferID: 9, Index: 0, Total: 2, Checksum: 0xcafe, Data: []byte("fragment"),
+	}))
+	// Malformed starters: truncated header, length prefix past the limit,
+	// valid length over a non-JSON body.
+	f.Add([]byte{0x00, 0x00})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff})
+	f.Add([]byte{0x00, 0x00, 0x00, 0x03, 'x', 'y', 'z'})
+
+	const maxSize = 1 << 16
+	f.Fuzz(func(t *testing.T, data []byte) {
+		codec := NewCodec(&byteConn{r: bytes.NewReader(data)}, maxSize)
+		for {
+			msg, n, err := codec.ReadMessage()
+			if err != nil {
+				return
+			}
+			if n > maxSize+4 {
+				t.Fatalf("frame of %d bytes exceeds the %d limit", n, maxSize)
+			}
+			if n > len(data) {
+				t.Fatalf("accounted %d bytes for a %d-byte input", n, len(data))
+			}
+			if len(msg.Payload) > maxSize {
+				t.Fatalf("payload of %d bytes escaped the frame limit", len(msg.Payload))
+			}
+		}
+	})
+}
@@ -0,0 +1,108 @@
+package tcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// TestLegacyHandshakeStillAccepted keeps version-0 one-shot clients
+// connecting against a challenge-capable server.
+func TestLegacyHandshakeStillAccepted(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-legacy")
+	client := NewClient(zap.NewNop(), ClientConfig{
+		Addr: srv.Addr().String(), ChainID: 4, LegacyHandshake: true,
+	}, signer)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+	require.Equal(t, 1, srv.ActiveConnections())
+}
+
+// TestChallengeResponseRejectsWrongNonce sends a version-1 opener but signs
+// a response over a nonce the server never issued.
+func TestChallengeResponseRejectsWrongNonce(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-forge")
+	netConn, err := net.Dial("tcp", srv.Addr().String())
+	require.NoError(t, err)
+	defer netConn.Close()
+	codec := NewCodec(netConn, DefaultMaxMessageSize)
+
+	hs := protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(),
+		Timestamp: time.Now().Unix(), Nonce: 1,
+		Version: protocol.HandshakeVersionChallenge,
+	}
+	msg, err := protocol.NewMessage(protocol.TypeHandshake, hs)
+	require.NoError(t, err)
+	_, err = codec.WriteMessage(msg)
+	require.NoError(t, err)
+
+	chMsg, _, err := codec.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, protocol.TypeHandshakeChallenge, chMsg.Type)
+	var challenge protocol.HandshakeChallenge
+	require.NoError(t, chMsg.Decode(&challenge))
+	require.Len(t, challenge.ServerNonce, 32)
+
+	// Echo a different nonce: a precomputed signature over attacker-chosen
+	// data must not pass.
+	hs.ServerNonce = make([]byte, 32)
+	hs.Signature = signer.Sign(hs.SigningBytes())
+	resp, err := protocol.NewMessage(protocol.TypeHandshake, hs)
+	require.NoError(t, err)
+	_, err = codec.WriteMessage(resp)
+	require.NoError(t, err)
+
+	ackMsg, _, err := codec.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, protocol.TypeHandshakeAck, ackMsg.Type)
+	var ack protocol.HandshakeAck
+	require.NoError(t, ackMsg.Decode(&ack))
+	require.False(t, ack.OK)
+	require.Equal(t, 0, srv.ActiveConnections())
+}
+
+// TestHandshakeEnforcesKeyMetadata checks that the chain allowlist on a
+// trusted key is enforced at handshake time.
+func TestHandshakeEnforcesKeyMetadata(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer, err := auth.GenerateSigner("seq-meta")
+	require.NoError(t, err)
+	require.NoError(t, authManager.AddTrustedKeyWithMetadata("seq-meta", signer.PublicKeyHex(), auth.KeyMetadata{
+		AllowedChains: []types.ChainID{5},
+	}))
+
+	denied := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 6}, signer)
+	require.ErrorIs(t, denied.Connect(), ErrHandshakeRejected)
+
+	allowed := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 5}, signer)
+	require.NoError(t, allowed.Connect())
+	defer allowed.Close()
+}
+
+// TestChallengeNonceCoveredBySignature verifies the signed bytes change
+// with the server nonce, so signatures cannot be minted ahead of time.
+func TestChallengeNonceCoveredBySignature(t *testing.T) {
+	hs := protocol.Handshake{ChainID: 1, KeyID: "k", Timestamp: 42, Nonce: 7}
+	base := hs.SigningBytes()
+	hs.ServerNonce = []byte{1, 2, 3}
+	require.NotEqual(t, base, hs.SigningBytes())
+}
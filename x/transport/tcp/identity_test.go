@@ -0,0 +1,108 @@
+package tcp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+func TestIdentityBroadcastAndPinning(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	publisher, err := auth.GenerateSigner("publisher-main")
+	require.NoError(t, err)
+	srv.SetIdentity(publisher)
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-ident")
+	client := NewClient(zap.NewNop(), ClientConfig{
+		Addr: srv.Addr().String(), ChainID: 10,
+		PinnedPublisherKey: publisher.PublicKeyHex(),
+	}, signer)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	require.Eventually(t, func() bool {
+		_, ok := client.PublisherIdentity()
+		return ok
+	}, 2*time.Second, 10*time.Millisecond, "identity announcement never verified")
+
+	ident, _ := client.PublisherIdentity()
+	require.Equal(t, "publisher-main", ident.KeyID)
+	require.Equal(t, publisher.PublicKeyHex(), ident.PublicKey)
+	require.Equal(t, protocol.ProtocolVersion, ident.ProtocolVersion)
+}
+
+func TestIdentityPinMismatchDropsConnection(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	publisher, err := auth.GenerateSigner("publisher-main")
+	require.NoError(t, err)
+	srv.SetIdentity(publisher)
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	// The client pins a key the publisher does not hold.
+	other, err := auth.GenerateSigner("publisher-other")
+	require.NoError(t, err)
+
+	signer := trustedSigner(t, authManager, "seq-pin")
+	client := NewClient(zap.NewNop(), ClientConfig{
+		Addr: srv.Addr().String(), ChainID: 11,
+		PinnedPublisherKey: other.PublicKeyHex(),
+	}, signer)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	require.Eventually(t, func() bool {
+		return client.Send(protocol.TypePong, protocol.Pong{}) != nil
+	}, 2*time.Second, 10*time.Millisecond, "mismatched identity should drop the connection")
+	_, ok := client.PublisherIdentity()
+	require.False(t, ok)
+}
+
+func TestHandleIdentityRejectsForgeries(t *testing.T) {
+	publisher, err := auth.GenerateSigner("publisher-main")
+	require.NoError(t, err)
+	imposter, err := auth.GenerateSigner("imposter")
+	require.NoError(t, err)
+
+	newTestClient := func() *Client {
+		c := NewClient(zap.NewNop(), ClientConfig{}, nil)
+		c.sessionBinding = []byte("session binding")
+		return c
+	}
+	identity := func(signer *auth.Signer, announcedKey, binding string) protocol.Message {
+		ident := protocol.Identity{
+			KeyID: signer.KeyID(), PublicKey: announcedKey,
+			ProtocolVersion: protocol.ProtocolVersion,
+			SessionBinding:  []byte(binding),
+		}
+		ident.Signature = signer.Sign(ident.SigningBytes())
+		msg, err := protocol.NewMessage(protocol.TypeIdentity, ident)
+		require.NoError(t, err)
+		return msg
+	}
+
+	// A replayed announcement from another session is rejected.
+	c := newTestClient()
+	c.handleIdentity(identity(publisher, publisher.PublicKeyHex(), "stale binding"))
+	_, ok := c.PublisherIdentity()
+	require.False(t, ok)
+
+	// An imposter announcing the real key cannot produce its signature.
+	c = newTestClient()
+	c.handleIdentity(identity(imposter, publisher.PublicKeyHex(), "session binding"))
+	_, ok = c.PublisherIdentity()
+	require.False(t, ok)
+
+	// The genuine announcement verifies.
+	c = newTestClient()
+	c.handleIdentity(identity(publisher, publisher.PublicKeyHex(), "session binding"))
+	_, ok = c.PublisherIdentity()
+	require.True(t, ok)
+}
@@ -0,0 +1,80 @@
+package tcp
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/compose-network/publisher/metrics"
+)
+
+// DefaultMaxBufferedBytes bounds reassembly buffering across all
+// connections when no global cap is configured.
+const DefaultMaxBufferedBytes = 256 << 20 // 256 MiB
+
+// Buffer-accounting errors. The per-connection and global limits get
+// distinct errors so operators can tell a single abusive peer from an
+// overloaded server.
+var (
+	ErrConnBufferLimit   = errors.New("tcp: connection reassembly buffer limit exceeded")
+	ErrServerBufferLimit = errors.New("tcp: server reassembly buffer limit exceeded")
+)
+
+// MemoryConfig caps the bytes held in chunk reassembly buffers. The
+// per-frame bufio buffers are small and fixed; reassembly is the one
+// place a peer can make the server hold megabytes per connection, so it
+// is accounted explicitly and submissions past a cap are rejected.
+type MemoryConfig struct {
+	// MaxBufferedBytes caps reassembly bytes across all connections.
+	// Zero means DefaultMaxBufferedBytes.
+	MaxBufferedBytes int `yaml:"MaxBufferedBytes"`
+	// MaxConnBufferedBytes caps reassembly bytes per connection. Zero
+	// means MaxChunkedSize: one in-flight transfer's worth.
+	MaxConnBufferedBytes int `yaml:"MaxConnBufferedBytes"`
+}
+
+// memoryAccountant tracks the server-wide reassembly byte total against
+// the global cap. Per-connection totals live in each reassembler.
+type memoryAccountant struct {
+	maxBytes int
+
+	mu    sync.Mutex
+	total int
+}
+
+func newMemoryAccountant(maxBytes int) *memoryAccountant {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBufferedBytes
+	}
+	return &memoryAccountant{maxBytes: maxBytes}
+}
+
+// reserve accounts size bytes against the global cap, reporting whether
+// they fit.
+func (m *memoryAccountant) reserve(size int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.total+size > m.maxBytes {
+		return false
+	}
+	m.total += size
+	metrics.RecordBufferedBytes(size)
+	return true
+}
+
+// release returns size bytes reserved earlier.
+func (m *memoryAccountant) release(size int) {
+	if size == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.total -= size
+	metrics.RecordBufferedBytes(-size)
+}
+
+// buffered returns the bytes currently reserved.
+func (m *memoryAccountant) buffered() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total
+}
@@ -0,0 +1,80 @@
+package tcp
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReassemblerEnforcesConnBufferCap(t *testing.T) {
+	// Two interleaved 800-byte transfers exceed a 1000-byte connection
+	// cap even though each fits the per-transfer limit on its own.
+	first := splitChunks(bytes.Repeat([]byte{1}, 800), 400)
+	second := splitChunks(bytes.Repeat([]byte{2}, 800), 400)
+
+	r := newReassembler(800, 1000, nil)
+	_, err := r.add(first[0])
+	require.NoError(t, err)
+	_, err = r.add(second[0])
+	require.NoError(t, err)
+	_, err = r.add(second[1])
+	require.ErrorIs(t, err, ErrConnBufferLimit)
+
+	// The rejected transfer was dropped whole, so the first can still
+	// finish.
+	payload, err := r.add(first[1])
+	require.NoError(t, err)
+	require.Len(t, payload, 800)
+}
+
+func TestReassemblerEnforcesServerBufferCap(t *testing.T) {
+	mem := newMemoryAccountant(1000)
+	a := newReassembler(800, 0, mem)
+	b := newReassembler(800, 0, mem)
+
+	chunksA := splitChunks(bytes.Repeat([]byte{1}, 800), 400)
+	chunksB := splitChunks(bytes.Repeat([]byte{2}, 800), 400)
+
+	_, err := a.add(chunksA[0])
+	require.NoError(t, err)
+	_, err = b.add(chunksB[0])
+	require.NoError(t, err)
+	require.Equal(t, 800, mem.buffered())
+
+	// The second connection's next chunk would push the server total past
+	// the cap; its transfer is dropped whole.
+	_, err = b.add(chunksB[1])
+	require.ErrorIs(t, err, ErrServerBufferLimit)
+	require.Equal(t, 400, mem.buffered())
+
+	// Completing the first transfer returns its bytes, making room for a
+	// retry.
+	payload, err := a.add(chunksA[0])
+	require.NoError(t, err)
+	require.Nil(t, payload) // duplicate, ignored
+	payload, err = a.add(chunksA[1])
+	require.NoError(t, err)
+	require.Len(t, payload, 800)
+	require.Zero(t, mem.buffered())
+
+	_, err = b.add(chunksB[0])
+	require.NoError(t, err)
+	require.Equal(t, 400, mem.buffered())
+}
+
+func TestReassemblerReleaseReturnsBufferedBytes(t *testing.T) {
+	mem := newMemoryAccountant(1 << 20)
+	r := newReassembler(0, 0, mem)
+
+	chunks := splitChunks(bytes.Repeat([]byte{3}, 900), 300)
+	_, err := r.add(chunks[0])
+	require.NoError(t, err)
+	_, err = r.add(chunks[1])
+	require.NoError(t, err)
+	require.Equal(t, 600, mem.buffered())
+
+	// Connection teardown frees every half-finished transfer.
+	r.release()
+	require.Zero(t, mem.buffered())
+}
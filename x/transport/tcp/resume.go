@@ -0,0 +1,100 @@
+package tcp
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/compose-network/publisher/types"
+)
+
+// DefaultResumeTTL is how long a session resumption token stays valid.
+const DefaultResumeTTL = 5 * time.Minute
+
+// resumeSession is the server-side state behind one resumption token: the
+// identity the full handshake established, frozen for the token's TTL.
+type resumeSession struct {
+	keyID     string
+	networkID types.NetworkID
+	chainID   types.ChainID
+	expiresAt time.Time
+}
+
+// sessionTable issues and redeems single-use resumption tokens. Single use
+// keeps a captured token from being replayed: the legitimate reconnect
+// consumes it and gets a fresh one in the ack.
+type sessionTable struct {
+	ttl time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]resumeSession
+	// consumed remembers redeemed tokens until their original expiry, so a
+	// replay of a spent token is distinguishable from a token that merely
+	// expired or predates a restart.
+	consumed map[string]time.Time
+}
+
+func newSessionTable(ttl time.Duration) *sessionTable {
+	return &sessionTable{
+		ttl:      ttl,
+		sessions: make(map[string]resumeSession),
+		consumed: make(map[string]time.Time),
+	}
+}
+
+// issue mints a token for an authenticated connection and sweeps expired
+// entries while it holds the lock.
+func (t *sessionTable) issue(keyID string, networkID types.NetworkID, chainID types.ChainID) ([]byte, error) {
+	token := make([]byte, 32)
+	if _, err := cryptorand.Read(token); err != nil {
+		return nil, fmt.Errorf("generate resume token: %w", err)
+	}
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, sess := range t.sessions {
+		if now.After(sess.expiresAt) {
+			delete(t.sessions, key)
+		}
+	}
+	t.sessions[hex.EncodeToString(token)] = resumeSession{
+		keyID:     keyID,
+		networkID: networkID,
+		chainID:   chainID,
+		expiresAt: now.Add(t.ttl),
+	}
+	return token, nil
+}
+
+// redeem consumes a token, returning its session. A token that was already
+// redeemed once fails with errResumeReplay — single use was violated, which
+// only an attacker holding a captured token does. An unknown or expired
+// token fails with errResumeExpired: that is normal operation after the TTL
+// lapses or a restart wipes the table, and the client falls back to the
+// full handshake.
+func (t *sessionTable) redeem(token []byte) (resumeSession, error) {
+	key := hex.EncodeToString(token)
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for k, until := range t.consumed {
+		if now.After(until) {
+			delete(t.consumed, k)
+		}
+	}
+	sess, ok := t.sessions[key]
+	if !ok {
+		if until, spent := t.consumed[key]; spent && now.Before(until) {
+			return resumeSession{}, errResumeReplay
+		}
+		return resumeSession{}, errResumeExpired
+	}
+	delete(t.sessions, key)
+	t.consumed[key] = sess.expiresAt
+	if now.After(sess.expiresAt) {
+		return resumeSession{}, errResumeExpired
+	}
+	return sess, nil
+}
@@ -0,0 +1,125 @@
+package tcp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// rawHandshake runs a legacy one-shot handshake on a fresh connection and
+// returns the codec and the server's ack.
+func rawHandshake(t *testing.T, addr string, signer *auth.Signer, hs protocol.Handshake) (*Codec, protocol.HandshakeAck) {
+	t.Helper()
+	netConn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = netConn.Close() })
+	codec := NewCodec(netConn, DefaultMaxMessageSize)
+
+	if len(hs.ResumeToken) == 0 {
+		hs.Timestamp = time.Now().Unix()
+		hs.Signature = signer.Sign(hs.SigningBytes())
+	}
+	msg, err := protocol.NewMessage(protocol.TypeHandshake, hs)
+	require.NoError(t, err)
+	_, err = codec.WriteMessage(msg)
+	require.NoError(t, err)
+
+	ackMsg, _, err := codec.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, protocol.TypeHandshakeAck, ackMsg.Type)
+	var ack protocol.HandshakeAck
+	require.NoError(t, ackMsg.Decode(&ack))
+	return codec, ack
+}
+
+// TestResumeTokenSkipsSignature establishes a session, then reconnects
+// presenting only the resumption token: no signature, no challenge round
+// trip, and a fresh token in the new ack.
+func TestResumeTokenSkipsSignature(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-resume")
+	_, ack := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), Nonce: 1,
+	})
+	require.True(t, ack.OK)
+	require.NotEmpty(t, ack.ResumeToken)
+	require.Greater(t, ack.ResumeTTLMS, int64(0))
+
+	_, resumed := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), ResumeToken: ack.ResumeToken,
+	})
+	require.True(t, resumed.OK)
+	require.NotEmpty(t, resumed.ResumeToken)
+	require.NotEqual(t, ack.ResumeToken, resumed.ResumeToken)
+}
+
+// TestResumeTokenSingleUse replays a consumed token and expects a refusal.
+func TestResumeTokenSingleUse(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-replay")
+	_, ack := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), Nonce: 1,
+	})
+	require.True(t, ack.OK)
+
+	_, first := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), ResumeToken: ack.ResumeToken,
+	})
+	require.True(t, first.OK)
+
+	_, replay := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), ResumeToken: ack.ResumeToken,
+	})
+	require.False(t, replay.OK)
+}
+
+// TestResumeRespectsRevocation checks that redeeming a token still runs
+// authorization, so a key revoked after the token was issued cannot resume.
+func TestResumeRespectsRevocation(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-revoked")
+	_, ack := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), Nonce: 1,
+	})
+	require.True(t, ack.OK)
+
+	authManager.RevokeKey(signer.KeyID())
+	_, resumed := rawHandshake(t, srv.Addr().String(), signer, protocol.Handshake{
+		ChainID: 4, KeyID: signer.KeyID(), ResumeToken: ack.ResumeToken,
+	})
+	require.False(t, resumed.OK)
+}
+
+// TestClientResumeFallback seeds the client with a bogus token and checks
+// Connect falls back to the full handshake transparently, then reconnects
+// using the real token it was issued.
+func TestClientResumeFallback(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-fallback")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 4}, signer)
+	client.resumeToken = []byte("not a real token")
+	require.NoError(t, client.Connect())
+	require.NotEmpty(t, client.resumeToken)
+
+	require.NoError(t, client.Close())
+	require.NoError(t, client.Connect())
+	defer client.Close()
+}
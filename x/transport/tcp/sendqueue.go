@@ -0,0 +1,89 @@
+package tcp
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// ErrSendQueueFull is returned by Send when the outbound queue is at
+// capacity and the message's type is not coalescable.
+var ErrSendQueueFull = errors.New("tcp: send queue full")
+
+// sendQueue is the client's bounded outbound buffer. Messages keep FIFO
+// order; a coalescable type occupies at most one slot, with a newer message
+// replacing the queued one in place so per-type ordering is preserved.
+type sendQueue struct {
+	max      int
+	coalesce map[string]struct{}
+
+	mu    sync.Mutex
+	items []protocol.Message
+	// ready holds one token while the queue may be non-empty, waking a
+	// blocked pop.
+	ready chan struct{}
+}
+
+func newSendQueue(max int, coalesceTypes []string) *sendQueue {
+	q := &sendQueue{
+		max:      max,
+		coalesce: make(map[string]struct{}, len(coalesceTypes)),
+		ready:    make(chan struct{}, 1),
+	}
+	for _, t := range coalesceTypes {
+		q.coalesce[t] = struct{}{}
+	}
+	return q
+}
+
+// push enqueues a message, coalescing onto an already-queued message of the
+// same type when the type allows it.
+func (q *sendQueue) push(msg protocol.Message) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if _, ok := q.coalesce[msg.Type]; ok {
+		for i := range q.items {
+			if q.items[i].Type == msg.Type {
+				q.items[i] = msg
+				return nil
+			}
+		}
+	}
+	if len(q.items) >= q.max {
+		return ErrSendQueueFull
+	}
+	q.items = append(q.items, msg)
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// pop blocks until a message is available and dequeues it, or until done
+// closes, in which case ok is false.
+func (q *sendQueue) pop(done <-chan struct{}) (msg protocol.Message, ok bool) {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			msg = q.items[0]
+			q.items = q.items[1:]
+			q.mu.Unlock()
+			return msg, true
+		}
+		q.mu.Unlock()
+		select {
+		case <-done:
+			return protocol.Message{}, false
+		case <-q.ready:
+		}
+	}
+}
+
+// len returns the number of queued messages.
+func (q *sendQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
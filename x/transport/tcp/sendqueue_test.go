@@ -0,0 +1,155 @@
+package tcp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// TestQueuedSendSurvivesReconnect queues a message while disconnected and
+// checks the flusher delivers it once the client reconnects.
+func TestQueuedSendSurvivesReconnect(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+
+	got := make(chan protocol.Message, 4)
+	srv.SetHandler(func(_ context.Context, _ *Conn, msg protocol.Message) { got <- msg })
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-queue")
+	client := NewClient(zap.NewNop(), ClientConfig{
+		Addr: srv.Addr().String(), ChainID: 10,
+		SendQueueSize: 8, SendRetryInterval: 5 * time.Millisecond,
+	}, signer)
+
+	// Not connected yet: fire-and-forget accepts the message anyway.
+	require.NoError(t, client.Send(protocol.TypeVote, protocol.Vote{XtID: types.Hash{1}, ChainID: 10, Commit: true}))
+	require.Equal(t, 1, client.PendingSends())
+
+	require.NoError(t, client.Connect())
+	defer client.Close()
+	select {
+	case msg := <-got:
+		require.Equal(t, protocol.TypeVote, msg.Type)
+	case <-time.After(2 * time.Second):
+		t.Fatal("queued message never delivered")
+	}
+
+	// Disconnect, queue another, reconnect: same story.
+	require.NoError(t, client.Close())
+	require.NoError(t, client.Send(protocol.TypeVote, protocol.Vote{XtID: types.Hash{2}, ChainID: 10, Commit: true}))
+	require.NoError(t, client.Connect())
+	select {
+	case msg := <-got:
+		var vote protocol.Vote
+		require.NoError(t, msg.Decode(&vote))
+		require.Equal(t, types.Hash{2}, vote.XtID)
+	case <-time.After(2 * time.Second):
+		t.Fatal("message queued across reconnect never delivered")
+	}
+}
+
+// TestSendQueueCoalescesAndBounds exercises the queue directly: a
+// coalescable type holds one slot with the newest payload, and a full
+// queue refuses non-coalescable messages.
+func TestSendQueueCoalescesAndBounds(t *testing.T) {
+	q := newSendQueue(2, []string{protocol.TypeStartSlot})
+
+	first, err := protocol.NewMessage(protocol.TypeStartSlot, protocol.StartSlot{Slot: 1, Timestamp: 1})
+	require.NoError(t, err)
+	second, err := protocol.NewMessage(protocol.TypeStartSlot, protocol.StartSlot{Slot: 2, Timestamp: 2})
+	require.NoError(t, err)
+	vote, err := protocol.NewMessage(protocol.TypeVote, protocol.Vote{XtID: types.Hash{1}, ChainID: 10})
+	require.NoError(t, err)
+
+	require.NoError(t, q.push(first))
+	require.NoError(t, q.push(vote))
+	// Queue is full, but the newer slot announcement replaces the stale one.
+	require.NoError(t, q.push(second))
+	require.ErrorIs(t, q.push(vote), ErrSendQueueFull)
+	require.Equal(t, 2, q.len())
+
+	done := make(chan struct{})
+	head, ok := q.pop(done)
+	require.True(t, ok)
+	require.Equal(t, protocol.TypeStartSlot, head.Type)
+	var slot protocol.StartSlot
+	require.NoError(t, head.Decode(&slot))
+	require.Equal(t, types.Slot(2), slot.Slot, "coalescing should keep the newest payload")
+	next, ok := q.pop(done)
+	require.True(t, ok)
+	require.Equal(t, protocol.TypeVote, next.Type)
+
+	// An empty queue unblocks pop when done closes.
+	close(done)
+	_, ok = q.pop(done)
+	require.False(t, ok)
+}
+
+// TestCloseStopsQueueFlusher checks the flusher goroutine exits on Close
+// instead of retrying against a dead connection forever; Close waits for
+// it, so a hung Close is the failure mode.
+func TestCloseStopsQueueFlusher(t *testing.T) {
+	client := NewClient(zap.NewNop(), ClientConfig{
+		ChainID: 10, SendQueueSize: 4, SendRetryInterval: time.Millisecond,
+	}, nil)
+	// Never connected: the flusher is stuck retrying this message.
+	require.NoError(t, client.Send(protocol.TypeVote, protocol.Vote{XtID: types.Hash{1}, ChainID: 10}))
+
+	closed := make(chan struct{})
+	go func() {
+		_ = client.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close never returned: queue flusher still running")
+	}
+}
+
+// TestSendQueuePreservesOrder floods the queue while connected and checks
+// messages arrive in submission order.
+func TestSendQueuePreservesOrder(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+
+	var (
+		mu  sync.Mutex
+		ids []byte
+	)
+	srv.SetHandler(func(_ context.Context, _ *Conn, msg protocol.Message) {
+		var vote protocol.Vote
+		require.NoError(t, msg.Decode(&vote))
+		mu.Lock()
+		ids = append(ids, vote.XtID[0])
+		mu.Unlock()
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-order")
+	client := NewClient(zap.NewNop(), ClientConfig{
+		Addr: srv.Addr().String(), ChainID: 10, SendQueueSize: 32,
+	}, signer)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	for i := byte(1); i <= 10; i++ {
+		require.NoError(t, client.Send(protocol.TypeVote, protocol.Vote{XtID: types.Hash{i}, ChainID: 10}))
+	}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(ids) == 10
+	}, 2*time.Second, time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, ids)
+}
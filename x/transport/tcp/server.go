@@ -0,0 +1,912 @@
+package tcp
+
+import (
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/metrics"
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/superblock/slot"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+// HeartbeatConfig tunes the server's active liveness probing.
+type HeartbeatConfig struct {
+	// Interval between pings on an idle connection.
+	Interval time.Duration `yaml:"Interval"`
+	// MissLimit is how many consecutive unanswered pings are tolerated
+	// before the connection is reaped.
+	MissLimit int `yaml:"MissLimit"`
+}
+
+// Validation modes for inbound payload schema checks.
+const (
+	// ValidationWarn logs and counts invalid payloads but still dispatches
+	// them, for rolling the checks out against live traffic.
+	ValidationWarn = "warn"
+	// ValidationEnforce drops invalid payloads before dispatch.
+	ValidationEnforce = "enforce"
+	// ValidationOff disables the checks entirely.
+	ValidationOff = "off"
+)
+
+// Config configures the sequencer-facing TCP server.
+type Config struct {
+	Addr             string          `yaml:"Addr"`
+	MaxMessageSize   int             `yaml:"MaxMessageSize"`
+	HandshakeTimeout time.Duration   `yaml:"HandshakeTimeout"`
+	MaxClockDrift    time.Duration   `yaml:"MaxClockDrift"`
+	Heartbeat        HeartbeatConfig `yaml:"Heartbeat"`
+	Bandwidth        BandwidthConfig `yaml:"Bandwidth"`
+	// Networks lists the hosted networks connections may join; empty means
+	// only the default network.
+	Networks []types.NetworkID `yaml:"Networks"`
+	// MaxChunkedSize bounds a reassembled chunked L2Block payload.
+	MaxChunkedSize int `yaml:"MaxChunkedSize"`
+	// Memory caps the bytes held in reassembly buffers, per connection
+	// and server-wide.
+	Memory MemoryConfig `yaml:"Memory"`
+	// Accept rate-limits new connections and bounds handshake concurrency.
+	Accept AcceptConfig `yaml:"Accept"`
+	// Ban temporarily refuses source IPs with repeated handshake failures.
+	Ban BanConfig `yaml:"Ban"`
+	// ResumeTTL is how long session resumption tokens stay valid.
+	ResumeTTL time.Duration `yaml:"ResumeTTL"`
+	// ValidationMode controls inbound payload schema validation; see
+	// ValidationWarn (the default), ValidationEnforce and ValidationOff.
+	ValidationMode string `yaml:"ValidationMode"`
+	// DispatchQueueSize bounds each priority class of the dispatch queue.
+	// A full class applies back-pressure to the submitting connection.
+	DispatchQueueSize int `yaml:"DispatchQueueSize"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxMessageSize <= 0 {
+		c.MaxMessageSize = DefaultMaxMessageSize
+	}
+	if c.HandshakeTimeout <= 0 {
+		c.HandshakeTimeout = 10 * time.Second
+	}
+	if c.MaxClockDrift <= 0 {
+		c.MaxClockDrift = 30 * time.Second
+	}
+	if c.Heartbeat.Interval <= 0 {
+		c.Heartbeat.Interval = 15 * time.Second
+	}
+	if c.Heartbeat.MissLimit <= 0 {
+		c.Heartbeat.MissLimit = 3
+	}
+	if c.MaxChunkedSize <= 0 {
+		c.MaxChunkedSize = DefaultMaxChunkedSize
+	}
+	if c.Memory.MaxBufferedBytes <= 0 {
+		c.Memory.MaxBufferedBytes = DefaultMaxBufferedBytes
+	}
+	if c.Memory.MaxConnBufferedBytes <= 0 {
+		c.Memory.MaxConnBufferedBytes = c.MaxChunkedSize
+	}
+	if c.Accept.MaxHandshakes <= 0 {
+		c.Accept.MaxHandshakes = 64
+	}
+	if c.Accept.QueueTimeout <= 0 {
+		c.Accept.QueueTimeout = 5 * time.Second
+	}
+	if len(c.Networks) == 0 {
+		c.Networks = []types.NetworkID{types.DefaultNetworkID}
+	}
+	if c.ResumeTTL <= 0 {
+		c.ResumeTTL = DefaultResumeTTL
+	}
+	if c.ValidationMode == "" {
+		c.ValidationMode = ValidationWarn
+	}
+	if c.DispatchQueueSize <= 0 {
+		c.DispatchQueueSize = 256
+	}
+	return c
+}
+
+// Handshake failure sentinels, kept distinct so rejections can be
+// classified for metrics and the ban list.
+var (
+	errClockDrift    = errors.New("handshake timestamp outside allowed clock drift")
+	errNonceReplay   = errors.New("handshake does not echo the challenge nonce")
+	errResumeReplay  = errors.New("resume token already used")
+	errResumeExpired = errors.New("unknown or expired resume token")
+)
+
+// handshakeFailureReason maps a handshake error to its metrics label.
+func handshakeFailureReason(err error) string {
+	switch {
+	case errors.Is(err, auth.ErrUntrustedKey):
+		return "untrusted_key"
+	case errors.Is(err, auth.ErrBadSignature):
+		return "bad_signature"
+	case errors.Is(err, auth.ErrKeyRevoked):
+		return "revoked_key"
+	case errors.Is(err, auth.ErrKeyExpired):
+		return "expired_key"
+	case errors.Is(err, auth.ErrChainDenied):
+		return "chain_denied"
+	case errors.Is(err, errClockDrift):
+		return "clock_drift"
+	case errors.Is(err, errNonceReplay), errors.Is(err, errResumeReplay):
+		return "replay"
+	case errors.Is(err, errResumeExpired):
+		return "resume_expired"
+	default:
+		return "other"
+	}
+}
+
+// banworthyReason reports whether a handshake failure reason counts toward
+// banning its source IP. Read errors ("other") and expired or unknown
+// resume tokens — routine after a TTL lapse or a server restart, and
+// followed by a legitimate full-handshake fallback — do not.
+func banworthyReason(reason string) bool {
+	return reason != "other" && reason != "resume_expired"
+}
+
+// connKey identifies a connection slot: one per chain per hosted network.
+type connKey struct {
+	network types.NetworkID
+	chain   types.ChainID
+}
+
+// Handler receives every authenticated, non-heartbeat inbound message. ctx
+// is the connection's context: it is canceled when the peer disconnects,
+// so handlers should thread it into store and L1 calls rather than using a
+// background context.
+type Handler func(ctx context.Context, conn *Conn, msg protocol.Message)
+
+// DisconnectFunc is notified when an authenticated connection ends, with a
+// human-readable reason. The coordinator uses it to track chain liveness.
+type DisconnectFunc func(networkID types.NetworkID, chainID types.ChainID, reason string)
+
+// Server accepts and authenticates sequencer connections and dispatches
+// their messages.
+type Server struct {
+	logger       *zap.Logger
+	cfg          Config
+	auth         *auth.Manager
+	handler      Handler
+	onDisconnect DisconnectFunc
+	identity     *auth.Signer
+	bandwidth    *bandwidthAccountant
+	memory       *memoryAccountant
+	acceptRate   *acceptLimiter
+	bans         *banList
+	sessions     *sessionTable
+
+	// handshakeSlots bounds concurrent handshakes: signature verification
+	// is the expensive part of a reconnect storm.
+	handshakeSlots chan struct{}
+	validator      protocol.Validator
+
+	mu         sync.RWMutex
+	conns      map[connKey]*Conn
+	slotParams map[types.NetworkID]protocol.SlotParams
+
+	// Two-class dispatch queue: the dispatcher drains urgent ahead of bulk,
+	// so votes and blocks near the seal cutover are not stuck behind a
+	// backlog of submissions.
+	urgentCh chan dispatchItem
+	bulkCh   chan dispatchItem
+
+	// configSeq stamps outbound ConfigUpdate pushes so sequencers can
+	// discard stale redeliveries.
+	configSeq atomic.Uint64
+
+	ln     net.Listener
+	wg     sync.WaitGroup
+	stopCh chan struct{}
+}
+
+// dispatchItem is one inbound message waiting for the handler.
+type dispatchItem struct {
+	conn *Conn
+	msg  protocol.Message
+}
+
+// NewServer creates a server; wire a Handler and DisconnectFunc before
+// calling Start.
+func NewServer(logger *zap.Logger, cfg Config, authManager *auth.Manager) *Server {
+	cfg = cfg.withDefaults()
+	var validator protocol.Validator
+	if cfg.ValidationMode != ValidationOff {
+		validator = protocol.BasicValidator{}
+	}
+	return &Server{
+		logger:     logger.Named("tcp"),
+		cfg:        cfg,
+		auth:       authManager,
+		bandwidth:  newBandwidthAccountant(cfg.Bandwidth),
+		memory:     newMemoryAccountant(cfg.Memory.MaxBufferedBytes),
+		acceptRate: newAcceptLimiter(cfg.Accept.RatePerSec, cfg.Accept.Burst),
+		bans:       newBanList(cfg.Ban),
+
+		handshakeSlots: make(chan struct{}, cfg.Accept.MaxHandshakes),
+		sessions:       newSessionTable(cfg.ResumeTTL),
+		validator:      validator,
+		urgentCh:       make(chan dispatchItem, cfg.DispatchQueueSize),
+		bulkCh:         make(chan dispatchItem, cfg.DispatchQueueSize),
+		conns:          make(map[connKey]*Conn),
+		slotParams:     make(map[types.NetworkID]protocol.SlotParams),
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// SetHandler installs the message handler. Must be called before Start.
+func (s *Server) SetHandler(handler Handler) {
+	s.handler = handler
+}
+
+// SetDisconnectHandler installs the disconnect callback. Must be called
+// before Start.
+func (s *Server) SetDisconnectHandler(fn DisconnectFunc) {
+	s.onDisconnect = fn
+}
+
+// SetIdentity installs the publisher's signing key. When set, every
+// authenticated connection receives a signed Identity announcement right
+// after the handshake ack, letting clients pin the publisher key. Must be
+// called before Start.
+func (s *Server) SetIdentity(signer *auth.Signer) {
+	s.identity = signer
+}
+
+// SetSlotParams registers the slot timing advertised to a network's
+// sequencers in the handshake ack, letting a misconfigured peer refuse the
+// connection instead of silently misaligning. Must be called before Start.
+func (s *Server) SetSlotParams(networkID types.NetworkID, cfg slot.Config) {
+	if networkID == "" {
+		networkID = types.DefaultNetworkID
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.slotParams[networkID] = wireSlotParams(cfg)
+}
+
+// wireSlotParams converts slot timing to its wire representation.
+func wireSlotParams(cfg slot.Config) protocol.SlotParams {
+	return protocol.SlotParams{
+		GenesisTime:    cfg.GenesisTime.Unix(),
+		SlotDurationMS: cfg.Duration.Milliseconds(),
+		SealCutoverMS:  cfg.SealCutover.Milliseconds(),
+	}
+}
+
+// Start binds the listener and begins accepting connections.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	s.ln = ln
+	s.logger.Info("tcp server listening", zap.String("addr", ln.Addr().String()))
+	s.wg.Add(2)
+	go s.acceptLoop()
+	go s.dispatchLoop()
+	return nil
+}
+
+// Addr returns the bound listener address.
+func (s *Server) Addr() net.Addr {
+	return s.ln.Addr()
+}
+
+// Stop closes the listener and all connections and waits for their loops.
+func (s *Server) Stop() {
+	close(s.stopCh)
+	if s.ln != nil {
+		_ = s.ln.Close()
+	}
+	s.mu.Lock()
+	for _, conn := range s.conns {
+		conn.close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+}
+
+func (s *Server) acceptLoop() {
+	defer s.wg.Done()
+	for {
+		netConn, err := s.ln.Accept()
+		if err != nil {
+			select {
+			case <-s.stopCh:
+				return
+			default:
+				s.logger.Warn("accept failed", zap.Error(err))
+				continue
+			}
+		}
+		if !s.acceptRate.allow() {
+			metrics.RecordAcceptRejected("rate")
+			_ = netConn.Close()
+			continue
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(netConn)
+		}()
+	}
+}
+
+func (s *Server) handleConn(netConn net.Conn) {
+	ip := remoteIP(netConn.RemoteAddr())
+	if s.bans.banned(ip) {
+		metrics.RecordAcceptRejected("banned")
+		_ = netConn.Close()
+		return
+	}
+	// Wait for a handshake worker slot; a connection that cannot get one
+	// within the queue timeout is dropped rather than queued unboundedly.
+	select {
+	case s.handshakeSlots <- struct{}{}:
+	case <-time.After(s.cfg.Accept.QueueTimeout):
+		metrics.RecordAcceptRejected("handshake_queue")
+		s.logger.Warn("connection dropped: handshake queue timeout",
+			zap.String("remote", netConn.RemoteAddr().String()))
+		_ = netConn.Close()
+		return
+	case <-s.stopCh:
+		_ = netConn.Close()
+		return
+	}
+	conn, err := s.handshake(netConn)
+	<-s.handshakeSlots
+	if err != nil {
+		reason := handshakeFailureReason(err)
+		metrics.RecordHandshakeFailure(reason)
+		s.logger.Debug("handshake rejected",
+			zap.String("remote", netConn.RemoteAddr().String()),
+			zap.String("reason", reason), zap.Error(err))
+		// Only classified auth failures feed the ban list; read errors
+		// from flaky-but-honest peers should not get them locked out.
+		if banworthyReason(reason) && s.bans.recordFailure(ip) {
+			s.logger.Warn("source IP banned after repeated handshake failures",
+				zap.String("ip", ip))
+		}
+		_ = netConn.Close()
+		return
+	}
+	s.register(conn)
+	s.sendIdentity(conn)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.heartbeatLoop(conn)
+	}()
+
+	s.readLoop(conn)
+}
+
+// sendIdentity announces the publisher's signing identity on a fresh
+// connection, bound to its handshake so the announcement cannot be
+// replayed across sessions.
+func (s *Server) sendIdentity(conn *Conn) {
+	if s.identity == nil {
+		return
+	}
+	ident := protocol.Identity{
+		KeyID:           s.identity.KeyID(),
+		PublicKey:       s.identity.PublicKeyHex(),
+		NetworkID:       conn.networkID,
+		ProtocolVersion: protocol.ProtocolVersion,
+		SessionBinding:  conn.sessionBinding,
+	}
+	ident.Signature = s.identity.Sign(ident.SigningBytes())
+	if err := conn.Send(protocol.TypeIdentity, ident); err != nil {
+		s.logger.Warn("failed to send identity announcement",
+			zap.Uint64("chain_id", uint64(conn.chainID)), zap.Error(err))
+	}
+}
+
+func (s *Server) handshake(netConn net.Conn) (*Conn, error) {
+	_ = netConn.SetDeadline(time.Now().Add(s.cfg.HandshakeTimeout))
+	codec := NewCodec(netConn, s.cfg.MaxMessageSize)
+
+	msg, _, err := codec.ReadMessage()
+	if err != nil {
+		return nil, fmt.Errorf("read handshake: %w", err)
+	}
+	if msg.Type != protocol.TypeHandshake {
+		return nil, fmt.Errorf("expected handshake, got %q", msg.Type)
+	}
+	var hs protocol.Handshake
+	if err := msg.Decode(&hs); err != nil {
+		return nil, err
+	}
+
+	var (
+		ackSlots  *protocol.SlotParams
+		ackResume []byte
+	)
+	ack := func(err error) error {
+		payload := protocol.HandshakeAck{OK: err == nil, MaxMessageSize: s.cfg.MaxMessageSize, Slots: ackSlots}
+		if err != nil {
+			payload.Error = err.Error()
+		}
+		if len(ackResume) > 0 {
+			payload.ResumeToken = ackResume
+			payload.ResumeTTLMS = s.cfg.ResumeTTL.Milliseconds()
+		}
+		ackMsg, mErr := protocol.NewMessage(protocol.TypeHandshakeAck, payload)
+		if mErr != nil {
+			return mErr
+		}
+		_, wErr := codec.WriteMessage(ackMsg)
+		return wErr
+	}
+
+	// accept finishes an authenticated handshake: it advertises slot
+	// timing, mints the next resumption token and builds the connection.
+	accept := func(keyID string, networkID types.NetworkID, chainID types.ChainID) (*Conn, error) {
+		s.mu.RLock()
+		if params, ok := s.slotParams[networkID]; ok {
+			ackSlots = &params
+		}
+		s.mu.RUnlock()
+		if token, err := s.sessions.issue(keyID, networkID, chainID); err == nil {
+			ackResume = token
+		}
+		if err := ack(nil); err != nil {
+			return nil, fmt.Errorf("write handshake ack: %w", err)
+		}
+		_ = netConn.SetDeadline(time.Time{})
+		binding := hs.Signature
+		if len(hs.ResumeToken) > 0 {
+			binding = hs.ResumeToken
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		conn := &Conn{
+			chainID:     chainID,
+			networkID:   networkID,
+			keyID:       keyID,
+			netConn:     netConn,
+			codec:       codec,
+			ctx:         ctx,
+			cancel:      cancel,
+			reassembler: newReassembler(s.cfg.MaxChunkedSize, s.cfg.Memory.MaxConnBufferedBytes, s.memory),
+
+			sessionBinding:  binding,
+			disconnectAcked: make(chan struct{}),
+		}
+		conn.touch()
+		return conn, nil
+	}
+
+	// Session resumption: a valid single-use token re-establishes the
+	// identity a recent signature handshake proved.
+	if len(hs.ResumeToken) > 0 {
+		networkID := hs.NetworkID
+		if networkID == "" {
+			networkID = types.DefaultNetworkID
+		}
+		sess, err := s.sessions.redeem(hs.ResumeToken)
+		if err == nil && (sess.chainID != hs.ChainID || sess.networkID != networkID) {
+			// A valid token presented with the wrong identity is as
+			// suspicious as reusing a spent one.
+			err = errResumeReplay
+		}
+		if err != nil {
+			_ = ack(err)
+			return nil, err
+		}
+		// Revocations and metadata changes since the token was issued
+		// still apply.
+		if err := s.auth.Authorize(sess.keyID, hs.ChainID); err != nil {
+			_ = ack(err)
+			return nil, err
+		}
+		return accept(sess.keyID, networkID, hs.ChainID)
+	}
+
+	if hs.Version >= protocol.HandshakeVersionChallenge {
+		// Challenge step: issue a nonce the client must cover with its
+		// signature, so handshake signatures cannot be precomputed.
+		nonce := make([]byte, 32)
+		if _, err := cryptorand.Read(nonce); err != nil {
+			return nil, fmt.Errorf("generate challenge nonce: %w", err)
+		}
+		chMsg, err := protocol.NewMessage(protocol.TypeHandshakeChallenge, protocol.HandshakeChallenge{ServerNonce: nonce})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := codec.WriteMessage(chMsg); err != nil {
+			return nil, fmt.Errorf("write handshake challenge: %w", err)
+		}
+		msg, _, err := codec.ReadMessage()
+		if err != nil {
+			return nil, fmt.Errorf("read challenge response: %w", err)
+		}
+		if msg.Type != protocol.TypeHandshake {
+			return nil, fmt.Errorf("expected challenge response handshake, got %q", msg.Type)
+		}
+		if err := msg.Decode(&hs); err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(hs.ServerNonce, nonce) {
+			_ = ack(errNonceReplay)
+			return nil, errNonceReplay
+		}
+	}
+
+	if drift := time.Since(time.Unix(hs.Timestamp, 0)); drift > s.cfg.MaxClockDrift || drift < -s.cfg.MaxClockDrift {
+		_ = ack(errClockDrift)
+		return nil, errClockDrift
+	}
+	// Normalize after verification: the signature covers the network ID
+	// exactly as the client sent it.
+	networkID := hs.NetworkID
+	if networkID == "" {
+		networkID = types.DefaultNetworkID
+	}
+	if !s.hostsNetwork(networkID) {
+		err := fmt.Errorf("unknown network %q", networkID)
+		_ = ack(err)
+		return nil, err
+	}
+	if scheme, err := s.auth.SchemeOf(hs.KeyID); err == nil {
+		claimed := hs.KeyScheme
+		if claimed == "" {
+			claimed = auth.SchemeSecp256k1
+		}
+		if claimed != scheme {
+			err := fmt.Errorf("key %s is registered for scheme %q, not %q", hs.KeyID, scheme, claimed)
+			_ = ack(err)
+			return nil, err
+		}
+	}
+	if err := s.auth.Verify(hs.KeyID, hs.SigningBytes(), hs.Signature); err != nil {
+		_ = ack(err)
+		return nil, err
+	}
+	if err := s.auth.Authorize(hs.KeyID, hs.ChainID); err != nil {
+		_ = ack(err)
+		return nil, err
+	}
+	return accept(hs.KeyID, networkID, hs.ChainID)
+}
+
+// HandshakeFailuresByIP returns the cumulative handshake failure count
+// per source IP, for operator inspection alongside the per-reason
+// metrics.
+func (s *Server) HandshakeFailuresByIP() map[string]int {
+	return s.bans.failuresByIP()
+}
+
+// hostsNetwork reports whether the server serves the named network.
+func (s *Server) hostsNetwork(networkID types.NetworkID) bool {
+	for _, id := range s.cfg.Networks {
+		if id == networkID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) register(conn *Conn) {
+	key := connKey{network: conn.networkID, chain: conn.chainID}
+	s.mu.Lock()
+	prev := s.conns[key]
+	s.conns[key] = conn
+	s.mu.Unlock()
+	if prev != nil {
+		s.logger.Info("replacing existing connection",
+			zap.String("network_id", string(conn.networkID)),
+			zap.Uint64("chain_id", uint64(conn.chainID)))
+		// The grace wait must not block registration of the new connection.
+		go prev.CloseWithReason("replaced by new connection", disconnectAckWait)
+	}
+	s.logger.Info("sequencer connected",
+		zap.String("network_id", string(conn.networkID)),
+		zap.Uint64("chain_id", uint64(conn.chainID)),
+		zap.String("key_id", conn.keyID),
+		zap.String("remote", conn.RemoteAddr().String()))
+}
+
+func (s *Server) unregister(conn *Conn, reason string) {
+	conn.close()
+	key := connKey{network: conn.networkID, chain: conn.chainID}
+	s.mu.Lock()
+	current := s.conns[key]
+	replaced := current != conn
+	if !replaced {
+		delete(s.conns, key)
+	}
+	s.mu.Unlock()
+	if replaced {
+		// A newer connection took over; no liveness change to report.
+		return
+	}
+	s.logger.Info("sequencer disconnected",
+		zap.String("network_id", string(conn.networkID)),
+		zap.Uint64("chain_id", uint64(conn.chainID)), zap.String("reason", reason))
+	if s.onDisconnect != nil {
+		s.onDisconnect(conn.networkID, conn.chainID, reason)
+	}
+}
+
+func (s *Server) readLoop(conn *Conn) {
+	for {
+		msg, size, err := conn.codec.ReadMessage()
+		if err != nil {
+			s.unregister(conn, disconnectReason(conn, err))
+			return
+		}
+		conn.touch()
+		metrics.RecordMessageReceived(uint64(conn.chainID), msg.Type)
+		metrics.RecordBytesReceived(uint64(conn.chainID), msg.Type, size)
+
+		if !isControlType(msg.Type) && !s.auth.PayloadAllowed(conn.keyID, msg.Type) {
+			s.logger.Warn("message rejected: payload type not allowed for key",
+				zap.String("key_id", conn.keyID),
+				zap.String("type", msg.Type))
+			continue
+		}
+
+		if !s.validateMessage(conn, msg) {
+			continue
+		}
+
+		if (msg.Type == protocol.TypeL2Block || msg.Type == protocol.TypeL2BlockChunk) && !s.bandwidth.allow(conn.chainID, size) {
+			metrics.RecordQuotaExceeded(uint64(conn.chainID))
+			s.logger.Warn("l2 block rejected: byte quota exhausted",
+				zap.Uint64("chain_id", uint64(conn.chainID)),
+				zap.Int("size", size))
+			continue
+		}
+
+		switch msg.Type {
+		case protocol.TypePing:
+			var ping protocol.Ping
+			if err := msg.Decode(&ping); err == nil {
+				_ = conn.Send(protocol.TypePong, protocol.Pong{Seq: ping.Seq, SentAt: ping.SentAt})
+			}
+		case protocol.TypePong:
+			var pong protocol.Pong
+			if err := msg.Decode(&pong); err == nil {
+				conn.rttNanos.Store(time.Now().UnixNano() - pong.SentAt)
+			}
+		case protocol.TypeDisconnect:
+			// The peer is closing and wants the reason on record; confirm
+			// receipt so its grace wait does not run out the clock.
+			var d protocol.Disconnect
+			if err := msg.Decode(&d); err == nil && d.Reason != "" {
+				conn.setCloseReason("peer disconnect: " + d.Reason)
+			} else {
+				conn.setCloseReason("peer disconnect")
+			}
+			_ = conn.Send(protocol.TypeDisconnectAck, protocol.DisconnectAck{})
+		case protocol.TypeDisconnectAck:
+			conn.ackDisconnect()
+		case protocol.TypeL2BlockChunk:
+			var chunk protocol.Chunk
+			if err := msg.Decode(&chunk); err != nil {
+				s.logger.Warn("bad l2 block chunk", zap.Uint64("chain_id", uint64(conn.chainID)), zap.Error(err))
+				continue
+			}
+			payload, err := conn.reassembler.add(chunk)
+			if err != nil {
+				switch {
+				case errors.Is(err, ErrConnBufferLimit):
+					metrics.RecordBufferRejected(uint64(conn.chainID), "connection")
+				case errors.Is(err, ErrServerBufferLimit):
+					metrics.RecordBufferRejected(uint64(conn.chainID), "server")
+				}
+				s.logger.Warn("chunked transfer failed",
+					zap.Uint64("chain_id", uint64(conn.chainID)),
+					zap.Uint64("transfer_id", chunk.TransferID),
+					zap.Error(err))
+				continue
+			}
+			if payload != nil && s.handler != nil {
+				full := protocol.Message{Type: protocol.TypeL2Block, Payload: payload}
+				// Chunked blocks are validated once reassembled; the chunk
+				// frames themselves carry no schema.
+				if !s.validateMessage(conn, full) {
+					continue
+				}
+				s.enqueue(conn, full)
+			}
+		default:
+			if s.handler != nil {
+				s.enqueue(conn, msg)
+			}
+		}
+	}
+}
+
+// enqueue hands a message to the dispatcher in its priority class. A full
+// class blocks the connection's read loop — back-pressure on the sender —
+// rather than dropping the message.
+func (s *Server) enqueue(conn *Conn, msg protocol.Message) {
+	ch := s.bulkCh
+	if protocol.PriorityOf(msg.Type) == protocol.PriorityUrgent {
+		ch = s.urgentCh
+	}
+	select {
+	case ch <- dispatchItem{conn: conn, msg: msg}:
+	case <-s.stopCh:
+	}
+}
+
+// dispatchLoop drains the dispatch queue, always preferring urgent
+// messages: bulk traffic is only handled while no vote or L2 block waits.
+func (s *Server) dispatchLoop() {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case item := <-s.urgentCh:
+			s.handler(item.conn.ctx, item.conn, item.msg)
+		default:
+			select {
+			case <-s.stopCh:
+				return
+			case item := <-s.urgentCh:
+				s.handler(item.conn.ctx, item.conn, item.msg)
+			case item := <-s.bulkCh:
+				s.handler(item.conn.ctx, item.conn, item.msg)
+			}
+		}
+	}
+}
+
+// validateMessage runs the configured schema validator and reports whether
+// the message should be dispatched. In warn mode failures are logged and
+// counted but still dispatched; in enforce mode they are dropped.
+func (s *Server) validateMessage(conn *Conn, msg protocol.Message) bool {
+	if s.validator == nil {
+		return true
+	}
+	err := s.validator.Validate(msg)
+	if err == nil {
+		return true
+	}
+	metrics.RecordInvalidMessage(uint64(conn.chainID), msg.Type)
+	s.logger.Warn("message failed schema validation",
+		zap.Uint64("chain_id", uint64(conn.chainID)),
+		zap.String("type", msg.Type),
+		zap.Bool("enforced", s.cfg.ValidationMode == ValidationEnforce),
+		zap.Error(err))
+	return s.cfg.ValidationMode != ValidationEnforce
+}
+
+// heartbeatLoop pings idle connections and reaps those that miss too many
+// pongs in a row.
+func (s *Server) heartbeatLoop(conn *Conn) {
+	ticker := time.NewTicker(s.cfg.Heartbeat.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+			if s.connOf(conn.networkID, conn.chainID) != conn {
+				return
+			}
+			idle := time.Since(time.Unix(0, conn.lastRead.Load()))
+			if idle < s.cfg.Heartbeat.Interval {
+				continue
+			}
+			if int(conn.missedPongs.Load()) >= s.cfg.Heartbeat.MissLimit {
+				s.logger.Warn("reaping dead connection",
+					zap.Uint64("chain_id", uint64(conn.chainID)),
+					zap.Duration("idle", idle))
+				conn.setCloseReason("heartbeat timeout")
+				conn.close() // readLoop unblocks and unregisters
+				return
+			}
+			ping := protocol.Ping{Seq: conn.pingSeq.Add(1), SentAt: time.Now().UnixNano()}
+			conn.missedPongs.Add(1)
+			if err := conn.Send(protocol.TypePing, ping); err != nil {
+				conn.setCloseReason("heartbeat write failed")
+				conn.close()
+				return
+			}
+		}
+	}
+}
+
+func (s *Server) connOf(networkID types.NetworkID, chainID types.ChainID) *Conn {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.conns[connKey{network: networkID, chain: chainID}]
+}
+
+// SendTo sends a message to the chain's connection on the given network.
+func (s *Server) SendTo(networkID types.NetworkID, chainID types.ChainID, msgType string, payload any) error {
+	conn := s.connOf(networkID, chainID)
+	if conn == nil {
+		return fmt.Errorf("%w: chain %d on network %q", ErrChainNotConnected, chainID, networkID)
+	}
+	return conn.Send(msgType, payload)
+}
+
+// Broadcast sends a message to every sequencer of one network.
+func (s *Server) Broadcast(networkID types.NetworkID, msgType string, payload any) {
+	s.mu.RLock()
+	conns := make([]*Conn, 0, len(s.conns))
+	for key, conn := range s.conns {
+		if key.network == networkID {
+			conns = append(conns, conn)
+		}
+	}
+	s.mu.RUnlock()
+	for _, conn := range conns {
+		if err := conn.Send(msgType, payload); err != nil {
+			s.logger.Debug("broadcast send failed",
+				zap.Uint64("chain_id", uint64(conn.chainID)), zap.Error(err))
+		}
+	}
+}
+
+// PushConfigUpdate broadcasts a configuration change to every sequencer on
+// the network. The update's Seq is stamped here; callers leave it zero.
+func (s *Server) PushConfigUpdate(networkID types.NetworkID, update protocol.ConfigUpdate) {
+	update.Seq = s.configSeq.Add(1)
+	s.Broadcast(networkID, protocol.TypeConfigUpdate, update)
+}
+
+// ActiveConnections returns the number of authenticated connections.
+func (s *Server) ActiveConnections() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.conns)
+}
+
+// BandwidthUsed returns the L2Block bytes the chain has submitted in the
+// current quota window.
+func (s *Server) BandwidthUsed(chainID types.ChainID) uint64 {
+	return s.bandwidth.used(chainID)
+}
+
+// ConnectedChains lists the chains of one network with a live connection.
+func (s *Server) ConnectedChains(networkID types.NetworkID) []types.ChainID {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	chains := make([]types.ChainID, 0, len(s.conns))
+	for key := range s.conns {
+		if key.network == networkID {
+			chains = append(chains, key.chain)
+		}
+	}
+	return chains
+}
+
+// isControlType reports whether the message is transport-level plumbing
+// every authenticated peer may send regardless of its payload allowlist.
+func isControlType(msgType string) bool {
+	switch msgType {
+	case protocol.TypePing, protocol.TypePong, protocol.TypeDisconnect, protocol.TypeDisconnectAck:
+		return true
+	}
+	return false
+}
+
+func disconnectReason(conn *Conn, err error) string {
+	if reason := conn.closeReason(); reason != "" {
+		return reason
+	}
+	if errors.Is(err, net.ErrClosed) {
+		return "connection closed"
+	}
+	return fmt.Sprintf("read failed: %v", err)
+}
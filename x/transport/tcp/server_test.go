@@ -0,0 +1,394 @@
+package tcp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/compose-network/publisher/types"
+	"github.com/compose-network/publisher/x/auth"
+	"github.com/compose-network/publisher/x/superblock/slot"
+	"github.com/compose-network/publisher/x/transport/protocol"
+)
+
+func startTestServer(t *testing.T, cfg Config) (*Server, *auth.Manager) {
+	t.Helper()
+	cfg.Addr = "127.0.0.1:0"
+	authManager := auth.NewManager()
+	srv := NewServer(zap.NewNop(), cfg, authManager)
+	return srv, authManager
+}
+
+func trustedSigner(t *testing.T, authManager *auth.Manager, keyID string) *auth.Signer {
+	t.Helper()
+	signer, err := auth.GenerateSigner(keyID)
+	require.NoError(t, err)
+	require.NoError(t, authManager.AddTrustedKey(keyID, signer.PublicKeyHex()))
+	return signer
+}
+
+func TestHandshakeAndMessageRoundtrip(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+
+	var (
+		mu       sync.Mutex
+		received []protocol.Message
+	)
+	srv.SetHandler(func(_ context.Context, conn *Conn, msg protocol.Message) {
+		mu.Lock()
+		received = append(received, msg)
+		mu.Unlock()
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-10")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+
+	var clientGot []protocol.Message
+	var clientMu sync.Mutex
+	client.SetHandler(func(msg protocol.Message) {
+		clientMu.Lock()
+		clientGot = append(clientGot, msg)
+		clientMu.Unlock()
+	})
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	block := types.L2Block{ChainID: 10, Number: 1, Slot: 2}
+	require.NoError(t, client.Send(protocol.TypeL2Block, block))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) == 1
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	require.Equal(t, protocol.TypeL2Block, received[0].Type)
+	var got types.L2Block
+	require.NoError(t, received[0].Decode(&got))
+	require.Equal(t, uint64(1), got.Number)
+	mu.Unlock()
+
+	require.Equal(t, 1, srv.ActiveConnections())
+	require.NoError(t, srv.SendTo(types.DefaultNetworkID, 10, protocol.TypeStartSlot, protocol.StartSlot{Slot: 3}))
+	require.Eventually(t, func() bool {
+		clientMu.Lock()
+		defer clientMu.Unlock()
+		return len(clientGot) == 1 && clientGot[0].Type == protocol.TypeStartSlot
+	}, time.Second, time.Millisecond)
+}
+
+// TestMultiNetworkRouting hosts two networks and checks that the same chain
+// ID can connect on each without evicting the other, and that sends route
+// by network.
+func TestMultiNetworkRouting(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{
+		Networks: []types.NetworkID{"alpha", "beta"},
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	connect := func(keyID string, network types.NetworkID) (*Client, chan protocol.Message) {
+		signer := trustedSigner(t, authManager, keyID)
+		client := NewClient(zap.NewNop(), ClientConfig{
+			Addr: srv.Addr().String(), ChainID: 10, NetworkID: network,
+		}, signer)
+		got := make(chan protocol.Message, 1)
+		client.SetHandler(func(msg protocol.Message) { got <- msg })
+		require.NoError(t, client.Connect())
+		t.Cleanup(func() { client.Close() })
+		return client, got
+	}
+
+	_, alphaGot := connect("seq-alpha", "alpha")
+	_, betaGot := connect("seq-beta", "beta")
+	require.Equal(t, 2, srv.ActiveConnections())
+	require.Equal(t, []types.ChainID{10}, srv.ConnectedChains("alpha"))
+	require.Equal(t, []types.ChainID{10}, srv.ConnectedChains("beta"))
+
+	require.NoError(t, srv.SendTo("alpha", 10, protocol.TypeStartSlot, protocol.StartSlot{Slot: 7}))
+	select {
+	case msg := <-alphaGot:
+		require.Equal(t, protocol.TypeStartSlot, msg.Type)
+	case <-time.After(time.Second):
+		t.Fatal("alpha never received the slot announcement")
+	}
+	select {
+	case msg := <-betaGot:
+		t.Fatalf("beta received %s meant for alpha", msg.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The default network is not hosted here.
+	signer := trustedSigner(t, authManager, "seq-default")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.ErrorContains(t, client.Connect(), "unknown network")
+}
+
+func TestHandshakeRejectsUntrustedKey(t *testing.T) {
+	srv, _ := startTestServer(t, Config{})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer, err := auth.GenerateSigner("rogue")
+	require.NoError(t, err)
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.Error(t, client.Connect())
+	require.Equal(t, 0, srv.ActiveConnections())
+}
+
+// TestHeartbeatReapsDeadConnection connects a raw socket that completes the
+// handshake but never answers pings; the server must reap it and emit a
+// disconnect event.
+func TestHeartbeatReapsDeadConnection(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{
+		Heartbeat: HeartbeatConfig{Interval: 10 * time.Millisecond, MissLimit: 2},
+	})
+
+	disconnects := make(chan string, 1)
+	srv.SetDisconnectHandler(func(_ types.NetworkID, chainID types.ChainID, reason string) {
+		require.Equal(t, types.ChainID(42), chainID)
+		disconnects <- reason
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-42")
+
+	netConn, err := net.Dial("tcp", srv.Addr().String())
+	require.NoError(t, err)
+	defer netConn.Close()
+	codec := NewCodec(netConn, 0)
+
+	hs := protocol.Handshake{ChainID: 42, KeyID: "seq-42", Timestamp: time.Now().Unix(), Nonce: 1}
+	hs.Signature = signer.Sign(hs.SigningBytes())
+	msg, err := protocol.NewMessage(protocol.TypeHandshake, hs)
+	require.NoError(t, err)
+	_, err = codec.WriteMessage(msg)
+	require.NoError(t, err)
+
+	ackMsg, _, err := codec.ReadMessage()
+	require.NoError(t, err)
+	var ack protocol.HandshakeAck
+	require.NoError(t, ackMsg.Decode(&ack))
+	require.True(t, ack.OK)
+	require.Equal(t, 1, srv.ActiveConnections())
+
+	// Never answer pings; the server should reap us.
+	select {
+	case reason := <-disconnects:
+		require.Equal(t, "heartbeat timeout", reason)
+	case <-time.After(2 * time.Second):
+		t.Fatal("connection was not reaped")
+	}
+	require.Equal(t, 0, srv.ActiveConnections())
+}
+
+func TestHandshakeSlotParamsChecked(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+	slots := slot.Config{
+		GenesisTime: time.Unix(1700000000, 0),
+		Duration:    12 * time.Second,
+		SealCutover: 8 * time.Second,
+	}
+	srv.SetSlotParams(types.DefaultNetworkID, slots)
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+	signer := trustedSigner(t, authManager, "seq-10")
+
+	// Matching parameters connect.
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10, Slots: slots}, signer)
+	require.NoError(t, client.Connect())
+	require.NoError(t, client.Close())
+
+	// A sequencer with nothing configured is not blocked.
+	client = NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.NoError(t, client.Connect())
+	require.NoError(t, client.Close())
+
+	// A different genesis is refused before any batch exchange.
+	wrong := slots
+	wrong.GenesisTime = slots.GenesisTime.Add(time.Second)
+	client = NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10, Slots: wrong}, signer)
+	err := client.Connect()
+	require.ErrorIs(t, err, ErrSlotParamsMismatch)
+}
+
+// TestConnContextCanceledOnDisconnect checks the per-connection context the
+// handler receives is canceled when the peer goes away, so downstream work
+// does not outlive the connection.
+func TestConnContextCanceledOnDisconnect(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+
+	ctxCh := make(chan context.Context, 1)
+	srv.SetHandler(func(ctx context.Context, _ *Conn, _ protocol.Message) {
+		select {
+		case ctxCh <- ctx:
+		default:
+		}
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-ctx")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.NoError(t, client.Connect())
+	require.NoError(t, client.Send(protocol.TypeVote, protocol.Vote{ChainID: 10}))
+
+	var ctx context.Context
+	select {
+	case ctx = <-ctxCh:
+	case <-time.After(time.Second):
+		t.Fatal("handler never ran")
+	}
+	require.NoError(t, ctx.Err())
+
+	require.NoError(t, client.Close())
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("connection context not canceled on disconnect")
+	}
+}
+
+// TestValidationEnforceDropsInvalid runs the server in enforce mode and
+// checks a schema-invalid vote never reaches the handler while a valid one
+// does.
+func TestValidationEnforceDropsInvalid(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{ValidationMode: ValidationEnforce})
+
+	got := make(chan protocol.Message, 2)
+	srv.SetHandler(func(_ context.Context, _ *Conn, msg protocol.Message) { got <- msg })
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-validate")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	require.NoError(t, client.Send(protocol.TypeVote, protocol.Vote{ChainID: 10})) // no xt_id
+	require.NoError(t, client.Send(protocol.TypeVote, protocol.Vote{XtID: types.Hash{1}, ChainID: 10, Commit: true}))
+
+	select {
+	case msg := <-got:
+		var vote protocol.Vote
+		require.NoError(t, msg.Decode(&vote))
+		require.Equal(t, types.Hash{1}, vote.XtID, "the invalid vote should have been dropped")
+	case <-time.After(time.Second):
+		t.Fatal("valid vote never dispatched")
+	}
+	select {
+	case msg := <-got:
+		t.Fatalf("unexpected second dispatch: %s", msg.Type)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestUrgentMessagesOvertakeBulk blocks the dispatcher on one bulk message,
+// queues more bulk traffic and then a vote, and checks the vote is handled
+// ahead of the waiting bulk once the dispatcher resumes.
+func TestUrgentMessagesOvertakeBulk(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	gate := make(chan struct{})
+	srv.SetHandler(func(_ context.Context, _ *Conn, msg protocol.Message) {
+		mu.Lock()
+		order = append(order, msg.Type)
+		plug := len(order) == 1
+		mu.Unlock()
+		if plug {
+			<-gate
+		}
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-prio")
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+
+	// The first bulk message occupies the dispatcher; the rest queue up.
+	for i := 0; i < 4; i++ {
+		require.NoError(t, client.Send(protocol.TypeXTRequest, types.XTRequest{
+			XtID: types.Hash{byte(i + 1)}, Chains: []types.ChainID{10},
+		}))
+	}
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(order) == 1
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, client.Send(protocol.TypeVote, protocol.Vote{XtID: types.Hash{9}, ChainID: 10, Commit: true}))
+	time.Sleep(50 * time.Millisecond) // let the vote reach the urgent queue
+	close(gate)
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(order) == 5
+	}, time.Second, time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, protocol.TypeVote, order[1], "the vote should overtake queued bulk traffic: %v", order)
+}
+
+// TestSendWithoutConnection checks a queue-less client surfaces the
+// matchable sentinel instead of an opaque string.
+func TestSendWithoutConnection(t *testing.T) {
+	signer, err := auth.GenerateSigner("seq-offline")
+	require.NoError(t, err)
+	client := NewClient(zap.NewNop(), ClientConfig{Addr: "127.0.0.1:1", ChainID: 10}, signer)
+	err = client.Send(protocol.TypeVote, protocol.Vote{XtID: types.Hash{1}, ChainID: 10})
+	require.ErrorIs(t, err, ErrNotConnected)
+}
+
+// TestDisconnectReasonReachesPeer exercises the graceful disconnect
+// handshake in both directions: a client closing with a reason shows up in
+// the server's disconnect callback, and a client bumped by a replacement
+// connection learns why before its socket dies.
+func TestDisconnectReasonReachesPeer(t *testing.T) {
+	srv, authManager := startTestServer(t, Config{})
+
+	reasons := make(chan string, 2)
+	srv.SetDisconnectHandler(func(_ types.NetworkID, _ types.ChainID, reason string) {
+		reasons <- reason
+	})
+	require.NoError(t, srv.Start())
+	defer srv.Stop()
+
+	signer := trustedSigner(t, authManager, "seq-bye")
+	first := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.NoError(t, first.Connect())
+	require.NoError(t, first.CloseWithReason("scheduled maintenance"))
+	select {
+	case reason := <-reasons:
+		require.Equal(t, "peer disconnect: scheduled maintenance", reason)
+	case <-time.After(2 * time.Second):
+		t.Fatal("server never reported the disconnect")
+	}
+
+	// Server-initiated: a second connection for the same chain bumps the
+	// first, which should learn the reason before the socket closes.
+	victim := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.NoError(t, victim.Connect())
+	defer victim.Close()
+	usurper := NewClient(zap.NewNop(), ClientConfig{Addr: srv.Addr().String(), ChainID: 10}, signer)
+	require.NoError(t, usurper.Connect())
+	defer usurper.Close()
+	require.Eventually(t, func() bool {
+		return victim.LastDisconnectReason() == "replaced by new connection"
+	}, 2*time.Second, 5*time.Millisecond)
+}